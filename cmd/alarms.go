@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alarmsUnarchivedOnly bool
+	alarmsFormat         string
+	alarmsSort           string
+	alarmsArchiveAll     bool
+	alarmsArchiveYes     bool
+)
+
+var alarmsCmd = &cobra.Command{
+	Use:   "alarms",
+	Short: "List and archive controller alarms",
+	Long: `List the controller's alarms, the events that need attention such as
+devices losing contact or threat management alerts, and archive them once they
+have been dealt with.`,
+}
+
+var alarmsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List alarms",
+	Long: `List alarms, newest first, with their severity: threat management alarms
+keep their own severity, devices losing contact and WAN failures are high,
+everything else is medium. Only alarms that have not been archived are listed
+unless --unarchived-only=false is given.`,
+	Example: `  unifi alarms list
+  unifi alarms list --unarchived-only=false
+  unifi alarms list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runAlarmsList,
+}
+
+var alarmsArchiveCmd = &cobra.Command{
+	Use:   "archive <id>... | --all",
+	Short: "Archive alarms",
+	Long: `Archive alarms by ID (as shown by "alarms list"), or every alarm with --all.
+Archived alarms no longer show as active in the controller.`,
+	Example: `  unifi alarms archive 64f1c2e8a1b2c3d4e5f60718
+  unifi alarms archive --all --yes`,
+	RunE: runAlarmsArchive,
+}
+
+func init() {
+	rootCmd.AddCommand(alarmsCmd)
+	alarmsCmd.AddCommand(alarmsListCmd)
+	alarmsCmd.AddCommand(alarmsArchiveCmd)
+
+	alarmsListCmd.Flags().BoolVar(&alarmsUnarchivedOnly, "unarchived-only", true, "Only list alarms that have not been archived")
+	alarmsListCmd.Flags().StringVarP(&alarmsFormat, "format", "f", "table", "Output format (table or json)")
+	alarmsListCmd.Flags().StringVar(&alarmsSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: -time)")
+
+	alarmsArchiveCmd.Flags().BoolVar(&alarmsArchiveAll, "all", false, "Archive every alarm")
+	alarmsArchiveCmd.Flags().BoolVarP(&alarmsArchiveYes, "yes", "y", false, "Archive all alarms without asking for confirmation")
+}
+
+func runAlarmsList(cmd *cobra.Command, args []string) error {
+	if alarmsFormat != "table" && alarmsFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", alarmsFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	alarms, err := apiClient.ListAlarms(!alarmsUnarchivedOnly)
+	if err != nil {
+		return fmt.Errorf("failed to list alarms: %w", err)
+	}
+
+	api.SortAlarms(alarms)
+	if err := sorting.ByKeys(alarms, alarmsSort); err != nil {
+		return err
+	}
+
+	if alarmsFormat == "json" {
+		if alarms == nil {
+			alarms = []api.Alarm{}
+		}
+		return output.PrintJSON(alarms)
+	}
+
+	if len(alarms) == 0 {
+		fmt.Println("No alarms")
+		return nil
+	}
+	output.PrintAlarmsTable(alarms)
+	return nil
+}
+
+func runAlarmsArchive(cmd *cobra.Command, args []string) error {
+	switch {
+	case alarmsArchiveAll && len(args) > 0:
+		return fmt.Errorf("give either alarm IDs or --all")
+	case !alarmsArchiveAll && len(args) == 0:
+		return fmt.Errorf("give the IDs of the alarms to archive, or --all")
+	}
+
+	apiClient := newAPIClient()
+
+	alarms, err := apiClient.ListAlarms(false)
+	if err != nil {
+		return fmt.Errorf("failed to list alarms: %w", err)
+	}
+
+	if alarmsArchiveAll {
+		if len(alarms) == 0 {
+			fmt.Println("No alarms to archive")
+			return nil
+		}
+
+		if !alarmsArchiveYes {
+			ok, err := confirm(fmt.Sprintf("Archive all %d alarms?", len(alarms)))
+			if errors.Is(err, errNonInteractive) {
+				return fmt.Errorf("%w (use --yes to archive without asking)", err)
+			}
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		if err := apiClient.ArchiveAllAlarms(); err != nil {
+			return fmt.Errorf("failed to archive alarms: %w", err)
+		}
+		fmt.Printf("Archived %d alarms\n", len(alarms))
+		return nil
+	}
+
+	// Check every ID first, so nothing is archived after a typo
+	for _, id := range args {
+		if _, err := api.FindAlarm(alarms, id); err != nil {
+			return fmt.Errorf("%w among the unarchived alarms", err)
+		}
+	}
+
+	var results []output.ActionResult
+	for _, id := range args {
+		results = append(results, output.ActionResult{Target: id, Err: apiClient.ArchiveAlarm(id)})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d alarms failed", failures, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/audit"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var auditFormat string
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit the network configuration",
+}
+
+var auditWLANsCmd = &cobra.Command{
+	Use:   "wlans",
+	Short: "Flag wireless networks with weak security",
+	Long: `Check every WLAN for weak security settings and report findings by severity:
+
+  - open (unencrypted) and WEP networks
+  - WPA1/TKIP
+  - default-ish or short passphrases
+  - protected management frames (PMF) disabled
+  - guest networks without client isolation`,
+	RunE: runAuditWLANs,
+}
+
+var auditWiredCmd = &cobra.Command{
+	Use:   "wired",
+	Short: "Flag wired clients on a different network than their switch port",
+	Long: `Compare the network every wired client got its address on with the native
+network of the switch port it is connected to, and flag mismatches, which
+usually mean a cable was moved to a port with another profile.
+
+Mismatches on access ports are reported as medium; trunk ports may carry the
+client's network tagged, so those are reported as low. Uplink ports are
+skipped.`,
+	RunE: runAuditWired,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditWLANsCmd)
+	auditCmd.AddCommand(auditWiredCmd)
+
+	auditCmd.PersistentFlags().StringVarP(&auditFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+func runAuditWLANs(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	return printFindings(audit.WLANs(wlans))
+}
+
+func runAuditWired(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	devices, err := apiClient.ListDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	profiles, err := apiClient.ListPortProfiles()
+	if err != nil {
+		return fmt.Errorf("failed to list port profiles: %w", err)
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	return printFindings(audit.Wired(clients, devices, profiles, networks))
+}
+
+func printFindings(findings []audit.Finding) error {
+	switch auditFormat {
+	case "json":
+		if findings == nil {
+			findings = []audit.Finding{}
+		}
+		return output.PrintJSON(findings)
+	case "table":
+		if len(findings) == 0 {
+			fmt.Println("No findings")
+			return nil
+		}
+		output.PrintFindingsTable(findings)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", auditFormat)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupOutput string
+	backupDays   int
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the controller",
+}
+
+var backupDownloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "Create a controller backup and download it",
+	Long: `Have the controller write a backup of its configuration now and download
+it, for keeping backups off the console. The .unf file can be restored from the
+Network Application's settings.
+
+Backups include settings only by default; --days adds that many days of
+statistics (-1 for all). Large backups may need a longer --timeout. The file
+is only replaced once the download is complete.`,
+	Example: `  unifi backup download --output site-backup.unf
+  unifi backup download --days 7 --timeout 5m`,
+	Args: cobra.NoArgs,
+	RunE: runBackupDownload,
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupDownloadCmd)
+
+	backupDownloadCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "File to save the backup to (default <site>-<timestamp>.unf)")
+	backupDownloadCmd.Flags().IntVar(&backupDays, "days", 0, "Days of statistics to include (-1 for all)")
+}
+
+func runBackupDownload(cmd *cobra.Command, args []string) error {
+	if backupDays < -1 {
+		return fmt.Errorf("--days must be -1 (all) or more, got %d", backupDays)
+	}
+
+	path := backupOutput
+	if path == "" {
+		path = fmt.Sprintf("%s-%s.unf", config.Get().Site, time.Now().Format("20060102-150405"))
+	}
+
+	apiClient := newAPIClient()
+
+	url, err := apiClient.CreateBackup(backupDays)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	// Download next to the target so a failed download never replaces an
+	// earlier backup
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".backup-*.unf")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	size, err := apiClient.DownloadBackup(url, tmp)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write backup file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save backup: %w", err)
+	}
+
+	fmt.Printf("Backup saved to %s (%s)\n", path, api.FormatBytes(size))
+	return nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkFormat         string
+	checkClockThreshold time.Duration
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check the controller for common problems",
+}
+
+var checkClockCmd = &cobra.Command{
+	Use:   "clock",
+	Short: "Compare the controller's clock with the local clock",
+	Long: `Compare the controller's time with the local time and flag drift beyond
+--threshold. A drifting controller clock silently breaks WLAN schedules,
+voucher expiry and the correlation of logs.
+
+The controller's time is taken from its answer to the sysinfo request and has
+a resolution of one second, so drift below about a second cannot be measured.
+Exits non-zero when the drift exceeds the threshold.`,
+	Example: `  unifi check clock
+  unifi check clock --threshold 30s`,
+	Args: cobra.NoArgs,
+	RunE: runCheckClock,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.AddCommand(checkClockCmd)
+
+	checkCmd.PersistentFlags().StringVarP(&checkFormat, "format", "f", "table", "Output format (table or json)")
+	checkClockCmd.Flags().DurationVar(&checkClockThreshold, "threshold", 5*time.Second, "Largest acceptable drift")
+}
+
+// clockCheck is the result of check clock
+type clockCheck struct {
+	ControllerTime   time.Time `json:"controller_time"`
+	LocalTime        time.Time `json:"local_time"`
+	Timezone         string    `json:"timezone"`
+	DriftSeconds     float64   `json:"drift_seconds"`
+	ThresholdSeconds float64   `json:"threshold_seconds"`
+	OK               bool      `json:"ok"`
+}
+
+func runCheckClock(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	sent := time.Now()
+	info, err := apiClient.GetSysInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get system information: %w", err)
+	}
+	received := time.Now()
+
+	if info.Time.IsZero() {
+		return fmt.Errorf("controller did not report its time")
+	}
+
+	local := sent.Add(received.Sub(sent) / 2)
+	drift := clockDrift(info.Time, local)
+	result := clockCheck{
+		ControllerTime:   info.Time,
+		LocalTime:        local.Truncate(time.Second),
+		Timezone:         info.Timezone,
+		DriftSeconds:     drift.Seconds(),
+		ThresholdSeconds: checkClockThreshold.Seconds(),
+		OK:               drift.Abs() <= checkClockThreshold,
+	}
+
+	switch checkFormat {
+	case "json":
+		if err := output.PrintJSON(result); err != nil {
+			return err
+		}
+	case "table":
+		controllerTime := info.Time
+		if loc, err := time.LoadLocation(info.Timezone); err == nil && info.Timezone != "" {
+			controllerTime = controllerTime.In(loc)
+		}
+		status := "ok"
+		if !result.OK {
+			status = "drift"
+		}
+		output.PrintDetails([]output.Detail{
+			{Key: "Controller time", Value: controllerTime.Format("2006-01-02 15:04:05 MST")},
+			{Key: "Local time", Value: result.LocalTime.Format("2006-01-02 15:04:05 MST")},
+			{Key: "Timezone", Value: info.Timezone},
+			{Key: "Drift", Value: formatDrift(drift)},
+			{Key: "Status", Value: status},
+		})
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", checkFormat)
+	}
+
+	if !result.OK {
+		return fmt.Errorf("controller clock is off by %s (threshold %s)", drift.Abs(), checkClockThreshold)
+	}
+	return nil
+}
+
+// clockDrift returns how far the controller's clock (truncated to whole
+// seconds) is ahead of local, rounded to whole seconds. The controller's time
+// is taken as the middle of its second.
+func clockDrift(controller, local time.Time) time.Duration {
+	return controller.Add(500 * time.Millisecond).Sub(local).Round(time.Second)
+}
+
+func formatDrift(drift time.Duration) string {
+	switch {
+	case drift > 0:
+		return fmt.Sprintf("+%s (controller ahead)", drift)
+	case drift < 0:
+		return fmt.Sprintf("%s (controller behind)", drift)
+	default:
+		return "0s"
+	}
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/certcheck"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// portalPort is where controllers serve the hotspot portal over HTTPS
+const portalPort = "8843"
+
+var (
+	checkCertsPortal    string
+	checkCertsEndpoints []string
+	checkCertsFiles     []string
+	checkCertsWarning   int
+	checkCertsCritical  int
+)
+
+var checkRadiusCertsCmd = &cobra.Command{
+	Use:   "radius-certs",
+	Short: "Warn before RADIUS and hotspot certificates expire",
+	Long: `Check how long the certificates WPA-Enterprise and hotspot clients see
+remain valid, and warn before they expire: clients then fail to connect with
+little more than an authentication error.
+
+The hotspot portal's certificate is read from its HTTPS listener (the
+controller host on port ` + portalPort + ` unless --portal is given) when the portal
+is enabled. The controller does not expose the certificate its RADIUS server
+presents during EAP, so check it from the exported file with --cert, or, for
+RADIUS over TLS (RadSec), from its listener with --endpoint.
+
+Exits like a monitoring plugin: 0 when all certificates are OK, 1 when one
+expires within --warning days, 2 when one expires within --critical days or
+has expired, and 3 when a certificate could not be read.`,
+	Example: `  unifi check radius-certs
+  unifi check radius-certs --cert /etc/freeradius/certs/server.pem --warning 45
+  unifi check radius-certs --endpoint radius.example.com:2083 --format json`,
+	Args: cobra.NoArgs,
+	RunE: runCheckRadiusCerts,
+}
+
+func init() {
+	checkCmd.AddCommand(checkRadiusCertsCmd)
+
+	flags := checkRadiusCertsCmd.Flags()
+	flags.StringVar(&checkCertsPortal, "portal", "", "Hotspot portal address (default the controller host on port "+portalPort+")")
+	flags.StringSliceVar(&checkCertsEndpoints, "endpoint", nil, "Also check the certificate of this TLS endpoint, host:port (repeatable)")
+	flags.StringSliceVar(&checkCertsFiles, "cert", nil, "Also check the certificates in this PEM file (repeatable)")
+	flags.IntVar(&checkCertsWarning, "warning", 30, "Warn when a certificate expires within this many days")
+	flags.IntVar(&checkCertsCritical, "critical", 7, "Fail when a certificate expires within this many days")
+}
+
+func runCheckRadiusCerts(cmd *cobra.Command, args []string) error {
+	if checkFormat != "table" && checkFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", checkFormat)
+	}
+	if checkCertsCritical > checkCertsWarning {
+		return fmt.Errorf("--critical (%d days) must not be more than --warning (%d days)", checkCertsCritical, checkCertsWarning)
+	}
+
+	// Failed checks are reported with their exit code, not as misuse
+	cmd.SilenceUsage = true
+
+	cfg := config.Get()
+	now := time.Now()
+	warning := time.Duration(checkCertsWarning) * 24 * time.Hour
+	critical := time.Duration(checkCertsCritical) * 24 * time.Hour
+
+	var results []certcheck.Result
+	checkEndpoint := func(name, addr string) {
+		certs, err := api.FetchCertificates(addr, nil, cfg.Timeout)
+		if err != nil {
+			results = append(results, certcheck.Failed(name, addr, err))
+			return
+		}
+		results = append(results, certcheck.Check(name, addr, certs[0], now, warning, critical))
+	}
+
+	portal, err := portalAddress(cfg)
+	if err != nil {
+		results = append(results, certcheck.Failed("Hotspot portal", cfg.Host, err))
+	} else if portal != "" {
+		checkEndpoint("Hotspot portal", portal)
+	}
+
+	for _, addr := range checkCertsEndpoints {
+		checkEndpoint("Endpoint", addr)
+	}
+
+	for _, path := range checkCertsFiles {
+		certs, err := certcheck.LoadPEM(path)
+		if err != nil {
+			results = append(results, certcheck.Failed("File", path, err))
+			continue
+		}
+		// Chains expire with their first certificate to expire
+		for _, cert := range certs {
+			results = append(results, certcheck.Check("File", path, cert, now, warning, critical))
+		}
+	}
+
+	if len(results) == 0 {
+		return &exitError{code: int(certcheck.Unknown), err: fmt.Errorf("no certificates to check: the hotspot portal is disabled (use --cert or --endpoint)")}
+	}
+
+	if checkFormat == "json" {
+		if err := output.PrintJSON(results); err != nil {
+			return err
+		}
+	} else {
+		output.PrintCertificatesTable(results)
+	}
+
+	status := certcheck.Worst(results)
+	if status == certcheck.OK {
+		return nil
+	}
+	return &exitError{code: int(status), err: fmt.Errorf("certificate check %s", status)}
+}
+
+// portalAddress returns the host:port of the hotspot portal's HTTPS listener
+// to check: --portal, or the controller host when the portal is enabled, or
+// nothing when it is not
+func portalAddress(cfg *config.Config) (string, error) {
+	if checkCertsPortal != "" {
+		if _, _, err := net.SplitHostPort(checkCertsPortal); err != nil {
+			return net.JoinHostPort(checkCertsPortal, portalPort), nil
+		}
+		return checkCertsPortal, nil
+	}
+
+	settings, err := newAPIClient().GetGuestAccessSettings()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hotspot portal settings: %w", err)
+	}
+	if !settings.PortalEnabled {
+		return "", nil
+	}
+
+	u, err := url.Parse(cfg.Host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", cfg.Host, err)
+	}
+	return net.JoinHostPort(u.Hostname(), portalPort), nil
+}
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/nkn/unifi-cli/internal/api"
-	"github.com/nkn/unifi-cli/internal/config"
 	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/oui"
 	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +20,11 @@ var (
 	filterWireless bool
 	filterBlocked  bool
 	filterAP       string
+	filterVendors  []string
 	filterSQL      string
+	sortSpec       string
+	watchClients   bool
+	watchInterval  time.Duration
 )
 
 var clientsCmd = &cobra.Command{
@@ -42,38 +49,51 @@ func init() {
 	clientsListCmd.Flags().BoolVar(&filterWireless, "wireless", false, "Show only wireless clients")
 	clientsListCmd.Flags().BoolVar(&filterBlocked, "blocked", false, "Show only blocked clients")
 	clientsListCmd.Flags().StringVar(&filterAP, "ap", "", "Filter by Access Point MAC address")
+	clientsListCmd.Flags().StringSliceVar(&filterVendors, "vendor", nil, "Show only clients made by these vendors ("+strings.Join(oui.Shortcuts(), ", ")+")")
 	clientsListCmd.Flags().StringVar(&filterSQL, "filter", "", "SQL WHERE clause (e.g., 'signal >= -65 AND essid = \"HomeWiFi\"')")
+	clientsListCmd.Flags().BoolVarP(&watchClients, "watch", "w", false, "Refresh the list periodically and highlight connects and disconnects")
+	clientsListCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "Refresh interval in watch mode")
+	clientsListCmd.Flags().StringVar(&sortSpec, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: name, then MAC)")
 }
 
 func runClientsList(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-
-	apiClient := api.NewAPIClient(cfg.Host, cfg.APIKey, cfg.Site, cfg.Insecure)
-
-	clients, err := apiClient.ListClients()
-	if err != nil {
-		return fmt.Errorf("failed to list clients: %w", err)
-	}
-
 	// Build WHERE clause from flags
 	whereClause, err := buildWhereClause()
 	if err != nil {
 		return err
 	}
 
-	// Apply filter if needed
-	filteredClients := clients
+	// The filter engine is created once and reused by every watch refresh
+	var filterEngine *filter.Filter
 	if whereClause != "" {
-		filterEngine, err := filter.NewFilter(whereClause)
+		filterEngine, err = filter.NewFilter(whereClause)
 		if err != nil {
 			return fmt.Errorf("failed to create filter: %w", err)
 		}
 		defer filterEngine.Close()
+	}
 
-		filteredClients, err = filterEngine.Apply(clients)
-		if err != nil {
-			return fmt.Errorf("failed to apply filter: %w", err)
-		}
+	apiClient := newAPIClient()
+
+	// Network and user group names are joined into every listing, so fetch
+	// them only once. They are only a nicety: a key that may not read them,
+	// or a controller without user groups, still gets the client list.
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: network names not shown: failed to list networks: %v\n", err)
+	}
+	groups, err := apiClient.ListUserGroups()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: user group names not shown: failed to list user groups: %v\n", err)
+	}
+
+	if watchClients {
+		return runClientsWatch(apiClient, filterEngine, networks, groups)
+	}
+
+	filteredClients, err := fetchClients(apiClient, filterEngine, networks, groups)
+	if err != nil {
+		return err
 	}
 
 	if len(filteredClients) == 0 {
@@ -92,6 +112,36 @@ func runClientsList(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// fetchClients lists the connected clients, joins in their network and user
+// group names, applies the filter engine (if any) and sorts the result
+func fetchClients(apiClient *api.APIClient, filterEngine *filter.Filter, networks []api.Network, groups []api.UserGroup) ([]api.Client, error) {
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+	api.JoinNetworkNames(clients, networks)
+	api.JoinUserGroupNames(clients, groups)
+
+	if filterEngine != nil {
+		clients, err = filterEngine.Apply(clients)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply filter: %w", err)
+		}
+	}
+
+	if err := sortClients(clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// sortClients applies the default stable order and then any --sort keys, so
+// clients that tie on the requested keys still come out in a fixed order
+func sortClients(clients []api.Client) error {
+	api.SortClients(clients)
+	return sorting.ByKeys(clients, sortSpec)
+}
+
 func buildWhereClause() (string, error) {
 	var conditions []string
 
@@ -114,6 +164,14 @@ func buildWhereClause() (string, error) {
 		conditions = append(conditions, fmt.Sprintf("ap_mac = '%s'", filterAP))
 	}
 
+	if len(filterVendors) > 0 {
+		condition, err := vendorCondition(filterVendors)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, condition)
+	}
+
 	// Add custom SQL filter
 	if filterSQL != "" {
 		conditions = append(conditions, fmt.Sprintf("(%s)", filterSQL))
@@ -125,3 +183,21 @@ func buildWhereClause() (string, error) {
 
 	return strings.Join(conditions, " AND "), nil
 }
+
+// vendorCondition matches clients whose MAC has one of the vendors' bundled
+// OUIs, or whose vendor as reported by the controller names one of them
+func vendorCondition(shortcuts []string) (string, error) {
+	var prefixes, names []string
+	for _, shortcut := range shortcuts {
+		vendor, err := oui.Lookup(shortcut)
+		if err != nil {
+			return "", err
+		}
+		for _, prefix := range vendor.Prefixes {
+			prefixes = append(prefixes, fmt.Sprintf("'%s'", prefix))
+		}
+		names = append(names, fmt.Sprintf("oui LIKE '%%%s%%'", vendor.Name))
+	}
+
+	return fmt.Sprintf("(substr(lower(mac), 1, 8) IN (%s) OR %s)", strings.Join(prefixes, ", "), strings.Join(names, " OR ")), nil
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/pending"
+	"github.com/spf13/cobra"
+)
+
+var blockFor time.Duration
+
+var clientsBlockCmd = &cobra.Command{
+	Use:   "block <mac>...",
+	Short: "Block one or more clients",
+	Long: `Block clients from connecting to the network.
+
+With --for the block expires automatically: an unblock is scheduled and
+completed by the first unifi invocation after it falls due.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runClientsBlock,
+}
+
+var clientsUnblockCmd = &cobra.Command{
+	Use:   "unblock <mac>...",
+	Short: "Unblock one or more clients",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runClientsUnblock,
+}
+
+var clientsBlocksCmd = &cobra.Command{
+	Use:   "blocks",
+	Short: "Inspect temporary client blocks",
+}
+
+var clientsBlocksPendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List scheduled block expiries",
+	RunE:  runClientsBlocksPending,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsBlockCmd)
+	clientsCmd.AddCommand(clientsUnblockCmd)
+	clientsCmd.AddCommand(clientsBlocksCmd)
+	clientsBlocksCmd.AddCommand(clientsBlocksPendingCmd)
+
+	clientsBlockCmd.Flags().DurationVar(&blockFor, "for", 0, "Automatically unblock after this duration (e.g. 30m, 2h)")
+}
+
+func runClientsBlock(cmd *cobra.Command, args []string) error {
+	if blockFor < 0 {
+		return fmt.Errorf("--for must be a positive duration")
+	}
+
+	apiClient := newAPIClient()
+	due := time.Now().Add(blockFor)
+
+	return applyBlockChange(args, apiClient.BlockClient, func(store *pending.Store, action pending.Action) {
+		if blockFor > 0 {
+			action.Due = due
+			store.Add(action)
+		} else {
+			// A permanent block supersedes any earlier timed one
+			store.Remove(action)
+		}
+	})
+}
+
+func runClientsUnblock(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	return applyBlockChange(args, apiClient.UnblockClient, func(store *pending.Store, action pending.Action) {
+		store.Remove(action)
+	})
+}
+
+// applyBlockChange runs change for every MAC, updates the scheduled unblocks of
+// the clients it succeeded for and prints a per-client summary
+func applyBlockChange(macs []string, change func(mac string) error, schedule func(*pending.Store, pending.Action)) error {
+	cfg := config.Get()
+
+	var results []output.ActionResult
+	var changed []pending.Action
+	for _, mac := range macs {
+		mac = strings.ToLower(mac)
+		err := change(mac)
+		if err == nil {
+			changed = append(changed, pending.Action{Type: pending.ActionUnblock, Host: cfg.Host, Site: cfg.Site, MAC: mac})
+		}
+		results = append(results, output.ActionResult{Target: mac, Err: err})
+	}
+
+	// The store is only locked once the controller calls are done
+	if len(changed) > 0 {
+		err := pending.Update(pendingStorePath(), func(store *pending.Store) {
+			for _, action := range changed {
+				schedule(store, action)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d clients failed", failures, len(results))
+	}
+	return nil
+}
+
+func runClientsBlocksPending(cmd *cobra.Command, args []string) error {
+	store, err := pending.Load(pendingStorePath())
+	if err != nil {
+		return err
+	}
+
+	cfg := config.Get()
+	var actions []pending.Action
+	for _, action := range store.Actions {
+		if action.Host == cfg.Host {
+			actions = append(actions, action)
+		}
+	}
+
+	if len(actions) == 0 {
+		fmt.Println("No pending block expiries")
+		return nil
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Due.Before(actions[j].Due)
+	})
+
+	output.PrintPendingActionsTable(actions, time.Now())
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/dpi"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	clientDPIBy     string
+	clientDPITop    int
+	clientDPIFormat string
+)
+
+var clientsDPICmd = &cobra.Command{
+	Use:   "dpi <mac|name>",
+	Short: "Show the applications a client has been using",
+	Long: `Show a client's traffic as classified by deep packet inspection, by
+application or by category, with the most traffic first.
+
+Application IDs are named from a bundled table of common applications; others
+show as their category and number. Add or correct names in the config file:
+
+  dpi_apps:
+    "13:200": Intranet`,
+	Example: `  unifi clients dpi kids-tablet
+  unifi clients dpi aa:bb:cc:dd:ee:ff --by category
+  unifi clients dpi kids-tablet --top 5 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClientsDPI,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsDPICmd)
+
+	clientsDPICmd.Flags().StringVar(&clientDPIBy, "by", "app", "Group traffic by app or category")
+	clientsDPICmd.Flags().IntVar(&clientDPITop, "top", 0, "Only show the N applications or categories with the most traffic")
+	clientsDPICmd.Flags().StringVarP(&clientDPIFormat, "format", "f", "table", "Output format (table, csv or json)")
+}
+
+func runClientsDPI(cmd *cobra.Command, args []string) error {
+	if clientDPIFormat != "table" && clientDPIFormat != "csv" && clientDPIFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, csv, json)", clientDPIFormat)
+	}
+
+	var byCategory bool
+	switch clientDPIBy {
+	case "app":
+	case "category":
+		byCategory = true
+	default:
+		return fmt.Errorf("invalid --by: %s (valid options: app, category)", clientDPIBy)
+	}
+
+	if err := dpi.AddAppNames(config.Get().DPIApps); err != nil {
+		return fmt.Errorf("invalid dpi_apps in config file: %w", err)
+	}
+
+	apiClient := newAPIClient()
+
+	macs, err := resolveClientMACs(apiClient, args)
+	if err != nil {
+		return err
+	}
+
+	entries, err := apiClient.GetClientDPI(macs[0])
+	if err != nil {
+		return fmt.Errorf("failed to get DPI statistics: %w", err)
+	}
+
+	usages := dpi.ByApp(entries)
+	if byCategory {
+		usages = dpi.ByCategory(entries)
+	}
+	usages = dpi.Top(usages, clientDPITop)
+
+	switch clientDPIFormat {
+	case "json":
+		return output.PrintJSON(usages)
+	case "csv":
+		return output.PrintDPICSV(usages, byCategory)
+	default:
+		if len(usages) == 0 {
+			fmt.Printf("No DPI statistics for %s (is traffic identification turned on?)\n", macs[0])
+			return nil
+		}
+		output.PrintDPITable(usages, byCategory)
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/archive"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forgetOlderThan string
+	forgetYes       bool
+)
+
+var clientsForgetCmd = &cobra.Command{
+	Use:   "forget [<mac|name>...]",
+	Short: "Forget historical client records",
+	Long: `Remove clients from the controller's list of known clients, together with
+their alias, note and history. Clients can be given by MAC address, alias or
+hostname; names are looked up among all known clients, connected or not.
+
+With --all-disconnected-older-than every client that is not connected and was
+last seen longer ago than the given age (e.g. 30d, 12h) is forgotten. Such bulk
+purges only list the affected clients unless --yes is given.
+
+The full records of forgotten clients are first appended to archive.jsonl in
+the data directory; "clients restore" re-applies their alias, note and fixed IP
+if they return.`,
+	Example: `  unifi clients forget aa:bb:cc:dd:ee:ff
+  unifi clients forget --all-disconnected-older-than 30d
+  unifi clients forget --all-disconnected-older-than 30d --yes`,
+	RunE: runClientsForget,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsForgetCmd)
+
+	clientsForgetCmd.Flags().StringVar(&forgetOlderThan, "all-disconnected-older-than", "", "Forget all disconnected clients last seen longer ago than this age (e.g. 30d)")
+	clientsForgetCmd.Flags().BoolVarP(&forgetYes, "yes", "y", false, "Confirm a bulk purge")
+}
+
+func runClientsForget(cmd *cobra.Command, args []string) error {
+	if forgetOlderThan == "" && len(args) == 0 {
+		return fmt.Errorf("specify clients to forget or --all-disconnected-older-than")
+	}
+	if forgetOlderThan != "" && len(args) > 0 {
+		return fmt.Errorf("--all-disconnected-older-than cannot be combined with explicit clients")
+	}
+
+	apiClient := newAPIClient()
+
+	if forgetOlderThan == "" {
+		macs, err := resolveKnownClientMACs(apiClient, args)
+		if err != nil {
+			return err
+		}
+		return forgetClients(apiClient, macs)
+	}
+
+	age, err := parseAge(forgetOlderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --all-disconnected-older-than: %w", err)
+	}
+
+	users, err := apiClient.ListUsers()
+	if err != nil {
+		return fmt.Errorf("failed to list known clients: %w", err)
+	}
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	stale := api.DisconnectedSince(users, clients, time.Now().Add(-age))
+	if len(stale) == 0 {
+		fmt.Println("No clients to forget")
+		return nil
+	}
+
+	if !forgetYes {
+		for _, user := range stale {
+			fmt.Printf("%s  %-24s  last seen %s\n", user.MAC, userDisplayName(user), time.Unix(user.LastSeen, 0).Format("2006-01-02"))
+		}
+		return fmt.Errorf("refusing to forget %d clients without --yes", len(stale))
+	}
+
+	macs := make([]string, len(stale))
+	for i, user := range stale {
+		macs[i] = user.MAC
+	}
+	return forgetClients(apiClient, macs)
+}
+
+// archivePath is where the records of forgotten clients are kept
+func archivePath() string {
+	return filepath.Join(config.GetDataDir(), "archive.jsonl")
+}
+
+// forgetClients archives the known-client records of macs and then forgets
+// them. Nothing is forgotten if the archive cannot be written.
+func forgetClients(apiClient *api.APIClient, macs []string) error {
+	records, err := apiClient.ListUserRecords()
+	if err != nil {
+		return fmt.Errorf("failed to list known clients: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(macs))
+	for _, mac := range macs {
+		wanted[strings.ToLower(mac)] = true
+	}
+
+	cfg := config.Get()
+	now := time.Now()
+	var entries []archive.Entry
+	for _, record := range records {
+		entry := archive.Entry{ArchivedAt: now, Host: cfg.Host, Site: apiClient.Site, Record: record}
+		user, err := entry.User()
+		if err != nil {
+			return err
+		}
+		if wanted[strings.ToLower(user.MAC)] {
+			entries = append(entries, entry)
+		}
+	}
+
+	if err := archive.Append(archivePath(), entries); err != nil {
+		return fmt.Errorf("refusing to forget clients: %w", err)
+	}
+
+	if err := apiClient.ForgetClients(macs); err != nil {
+		return fmt.Errorf("failed to forget clients: %w", err)
+	}
+
+	fmt.Printf("Forgot %d client(s), archived %d record(s) to %s\n", len(macs), len(entries), archivePath())
+	return nil
+}
+
+func userDisplayName(user api.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Hostname
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var clientsKickCmd = &cobra.Command{
+	Use:   "kick <mac|name>...",
+	Short: "Force wireless clients to reconnect",
+	Long: `Disconnect wireless clients so they re-associate with the network.
+
+Clients can be given by MAC address, alias or hostname.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runClientsKick,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsKickCmd)
+}
+
+func runClientsKick(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	macs, err := resolveClientMACs(apiClient, args)
+	if err != nil {
+		return err
+	}
+
+	var results []output.ActionResult
+	for _, mac := range macs {
+		results = append(results, output.ActionResult{Target: mac, Err: apiClient.KickClient(mac)})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d clients failed", failures, len(results))
+	}
+	return nil
+}
+
+// resolveClientMACs maps each argument to a MAC address. MACs are passed
+// through as-is; names are looked up among the connected clients, which are
+// only fetched when at least one argument is not a MAC.
+func resolveClientMACs(apiClient *api.APIClient, args []string) ([]string, error) {
+	var clients []api.Client
+	macs := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if mac, ok := api.NormalizeMAC(arg); ok {
+			macs = append(macs, mac)
+			continue
+		}
+
+		if clients == nil {
+			var err error
+			clients, err = apiClient.ListClients()
+			if err != nil {
+				return nil, fmt.Errorf("failed to list clients: %w", err)
+			}
+		}
+
+		client, err := api.FindClient(clients, arg)
+		if err != nil {
+			return nil, err
+		}
+		macs = append(macs, client.MAC)
+	}
+
+	return macs, nil
+}
+
+// resolveKnownClientMACs is resolveClientMACs for commands that also work on
+// clients that are not connected: names are looked up among all known
+// clients instead.
+func resolveKnownClientMACs(apiClient *api.APIClient, args []string) ([]string, error) {
+	var users []api.User
+	macs := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if mac, ok := api.NormalizeMAC(arg); ok {
+			macs = append(macs, mac)
+			continue
+		}
+
+		if users == nil {
+			var err error
+			users, err = apiClient.ListUsers()
+			if err != nil {
+				return nil, fmt.Errorf("failed to list known clients: %w", err)
+			}
+		}
+
+		user, err := api.FindUser(users, arg)
+		if err != nil {
+			return nil, err
+		}
+		macs = append(macs, user.MAC)
+	}
+
+	return macs, nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var clientsNoteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Manage client notes",
+	Long: `Set or clear the note of a client. Clients can be given by MAC address, alias
+or hostname and need not be connected.`,
+}
+
+var clientsNoteSetCmd = &cobra.Command{
+	Use:     "set <mac|name> <text>",
+	Short:   "Set the note of a client",
+	Example: `  unifi clients note set aa:bb:cc:dd:ee:ff "Kids tablet, blocked at night"`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runClientsNoteSet,
+}
+
+var clientsNoteClearCmd = &cobra.Command{
+	Use:   "clear <mac|name>",
+	Short: "Remove the note of a client",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runClientsNoteClear,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsNoteCmd)
+	clientsNoteCmd.AddCommand(clientsNoteSetCmd)
+	clientsNoteCmd.AddCommand(clientsNoteClearCmd)
+}
+
+func runClientsNoteSet(cmd *cobra.Command, args []string) error {
+	if args[1] == "" {
+		return fmt.Errorf("note text is empty (use 'clients note clear' to remove a note)")
+	}
+
+	mac, err := updateClientNote(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Set note of %s\n", mac)
+	return nil
+}
+
+func runClientsNoteClear(cmd *cobra.Command, args []string) error {
+	mac, err := updateClientNote(args[0], "")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cleared note of %s\n", mac)
+	return nil
+}
+
+// updateClientNote sets or, with an empty note, clears the note of a client
+// and returns the client's MAC
+func updateClientNote(query, note string) (string, error) {
+	apiClient := newAPIClient()
+
+	users, err := apiClient.ListUsers()
+	if err != nil {
+		return "", fmt.Errorf("failed to list known clients: %w", err)
+	}
+
+	user, err := api.FindUser(users, query)
+	if err != nil {
+		return "", err
+	}
+
+	fields := map[string]interface{}{"note": note, "noted": note != ""}
+	if _, err := apiClient.UpdateUser(user.ID, fields); err != nil {
+		return "", fmt.Errorf("failed to update note: %w", err)
+	}
+
+	return user.MAC, nil
+}
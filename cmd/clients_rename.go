@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var clientsRenameCmd = &cobra.Command{
+	Use:   "rename <mac|name> <alias>",
+	Short: "Set the alias of a client",
+	Long: `Set the alias shown for a client in the controller and in this CLI. The
+client can be given by MAC address, alias or hostname and need not be
+connected.
+
+Pass an empty alias ("") to remove it.`,
+	Example: `  unifi clients rename aa:bb:cc:dd:ee:ff "Living Room TV"`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runClientsRename,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsRenameCmd)
+}
+
+func runClientsRename(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	users, err := apiClient.ListUsers()
+	if err != nil {
+		return fmt.Errorf("failed to list known clients: %w", err)
+	}
+
+	user, err := api.FindUser(users, args[0])
+	if err != nil {
+		return err
+	}
+
+	updated, err := apiClient.UpdateUser(user.ID, map[string]interface{}{"name": args[1]})
+	if err != nil {
+		return fmt.Errorf("failed to rename client: %w", err)
+	}
+
+	if updated.Name == "" {
+		fmt.Printf("Removed alias of %s\n", user.MAC)
+	} else {
+		fmt.Printf("Renamed %s to %q\n", user.MAC, updated.Name)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/archive"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var restoreFrom string
+
+var clientsRestoreCmd = &cobra.Command{
+	Use:   "restore <mac>",
+	Short: "Restore a forgotten client's alias, note and fixed IP",
+	Long: `Re-apply the alias, note and fixed IP of a client that was forgotten with
+"clients forget", using the record archived at the time. If the controller has
+seen the client again its new record is updated, otherwise the record is
+created so the settings apply as soon as the client returns.`,
+	Example: `  unifi clients restore aa:bb:cc:dd:ee:ff
+  unifi clients restore --from backup/archive.jsonl aa:bb:cc:dd:ee:ff`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClientsRestore,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsRestoreCmd)
+
+	clientsRestoreCmd.Flags().StringVar(&restoreFrom, "from", "", "Archive file to restore from (default: archive.jsonl in the data directory)")
+}
+
+func runClientsRestore(cmd *cobra.Command, args []string) error {
+	mac, ok := api.NormalizeMAC(args[0])
+	if !ok {
+		return fmt.Errorf("invalid MAC address: %s", args[0])
+	}
+
+	path := restoreFrom
+	if path == "" {
+		path = archivePath()
+	}
+
+	entry, err := archive.Find(path, mac)
+	if err != nil {
+		return err
+	}
+
+	archived, err := entry.User()
+	if err != nil {
+		return err
+	}
+
+	fields := archive.RestoreFields(archived)
+	if len(fields) == 0 {
+		fmt.Printf("Archived record of %s has no alias, note or fixed IP to restore\n", mac)
+		return nil
+	}
+
+	apiClient := newAPIClient()
+	if entry.Host != config.Get().Host || entry.Site != apiClient.Site {
+		fmt.Fprintf(os.Stderr, "Warning: %s was archived from site %s on %s\n", mac, entry.Site, entry.Host)
+	}
+
+	users, err := apiClient.ListUsers()
+	if err != nil {
+		return fmt.Errorf("failed to list known clients: %w", err)
+	}
+
+	if user, err := api.FindUser(users, mac); err == nil {
+		if _, err := apiClient.UpdateUser(user.ID, fields); err != nil {
+			return fmt.Errorf("failed to update client: %w", err)
+		}
+	} else {
+		fields["mac"] = strings.ToLower(mac)
+		if _, err := apiClient.CreateUser(fields); err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+	}
+
+	fmt.Printf("Restored %s of %s (archived %s)\n", strings.Join(restoredSettings(fields), ", "), mac, entry.ArchivedAt.Local().Format("2006-01-02 15:04"))
+	return nil
+}
+
+// restoredSettings names the settings in fields for the confirmation message
+func restoredSettings(fields map[string]interface{}) []string {
+	var settings []string
+	if name, ok := fields["name"]; ok {
+		settings = append(settings, fmt.Sprintf("alias %q", name))
+	}
+	if _, ok := fields["note"]; ok {
+		settings = append(settings, "note")
+	}
+	if ip, ok := fields["fixed_ip"]; ok {
+		settings = append(settings, fmt.Sprintf("fixed IP %s", ip))
+	}
+	return settings
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var clientsSetGroupCmd = &cobra.Command{
+	Use:   "set-group <mac|name> <group>",
+	Short: "Move a client into a user group",
+	Long: `Move a client into a user group, which applies the group's bandwidth
+limits to it.
+
+The group can be given by ID or name. Use "unifi clients list" to see the user
+group of every connected client.`,
+	Example: `  unifi clients set-group kids-tablet "Kids 10M"
+  unifi clients set-group aa:bb:cc:dd:ee:ff Default`,
+	Args: cobra.ExactArgs(2),
+	RunE: runClientsSetGroup,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsSetGroupCmd)
+}
+
+func runClientsSetGroup(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	macs, err := resolveClientMACs(apiClient, args[:1])
+	if err != nil {
+		return err
+	}
+
+	groups, err := apiClient.ListUserGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list user groups: %w", err)
+	}
+	group, err := api.FindUserGroup(groups, args[1])
+	if err != nil {
+		return err
+	}
+
+	user, err := apiClient.GetUser(macs[0])
+	if err != nil {
+		return fmt.Errorf("failed to look up client: %w", err)
+	}
+
+	if _, err := apiClient.UpdateUser(user.ID, map[string]interface{}{"usergroup_id": group.ID}); err != nil {
+		return fmt.Errorf("failed to set user group: %w", err)
+	}
+
+	fmt.Printf("Moved %s to user group %s (%s)\n", user.MAC, group.Name, group.Limits())
+	return nil
+}
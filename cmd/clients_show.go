@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/history"
+	"github.com/nkn/unifi-cli/internal/location"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	showFormat string
+	showField  string
+)
+
+var clientsShowCmd = &cobra.Command{
+	Use:     "show <mac|name>",
+	Aliases: []string{"get"},
+	Short:   "Show all details of a client",
+	Long: `Show every field the controller reports for a single connected client.
+
+For a known client that is not connected, its stored record is shown with the
+last AP or switch port it was seen on, from the changes recorded in watch mode
+and the controller's client events of the last 30 days.
+
+The client can be given by MAC address, alias or hostname. With --get only the
+raw value of one JSON field is printed, for assigning it in shell scripts.`,
+	Example: `  unifi clients show my-nas
+  unifi clients get my-nas --get ip
+  NAS_IP=$(unifi clients get my-nas --get ip)`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClientsShow,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsShowCmd)
+
+	clientsShowCmd.Flags().StringVarP(&showFormat, "format", "f", "table", "Output format (table or json)")
+	clientsShowCmd.Flags().StringVar(&showField, "get", "", "Print only the raw value of this JSON field (e.g. ip, hostname)")
+}
+
+func runClientsShow(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	mac, err := resolveKnownClientMAC(apiClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := apiClient.GetClient(mac)
+	if errors.Is(err, api.ErrNotConnected) {
+		return showOfflineClient(apiClient, mac)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get client: %w", err)
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+	groups, err := apiClient.ListUserGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list user groups: %w", err)
+	}
+	clients := []api.Client{*client}
+	api.JoinNetworkNames(clients, networks)
+	api.JoinUserGroupNames(clients, groups)
+	client = &clients[0]
+
+	if showField != "" {
+		return output.PrintField(client, showField)
+	}
+
+	switch showFormat {
+	case "json":
+		return output.PrintJSON(client)
+	case "table":
+		output.PrintClientDetails(client)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", showFormat)
+	}
+}
+
+// locationWindow is how far back controller events are searched for the
+// last location of an offline client
+const locationWindow = 30 * 24 * time.Hour
+
+// offlineClient is the JSON form of a known client that is not connected
+type offlineClient struct {
+	*api.User
+	Connected    bool               `json:"connected"`
+	LastLocation *location.Location `json:"last_location"`
+}
+
+// resolveKnownClientMAC resolves a MAC, or the name of a connected or else a
+// known client, to a MAC address
+func resolveKnownClientMAC(apiClient *api.APIClient, arg string) (string, error) {
+	macs, err := resolveClientMACs(apiClient, []string{arg})
+	if err == nil {
+		return macs[0], nil
+	}
+
+	users, listErr := apiClient.ListUsers()
+	if listErr != nil {
+		return "", fmt.Errorf("failed to list known clients: %w", listErr)
+	}
+	user, findErr := api.FindUser(users, arg)
+	if findErr != nil {
+		return "", err
+	}
+	return user.MAC, nil
+}
+
+func showOfflineClient(apiClient *api.APIClient, mac string) error {
+	user, err := apiClient.GetUser(mac)
+	if err != nil {
+		return fmt.Errorf("client %s is not connected: %w", mac, err)
+	}
+
+	cfg := config.Get()
+	ring, err := history.Load(historyPath(), cfg.HistorySize)
+	if err != nil {
+		return err
+	}
+
+	events, err := apiClient.ListEvents(locationWindow, 3000)
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	loc := location.Last(user.MAC, ring.Since(cfg.Host, time.Time{}), events)
+	if loc != nil {
+		devices, err := apiClient.ListDevices()
+		if err != nil {
+			return fmt.Errorf("failed to list devices: %w", err)
+		}
+		loc.NameDevices(devices)
+	}
+
+	offline := offlineClient{User: user, LastLocation: loc}
+
+	if showField != "" {
+		return output.PrintField(offline, showField)
+	}
+
+	switch showFormat {
+	case "json":
+		return output.PrintJSON(offline)
+	case "table":
+		output.PrintOfflineClientDetails(user, loc)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", showFormat)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsColumn  string
+	statsBuckets int
+	statsFormat  string
+)
+
+var clientsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarise a numeric client column",
+	Long: `Compute count, min, max, average, percentiles and a histogram for any
+numeric filter column, optionally over a filtered set of clients.`,
+	Example: `  unifi clients stats --column signal
+  unifi clients stats --column satisfaction --filter "essid = 'HomeWiFi'"`,
+	RunE: runClientsStats,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsStatsCmd)
+
+	clientsStatsCmd.Flags().StringVar(&statsColumn, "column", "", "Numeric column to summarise (e.g. signal, satisfaction, rx_bytes)")
+	clientsStatsCmd.Flags().IntVar(&statsBuckets, "buckets", 10, "Number of histogram buckets")
+	clientsStatsCmd.Flags().StringVarP(&statsFormat, "format", "f", "table", "Output format (table or json)")
+	clientsStatsCmd.Flags().StringVar(&filterSQL, "filter", "", "SQL WHERE clause selecting the clients to include")
+	clientsStatsCmd.MarkFlagRequired("column")
+}
+
+func runClientsStats(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	whereClause, err := buildWhereClause()
+	if err != nil {
+		return err
+	}
+	if whereClause == "" {
+		whereClause = "1 = 1"
+	}
+
+	filterEngine, err := filter.NewFilter(whereClause)
+	if err != nil {
+		return fmt.Errorf("failed to create filter: %w", err)
+	}
+	defer filterEngine.Close()
+
+	stats, err := filterEngine.Stats(clients, statsColumn, statsBuckets)
+	if err != nil {
+		return fmt.Errorf("failed to compute statistics: %w", err)
+	}
+
+	switch statsFormat {
+	case "json":
+		return output.PrintJSON(stats)
+	case "table":
+		output.PrintColumnStats(stats)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", statsFormat)
+	}
+}
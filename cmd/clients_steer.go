@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	steerBand     string
+	steerAttempts int
+	steerWait     time.Duration
+)
+
+// steerPollInterval is how often steer checks whether the client is back
+const steerPollInterval = 2 * time.Second
+
+var clientsSteerCmd = &cobra.Command{
+	Use:   "steer <mac|name>",
+	Short: "Push a dual-band client to a faster band",
+	Long: `Move a sticky dual-band client off 2.4 GHz. While the client is associated on
+another band it is kicked, and the command waits for it to reconnect; this is
+repeated up to --attempts times. Most clients prefer the faster band when they
+reconnect, but the final choice is always the client's.`,
+	Example: `  unifi clients steer aa:bb:cc:dd:ee:ff
+  unifi clients steer "Living Room TV" --band 5g --attempts 5`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClientsSteer,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsSteerCmd)
+
+	clientsSteerCmd.Flags().StringVar(&steerBand, "band", api.Band5G, "Band to steer to (5g or 6g)")
+	clientsSteerCmd.Flags().IntVar(&steerAttempts, "attempts", 3, "How many times to kick the client")
+	clientsSteerCmd.Flags().DurationVar(&steerWait, "wait", 30*time.Second, "How long to wait for the client to reconnect after each kick")
+}
+
+func runClientsSteer(cmd *cobra.Command, args []string) error {
+	if steerBand != api.Band5G && steerBand != api.Band6G {
+		return fmt.Errorf("invalid band: %s (valid options: 5g, 6g)", steerBand)
+	}
+	if steerAttempts < 1 {
+		return fmt.Errorf("--attempts must be at least 1")
+	}
+
+	apiClient := newAPIClient()
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	client, err := api.FindClient(clients, args[0])
+	if err != nil {
+		return err
+	}
+	if client.IsWired {
+		return fmt.Errorf("%s is a wired client", client.GetDisplayName())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for attempt := 1; ; attempt++ {
+		if client.GetBand() == steerBand {
+			fmt.Printf("%s is on %s (channel %d, %d dBm)\n", client.GetDisplayName(), api.BandName(steerBand), client.Channel, client.Signal)
+			return nil
+		}
+		if attempt > steerAttempts {
+			return fmt.Errorf("%s stayed on %s after %d attempts", client.GetDisplayName(), api.BandName(client.GetBand()), steerAttempts)
+		}
+
+		fmt.Printf("%s is on %s (channel %d), kicking (attempt %d of %d)\n", client.GetDisplayName(), api.BandName(client.GetBand()), client.Channel, attempt, steerAttempts)
+		if err := apiClient.KickClient(client.MAC); err != nil {
+			return fmt.Errorf("failed to kick client: %w", err)
+		}
+
+		client, err = waitForReassociation(ctx, apiClient, client)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// waitForReassociation polls until the client has associated again after a
+// kick, recognised by a new association time
+func waitForReassociation(ctx context.Context, apiClient *api.APIClient, before *api.Client) (*api.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, steerWait)
+	defer cancel()
+
+	ticker := time.NewTicker(steerPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, fmt.Errorf("%s did not reconnect within %s", before.GetDisplayName(), steerWait)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		// Lookups fail while the client is disconnected
+		client, err := apiClient.GetClient(before.MAC)
+		if err != nil {
+			continue
+		}
+		if client.AssocTime != before.AssocTime || client.LatestAssocTime != before.LatestAssocTime {
+			return client, nil
+		}
+	}
+}
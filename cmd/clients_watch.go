@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/output"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// runClientsWatch re-renders the client table every watchInterval until
+// interrupted. Clients that connected or disconnected since the previous
+// refresh are highlighted and recorded for "events replay"; a failed refresh
+// is reported and retried on the next tick.
+func runClientsWatch(apiClient *api.APIClient, filterEngine *filter.Filter, networks []api.Network, groups []api.UserGroup) error {
+	if outputFormat != "table" {
+		return fmt.Errorf("--watch only supports table output")
+	}
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be a positive duration")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var previous []api.Client
+	first := true
+
+	for {
+		clients, err := fetchClients(apiClient, filterEngine, networks, groups)
+
+		fmt.Print(clearScreen)
+		fmt.Printf("Every %s: unifi clients list    %s\n\n", watchInterval, time.Now().Format(time.TimeOnly))
+
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			rows := clients
+			changes := map[string]output.ClientChange{}
+			var historyErr error
+
+			if !first {
+				connected, disconnected := api.DiffClients(previous, clients)
+				for _, client := range connected {
+					changes[client.MAC] = output.ClientConnected
+				}
+				for _, client := range disconnected {
+					changes[client.MAC] = output.ClientDisconnected
+				}
+
+				// Clients that just left stay visible for one refresh
+				rows = append(append([]api.Client{}, clients...), disconnected...)
+				if err := sortClients(rows); err != nil {
+					return err
+				}
+
+				if err := recordHistory(connected, disconnected); err != nil {
+					historyErr = err
+				}
+			}
+
+			output.PrintClientsWatchTable(rows, changes)
+			fmt.Printf("\n%d clients\n", len(clients))
+			if historyErr != nil {
+				fmt.Printf("Warning: failed to record changes: %v\n", historyErr)
+			}
+
+			previous = clients
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var clientsWolCmd = &cobra.Command{
+	Use:   "wol <mac|name>...",
+	Short: "Wake sleeping clients with Wake-on-LAN",
+	Long: `Ask the gateway to send a Wake-on-LAN magic packet to wired clients.
+
+Clients can be given by MAC address, alias or hostname. Names are looked up
+among all known clients, since a sleeping machine is not connected.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runClientsWol,
+}
+
+func init() {
+	clientsCmd.AddCommand(clientsWolCmd)
+}
+
+func runClientsWol(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	macs, err := resolveKnownClientMACs(apiClient, args)
+	if err != nil {
+		return err
+	}
+
+	var results []output.ActionResult
+	for _, mac := range macs {
+		results = append(results, output.ActionResult{Target: mac, Err: apiClient.WakeClient(mac)})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d clients failed", failures, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	controllerFormat string
+	controllerYes    bool
+)
+
+var controllerCmd = &cobra.Command{
+	Use:   "controller",
+	Short: "Maintain the Unifi controller",
+	Long:  `Inspect and maintain a self-hosted Network Application reachable via the UniFi OS API.`,
+}
+
+var controllerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show controller service state and version",
+	RunE:  runControllerStatus,
+}
+
+var controllerRebootCmd = &cobra.Command{
+	Use:   "reboot",
+	Short: "Reboot the console hosting the controller",
+	Long: `Reboot the whole console the Network Application runs on. The API offers no
+way to restart only the application.
+
+On a UniFi OS gateway (UDM, UCG) the console is the router, so the site loses
+its internet connection and routing until the gateway is back up, typically a
+few minutes.`,
+	Example: `  unifi controller reboot
+  unifi controller reboot --yes`,
+	Args: cobra.NoArgs,
+	RunE: runControllerReboot,
+}
+
+func init() {
+	rootCmd.AddCommand(controllerCmd)
+	controllerCmd.AddCommand(controllerStatusCmd)
+	controllerCmd.AddCommand(controllerRebootCmd)
+
+	controllerStatusCmd.Flags().StringVarP(&controllerFormat, "format", "f", "table", "Output format (table or json)")
+	controllerRebootCmd.Flags().BoolVarP(&controllerYes, "yes", "y", false, "Reboot without asking for confirmation")
+}
+
+func runControllerStatus(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	status, err := apiClient.GetControllerStatus()
+	if err != nil {
+		return fmt.Errorf("failed to get controller status: %w", err)
+	}
+
+	switch controllerFormat {
+	case "json":
+		return output.PrintJSON(status)
+	case "table":
+		state := "down"
+		if status.Up {
+			state = "up"
+		}
+		output.PrintDetails([]output.Detail{
+			{Key: "Controller", Value: config.Get().Host},
+			{Key: "Status", Value: state},
+			{Key: "Version", Value: status.ServerVersion},
+			{Key: "UUID", Value: status.UUID},
+		})
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", controllerFormat)
+	}
+}
+
+func runControllerReboot(cmd *cobra.Command, args []string) error {
+	if !controllerYes {
+		ok, err := confirm(fmt.Sprintf("Reboot the console at %s? This reboots the whole host; if it is the gateway, the site is offline until it is back up.", config.Get().Host))
+		if errors.Is(err, errNonInteractive) {
+			return fmt.Errorf("%w (use --yes to reboot without asking)", err)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	apiClient := newAPIClient()
+	if err := apiClient.RebootConsole(); err != nil {
+		return fmt.Errorf("failed to reboot console: %w", err)
+	}
+
+	fmt.Println("Console reboot requested")
+	return nil
+}
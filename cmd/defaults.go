@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// applyFlagDefaults sets the configured default values of a command's flags.
+// Flags given on the command line keep their value.
+func applyFlagDefaults(cmd *cobra.Command, defaults map[string]string) error {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	command := strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("invalid default for %q: unknown flag --%s", command, name)
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := flag.Value.Set(defaults[name]); err != nil {
+			return fmt.Errorf("invalid default for %q: --%s %s: %w", command, name, defaults[name], err)
+		}
+		flag.Changed = true
+	}
+	return nil
+}
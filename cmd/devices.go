@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devicesFormat  string
+	devicesFilter  string
+	devicesSort    string
+	devicesDetails bool
+)
+
+// detailWorkers bounds the concurrent per-device requests of devices list
+// --details
+const detailWorkers = 8
+
+var devicesCmd = &cobra.Command{
+	Use:   "devices",
+	Short: "Manage Unifi devices",
+	Long:  `View and manage access points, switches and gateways.`,
+}
+
+var devicesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List devices",
+	Long: `List all access points, switches and gateways of the site.
+
+With --details every device's own record is fetched as well (several at a time)
+to add resource usage and uplink, which can also be filtered on.`,
+	Example: `  unifi devices list
+  unifi devices list --filter "type = 'uap' AND state != 'connected'"
+  unifi devices list --sort -num_sta --format json
+  unifi devices list --details --filter "cpu > 80 OR uplink_type = 'wireless'"`,
+	RunE: runDevicesList,
+}
+
+func init() {
+	rootCmd.AddCommand(devicesCmd)
+	devicesCmd.AddCommand(devicesListCmd)
+
+	devicesListCmd.Flags().StringVarP(&devicesFormat, "format", "f", "table", "Output format (table or json)")
+	devicesListCmd.Flags().StringVar(&devicesFilter, "filter", "", "SQL WHERE clause (e.g., \"type = 'uap' AND num_sta > 20\")")
+	devicesListCmd.Flags().StringVar(&devicesSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: name, then MAC)")
+	devicesListCmd.Flags().BoolVar(&devicesDetails, "details", false, "Fetch per-device details (CPU, memory, uplink)")
+}
+
+func runDevicesList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	devices, err := apiClient.ListDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	if devicesDetails {
+		devices, err = apiClient.HydrateDevices(devices, detailWorkers)
+		if err != nil {
+			for _, line := range strings.Split(err.Error(), "\n") {
+				fmt.Fprintf(os.Stderr, "Warning: failed to get details of %s\n", line)
+			}
+		}
+	}
+
+	if devicesFilter != "" {
+		filterEngine, err := filter.NewFilter(devicesFilter)
+		if err != nil {
+			return fmt.Errorf("failed to create filter: %w", err)
+		}
+		defer filterEngine.Close()
+
+		devices, err = filterEngine.ApplyDevices(devices)
+		if err != nil {
+			return fmt.Errorf("failed to apply filter: %w", err)
+		}
+	}
+
+	if len(devices) == 0 {
+		fmt.Println("No devices match the specified filters")
+		return nil
+	}
+
+	api.SortDevices(devices)
+	if err := sorting.ByKeys(devices, devicesSort); err != nil {
+		return err
+	}
+
+	switch devicesFormat {
+	case "json":
+		return output.PrintJSON(devices)
+	case "table":
+		if devicesDetails {
+			output.PrintDevicesDetailsTable(devices)
+		} else {
+			output.PrintDevicesTable(devices)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", devicesFormat)
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var adoptAllPending bool
+
+var devicesAdoptCmd = &cobra.Command{
+	Use:   "adopt [<mac>...]",
+	Short: "Adopt pending devices",
+	Long: `Adopt devices that are waiting for adoption into the site, either by MAC
+address or all at once with --all-pending.`,
+	Example: `  unifi devices adopt f0:9f:c2:00:00:02
+  unifi devices adopt --all-pending`,
+	RunE: runDevicesAdopt,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesAdoptCmd)
+
+	devicesAdoptCmd.Flags().BoolVar(&adoptAllPending, "all-pending", false, "Adopt every device pending adoption")
+}
+
+func runDevicesAdopt(cmd *cobra.Command, args []string) error {
+	if !adoptAllPending && len(args) == 0 {
+		return fmt.Errorf("specify devices to adopt or --all-pending")
+	}
+	if adoptAllPending && len(args) > 0 {
+		return fmt.Errorf("--all-pending cannot be combined with explicit devices")
+	}
+
+	for i, arg := range args {
+		mac, ok := api.NormalizeMAC(arg)
+		if !ok {
+			return fmt.Errorf("invalid MAC address: %s", arg)
+		}
+		args[i] = mac
+	}
+
+	apiClient := newAPIClient()
+
+	pending, err := apiClient.ListPendingDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	macs := args
+	if adoptAllPending {
+		if len(pending) == 0 {
+			fmt.Println("No devices pending adoption")
+			return nil
+		}
+		macs = make([]string, len(pending))
+		for i, device := range pending {
+			macs[i] = device.MAC
+		}
+	}
+
+	isPending := make(map[string]bool, len(pending))
+	for _, device := range pending {
+		isPending[strings.ToLower(device.MAC)] = true
+	}
+
+	var results []output.ActionResult
+	for _, mac := range macs {
+		if !isPending[strings.ToLower(mac)] {
+			results = append(results, output.ActionResult{Target: mac, Err: fmt.Errorf("not pending adoption")})
+			continue
+		}
+		results = append(results, output.ActionResult{Target: mac, Err: apiClient.AdoptDevice(mac)})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d devices failed", failures, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inventoryFormat   string
+	inventoryAllSites bool
+)
+
+var devicesInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Export a hardware inventory of the devices",
+	Long: `List every device with its site, model, serial number, MAC address, firmware
+and adoption date, e.g. for an asset register. LTS and EOL mark models that
+only get long-term support firmware or have reached end of life.
+
+The adoption date is empty when the controller does not report it.`,
+	Example: `  unifi devices inventory --format csv > inventory.csv
+  unifi devices inventory --all-sites --format csv
+  unifi devices inventory --format json`,
+	Args: cobra.NoArgs,
+	RunE: runDevicesInventory,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesInventoryCmd)
+
+	devicesInventoryCmd.Flags().StringVarP(&inventoryFormat, "format", "f", "table", "Output format (table, csv or json)")
+	devicesInventoryCmd.Flags().BoolVar(&inventoryAllSites, "all-sites", false, "Include the devices of every site")
+}
+
+func runDevicesInventory(cmd *cobra.Command, args []string) error {
+	if inventoryFormat != "table" && inventoryFormat != "csv" && inventoryFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, csv, json)", inventoryFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	sites := []string{apiClient.Site}
+	if inventoryAllSites {
+		all, err := apiClient.ListSites()
+		if err != nil {
+			return fmt.Errorf("failed to list sites: %w", err)
+		}
+		sites = sites[:0]
+		for _, site := range all {
+			sites = append(sites, site.Name)
+		}
+	}
+
+	items := []api.InventoryItem{}
+	for _, site := range sites {
+		apiClient.Site = site
+		devices, err := apiClient.ListDevices()
+		if err != nil {
+			return fmt.Errorf("failed to list devices of site %s: %w", site, err)
+		}
+		items = append(items, api.Inventory(site, devices)...)
+	}
+
+	switch inventoryFormat {
+	case "json":
+		return output.PrintJSON(items)
+	case "csv":
+		return output.PrintInventoryCSV(items)
+	default:
+		output.PrintInventoryTable(items)
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var locateDuration time.Duration
+
+var devicesLocateCmd = &cobra.Command{
+	Use:   "locate <mac>",
+	Short: "Flash a device's LED to find it",
+	Long: `Turn on a device's locate mode, which flashes its LED, and turn it off again
+after --duration or on Ctrl-C.`,
+	Example: `  unifi devices locate f0:9f:c2:00:00:01
+  unifi devices locate f0:9f:c2:00:00:01 --duration 5m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDevicesLocate,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesLocateCmd)
+
+	devicesLocateCmd.Flags().DurationVar(&locateDuration, "duration", 60*time.Second, "How long to flash the LED")
+}
+
+func runDevicesLocate(cmd *cobra.Command, args []string) error {
+	mac, ok := api.NormalizeMAC(args[0])
+	if !ok {
+		return fmt.Errorf("invalid MAC address: %s", args[0])
+	}
+	if locateDuration <= 0 {
+		return fmt.Errorf("--duration must be a positive duration")
+	}
+
+	apiClient := newAPIClient()
+
+	if err := apiClient.LocateDevice(mac, true); err != nil {
+		return fmt.Errorf("failed to enable locate mode: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Flashing the LED of %s for %s (Ctrl-C to stop)\n", mac, locateDuration)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(locateDuration):
+	}
+
+	if err := apiClient.LocateDevice(mac, false); err != nil {
+		return fmt.Errorf("failed to disable locate mode: %w", err)
+	}
+
+	fmt.Println("Locate mode off")
+	return nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var devicesPortCmd = &cobra.Command{
+	Use:   "port",
+	Short: "Manage switch ports",
+	Long:  `Act on individual ports of a switch.`,
+}
+
+var devicesPortPoECycleCmd = &cobra.Command{
+	Use:   "poe-cycle <switch-mac> <port-idx>",
+	Short: "Power-cycle a PoE port",
+	Long: `Briefly cut PoE power on a switch port, rebooting the camera, access point
+or other device powered by it. The port must exist and supply PoE.`,
+	Example: `  unifi devices port poe-cycle f0:9f:c2:00:00:02 7`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runDevicesPortPoECycle,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesPortCmd)
+	devicesPortCmd.AddCommand(devicesPortPoECycleCmd)
+}
+
+func runDevicesPortPoECycle(cmd *cobra.Command, args []string) error {
+	mac, ok := api.NormalizeMAC(args[0])
+	if !ok {
+		return fmt.Errorf("invalid MAC address: %s", args[0])
+	}
+
+	index, err := strconv.Atoi(args[1])
+	if err != nil || index < 1 {
+		return fmt.Errorf("invalid port index: %s", args[1])
+	}
+
+	apiClient := newAPIClient()
+
+	device, err := apiClient.GetDevice(mac)
+	if err != nil {
+		return fmt.Errorf("failed to get device: %w", err)
+	}
+
+	port, err := device.FindPort(index)
+	if err != nil {
+		return err
+	}
+	if !port.PoE {
+		return fmt.Errorf("port %d of %s does not supply PoE", index, device.GetDisplayName())
+	}
+	if port.PoEMode == "off" {
+		return fmt.Errorf("PoE is turned off on port %d of %s", index, device.GetDisplayName())
+	}
+
+	if err := apiClient.PowerCyclePort(mac, index); err != nil {
+		return fmt.Errorf("failed to power-cycle port: %w", err)
+	}
+
+	fmt.Printf("Power-cycled port %d (%s) of %s\n", index, port.Name, device.GetDisplayName())
+	return nil
+}
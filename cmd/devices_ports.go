@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portsFormat string
+	portsFilter string
+)
+
+var devicesPortsCmd = &cobra.Command{
+	Use:   "ports <switch-mac>",
+	Short: "Show the port table of a switch",
+	Long: `Show link state, speed, PoE draw, port profile and native network, traffic,
+errors and the number of connected clients for every port of a switch.`,
+	Example: `  unifi devices ports f0:9f:c2:00:00:02
+  unifi devices ports f0:9f:c2:00:00:02 --filter "errors > 0"
+  unifi devices ports f0:9f:c2:00:00:02 --filter "poe AND up AND NOT poe_good" --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDevicesPorts,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesPortsCmd)
+
+	devicesPortsCmd.Flags().StringVarP(&portsFormat, "format", "f", "table", "Output format (table or json)")
+	devicesPortsCmd.Flags().StringVar(&portsFilter, "filter", "", "SQL WHERE clause (e.g., \"errors > 0 OR NOT up\")")
+}
+
+func runDevicesPorts(cmd *cobra.Command, args []string) error {
+	mac, ok := api.NormalizeMAC(args[0])
+	if !ok {
+		return fmt.Errorf("invalid MAC address: %s", args[0])
+	}
+
+	apiClient := newAPIClient()
+
+	device, err := apiClient.GetDevice(mac)
+	if err != nil {
+		return fmt.Errorf("failed to get device: %w", err)
+	}
+	if len(device.PortTable) == 0 {
+		return fmt.Errorf("%s has no ports", device.GetDisplayName())
+	}
+
+	profiles, err := apiClient.ListPortProfiles()
+	if err != nil {
+		return fmt.Errorf("failed to list port profiles: %w", err)
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	ports := api.PortStatuses(*device, profiles, networks, clients)
+
+	if portsFilter != "" {
+		filterEngine, err := filter.NewFilter(portsFilter)
+		if err != nil {
+			return fmt.Errorf("failed to create filter: %w", err)
+		}
+		defer filterEngine.Close()
+
+		ports, err = filterEngine.ApplyPorts(ports)
+		if err != nil {
+			return fmt.Errorf("failed to apply filter: %w", err)
+		}
+	}
+
+	switch portsFormat {
+	case "json":
+		return output.PrintJSON(ports)
+	case "table":
+		output.PrintPortsTable(ports)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", portsFormat)
+	}
+}
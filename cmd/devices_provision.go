@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var provisionAll bool
+
+var devicesProvisionCmd = &cobra.Command{
+	Use:   "provision [<mac>...]",
+	Short: "Push the current configuration to devices",
+	Long: `Force-provision devices so configuration changes are applied immediately
+instead of with the next regular provisioning, either by MAC address or every
+adopted device of the site with --all.`,
+	Example: `  unifi devices provision f0:9f:c2:00:00:02
+  unifi devices provision --all`,
+	RunE: runDevicesProvision,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesProvisionCmd)
+
+	devicesProvisionCmd.Flags().BoolVar(&provisionAll, "all", false, "Provision every adopted device of the site")
+}
+
+func runDevicesProvision(cmd *cobra.Command, args []string) error {
+	if !provisionAll && len(args) == 0 {
+		return fmt.Errorf("specify devices to provision or --all")
+	}
+	if provisionAll && len(args) > 0 {
+		return fmt.Errorf("--all cannot be combined with explicit devices")
+	}
+
+	for i, arg := range args {
+		mac, ok := api.NormalizeMAC(arg)
+		if !ok {
+			return fmt.Errorf("invalid MAC address: %s", arg)
+		}
+		args[i] = mac
+	}
+
+	apiClient := newAPIClient()
+
+	devices, err := apiClient.ListDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	isAdopted := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		isAdopted[strings.ToLower(device.MAC)] = device.Adopted
+	}
+
+	macs := args
+	if provisionAll {
+		macs = nil
+		for _, device := range devices {
+			if device.Adopted {
+				macs = append(macs, device.MAC)
+			}
+		}
+		if len(macs) == 0 {
+			fmt.Println("No adopted devices")
+			return nil
+		}
+	}
+
+	var results []output.ActionResult
+	for _, mac := range macs {
+		if !isAdopted[strings.ToLower(mac)] {
+			results = append(results, output.ActionResult{Target: mac, Err: fmt.Errorf("not adopted")})
+			continue
+		}
+		results = append(results, output.ActionResult{Target: mac, Err: apiClient.ProvisionDevice(mac)})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d devices failed", failures, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	radiosFilter   string
+	radiosInterval time.Duration
+)
+
+var devicesRadiosCmd = &cobra.Command{
+	Use:   "radios",
+	Short: "Inspect access point radios",
+}
+
+var devicesRadiosWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch channel utilization per AP radio",
+	Long: `Show the channel, channel utilization and interference of every access point
+radio, refreshed every --interval until Ctrl-C, to see the effect of channel
+changes as they happen.
+
+Utilization is the share of airtime the radio sees as busy; interference is the
+part not caused by the AP's own traffic (neighbouring networks, non-WiFi
+noise). Changes since the previous refresh are shown next to each value, and a
+changed channel is highlighted with the channel it had before.`,
+	Example: `  unifi devices radios watch
+  unifi devices radios watch --filter "name LIKE 'Office%'" --interval 10s`,
+	Args: cobra.NoArgs,
+	RunE: runDevicesRadiosWatch,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesRadiosCmd)
+	devicesRadiosCmd.AddCommand(devicesRadiosWatchCmd)
+
+	devicesRadiosWatchCmd.Flags().StringVar(&radiosFilter, "filter", "", "SQL WHERE clause selecting the devices (e.g., \"name LIKE 'Office%'\")")
+	devicesRadiosWatchCmd.Flags().DurationVar(&radiosInterval, "interval", 5*time.Second, "Refresh interval")
+}
+
+func runDevicesRadiosWatch(cmd *cobra.Command, args []string) error {
+	if radiosInterval <= 0 {
+		return fmt.Errorf("--interval must be a positive duration")
+	}
+
+	var filterEngine *filter.Filter
+	if radiosFilter != "" {
+		var err error
+		filterEngine, err = filter.NewFilter(radiosFilter)
+		if err != nil {
+			return fmt.Errorf("failed to create filter: %w", err)
+		}
+		defer filterEngine.Close()
+	}
+
+	apiClient := newAPIClient()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(radiosInterval)
+	defer ticker.Stop()
+
+	var previous []api.Device
+
+	for {
+		radios, err := fetchRadios(apiClient, filterEngine)
+
+		fmt.Print(clearScreen)
+		fmt.Printf("Every %s: unifi devices radios watch    %s\n\n", radiosInterval, time.Now().Format(time.TimeOnly))
+
+		switch {
+		case err != nil:
+			fmt.Printf("Error: %v\n", err)
+		case len(radios) == 0:
+			fmt.Println("No access point radios match the specified filters")
+		default:
+			output.PrintRadiosWatchTable(radios, previous)
+			previous = radios
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchRadios returns the devices that report radio counters, filtered and
+// sorted for a radios watch refresh
+func fetchRadios(apiClient *api.APIClient, filterEngine *filter.Filter) ([]api.Device, error) {
+	devices, err := apiClient.ListDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	if filterEngine != nil {
+		devices, err = filterEngine.ApplyDevices(devices)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply filter: %w", err)
+		}
+	}
+
+	var radios []api.Device
+	for _, device := range devices {
+		if len(device.RadioStats) > 0 {
+			radios = append(radios, device)
+		}
+	}
+
+	api.SortDevices(radios)
+	return radios, nil
+}
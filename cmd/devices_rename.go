@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/naming"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devicesRenamePattern string
+	devicesRenameFilter  string
+	devicesRenameSort    string
+	devicesRenameDryRun  bool
+	devicesRenameYes     bool
+)
+
+var devicesRenameCmd = &cobra.Command{
+	Use:   "rename --pattern <template>",
+	Short: "Give devices standardized names",
+	Long: `Name devices after a pattern. The new names are previewed first and only
+applied after confirmation (or with --yes); --dry-run stops after the preview.
+Devices whose name would not change are left out.
+
+The pattern is a Go template with these fields:
+  .Name .Model .Type .MAC .IP .Serial .Site
+
+.Site is the site's description, or its ID when it has none. The counter
+function numbers the devices in --sort order (default: name, then MAC), and
+{{counter .Model}} numbers each model separately. Pad it with printf, e.g.
+{{printf "%02d" counter}}. The lower, upper and slug functions change the case
+of a value; slug also replaces spaces and punctuation with "-".`,
+	Example: `  unifi devices rename --pattern '{{.Model}}-{{.Site}}-{{counter}}' --dry-run
+  unifi devices rename --pattern '{{.Site | slug}}-ap-{{printf "%02d" counter}}' --filter "type = 'uap'"
+  unifi devices rename --pattern '{{.Model}}-{{counter .Model}}' --sort ip --yes`,
+	Args: cobra.NoArgs,
+	RunE: runDevicesRename,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesRenameCmd)
+
+	devicesRenameCmd.Flags().StringVar(&devicesRenamePattern, "pattern", "", "Name template, e.g. '{{.Model}}-{{.Site}}-{{counter}}'")
+	devicesRenameCmd.Flags().StringVar(&devicesRenameFilter, "filter", "", "SQL WHERE clause selecting the devices to rename (e.g., \"type = 'uap'\")")
+	devicesRenameCmd.Flags().StringVar(&devicesRenameSort, "sort", "", "Comma separated sort keys that decide the counter order (default: name, then MAC)")
+	devicesRenameCmd.Flags().BoolVar(&devicesRenameDryRun, "dry-run", false, "Only show the new names")
+	devicesRenameCmd.Flags().BoolVarP(&devicesRenameYes, "yes", "y", false, "Rename without asking for confirmation")
+	devicesRenameCmd.MarkFlagRequired("pattern")
+}
+
+// deviceNameData is what {{.Field}} refers to in a rename pattern
+type deviceNameData struct {
+	Name   string
+	Model  string
+	Type   string
+	MAC    string
+	IP     string
+	Serial string
+	Site   string
+}
+
+func runDevicesRename(cmd *cobra.Command, args []string) error {
+	pattern, err := naming.Compile(devicesRenamePattern)
+	if err != nil {
+		return err
+	}
+
+	apiClient := newAPIClient()
+
+	devices, err := apiClient.ListDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	if devicesRenameFilter != "" {
+		filterEngine, err := filter.NewFilter(devicesRenameFilter)
+		if err != nil {
+			return fmt.Errorf("failed to create filter: %w", err)
+		}
+		defer filterEngine.Close()
+
+		devices, err = filterEngine.ApplyDevices(devices)
+		if err != nil {
+			return fmt.Errorf("failed to apply filter: %w", err)
+		}
+	}
+
+	if len(devices) == 0 {
+		fmt.Println("No devices match the specified filters")
+		return nil
+	}
+
+	api.SortDevices(devices)
+	if err := sorting.ByKeys(devices, devicesRenameSort); err != nil {
+		return err
+	}
+
+	site := siteLabel(apiClient, devicesRenamePattern)
+
+	// Every device takes part in numbering, even when its name does not
+	// change, so the counter does not depend on the current names
+	var renames []output.DeviceRename
+	ids := map[string]string{}
+	taken := map[string]string{}
+	for _, device := range devices {
+		name, err := pattern.Next(deviceNameData{
+			Name:   device.Name,
+			Model:  device.Model,
+			Type:   device.Type,
+			MAC:    device.MAC,
+			IP:     device.IP,
+			Serial: device.Serial,
+			Site:   site,
+		})
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return fmt.Errorf("pattern gives %s an empty name", device.MAC)
+		}
+		if other, ok := taken[strings.ToLower(name)]; ok {
+			return fmt.Errorf("pattern gives %s and %s the same name %q (add {{counter}} to the pattern)", other, device.MAC, name)
+		}
+		taken[strings.ToLower(name)] = device.MAC
+
+		if name == device.Name {
+			continue
+		}
+		renames = append(renames, output.DeviceRename{MAC: device.MAC, Model: device.Model, From: device.Name, To: name})
+		ids[device.MAC] = device.ID
+	}
+
+	if len(renames) == 0 {
+		fmt.Println("All devices already have their pattern name")
+		return nil
+	}
+
+	output.PrintDeviceRenames(renames)
+
+	if devicesRenameDryRun {
+		return nil
+	}
+
+	if !devicesRenameYes {
+		ok, err := confirm(fmt.Sprintf("Rename %d devices?", len(renames)))
+		if errors.Is(err, errNonInteractive) {
+			return fmt.Errorf("%w (use --yes to rename without asking)", err)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	var results []output.ActionResult
+	for _, rename := range renames {
+		err := apiClient.UpdateDevice(ids[rename.MAC], map[string]interface{}{"name": rename.To})
+		results = append(results, output.ActionResult{Target: rename.MAC, Err: err})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d devices failed", failures, len(results))
+	}
+	return nil
+}
+
+// siteLabel returns the description of the current site for patterns that
+// use .Site, falling back to its ID when the site has no description or the
+// sites cannot be listed
+func siteLabel(apiClient *api.APIClient, pattern string) string {
+	siteID := config.Get().Site
+	if !strings.Contains(pattern, ".Site") {
+		return siteID
+	}
+
+	sites, err := apiClient.ListSites()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: using the site ID for .Site: %v\n", err)
+		return siteID
+	}
+
+	for _, site := range sites {
+		if site.Name == siteID && site.Desc != "" {
+			return site.Desc
+		}
+	}
+	return siteID
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var devicesTagCmd = &cobra.Command{
+	Use:   "tag <mac> <key=value>...",
+	Short: "Set tags on a device",
+	Long: `Set key=value tags on a device. Tags are stored in the device note on the
+controller and can be filtered on as tag_<key> columns, e.g.
+--filter "tag_location LIKE 'branch-%'".
+
+Keys may contain lower-case letters, digits and _, values cannot contain
+spaces. An empty value (key=) removes the tag. Other words in the note are
+kept.`,
+	Example: `  unifi devices tag f0:9f:c2:00:00:01 location=closet-2
+  unifi devices tag f0:9f:c2:00:00:01 location=branch-north rack=3
+  unifi devices tag f0:9f:c2:00:00:01 rack=`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runDevicesTag,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesTagCmd)
+}
+
+func runDevicesTag(cmd *cobra.Command, args []string) error {
+	mac, ok := api.NormalizeMAC(args[0])
+	if !ok {
+		return fmt.Errorf("invalid MAC address: %s", args[0])
+	}
+
+	// Check every tag first, so nothing is changed after a typo
+	type tag struct{ key, value string }
+	var tags []tag
+	for _, arg := range args[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid tag %q (expected key=value)", arg)
+		}
+		if !api.ValidTagKey(key) {
+			return fmt.Errorf("invalid tag key %q (use lower-case letters, digits and _)", key)
+		}
+		if strings.ContainsAny(value, " \t\r\n") {
+			return fmt.Errorf("invalid value for tag %s: values cannot contain spaces", key)
+		}
+		tags = append(tags, tag{key, value})
+	}
+
+	apiClient := newAPIClient()
+
+	device, err := apiClient.GetDevice(mac)
+	if err != nil {
+		return fmt.Errorf("failed to get device: %w", err)
+	}
+
+	note := device.Note
+	for _, t := range tags {
+		note = api.SetTag(note, t.key, t.value)
+	}
+
+	if note != device.Note {
+		if err := apiClient.UpdateDevice(device.ID, map[string]interface{}{"note": note}); err != nil {
+			return fmt.Errorf("failed to tag device: %w", err)
+		}
+	}
+
+	current := api.ParseTags(note)
+	if len(current) == 0 {
+		fmt.Printf("%s has no tags\n", device.GetDisplayName())
+		return nil
+	}
+
+	words := make([]string, 0, len(current))
+	for key, value := range current {
+		words = append(words, key+"="+value)
+	}
+	sort.Strings(words)
+	fmt.Printf("Tags of %s: %s\n", device.GetDisplayName(), strings.Join(words, " "))
+	return nil
+}
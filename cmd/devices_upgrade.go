@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeFirmwareURL string
+	upgradeWait        bool
+	upgradeWaitTimeout time.Duration
+)
+
+// upgradePollInterval is how often --wait checks the device state
+const upgradePollInterval = 10 * time.Second
+
+var devicesUpgradeCmd = &cobra.Command{
+	Use:   "upgrade <mac>",
+	Short: "Upgrade device firmware",
+	Long: `Start a firmware upgrade of a device, to the latest release known to the
+controller or to a specific image with --firmware-url.
+
+With --wait the command follows the device state until it is back online with
+new firmware, and fails if that takes longer than --wait-timeout.`,
+	Example: `  unifi devices upgrade f0:9f:c2:00:00:01
+  unifi devices upgrade f0:9f:c2:00:00:01 --wait
+  unifi devices upgrade f0:9f:c2:00:00:01 --firmware-url https://fw.example.com/BZ.mt7621_6.6.78.bin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDevicesUpgrade,
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesUpgradeCmd)
+
+	devicesUpgradeCmd.Flags().StringVar(&upgradeFirmwareURL, "firmware-url", "", "Install the firmware image at this URL instead of the latest release")
+	devicesUpgradeCmd.Flags().BoolVar(&upgradeWait, "wait", false, "Wait until the upgrade has completed")
+	devicesUpgradeCmd.Flags().DurationVar(&upgradeWaitTimeout, "wait-timeout", 15*time.Minute, "Give up waiting after this long")
+}
+
+func runDevicesUpgrade(cmd *cobra.Command, args []string) error {
+	mac, ok := api.NormalizeMAC(args[0])
+	if !ok {
+		return fmt.Errorf("invalid MAC address: %s", args[0])
+	}
+
+	apiClient := newAPIClient()
+
+	device, err := apiClient.GetDevice(mac)
+	if err != nil {
+		return fmt.Errorf("failed to get device: %w", err)
+	}
+
+	if upgradeFirmwareURL == "" {
+		if !device.Upgradable {
+			return fmt.Errorf("%s is already running the latest firmware (%s); use --firmware-url to install a specific image", device.GetDisplayName(), device.Version)
+		}
+		err = apiClient.UpgradeDevice(mac)
+	} else {
+		err = apiClient.UpgradeDeviceFromURL(mac, upgradeFirmwareURL)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start upgrade: %w", err)
+	}
+
+	target := device.UpgradeToFirmware
+	if upgradeFirmwareURL != "" {
+		target = upgradeFirmwareURL
+	}
+	fmt.Printf("Upgrading %s from %s to %s\n", device.GetDisplayName(), device.Version, target)
+
+	if !upgradeWait {
+		return nil
+	}
+	return waitForUpgrade(apiClient, device)
+}
+
+// waitForUpgrade polls the device until it is connected again after having
+// been seen upgrading, rebooting or with a different firmware version. Failed
+// lookups are reported and retried.
+func waitForUpgrade(apiClient *api.APIClient, before *api.Device) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(ctx, upgradeWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(upgradePollInterval)
+	defer ticker.Stop()
+
+	lastState := before.GetState()
+	started := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("upgrade of %s did not complete within %s (last state: %s)", before.GetDisplayName(), upgradeWaitTimeout, lastState)
+			}
+			return fmt.Errorf("stopped waiting; the upgrade continues on the device")
+		case <-ticker.C:
+		}
+
+		device, err := apiClient.GetDevice(before.MAC)
+		if err != nil {
+			fmt.Printf("%s  failed to get device: %v\n", time.Now().Format(time.TimeOnly), err)
+			continue
+		}
+
+		if state := device.GetState(); state != lastState {
+			fmt.Printf("%s  %s\n", time.Now().Format(time.TimeOnly), state)
+			lastState = state
+		}
+
+		if device.IsUpgrading() || device.State == 0 || device.Version != before.Version {
+			started = true
+		}
+
+		if started && device.State == 1 {
+			if device.Version == before.Version {
+				return fmt.Errorf("%s is back online but still runs %s", device.GetDisplayName(), device.Version)
+			}
+			fmt.Printf("Upgraded %s to %s\n", device.GetDisplayName(), device.Version)
+			return nil
+		}
+	}
+}
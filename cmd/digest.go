@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/digest"
+	"github.com/nkn/unifi-cli/internal/history"
+	"github.com/nkn/unifi-cli/internal/notify"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	digestFormat  string
+	digestTop     int
+	digestWebhook string
+	digestSlack   string
+	digestNtfy    string
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Summarize the site as a short message",
+}
+
+var digestGuestsCmd = &cobra.Command{
+	Use:   "guests",
+	Short: "Summarize who is on the guest network right now",
+	Long: `Print a compact summary of the guest network: how many guests are connected,
+which of them were first seen today, the top consumers and the average signal.
+Guests that connected today and already left are counted from the history
+recorded by watch mode.
+
+The summary is meant for a daily chat post: besides printing it, it can be sent
+to a Slack incoming webhook, an ntfy topic or a generic JSON webhook.`,
+	Example: `  unifi digest guests
+  unifi digest guests --slack https://hooks.slack.com/services/T000/B000/XXXX
+  unifi digest guests --ntfy https://ntfy.sh/office-guests --top 3`,
+	Args: cobra.NoArgs,
+	RunE: runDigestGuests,
+}
+
+func init() {
+	rootCmd.AddCommand(digestCmd)
+	digestCmd.AddCommand(digestGuestsCmd)
+
+	digestCmd.PersistentFlags().StringVarP(&digestFormat, "format", "f", "text", "Output format (text or json)")
+	digestCmd.PersistentFlags().StringVar(&digestWebhook, "webhook", "", "Also post the digest as JSON to this URL")
+	digestCmd.PersistentFlags().StringVar(&digestSlack, "slack", "", "Also post the digest to this Slack incoming webhook")
+	digestCmd.PersistentFlags().StringVar(&digestNtfy, "ntfy", "", "Also publish the digest to this ntfy topic URL")
+	digestGuestsCmd.Flags().IntVar(&digestTop, "top", 5, "Number of top consumers to list")
+}
+
+func runDigestGuests(cmd *cobra.Command, args []string) error {
+	if digestFormat != "text" && digestFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: text, json)", digestFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	cfg := config.Get()
+	ring, err := history.Load(historyPath(), cfg.HistorySize)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	guests := digest.BuildGuests(clients, ring.Since(cfg.Host, now.Add(-24*time.Hour)), now, digestTop)
+	text := guests.Text()
+
+	if digestFormat == "json" {
+		if err := output.PrintJSON(guests); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(text)
+	}
+
+	if digestWebhook != "" {
+		event := notify.Event{
+			Event: "digest.guests",
+			Time:  now,
+			Data: map[string]string{
+				"site":      cfg.Site,
+				"text":      text,
+				"connected": strconv.Itoa(guests.Connected),
+				"new_today": strconv.Itoa(len(guests.NewToday)),
+				"left":      strconv.Itoa(guests.Left),
+			},
+		}
+		if err := notify.Webhook(digestWebhook, event, cfg.Timeout); err != nil {
+			return fmt.Errorf("failed to deliver digest: %w", err)
+		}
+	}
+	if digestSlack != "" {
+		if err := notify.Slack(digestSlack, text, cfg.Timeout); err != nil {
+			return fmt.Errorf("failed to deliver digest: %w", err)
+		}
+	}
+	if digestNtfy != "" {
+		if err := notify.Ntfy(digestNtfy, "Guest network digest", text, cfg.Timeout); err != nil {
+			return fmt.Errorf("failed to deliver digest: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/dpi"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dpiBy     string
+	dpiTop    int
+	dpiFormat string
+)
+
+var dpiCmd = &cobra.Command{
+	Use:   "dpi",
+	Short: "Show deep packet inspection statistics of the site",
+	Long: `Show the site's traffic as classified by deep packet inspection, by
+application or by category, with the most traffic first.
+
+Traffic identification must be turned on in the controller's settings;
+otherwise there are no statistics. In CSV output traffic is in bytes.`,
+	Example: `  unifi dpi
+  unifi dpi --by category
+  unifi dpi --top 10 --format csv > dpi.csv`,
+	Args: cobra.NoArgs,
+	RunE: runDPI,
+}
+
+func init() {
+	rootCmd.AddCommand(dpiCmd)
+
+	dpiCmd.Flags().StringVar(&dpiBy, "by", "app", "Group traffic by app or category")
+	dpiCmd.Flags().IntVar(&dpiTop, "top", 0, "Only show the N applications or categories with the most traffic")
+	dpiCmd.Flags().StringVarP(&dpiFormat, "format", "f", "table", "Output format (table, csv or json)")
+}
+
+func runDPI(cmd *cobra.Command, args []string) error {
+	if dpiFormat != "table" && dpiFormat != "csv" && dpiFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, csv, json)", dpiFormat)
+	}
+
+	var byCategory bool
+	switch dpiBy {
+	case "app":
+	case "category":
+		byCategory = true
+	default:
+		return fmt.Errorf("invalid --by: %s (valid options: app, category)", dpiBy)
+	}
+
+	if err := dpi.AddAppNames(config.Get().DPIApps); err != nil {
+		return fmt.Errorf("invalid dpi_apps in config file: %w", err)
+	}
+
+	apiClient := newAPIClient()
+
+	entries, err := apiClient.GetSiteDPI()
+	if err != nil {
+		return fmt.Errorf("failed to get DPI statistics: %w", err)
+	}
+
+	usages := dpi.ByApp(entries)
+	if byCategory {
+		usages = dpi.ByCategory(entries)
+	}
+	usages = dpi.Top(usages, dpiTop)
+
+	switch dpiFormat {
+	case "json":
+		return output.PrintJSON(usages)
+	case "csv":
+		return output.PrintDPICSV(usages, byCategory)
+	default:
+		if len(usages) == 0 {
+			fmt.Println("No DPI statistics (is traffic identification turned on?)")
+			return nil
+		}
+		output.PrintDPITable(usages, byCategory)
+		return nil
+	}
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/dpi"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dpiBlockApp      string
+	dpiBlockClient   string
+	dpiBlockedClient string
+	dpiBlockedFormat string
+)
+
+var dpiBlockCmd = &cobra.Command{
+	Use:   "block",
+	Short: "Block an application for a client",
+	Long: `Block an application for a client with a traffic rule, so the client can no
+longer reach it. Applications are named as in "unifi dpi" (case insensitive),
+or given as category:app. Traffic rules need a controller with the v2 API
+(Network 7 or later).`,
+	Example: `  unifi dpi block --app BitTorrent --client kids-tablet
+  unifi dpi block --app 4:94 --client aa:bb:cc:dd:ee:ff`,
+	Args: cobra.NoArgs,
+	RunE: runDPIBlock,
+}
+
+var dpiUnblockCmd = &cobra.Command{
+	Use:   "unblock",
+	Short: "Unblock an application for a client",
+	Long: `Stop blocking an application for a client. Rules that only block it for this
+client are deleted; others keep blocking their other applications and clients.
+Rules for all clients, or for several applications and several clients, are
+left to the controller.`,
+	Example: `  unifi dpi unblock --app BitTorrent --client kids-tablet`,
+	Args:    cobra.NoArgs,
+	RunE:    runDPIUnblock,
+}
+
+var dpiBlockedCmd = &cobra.Command{
+	Use:   "blocked",
+	Short: "List blocked applications",
+	Example: `  unifi dpi blocked
+  unifi dpi blocked --client kids-tablet --format json`,
+	Args: cobra.NoArgs,
+	RunE: runDPIBlocked,
+}
+
+func init() {
+	dpiCmd.AddCommand(dpiBlockCmd)
+	dpiCmd.AddCommand(dpiUnblockCmd)
+	dpiCmd.AddCommand(dpiBlockedCmd)
+
+	// Traffic rules are only available through the v2 API
+	for _, c := range []*cobra.Command{dpiBlockCmd, dpiUnblockCmd, dpiBlockedCmd} {
+		c.Annotations = map[string]string{minVersionAnnotation: "7.0"}
+	}
+
+	for _, c := range []*cobra.Command{dpiBlockCmd, dpiUnblockCmd} {
+		c.Flags().StringVar(&dpiBlockApp, "app", "", "Application name or category:app")
+		c.Flags().StringVar(&dpiBlockClient, "client", "", "Client MAC or name")
+		c.MarkFlagRequired("app")
+		c.MarkFlagRequired("client")
+	}
+
+	dpiBlockedCmd.Flags().StringVar(&dpiBlockedClient, "client", "", "Only list rules that apply to this client (MAC or name)")
+	dpiBlockedCmd.Flags().StringVarP(&dpiBlockedFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+// resolveAppBlock resolves the --app and --client flags and lists the
+// traffic rules that block applications
+func resolveAppBlock(apiClient *api.APIClient) (int, string, []api.TrafficRule, error) {
+	if err := dpi.AddAppNames(config.Get().DPIApps); err != nil {
+		return 0, "", nil, fmt.Errorf("invalid dpi_apps in config file: %w", err)
+	}
+
+	appID, err := dpi.FindApp(dpiBlockApp)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	mac, err := resolveKnownClientMAC(apiClient, dpiBlockClient)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	rules, err := apiClient.ListTrafficRules()
+	if err != nil {
+		return 0, "", nil, fmt.Errorf("failed to list traffic rules: %w", err)
+	}
+
+	return appID, strings.ToLower(mac), dpi.AppBlocks(rules), nil
+}
+
+func runDPIBlock(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	appID, mac, rules, err := resolveAppBlock(apiClient)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if dpi.Blocks(rule, appID, mac) {
+			fmt.Printf("%s is already blocked for %s by rule %s\n", dpi.AppIDName(appID), mac, rule.Description)
+			return nil
+		}
+	}
+
+	created, err := apiClient.CreateTrafficRule(dpi.NewAppBlock(appID, mac))
+	if err != nil {
+		return fmt.Errorf("failed to create traffic rule: %w", err)
+	}
+
+	fmt.Printf("Blocked %s for %s (%s)\n", dpi.AppIDName(appID), mac, created.ID)
+	return nil
+}
+
+func runDPIUnblock(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	appID, mac, rules, err := resolveAppBlock(apiClient)
+	if err != nil {
+		return err
+	}
+
+	var blocking []api.TrafficRule
+	for _, rule := range rules {
+		if dpi.Blocks(rule, appID, mac) {
+			blocking = append(blocking, rule)
+		}
+	}
+	if len(blocking) == 0 {
+		fmt.Printf("%s is not blocked for %s\n", dpi.AppIDName(appID), mac)
+		return nil
+	}
+
+	// Work out every change first, so nothing is changed when one rule
+	// cannot be
+	changes := make([]dpi.Unblocking, len(blocking))
+	for i, rule := range blocking {
+		if changes[i], err = dpi.Unblock(rule, appID, mac); err != nil {
+			return err
+		}
+	}
+
+	for i, rule := range blocking {
+		if changes[i].Delete {
+			if err := apiClient.DeleteTrafficRule(rule.ID); err != nil {
+				return fmt.Errorf("failed to delete traffic rule: %w", err)
+			}
+			fmt.Printf("Deleted traffic rule %s\n", rule.Description)
+			continue
+		}
+		if _, err := apiClient.SetTrafficRuleTargets(rule.ID, changes[i].AppIDs, changes[i].Devices); err != nil {
+			return fmt.Errorf("failed to update traffic rule: %w", err)
+		}
+		fmt.Printf("Updated traffic rule %s\n", rule.Description)
+	}
+
+	fmt.Printf("Unblocked %s for %s\n", dpi.AppIDName(appID), mac)
+	return nil
+}
+
+func runDPIBlocked(cmd *cobra.Command, args []string) error {
+	if dpiBlockedFormat != "table" && dpiBlockedFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", dpiBlockedFormat)
+	}
+
+	if err := dpi.AddAppNames(config.Get().DPIApps); err != nil {
+		return fmt.Errorf("invalid dpi_apps in config file: %w", err)
+	}
+
+	apiClient := newAPIClient()
+
+	all, err := apiClient.ListTrafficRules()
+	if err != nil {
+		return fmt.Errorf("failed to list traffic rules: %w", err)
+	}
+	rules := dpi.AppBlocks(all)
+
+	if dpiBlockedClient != "" {
+		mac, err := resolveKnownClientMAC(apiClient, dpiBlockedClient)
+		if err != nil {
+			return err
+		}
+		var matching []api.TrafficRule
+		for _, rule := range rules {
+			if dpi.Targets(rule, mac) {
+				matching = append(matching, rule)
+			}
+		}
+		rules = matching
+	}
+
+	if dpiBlockedFormat == "json" {
+		if rules == nil {
+			rules = []api.TrafficRule{}
+		}
+		return output.PrintJSON(rules)
+	}
+
+	if len(rules) == 0 {
+		fmt.Println("No applications are blocked")
+		return nil
+	}
+
+	names, err := targetNames(apiClient)
+	if err != nil {
+		return err
+	}
+
+	output.PrintAppBlocksTable(rules, names)
+	return nil
+}
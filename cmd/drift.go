@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/drift"
+)
+
+// driftCheckInterval is how often the controller version is looked up
+const driftCheckInterval = time.Hour
+
+// versionCachePath is where the controller versions seen per host are kept
+func versionCachePath() string {
+	return filepath.Join(config.GetDataDir(), "versions.json")
+}
+
+// checkVersionDrift notices when the controller was upgraded since the last
+// run, prints a one-time notice and probes for fields the CLI relies on that
+// the new version no longer populates, so empty columns get an explanation.
+// Problems are never fatal; the command itself reports an unreachable
+// controller.
+func checkVersionDrift() {
+	cache, err := drift.LoadCache(versionCachePath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+
+	cfg := config.Get()
+	now := time.Now()
+	controllerVersion = cache.Versions[cfg.Host].Version
+	if !cache.Due(cfg.Host, now, driftCheckInterval) {
+		return
+	}
+
+	apiClient := newAPIClient()
+	status, err := apiClient.GetControllerStatus()
+	if err != nil {
+		return
+	}
+	controllerVersion = status.ServerVersion
+
+	previous, changed := cache.Update(cfg.Host, status.ServerVersion, now)
+	if err := cache.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if !changed {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Notice: controller was upgraded from %s to %s\n", previous, status.ServerVersion)
+
+	for _, probe := range drift.Probes {
+		body, err := apiClient.FetchSiteRaw(probe.Endpoint, nil)
+		if err != nil {
+			continue
+		}
+
+		missing, err := drift.MissingFields(body, probe.Fields)
+		if err != nil {
+			continue
+		}
+		for _, field := range missing {
+			fmt.Fprintf(os.Stderr, "Warning: %s field %s is no longer populated on version %s\n", probe.Resource, field, status.ServerVersion)
+		}
+	}
+}
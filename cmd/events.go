@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/history"
+	"github.com/nkn/unifi-cli/internal/notify"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	eventsType   string
+	eventsWithin string
+	eventsSince  string
+	eventsUntil  string
+	eventsLimit  int
+	eventsFormat string
+
+	replayLast    string
+	replaySpeed   string
+	replayWebhook string
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Review controller and recorded change events",
+	Long: `Review the controller's event log and client changes recorded by watch mode.
+
+While "clients list --watch" runs, every client that connects or disconnects
+is appended to a history file in the data directory. Only the most recent
+events are kept (history_size in the config file, default 1000).`,
+}
+
+var eventsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List controller events",
+	Long: `List the controller's event log, newest first. --type selects radar
+detections with "dfs", or events with a given key such as EVT_AP_Lost_Contact.
+
+--within (or --last) looks back from now. For another time range use --since
+and --until, each an age such as 2h or 7d, or a time such as 2026-01-12 08:00.
+Events are fetched a page at a time until --limit matching events are found
+or the start of the time range is reached; a warning says when older events
+in the range were cut off.`,
+	Example: `  unifi events list
+  unifi events list --last 24h --type EVT_WU_Disconnected
+  unifi events list --type dfs --within 7d
+  unifi events list --since "2026-01-12 08:00" --until "2026-01-12 12:00"
+  unifi events list --type EVT_AP_Lost_Contact --format json`,
+	Args: cobra.NoArgs,
+	RunE: runEventsList,
+}
+
+var eventsReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay recorded client changes",
+	Long: `Replay the recorded client changes of the configured controller in order,
+keeping the time between them scaled by --speed. Use --speed 0 to print them
+all at once. With --webhook every replayed event is also posted as JSON, the
+same way live notifications are.`,
+	Example: `  unifi events replay --last 2h
+  unifi events replay --last 1d --speed 10x
+  unifi events replay --last 30m --speed 0 --webhook https://example.com/hook`,
+	Args: cobra.NoArgs,
+	RunE: runEventsReplay,
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.AddCommand(eventsListCmd)
+	eventsCmd.AddCommand(eventsReplayCmd)
+
+	eventsListCmd.Flags().StringVar(&eventsType, "type", "", "Only show events of this type (dfs, or an event key)")
+	eventsListCmd.Flags().StringVar(&eventsWithin, "within", "24h", "How far back to look (e.g. 24h, 7d); also --last")
+	eventsListCmd.Flags().StringVar(&eventsSince, "since", "", "Only show events from this time or age on")
+	eventsListCmd.Flags().StringVar(&eventsUntil, "until", "", "Only show events up to this time or age")
+	eventsListCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "last" {
+			name = "within"
+		}
+		return pflag.NormalizedName(name)
+	})
+	eventsListCmd.Flags().IntVar(&eventsLimit, "limit", 1000, "Maximum number of matching events to show")
+	eventsListCmd.Flags().StringVarP(&eventsFormat, "format", "f", "table", "Output format (table or json)")
+
+	eventsReplayCmd.Flags().StringVar(&replayLast, "last", "1h", "How far back to replay (e.g. 2h, 30m, 7d)")
+	eventsReplayCmd.Flags().StringVar(&replaySpeed, "speed", "1x", "Playback speed (e.g. 10x); 0 replays without pauses")
+	eventsReplayCmd.Flags().StringVar(&replayWebhook, "webhook", "", "Also POST each replayed event to this URL")
+}
+
+func runEventsList(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+
+	within, err := parseAge(eventsWithin)
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+
+	var since, until time.Time
+	if eventsSince != "" {
+		if cmd.Flags().Changed("within") {
+			return fmt.Errorf("use either --within or --since")
+		}
+		if since, err = parseTime(eventsSince, now); err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		within = now.Sub(since)
+	}
+	if eventsUntil != "" {
+		if until, err = parseTime(eventsUntil, now); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		return fmt.Errorf("--until is before --since")
+	}
+	if within <= 0 {
+		return fmt.Errorf("--since is in the future")
+	}
+
+	if eventsLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	apiClient := newAPIClient()
+
+	events, cutoff, err := apiClient.QueryEvents(within, api.EventQuery{Since: since, Until: until, Type: eventsType, Limit: eventsLimit})
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	if cutoff != nil {
+		at := cutoff.Time.Local().Format("2006-01-02 15:04")
+		if cutoff.Limit {
+			fmt.Fprintf(os.Stderr, "Warning: showing the newest %d matching events; older ones from before %s are cut off (raise --limit or narrow the time range)\n", eventsLimit, at)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: stopped after searching %d events; events before %s were not searched (narrow the time range)\n", api.EventsScanCap, at)
+		}
+	}
+
+	switch eventsFormat {
+	case "json":
+		return output.PrintJSON(events)
+	case "table":
+		output.PrintEventsTable(events)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", eventsFormat)
+	}
+}
+
+// historyPath is where watch mode keeps its change events
+func historyPath() string {
+	return filepath.Join(config.GetDataDir(), "history.json")
+}
+
+// recordHistory appends the changes of one watch refresh to the history file
+func recordHistory(connected, disconnected []api.Client) error {
+	if len(connected) == 0 && len(disconnected) == 0 {
+		return nil
+	}
+
+	cfg := config.Get()
+
+	now := time.Now()
+	event := func(eventType string, client api.Client) history.Event {
+		return history.Event{
+			Time: now,
+			Type: eventType,
+			Host: cfg.Host,
+			Site: cfg.Site,
+			MAC:  client.MAC,
+			Name: client.GetDisplayName(),
+			IP:   client.IP,
+
+			Guest: client.IsGuest,
+
+			AP:         client.ApMAC,
+			SSID:       client.Essid,
+			Switch:     client.SWMAC,
+			SwitchPort: client.SWPort,
+		}
+	}
+
+	var events []history.Event
+	for _, client := range connected {
+		events = append(events, event(history.EventConnected, client))
+	}
+	for _, client := range disconnected {
+		events = append(events, event(history.EventDisconnected, client))
+	}
+
+	return history.Append(historyPath(), cfg.HistorySize, events...)
+}
+
+// parseSpeed accepts a playback speed such as "10x", "0.5" or "0"
+func parseSpeed(value string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(value), "x"), 64)
+	if err != nil || speed < 0 {
+		return 0, fmt.Errorf("invalid speed %q (e.g. 1x, 10x, or 0 for no pauses)", value)
+	}
+	return speed, nil
+}
+
+func runEventsReplay(cmd *cobra.Command, args []string) error {
+	window, err := parseAge(replayLast)
+	if err != nil {
+		return fmt.Errorf("invalid --last: %w", err)
+	}
+
+	speed, err := parseSpeed(replaySpeed)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.Get()
+	ring, err := history.Load(historyPath(), cfg.HistorySize)
+	if err != nil {
+		return err
+	}
+
+	events := ring.Since(cfg.Host, time.Now().Add(-window))
+	if len(events) == 0 {
+		fmt.Printf("No events recorded in the last %s\n", replayLast)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sleep := func(d time.Duration) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(d):
+		}
+	}
+
+	return history.Replay(events, speed, sleep, func(e history.Event) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		output.PrintHistoryEvent(e)
+
+		if replayWebhook != "" {
+			event := notify.Event{
+				Event: "client." + e.Type,
+				Time:  e.Time,
+				Data: map[string]string{
+					"mac":  e.MAC,
+					"name": e.Name,
+					"ip":   e.IP,
+					"site": e.Site,
+				},
+			}
+			if err := notify.Webhook(replayWebhook, event, cfg.Timeout); err != nil {
+				return fmt.Errorf("failed to deliver %s of %s: %w", e.Type, e.MAC, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/export"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOut     string
+	exportFormats []string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export site data to files",
+}
+
+var exportAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Export clients, devices, networks, WLANs, firewall rules and settings",
+	Long: `Export clients, devices, networks, WLANs, firewall rules and site settings
+to a directory in one go, one file per dataset and format (e.g. wlans.json and
+wlans.csv), for periodic configuration archives.
+
+Records are sorted and JSON keys ordered, so exporting into the same directory
+again and committing it to git shows what changed. CSV files have a column for
+every field; nested values are written as JSON. A dataset that fails to
+download does not stop the export; failures are listed in manifest.json.
+
+The files hold the controller's records as they are, including WLAN
+passphrases and other secrets: keep them private.`,
+	Example: `  unifi export all --out backup/
+  unifi export all --out config-archive --formats json && git -C config-archive diff`,
+	Args: cobra.NoArgs,
+	RunE: runExportAll,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportAllCmd)
+
+	exportAllCmd.Flags().StringVarP(&exportOut, "out", "o", "", "Output directory (default export-<timestamp>)")
+	exportAllCmd.Flags().StringSliceVar(&exportFormats, "formats", []string{"json", "csv"}, "File formats to write (json, csv)")
+}
+
+func runExportAll(cmd *cobra.Command, args []string) error {
+	formats, err := export.ParseFormats(exportFormats)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	dir := exportOut
+	if dir == "" {
+		dir = "export-" + now.Format("20060102-150405")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	apiClient := newAPIClient()
+	failures := map[string]string{}
+
+	var results []output.ActionResult
+	for _, ds := range export.Datasets {
+		err := exportDataset(apiClient.FetchSiteRaw, ds, dir, formats)
+		if err != nil {
+			failures[ds.Name] = err.Error()
+		}
+		results = append(results, output.ActionResult{Target: ds.Name, Err: err})
+	}
+
+	manifest := map[string]interface{}{
+		"exported_at": now.Format(time.RFC3339),
+		"host":        config.Get().Host,
+		"site":        config.Get().Site,
+		"formats":     formats,
+		"failures":    failures,
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	output.PrintActionResults(results)
+	fmt.Printf("Exported to %s\n", dir)
+
+	if len(failures) == len(export.Datasets) {
+		return fmt.Errorf("all datasets failed to download")
+	}
+	return nil
+}
+
+// exportDataset downloads a dataset with fetch and writes it to dir in every
+// format. Files are only written once all formats are encoded, so a failure
+// leaves the previous export of the dataset in place.
+func exportDataset(fetch func(endpoint string, payload interface{}) ([]byte, error), ds export.Dataset, dir string, formats []string) error {
+	body, err := fetch(ds.Endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	records, err := export.ParseRecords(body)
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string][]byte, len(formats))
+	for _, format := range formats {
+		var buf bytes.Buffer
+		if err := export.Write(&buf, format, records); err != nil {
+			return err
+		}
+		files[ds.Name+"."+format] = buf.Bytes()
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/fixtures"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filtersOn     string
+	filtersSample int
+	filtersFrom   string
+	filtersFormat string
+)
+
+var filtersCmd = &cobra.Command{
+	Use:   "filters",
+	Short: "Work with --filter expressions",
+}
+
+var filtersTestCmd = &cobra.Command{
+	Use:   "test <where>",
+	Short: "Try a filter expression and show what it matches",
+	Long: `Validate a --filter WHERE clause against the live data set (or a recorded
+fixture) and show which columns it uses, how many rows it matches and a few of
+the matching rows. A clause that does not compile is reported together with
+the columns that are available.
+
+--on picks the data set: clients (default), devices, sites or networks. --from
+reads the data set from a file instead of the controller: a fixture recorded
+with --record or the output of a list command with --format json. Switch ports
+can only be tested from a file, e.g. the output of devices ports --format json.`,
+	Example: `  unifi filters test "signal < -70 AND essid = 'Guest'"
+  unifi filters test "type = 'uap' AND num_sta > 20" --on devices --sample 10
+  unifi filters test "ip_in('10.0.0.0/8')" --from fixtures/GET_api_s_default_stat_sta.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFiltersTest,
+}
+
+func init() {
+	rootCmd.AddCommand(filtersCmd)
+	filtersCmd.AddCommand(filtersTestCmd)
+
+	filtersTestCmd.Flags().StringVar(&filtersOn, "on", "clients", "Data set to test against ("+strings.Join(filter.Datasets, ", ")+")")
+	filtersTestCmd.Flags().IntVar(&filtersSample, "sample", 5, "Number of matching rows to show")
+	filtersTestCmd.Flags().StringVar(&filtersFrom, "from", "", "Read the data set from a fixture or JSON file instead of the controller")
+	filtersTestCmd.Flags().StringVarP(&filtersFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+// filterTest is the result of filters test
+type filterTest struct {
+	Columns []string    `json:"columns"`
+	Matched int         `json:"matched"`
+	Total   int         `json:"total"`
+	Sample  interface{} `json:"sample"`
+}
+
+func runFiltersTest(cmd *cobra.Command, args []string) error {
+	if filtersFormat != "table" && filtersFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", filtersFormat)
+	}
+	if filtersSample < 0 {
+		return fmt.Errorf("--sample must not be negative")
+	}
+
+	filterEngine, err := filter.NewFilter(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to create filter: %w", err)
+	}
+	defer filterEngine.Close()
+
+	columns, err := filterEngine.UsedColumns(filtersOn)
+	if err != nil {
+		return err
+	}
+
+	switch filtersOn {
+	case "clients":
+		return testFilter(filterEngine, columns, loadFilterClients, filterEngine.Apply, output.PrintClientsTable)
+	case "devices":
+		return testFilter(filterEngine, columns, loadFilterDevices, filterEngine.ApplyDevices, output.PrintDevicesTable)
+	case "ports":
+		return testFilter(filterEngine, columns, loadFilterPorts, filterEngine.ApplyPorts, output.PrintPortsTable)
+	case "sites":
+		return testFilter(filterEngine, columns, loadFilterSites, filterEngine.ApplySites, output.PrintSitesTable)
+	default:
+		return testFilter(filterEngine, columns, loadFilterNetworks, filterEngine.ApplyNetworks, output.PrintNetworksTable)
+	}
+}
+
+// testFilter loads a data set, applies the filter and reports the matches
+func testFilter[T any](filterEngine *filter.Filter, columns []string, load func() ([]T, error), apply func([]T) ([]T, error), printTable func([]T)) error {
+	rows, err := load()
+	if err != nil {
+		return err
+	}
+
+	matched, err := apply(rows)
+	if err != nil {
+		if available, columnsErr := filterEngine.ColumnsOf(filtersOn); columnsErr == nil {
+			return fmt.Errorf("invalid filter: %w\nAvailable columns: %s", err, strings.Join(available, ", "))
+		}
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+
+	sample := matched
+	if len(sample) > filtersSample {
+		sample = sample[:filtersSample]
+	}
+	if sample == nil {
+		sample = []T{}
+	}
+	if columns == nil {
+		columns = []string{}
+	}
+
+	if filtersFormat == "json" {
+		return output.PrintJSON(filterTest{Columns: columns, Matched: len(matched), Total: len(rows), Sample: sample})
+	}
+
+	if len(columns) > 0 {
+		fmt.Printf("Columns used: %s\n", strings.Join(columns, ", "))
+	} else {
+		fmt.Println("Columns used: none (the clause does not depend on the rows)")
+	}
+	fmt.Printf("Matched %d of %d %s\n", len(matched), len(rows), filtersOn)
+
+	if len(sample) > 0 {
+		if len(sample) < len(matched) {
+			fmt.Printf("First %d matches:\n", len(sample))
+		}
+		printTable(sample)
+	}
+	return nil
+}
+
+func loadFilterClients() ([]api.Client, error) {
+	var clients []api.Client
+	if filtersFrom != "" {
+		return clients, fixtures.Load(filtersFrom, &clients)
+	}
+
+	apiClient := newAPIClient()
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	groups, err := apiClient.ListUserGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	clients, err = apiClient.ListClients()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+	api.JoinNetworkNames(clients, networks)
+	api.JoinUserGroupNames(clients, groups)
+	api.SortClients(clients)
+
+	return clients, nil
+}
+
+func loadFilterDevices() ([]api.Device, error) {
+	var devices []api.Device
+	if filtersFrom != "" {
+		return devices, fixtures.Load(filtersFrom, &devices)
+	}
+
+	devices, err := newAPIClient().ListDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	api.SortDevices(devices)
+
+	return devices, nil
+}
+
+func loadFilterPorts() ([]api.PortStatus, error) {
+	if filtersFrom == "" {
+		return nil, fmt.Errorf("switch ports can only be tested --from a file (e.g. the output of devices ports --format json)")
+	}
+
+	var ports []api.PortStatus
+	return ports, fixtures.Load(filtersFrom, &ports)
+}
+
+func loadFilterSites() ([]api.SiteSummary, error) {
+	var sites []api.Site
+	if filtersFrom != "" {
+		if err := fixtures.Load(filtersFrom, &sites); err != nil {
+			return nil, err
+		}
+		return api.SummarizeSites(sites), nil
+	}
+
+	sites, err := newAPIClient().ListSites()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sites: %w", err)
+	}
+
+	return api.SummarizeSites(sites), nil
+}
+
+func loadFilterNetworks() ([]api.Network, error) {
+	var networks []api.Network
+	if filtersFrom != "" {
+		return networks, fixtures.Load(filtersFrom, &networks)
+	}
+
+	networks, err := newAPIClient().ListNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	return networks, nil
+}
@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/firewall"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	firewallSyncCSV     string
+	firewallSyncReplace bool
+	firewallSyncDryRun  bool
+
+	firewallRulesFormat string
+	firewallRulesSort   string
+
+	firewallGroupsFormat string
+	firewallGroupsSort   string
+	firewallGroupType    string
+)
+
+var firewallCmd = &cobra.Command{
+	Use:   "firewall",
+	Short: "Manage firewall configuration",
+	Long:  `Inspect and change firewall rules and groups.`,
+}
+
+var firewallRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List and toggle firewall rules",
+}
+
+var firewallRulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List firewall rules",
+	Long: `List the firewall rules by ruleset and index, with their action, protocol,
+source, destination and whether they are enabled. Firewall groups and networks
+are shown by name; "any" matches everything.`,
+	Example: `  unifi firewall rules list
+  unifi firewall rules list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runFirewallRulesList,
+}
+
+var firewallRulesEnableCmd = &cobra.Command{
+	Use:   "enable <id|name>",
+	Short: "Enable a firewall rule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setFirewallRuleEnabled(args[0], true)
+	},
+}
+
+var firewallRulesDisableCmd = &cobra.Command{
+	Use:   "disable <id|name>",
+	Short: "Disable a firewall rule",
+	Long: `Disable a firewall rule without deleting it, e.g. to check whether it is what
+blocks some traffic.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setFirewallRuleEnabled(args[0], false)
+	},
+}
+
+var firewallGroupsCmd = &cobra.Command{
+	Use:   "groups",
+	Short: "Manage firewall address and port groups",
+}
+
+var firewallGroupsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List firewall groups",
+	Long: `List the firewall address and port groups with their members. The table
+shows the first members of large groups; use --format json for all of them.`,
+	Args: cobra.NoArgs,
+	RunE: runFirewallGroupsList,
+}
+
+var firewallGroupsCreateCmd = &cobra.Command{
+	Use:   "create <name> [member...]",
+	Short: "Create a firewall group",
+	Long: `Create an address, IPv6 address or port group, optionally with its first
+members. Members are validated against the group type.`,
+	Example: `  unifi firewall groups create "Blocked destinations" --type address
+  unifi firewall groups create "Web Ports" 80 443 8000-8100 --type port`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFirewallGroupsCreate,
+}
+
+var firewallGroupsAddMemberCmd = &cobra.Command{
+	Use:   "add-member <group> <member...>",
+	Short: "Add members to a firewall group",
+	Long: `Add addresses, networks (CIDR) or ports to a firewall group. Members that are
+already in the group are skipped.`,
+	Example: `  unifi firewall groups add-member "Blocked destinations" 203.0.113.7
+  unifi firewall groups add-member "Web Ports" 8443`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runFirewallGroupsAddMember,
+}
+
+var firewallGroupsRemoveMemberCmd = &cobra.Command{
+	Use:     "remove-member <group> <member...>",
+	Short:   "Remove members from a firewall group",
+	Example: `  unifi firewall groups remove-member "Blocked destinations" 203.0.113.7`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runFirewallGroupsRemoveMember,
+}
+
+var firewallGroupsSyncCmd = &cobra.Command{
+	Use:   "sync <group>",
+	Short: "Sync the members of a firewall group with a CSV file",
+	Long: `Compare the members of a firewall group with the first column of a CSV file
+and apply only the difference. Members missing from the group are added; with
+--replace, members that are not in the file are removed as well.
+
+Addresses, networks (CIDR) and ports are validated against the group type. Empty
+lines, lines starting with # and a header row are skipped.`,
+	Example: `  unifi firewall groups sync Blocklist --from-csv ips.csv
+  unifi firewall groups sync Blocklist --from-csv ips.csv --replace --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFirewallGroupsSync,
+}
+
+func init() {
+	rootCmd.AddCommand(firewallCmd)
+	firewallCmd.AddCommand(firewallRulesCmd)
+	firewallCmd.AddCommand(firewallGroupsCmd)
+	firewallRulesCmd.AddCommand(firewallRulesListCmd)
+	firewallRulesCmd.AddCommand(firewallRulesEnableCmd)
+	firewallRulesCmd.AddCommand(firewallRulesDisableCmd)
+	firewallGroupsCmd.AddCommand(firewallGroupsListCmd)
+	firewallGroupsCmd.AddCommand(firewallGroupsCreateCmd)
+	firewallGroupsCmd.AddCommand(firewallGroupsAddMemberCmd)
+	firewallGroupsCmd.AddCommand(firewallGroupsRemoveMemberCmd)
+	firewallGroupsCmd.AddCommand(firewallGroupsSyncCmd)
+
+	firewallRulesListCmd.Flags().StringVarP(&firewallRulesFormat, "format", "f", "table", "Output format (table or json)")
+	firewallRulesListCmd.Flags().StringVar(&firewallRulesSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: ruleset,rule_index)")
+
+	firewallGroupsListCmd.Flags().StringVarP(&firewallGroupsFormat, "format", "f", "table", "Output format (table or json)")
+	firewallGroupsListCmd.Flags().StringVar(&firewallGroupsSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: name)")
+	firewallGroupsCreateCmd.Flags().StringVar(&firewallGroupType, "type", "", "Group type (address, ipv6-address or port)")
+	firewallGroupsCreateCmd.MarkFlagRequired("type")
+
+	firewallGroupsSyncCmd.Flags().StringVar(&firewallSyncCSV, "from-csv", "", "CSV file with one member per line in the first column")
+	firewallGroupsSyncCmd.Flags().BoolVar(&firewallSyncReplace, "replace", false, "Also remove members that are not in the file")
+	firewallGroupsSyncCmd.Flags().BoolVar(&firewallSyncDryRun, "dry-run", false, "Only show what would be changed")
+	firewallGroupsSyncCmd.MarkFlagRequired("from-csv")
+}
+
+func runFirewallRulesList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	rules, err := apiClient.ListFirewallRules()
+	if err != nil {
+		return fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	api.SortFirewallRules(rules)
+	if err := sorting.ByKeys(rules, firewallRulesSort); err != nil {
+		return err
+	}
+
+	switch firewallRulesFormat {
+	case "json":
+		if rules == nil {
+			rules = []api.FirewallRule{}
+		}
+		return output.PrintJSON(rules)
+	case "table":
+		groups, err := apiClient.ListFirewallGroups()
+		if err != nil {
+			return fmt.Errorf("failed to list firewall groups: %w", err)
+		}
+
+		networks, err := apiClient.ListNetworks()
+		if err != nil {
+			return fmt.Errorf("failed to list networks: %w", err)
+		}
+
+		names := map[string]string{}
+		for _, g := range groups {
+			names[g.ID] = g.Name
+		}
+		for _, n := range networks {
+			names[n.ID] = n.Name
+		}
+
+		output.PrintFirewallRulesTable(rules, names)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", firewallRulesFormat)
+	}
+}
+
+// setFirewallRuleEnabled turns the firewall rule with the given ID or name on
+// or off
+func setFirewallRuleEnabled(query string, enabled bool) error {
+	apiClient := newAPIClient()
+
+	rules, err := apiClient.ListFirewallRules()
+	if err != nil {
+		return fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	rule, err := api.FindFirewallRule(rules, query)
+	if err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	if rule.Enabled == enabled {
+		fmt.Printf("Firewall rule %s is already %s\n", rule.Name, state)
+		return nil
+	}
+
+	if _, err := apiClient.SetFirewallRuleEnabled(rule.ID, enabled); err != nil {
+		return fmt.Errorf("failed to update firewall rule: %w", err)
+	}
+
+	fmt.Printf("Firewall rule %s %s\n", rule.Name, state)
+	return nil
+}
+
+func runFirewallGroupsList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	groups, err := apiClient.ListFirewallGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list firewall groups: %w", err)
+	}
+
+	api.SortFirewallGroups(groups)
+	if err := sorting.ByKeys(groups, firewallGroupsSort); err != nil {
+		return err
+	}
+
+	switch firewallGroupsFormat {
+	case "json":
+		if groups == nil {
+			groups = []api.FirewallGroup{}
+		}
+		return output.PrintJSON(groups)
+	case "table":
+		output.PrintFirewallGroupsTable(groups)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", firewallGroupsFormat)
+	}
+}
+
+func runFirewallGroupsCreate(cmd *cobra.Command, args []string) error {
+	groupType, err := firewall.ParseGroupType(firewallGroupType)
+	if err != nil {
+		return err
+	}
+
+	members, err := normalizeMembers(args[1:], groupType)
+	if err != nil {
+		return err
+	}
+
+	apiClient := newAPIClient()
+
+	groups, err := apiClient.ListFirewallGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list firewall groups: %w", err)
+	}
+	if _, err := api.FindFirewallGroup(groups, args[0]); err == nil {
+		return fmt.Errorf("a firewall group named %q already exists", args[0])
+	}
+
+	created, err := apiClient.CreateFirewallGroup(args[0], groupType, members)
+	if err != nil {
+		return fmt.Errorf("failed to create firewall group: %w", err)
+	}
+
+	fmt.Printf("Created firewall group %s (%s, %d members)\n", created.Name, created.ID, len(created.GroupMembers))
+	return nil
+}
+
+func runFirewallGroupsAddMember(cmd *cobra.Command, args []string) error {
+	return changeFirewallGroupMembers(args[0], args[1:], func(group *api.FirewallGroup, members []string) firewall.Sync {
+		return firewall.Plan(group.GroupMembers, members, group.GroupType, false)
+	})
+}
+
+func runFirewallGroupsRemoveMember(cmd *cobra.Command, args []string) error {
+	return changeFirewallGroupMembers(args[0], args[1:], func(group *api.FirewallGroup, members []string) firewall.Sync {
+		return firewall.PlanRemove(group.GroupMembers, members, group.GroupType)
+	})
+}
+
+// changeFirewallGroupMembers applies the change plan makes for the given
+// members to the group with the given name or ID
+func changeFirewallGroupMembers(query string, args []string, plan func(*api.FirewallGroup, []string) firewall.Sync) error {
+	apiClient := newAPIClient()
+
+	groups, err := apiClient.ListFirewallGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list firewall groups: %w", err)
+	}
+
+	group, err := api.FindFirewallGroup(groups, query)
+	if err != nil {
+		return err
+	}
+
+	members, err := normalizeMembers(args, group.GroupType)
+	if err != nil {
+		return err
+	}
+
+	sync := plan(group, members)
+	if !sync.Changed() {
+		fmt.Printf("%s is unchanged (%d members)\n", group.Name, len(group.GroupMembers))
+		return nil
+	}
+	if len(sync.Members) == 0 {
+		return fmt.Errorf("refusing to remove every member of %s", group.Name)
+	}
+
+	updated, err := apiClient.SetFirewallGroupMembers(*group, sync.Members)
+	if err != nil {
+		return fmt.Errorf("failed to update firewall group: %w", err)
+	}
+
+	fmt.Printf("%s: %d added, %d removed (%d members)\n", updated.Name, len(sync.Add), len(sync.Remove), len(updated.GroupMembers))
+	return nil
+}
+
+// normalizeMembers validates members given on the command line against the
+// group type
+func normalizeMembers(args []string, groupType string) ([]string, error) {
+	var members []string
+	for _, arg := range args {
+		member, err := firewall.Normalize(arg, groupType)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func runFirewallGroupsSync(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	groups, err := apiClient.ListFirewallGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list firewall groups: %w", err)
+	}
+
+	group, err := api.FindFirewallGroup(groups, args[0])
+	if err != nil {
+		return err
+	}
+
+	desired, err := firewall.LoadMembers(firewallSyncCSV, group.GroupType)
+	if err != nil {
+		return err
+	}
+
+	sync := firewall.Plan(group.GroupMembers, desired, group.GroupType, firewallSyncReplace)
+	if !sync.Changed() {
+		fmt.Printf("%s is up to date (%d members)\n", group.Name, len(group.GroupMembers))
+		return nil
+	}
+
+	for _, member := range sync.Add {
+		fmt.Printf("+ %s\n", member)
+	}
+	for _, member := range sync.Remove {
+		fmt.Printf("- %s\n", member)
+	}
+
+	if firewallSyncDryRun {
+		return nil
+	}
+
+	if len(sync.Members) == 0 {
+		return fmt.Errorf("refusing to remove every member of %s", group.Name)
+	}
+
+	updated, err := apiClient.SetFirewallGroupMembers(*group, sync.Members)
+	if err != nil {
+		return fmt.Errorf("failed to update firewall group: %w", err)
+	}
+
+	fmt.Printf("%s: %d added, %d removed (%d members)\n", updated.Name, len(sync.Add), len(sync.Remove), len(updated.GroupMembers))
+	return nil
+}
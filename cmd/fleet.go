@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/fleet"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	fleetProfiles []string
+	fleetParallel int
+	fleetQuiet    bool
+	fleetFormat   string
+)
+
+var fleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Work with all configured controllers at once",
+	// Every run picks its controller from a profile, so the default
+	// controller does not need to be configured
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+}
+
+var fleetExecCmd = &cobra.Command{
+	Use:   "exec -- <command> [args...]",
+	Short: "Run a command against several controllers concurrently",
+	Long: `Run any unifi command once per profile of the config file, concurrently,
+then summarize the runs with failures highlighted.
+
+--profiles takes profile names or glob patterns (all profiles by default). The
+command runs as a separate process with --profile set, without UNIFI_
+environment variables and in non-interactive mode, so commands that ask for
+confirmation need --yes. The output of every
+run is printed under its profile's name, followed by the summary; exits
+non-zero when the command failed for any profile.`,
+	Example: `  unifi fleet exec -- clients block aa:bb:cc:dd:ee:ff
+  unifi fleet exec --profiles 'site-*' --parallel 4 -- devices upgrade --all --yes
+  unifi fleet exec --profiles home,office --quiet -- check clock`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFleetExec,
+}
+
+func init() {
+	rootCmd.AddCommand(fleetCmd)
+	fleetCmd.AddCommand(fleetExecCmd)
+
+	fleetExecCmd.Flags().StringSliceVar(&fleetProfiles, "profiles", []string{"*"}, "Profiles to run against, by name or glob pattern")
+	fleetExecCmd.Flags().IntVar(&fleetParallel, "parallel", 0, "Run against at most N controllers at a time (default all)")
+	fleetExecCmd.Flags().BoolVarP(&fleetQuiet, "quiet", "q", false, "Only print the summary")
+	fleetExecCmd.Flags().StringVarP(&fleetFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+func runFleetExec(cmd *cobra.Command, args []string) error {
+	if fleetFormat != "table" && fleetFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", fleetFormat)
+	}
+
+	names := config.ProfileNames()
+	if len(names) == 0 {
+		return fmt.Errorf("no profiles in the config file")
+	}
+
+	profiles, err := fleet.Match(names, fleetProfiles)
+	if err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the unifi executable: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	configFile := viper.ConfigFileUsed()
+	results := fleet.Run(ctx, profiles, fleetParallel, func(ctx context.Context, profile string) *exec.Cmd {
+		commandArgs := []string{"--profile", profile, "--non-interactive"}
+		if configFile != "" {
+			commandArgs = append(commandArgs, "--config", configFile)
+		}
+		command := exec.CommandContext(ctx, executable, append(commandArgs, args...)...)
+		command.Env = fleet.Environ(os.Environ())
+		return command
+	})
+
+	if fleetFormat == "json" {
+		if err := output.PrintJSON(results); err != nil {
+			return err
+		}
+	} else {
+		if !fleetQuiet {
+			output.PrintFleetOutputs(results)
+		}
+		output.PrintFleetTable(results)
+	}
+
+	if failures := fleet.Failures(results); failures > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("failed for %d of %d profiles", failures, len(results))
+	}
+	return nil
+}
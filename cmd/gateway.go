@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var gatewayPortsFormat string
+
+var gatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "Inspect the site gateway",
+	Long:  `Inspect the gateway that routes for the site.`,
+}
+
+var gatewayPortsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "Show the WAN/LAN assignment of the gateway ports",
+	Long: `Show every physical port of the gateway with its role (WAN, WAN2, LAN), link
+speed, WAN address and the networks and VLANs it carries.`,
+	Example: `  unifi gateway ports
+  unifi gateway ports --format json`,
+	Args: cobra.NoArgs,
+	RunE: runGatewayPorts,
+}
+
+func init() {
+	rootCmd.AddCommand(gatewayCmd)
+	gatewayCmd.AddCommand(gatewayPortsCmd)
+
+	gatewayPortsCmd.Flags().StringVarP(&gatewayPortsFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+func runGatewayPorts(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	devices, err := apiClient.ListDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	gateway, err := api.FindGateway(devices)
+	if err != nil {
+		return err
+	}
+	if len(gateway.PortTable) == 0 {
+		return fmt.Errorf("%s reports no ports", gateway.GetDisplayName())
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	ports := api.GatewayPorts(*gateway, networks)
+
+	switch gatewayPortsFormat {
+	case "json":
+		return output.PrintJSON(ports)
+	case "table":
+		output.PrintGatewayPortsTable(ports)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", gatewayPortsFormat)
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	guestsFormat  string
+	guestsSort    string
+	guestSpec     api.GuestSpec
+	guestDuration string
+)
+
+var guestsCmd = &cobra.Command{
+	Use:   "guests",
+	Short: "Manage guest authorizations",
+	Long: `Authorize guest clients without the hotspot portal, end their authorization
+and list who is currently authorized.`,
+}
+
+var guestsAuthorizeCmd = &cobra.Command{
+	Use:   "authorize <mac|name>...",
+	Short: "Authorize guest clients",
+	Long: `Let guest clients onto the guest network without going through the portal,
+e.g. for a visitor whose device cannot show it. Clients can be given by MAC
+address, alias or hostname; a MAC address also works for a device that has
+not connected yet.
+
+--duration is how long the guests stay authorized (e.g. 90m, 24h, 7d).
+Bandwidth and data limits apply to each guest.`,
+	Example: `  unifi guests authorize aa:bb:cc:dd:ee:ff
+  unifi guests authorize visitor-laptop --duration 4h --down 10000 --data-limit 2000`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runGuestsAuthorize,
+}
+
+var guestsUnauthorizeCmd = &cobra.Command{
+	Use:   "unauthorize <mac|name>...",
+	Short: "End guest authorizations",
+	Long: `End the authorization of guest clients. They are disconnected and have to go
+through the portal again.`,
+	Example: `  unifi guests unauthorize aa:bb:cc:dd:ee:ff`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runGuestsUnauthorize,
+}
+
+var guestsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List authorized guests",
+	Long: `List the guests whose authorization has not run out, those expiring first
+first, with how they were authorized and the time they have left.`,
+	Example: `  unifi guests list
+  unifi guests list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runGuestsList,
+}
+
+func init() {
+	rootCmd.AddCommand(guestsCmd)
+	guestsCmd.AddCommand(guestsAuthorizeCmd)
+	guestsCmd.AddCommand(guestsUnauthorizeCmd)
+	guestsCmd.AddCommand(guestsListCmd)
+
+	flags := guestsAuthorizeCmd.Flags()
+	flags.StringVar(&guestDuration, "duration", "24h", "How long guests stay authorized (e.g. 90m, 24h, 7d)")
+	flags.IntVar(&guestSpec.Up, "up", 0, "Upload limit in Kbps")
+	flags.IntVar(&guestSpec.Down, "down", 0, "Download limit in Kbps")
+	flags.IntVar(&guestSpec.DataLimit, "data-limit", 0, "Data limit in MB")
+
+	guestsListCmd.Flags().StringVarP(&guestsFormat, "format", "f", "table", "Output format (table or json)")
+	guestsListCmd.Flags().StringVar(&guestsSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: end)")
+}
+
+func runGuestsAuthorize(cmd *cobra.Command, args []string) error {
+	duration, err := parseAge(guestDuration)
+	if err != nil {
+		return fmt.Errorf("invalid --duration: %w", err)
+	}
+	if duration < time.Minute {
+		return fmt.Errorf("invalid --duration: %s is less than a minute", guestDuration)
+	}
+	if guestSpec.Up < 0 || guestSpec.Down < 0 || guestSpec.DataLimit < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+
+	apiClient := newAPIClient()
+
+	macs, err := resolveClientMACs(apiClient, args)
+	if err != nil {
+		return err
+	}
+
+	var results []output.ActionResult
+	for _, mac := range macs {
+		spec := guestSpec
+		spec.MAC = strings.ToLower(mac)
+		spec.Duration = duration
+		results = append(results, output.ActionResult{Target: mac, Err: apiClient.AuthorizeGuest(spec)})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d guests failed", failures, len(results))
+	}
+	return nil
+}
+
+func runGuestsUnauthorize(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	macs, err := resolveClientMACs(apiClient, args)
+	if err != nil {
+		return err
+	}
+
+	var results []output.ActionResult
+	for _, mac := range macs {
+		results = append(results, output.ActionResult{Target: mac, Err: apiClient.UnauthorizeGuest(strings.ToLower(mac))})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d guests failed", failures, len(results))
+	}
+	return nil
+}
+
+func runGuestsList(cmd *cobra.Command, args []string) error {
+	if guestsFormat != "table" && guestsFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", guestsFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	now := time.Now()
+	guests, err := apiClient.ListAuthorizedGuests(now)
+	if err != nil {
+		return fmt.Errorf("failed to list guests: %w", err)
+	}
+
+	api.SortGuests(guests)
+	if err := sorting.ByKeys(guests, guestsSort); err != nil {
+		return err
+	}
+
+	if guestsFormat == "json" {
+		if guests == nil {
+			guests = []api.Guest{}
+		}
+		return output.PrintJSON(guests)
+	}
+
+	if len(guests) == 0 {
+		fmt.Println("No authorized guests")
+		return nil
+	}
+
+	// Names are a nicety; guests that are not connected have none anyway
+	names := make(map[string]string)
+	if clients, err := apiClient.ListClients(); err == nil {
+		for i := range clients {
+			names[strings.ToLower(clients[i].MAC)] = clients[i].GetDisplayName()
+		}
+	}
+
+	output.PrintGuestsTable(guests, names, now)
+	return nil
+}
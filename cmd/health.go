@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var healthFormat string
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Show the health of the site's subsystems",
+	Long: `Show the state of every subsystem of the site (WWW, WAN, WLAN, LAN and VPN)
+with internet latency, current throughput and device and client counts.
+
+Exits non-zero when a subsystem is in warning or error, so scripts can check
+that everything is green. Subsystems that are not in use report "unknown" and
+do not count as problems.`,
+	Example: `  unifi health
+  unifi health --format json
+  unifi health > /dev/null || echo "network needs attention"`,
+	Args: cobra.NoArgs,
+	RunE: runHealth,
+}
+
+func init() {
+	rootCmd.AddCommand(healthCmd)
+
+	healthCmd.Flags().StringVarP(&healthFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+func runHealth(cmd *cobra.Command, args []string) error {
+	if healthFormat != "table" && healthFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", healthFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	health, err := apiClient.GetSiteHealth()
+	if err != nil {
+		return fmt.Errorf("failed to get site health: %w", err)
+	}
+
+	if healthFormat == "json" {
+		if err := output.PrintJSON(health); err != nil {
+			return err
+		}
+	} else {
+		output.PrintHealthTable(health)
+	}
+
+	var problems []string
+	for _, h := range health {
+		if !h.Healthy() {
+			problems = append(problems, fmt.Sprintf("%s is %s", h.Subsystem, h.Status))
+		}
+	}
+	if len(problems) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("site is not healthy: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/capture"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/fixtures"
+)
+
+// newAPIClient builds an API client from the resolved configuration
+func newAPIClient() *api.APIClient {
+	return newAPIClientFor(config.Get())
+}
+
+// newAPIClientFor builds an API client for cfg. Controller warnings are
+// printed to stderr so they never mix with table or JSON output.
+func newAPIClientFor(cfg *config.Config) *api.APIClient {
+	apiClient := api.NewAPIClient(cfg.Host, cfg.APIKey, cfg.Site, cfg.Insecure)
+	apiClient.SetTimeout(cfg.Timeout)
+	if dial := tunnelDial(cfg); dial != nil {
+		apiClient.SetDial(dial)
+	}
+	if cfg.Fingerprint != "" {
+		apiClient.PinCertificate(cfg.Fingerprint)
+	}
+	apiClient.OnWarning = func(msg string) {
+		fmt.Fprintf(os.Stderr, "Warning: controller reported %s\n", msg)
+	}
+	if cfg.Record != "" {
+		recordResponses(apiClient, cfg.Record)
+	}
+	if cfg.SaveRaw != "" {
+		saveRawResponses(apiClient, cfg)
+	}
+	if cfg.UsageStats {
+		apiClient.OnRequest = recordRequest
+	}
+	if cfg.Strict {
+		apiClient.CheckResponse = strictResponseCheck(cfg.Site)
+	}
+	return apiClient
+}
+
+// recordResponses writes every response the client receives to dir as a
+// sanitized fixture. Recording problems are reported but never fail a command.
+func recordResponses(apiClient *api.APIClient, dir string) {
+	recorder, err := fixtures.NewRecorder(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not recording fixtures: %v\n", err)
+		return
+	}
+
+	apiClient.OnResponse = func(method, path string, body []byte) {
+		if err := recorder.Record(method, path, body); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record %s %s: %v\n", method, path, err)
+		}
+	}
+}
+
+// saveRawResponses writes every response the client receives, whatever its
+// status, to cfg.SaveRaw exactly as received. Like recording, problems are
+// reported but never fail a command.
+func saveRawResponses(apiClient *api.APIClient, cfg *config.Config) {
+	archive, err := capture.New(cfg.SaveRaw, cfg.Host, cfg.Site)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not saving raw responses: %v\n", err)
+		return
+	}
+
+	apiClient.OnExchange = func(ex api.Exchange) {
+		if err := archive.Save(ex); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save raw response to %s %s: %v\n", ex.Method, ex.Path, err)
+		}
+	}
+}
+
+// errNonInteractive is returned by prompts in non-interactive mode
+var errNonInteractive = errors.New("confirmation needed but running non-interactively")
+
+// confirm asks a yes/no question on stdin and reports whether the answer was
+// yes. In non-interactive mode, or when stdin ends without an answer (e.g.
+// under cron), it fails instead, so a skipped action is never mistaken for a
+// successful run.
+func confirm(prompt string) (bool, error) {
+	return confirmOn(os.Stdout, prompt)
+}
+
+// confirmOn is confirm printing the question to w, e.g. stderr for prompts
+// that come before a command's own output
+func confirmOn(w io.Writer, prompt string) (bool, error) {
+	if config.Get().NonInteractive {
+		return false, fmt.Errorf("%w: %s", errNonInteractive, prompt)
+	}
+
+	fmt.Fprintf(w, "%s [y/N]: ", prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	if err == io.EOF && strings.TrimSpace(answer) == "" {
+		fmt.Fprintln(w)
+		return false, fmt.Errorf("%w: %s", errNonInteractive, prompt)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// parseAge parses a duration that may also be given in days (e.g. "30d"),
+// which time.ParseDuration does not support
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("%q is not a valid number of days", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("%q is not a positive duration", value)
+	}
+	return age, nil
+}
+
+// timeLayouts are the absolute times parseTime accepts, in local time unless
+// they carry a zone
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// parseTime parses a point in time given either as an age relative to now
+// (e.g. "2h" or "7d" ago) or as an absolute time such as "2026-01-12 08:00"
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if age, err := parseAge(value); err == nil {
+		return now.Add(-age), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is neither an age (e.g. 2h, 7d) nor a time (e.g. 2026-01-12 08:00)", value)
+}
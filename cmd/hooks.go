@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/hooks"
+	"github.com/spf13/cobra"
+)
+
+// addHooks wraps the RunE of c and all its subcommands so the flag defaults
+// configured for a command apply and its pre and post hooks run around it. A
+// failing pre hook stops the command; a failing post hook is reported but
+// does not change the outcome. The run itself is recorded in the usage stats
+// when they are on.
+func addHooks(c *cobra.Command) {
+	for _, sub := range c.Commands() {
+		addHooks(sub)
+	}
+
+	if c.RunE == nil {
+		return
+	}
+
+	run := c.RunE
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+		ctx := hooks.Context{
+			Command: strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "),
+			Args:    args,
+			Host:    cfg.Host,
+			Site:    cfg.Site,
+			Profile: cfg.Profile,
+		}
+
+		if err := applyFlagDefaults(cmd, cfg.Defaults[ctx.Command]); err != nil {
+			return err
+		}
+		if cfg.Strict {
+			if err := checkDeprecatedFlags(cmd); err != nil {
+				return err
+			}
+		}
+		if err := checkMinVersion(cmd); err != nil {
+			return err
+		}
+
+		if hook, ok := cfg.Hooks.Pre[ctx.Command]; ok {
+			ctx.Phase = hooks.PhasePre
+			if err := hooks.Run(hook, ctx); err != nil {
+				return err
+			}
+		}
+
+		startUsage(ctx.Command)
+		err := run(cmd, args)
+		finishUsage(err)
+
+		if hook, ok := cfg.Hooks.Post[ctx.Command]; ok {
+			ctx.Phase = hooks.PhasePost
+			if err != nil {
+				ctx.Error = err.Error()
+			}
+			if hookErr := hooks.Run(hook, ctx); hookErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", hookErr)
+			}
+		}
+
+		return err
+	}
+}
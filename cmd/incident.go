@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var incidentOut string
+
+var incidentCmd = &cobra.Command{
+	Use:   "incident",
+	Short: "Capture network state for troubleshooting",
+}
+
+var incidentCaptureCmd = &cobra.Command{
+	Use:   "capture",
+	Short: "Dump the complete site state to a directory",
+	Long: `Dump clients, devices, health, the last hour of events, alarms and
+threat events to a timestamped directory in one shot, so that a complete
+picture of the network is preserved when something breaks.
+
+Each dataset is stored as the raw controller response. A dataset that fails
+to download does not stop the capture; failures are listed in manifest.json.`,
+	RunE: runIncidentCapture,
+}
+
+func init() {
+	rootCmd.AddCommand(incidentCmd)
+	incidentCmd.AddCommand(incidentCaptureCmd)
+
+	incidentCaptureCmd.Flags().StringVarP(&incidentOut, "out", "o", "", "Output directory (default incident-<timestamp>)")
+}
+
+// incidentDataset is one file of an incident capture
+type incidentDataset struct {
+	file     string
+	endpoint string
+	payload  interface{}
+}
+
+func runIncidentCapture(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+
+	dir := incidentOut
+	if dir == "" {
+		dir = "incident-" + now.Format("20060102-150405")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	hourAgo := now.Add(-time.Hour)
+	datasets := []incidentDataset{
+		{file: "clients.json", endpoint: "stat/sta"},
+		{file: "devices.json", endpoint: "stat/device"},
+		{file: "health.json", endpoint: "stat/health"},
+		{file: "events.json", endpoint: "stat/event", payload: map[string]int{"within": 1, "_limit": 3000}},
+		{file: "alarms.json", endpoint: "stat/alarm"},
+		{file: "threats.json", endpoint: "stat/ips/event", payload: map[string]int64{
+			"start": hourAgo.UnixMilli(),
+			"end":   now.UnixMilli(),
+		}},
+	}
+
+	apiClient := newAPIClient()
+	manifest := map[string]interface{}{
+		"captured_at": now.Format(time.RFC3339),
+		"host":        config.Get().Host,
+		"site":        config.Get().Site,
+	}
+	failures := map[string]string{}
+
+	var results []output.ActionResult
+	for _, ds := range datasets {
+		body, err := apiClient.FetchSiteRaw(ds.endpoint, ds.payload)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, ds.file), body, 0644)
+		}
+		if err != nil {
+			failures[ds.file] = err.Error()
+		}
+		results = append(results, output.ActionResult{Target: ds.file, Err: err})
+	}
+	manifest["failures"] = failures
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	output.PrintActionResults(results)
+	fmt.Printf("Incident captured in %s\n", dir)
+
+	if len(failures) == len(datasets) {
+		return fmt.Errorf("all datasets failed to download")
+	}
+	return nil
+}
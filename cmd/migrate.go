@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/migrate"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateFrom      string
+	migrateTo        string
+	migrateMap       string
+	migrateDryRun    bool
+	migrateOverwrite bool
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy configuration between controllers",
+	// Source and target come from profiles, so the default controller
+	// does not need to be configured
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+}
+
+var migrateClientsCmd = &cobra.Command{
+	Use:   "clients",
+	Short: "Copy client aliases, notes, fixed IPs and blocks",
+	Long: `Copy the metadata of known clients from one controller to another, e.g.
+after replacing a console, where a fresh adoption loses all client naming.
+
+Source and target are profiles from the config file. Aliases, notes, fixed IPs
+and blocks are copied where the target has none yet; a different alias, note
+or fixed IP on the target is left alone with a warning unless --overwrite is
+given. Fixed IPs need the client's network on the target: networks are matched by name, or
+mapped with a YAML file of "source network: target network" lines.`,
+	Example: `  unifi migrate clients --from old --to new --dry-run
+  unifi migrate clients --from old --to new --map networks.yaml`,
+	RunE: runMigrateClients,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateClientsCmd)
+
+	migrateClientsCmd.Flags().StringVar(&migrateFrom, "from", "", "Profile of the source controller")
+	migrateClientsCmd.Flags().StringVar(&migrateTo, "to", "", "Profile of the target controller")
+	migrateClientsCmd.Flags().StringVar(&migrateMap, "map", "", "YAML file mapping source to target network names")
+	migrateClientsCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Only show what would be changed")
+	migrateClientsCmd.Flags().BoolVar(&migrateOverwrite, "overwrite", false, "Replace aliases, notes and fixed IPs the target already has")
+	migrateClientsCmd.MarkFlagRequired("from")
+	migrateClientsCmd.MarkFlagRequired("to")
+}
+
+func runMigrateClients(cmd *cobra.Command, args []string) error {
+	if migrateFrom == migrateTo {
+		return fmt.Errorf("--from and --to must be different profiles")
+	}
+
+	networkMap := migrate.NetworkMap{}
+	if migrateMap != "" {
+		var err error
+		networkMap, err = migrate.LoadNetworkMap(migrateMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	sourceConfig, err := config.ForProfile(migrateFrom)
+	if err != nil {
+		return err
+	}
+	targetConfig, err := config.ForProfile(migrateTo)
+	if err != nil {
+		return err
+	}
+	source := newAPIClientFor(sourceConfig)
+	target := newAPIClientFor(targetConfig)
+
+	sourceUsers, err := source.ListUsers()
+	if err != nil {
+		return fmt.Errorf("failed to list clients on %s: %w", migrateFrom, err)
+	}
+	sourceNetworks, err := source.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks on %s: %w", migrateFrom, err)
+	}
+	targetUsers, err := target.ListUsers()
+	if err != nil {
+		return fmt.Errorf("failed to list clients on %s: %w", migrateTo, err)
+	}
+	targetNetworks, err := target.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks on %s: %w", migrateTo, err)
+	}
+
+	changes := migrate.PlanClients(sourceUsers, targetUsers, sourceNetworks, targetNetworks, networkMap, migrateOverwrite)
+	if len(changes) == 0 {
+		fmt.Println("Nothing to migrate")
+		return nil
+	}
+
+	for _, change := range changes {
+		for _, warning := range change.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", change.MAC, warning)
+		}
+	}
+
+	if migrateDryRun {
+		for _, change := range changes {
+			if summary := change.Summary(); summary != "" {
+				fmt.Printf("%s  %s\n", change.MAC, summary)
+			}
+		}
+		return nil
+	}
+
+	var results []output.ActionResult
+	for _, change := range changes {
+		if change.Summary() == "" {
+			continue
+		}
+
+		var err error
+		if len(change.Fields) > 0 {
+			if change.TargetID == "" {
+				change.Fields["mac"] = change.MAC
+				_, err = target.CreateUser(change.Fields)
+			} else {
+				_, err = target.UpdateUser(change.TargetID, change.Fields)
+			}
+		}
+		if err == nil && change.Block {
+			err = target.BlockClient(change.MAC)
+		}
+		results = append(results, output.ActionResult{Target: change.MAC, Err: err})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d clients failed", failures, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/drift"
+	"github.com/spf13/cobra"
+)
+
+// minVersionAnnotation is the command annotation holding the oldest
+// controller version a command (and its subcommands) works with
+const minVersionAnnotation = "min_version"
+
+// controllerVersion is the version of the selected controller as last seen by
+// the upgrade check, empty when unknown
+var controllerVersion string
+
+// minVersion returns the controller version cmd needs: the highest required
+// by it or any of its parents
+func minVersion(cmd *cobra.Command) string {
+	required := ""
+	for c := cmd; c != nil; c = c.Parent() {
+		if v := c.Annotations[minVersionAnnotation]; v != "" && drift.CompareVersions(v, required) > 0 {
+			required = v
+		}
+	}
+	return required
+}
+
+// checkMinVersion fails a command the controller is too old for, instead of
+// letting it run into a 404 for an endpoint the controller does not have
+func checkMinVersion(cmd *cobra.Command) error {
+	required := minVersion(cmd)
+	if required == "" || drift.AtLeast(controllerVersion, required) {
+		return nil
+	}
+
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%s needs controller version %s or later, but %s runs %s", cmd.CommandPath(), required, config.Get().Host, controllerVersion)
+}
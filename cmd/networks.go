@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/network"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networksFormat string
+	networksFilter string
+	networksSort   string
+
+	networkCreateSpec     network.Spec
+	networkCreateFromFile string
+)
+
+var networksCmd = &cobra.Command{
+	Use:   "networks",
+	Short: "Manage networks",
+	Long:  `Inspect and change network (VLAN) configuration.`,
+}
+
+var networksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List networks",
+	Long: `List the configured networks (VLANs) with their purpose, VLAN ID, subnet, DHCP
+range, domain, and IGMP snooping, mDNS and IPv6 settings. --filter accepts the
+network filter fields. Networks are sorted by name unless --sort is given.`,
+	Example: `  unifi networks list
+  unifi networks list --filter "purpose != 'wan' AND vlan > 0"
+  unifi networks list --sort vlan
+  unifi networks list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runNetworksList,
+}
+
+var networksCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a network",
+	Long: `Create a corporate or VLAN-only network from flags or from a YAML spec file
+with the same keys (name, purpose, vlan, subnet, dhcp_range, domain). Flags
+override values from the file.
+
+--subnet is the gateway address with its prefix length. The name, VLAN ID and
+subnet are checked against the existing networks before anything is submitted,
+so a subnet that overlaps another network is rejected. DHCP is enabled only
+when --dhcp-range is given.`,
+	Example: `  unifi networks create --name Cameras --vlan 40 --subnet 10.0.40.1/24 --dhcp-range 10.0.40.100-10.0.40.200
+  unifi networks create --name Transit --purpose vlan-only --vlan 50
+  unifi networks create --from-file cameras.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runNetworksCreate,
+}
+
+var networksSetCmd = &cobra.Command{
+	Use:   "set <name|id>",
+	Short: "Change network settings",
+	Long: `Change multicast related settings of a network.
+
+Only the given flags are changed, everything else is left as configured.`,
+	Example: `  unifi networks set IoT --mdns on
+  unifi networks set LAN --mdns off --igmp-snooping on`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNetworksSet,
+}
+
+func init() {
+	rootCmd.AddCommand(networksCmd)
+	networksCmd.AddCommand(networksListCmd)
+	networksCmd.AddCommand(networksCreateCmd)
+	networksCmd.AddCommand(networksSetCmd)
+
+	networksListCmd.Flags().StringVarP(&networksFormat, "format", "f", "table", "Output format (table or json)")
+	networksListCmd.Flags().StringVar(&networksFilter, "filter", "", "SQL WHERE clause (e.g., \"vlan > 0\")")
+	networksListCmd.Flags().StringVar(&networksSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: name)")
+
+	networksCreateCmd.Flags().StringVar(&networkCreateSpec.Name, "name", "", "Name of the new network")
+	networksCreateCmd.Flags().StringVar(&networkCreateSpec.Purpose, "purpose", "corporate", "Purpose (corporate or vlan-only)")
+	networksCreateCmd.Flags().IntVar(&networkCreateSpec.VLAN, "vlan", 0, "VLAN ID (2-4009)")
+	networksCreateCmd.Flags().StringVar(&networkCreateSpec.Subnet, "subnet", "", "Gateway address and prefix length (e.g., 10.0.40.1/24)")
+	networksCreateCmd.Flags().StringVar(&networkCreateSpec.DHCPRange, "dhcp-range", "", "DHCP pool as start-stop (DHCP is off without it)")
+	networksCreateCmd.Flags().StringVar(&networkCreateSpec.Domain, "domain", "", "Domain name handed out by DHCP")
+	networksCreateCmd.Flags().StringVar(&networkCreateFromFile, "from-file", "", "Read the network from a YAML spec file")
+
+	networksSetCmd.Flags().String("mdns", "", "Enable or disable the mDNS (Bonjour) reflector (on|off)")
+	networksSetCmd.Flags().String("igmp-snooping", "", "Enable or disable IGMP snooping (on|off)")
+}
+
+func runNetworksList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	if networksFilter != "" {
+		filterEngine, err := filter.NewFilter(networksFilter)
+		if err != nil {
+			return fmt.Errorf("failed to create filter: %w", err)
+		}
+		defer filterEngine.Close()
+
+		networks, err = filterEngine.ApplyNetworks(networks)
+		if err != nil {
+			return fmt.Errorf("failed to apply filter: %w", err)
+		}
+	}
+
+	api.SortNetworks(networks)
+	if err := sorting.ByKeys(networks, networksSort); err != nil {
+		return err
+	}
+
+	switch networksFormat {
+	case "json":
+		if networks == nil {
+			networks = []api.Network{}
+		}
+		return output.PrintJSON(networks)
+	case "table":
+		output.PrintNetworksTable(networks)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", networksFormat)
+	}
+}
+
+func runNetworksCreate(cmd *cobra.Command, args []string) error {
+	spec := networkCreateSpec
+	if networkCreateFromFile != "" {
+		var err error
+		if spec, err = loadNetworkSpec(cmd, networkCreateFromFile); err != nil {
+			return err
+		}
+	}
+
+	apiClient := newAPIClient()
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	fields, err := spec.Fields(networks)
+	if err != nil {
+		return err
+	}
+
+	created, err := apiClient.CreateNetwork(fields)
+	if err != nil {
+		return fmt.Errorf("failed to create network: %w", err)
+	}
+
+	fmt.Printf("Created network %s (%s)\n", created.Name, created.ID)
+	return nil
+}
+
+// loadNetworkSpec reads a spec file and applies the flags given on the
+// command line on top of it
+func loadNetworkSpec(cmd *cobra.Command, path string) (network.Spec, error) {
+	spec, err := network.LoadSpec(path)
+	if err != nil {
+		return spec, err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		spec.Name = networkCreateSpec.Name
+	}
+	if flags.Changed("purpose") {
+		spec.Purpose = networkCreateSpec.Purpose
+	}
+	if flags.Changed("vlan") {
+		spec.VLAN = networkCreateSpec.VLAN
+	}
+	if flags.Changed("subnet") {
+		spec.Subnet = networkCreateSpec.Subnet
+	}
+	if flags.Changed("dhcp-range") {
+		spec.DHCPRange = networkCreateSpec.DHCPRange
+	}
+	if flags.Changed("domain") {
+		spec.Domain = networkCreateSpec.Domain
+	}
+	return spec, nil
+}
+
+// networkSetFlags maps networks set flags to networkconf fields
+var networkSetFlags = []struct {
+	flag  string
+	field string
+}{
+	{"mdns", "mdns_enabled"},
+	{"igmp-snooping", "igmp_snooping"},
+}
+
+func runNetworksSet(cmd *cobra.Command, args []string) error {
+	fields := map[string]interface{}{}
+	for _, f := range networkSetFlags {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		value, _ := cmd.Flags().GetString(f.flag)
+		enabled, err := parseOnOff(value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", f.flag, err)
+		}
+		fields[f.field] = enabled
+	}
+
+	if len(fields) == 0 {
+		return fmt.Errorf("nothing to change (use --mdns or --igmp-snooping)")
+	}
+
+	apiClient := newAPIClient()
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	network, err := api.FindNetwork(networks, args[0])
+	if err != nil {
+		return err
+	}
+
+	updated, err := apiClient.UpdateNetwork(network.ID, fields)
+	if err != nil {
+		return fmt.Errorf("failed to update network: %w", err)
+	}
+
+	fmt.Printf("Updated %s: mdns %s, igmp-snooping %s\n", updated.Name, onOff(updated.MDNSEnabled), onOff(updated.IGMPSnooping))
+	return nil
+}
+
+// parseOnOff parses an on|off flag value
+func parseOnOff(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "on", "true", "yes":
+		return true, nil
+	case "off", "false", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%q is not on or off", value)
+	}
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
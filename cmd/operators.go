@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/passphrase"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+// operatorPasswordLength is the length of generated operator passwords
+const operatorPasswordLength = 12
+
+var (
+	operatorsFormat string
+	operatorsSort   string
+	operatorSpec    api.HotspotOperator
+	operatorsYes    bool
+)
+
+var operatorsCmd = &cobra.Command{
+	Use:     "operators",
+	Aliases: []string{"hotspot-operators"},
+	Short:   "Manage hotspot operator accounts",
+	Long: `List, create and delete hotspot operators: accounts for front desk staff that
+can sign in to the hotspot manager to issue vouchers and authorize guests, but
+cannot administer the site.`,
+}
+
+var operatorsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List hotspot operators",
+	Example: `  unifi operators list
+  unifi operators list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runOperatorsList,
+}
+
+var operatorsCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a hotspot operator",
+	Long: `Create a hotspot operator account. Without --password a random password is
+generated and printed once; hand it to the operator, it is not shown again.`,
+	Example: `  unifi operators create reception --note "Front desk"
+  unifi operators create bar --password 'happy-hour-2026'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOperatorsCreate,
+}
+
+var operatorsDeleteCmd = &cobra.Command{
+	Use:   "delete <name|id>",
+	Short: "Delete a hotspot operator",
+	Long:  `Delete a hotspot operator account. Vouchers the operator issued stay valid.`,
+	Example: `  unifi operators delete reception
+  unifi operators delete reception --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOperatorsDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(operatorsCmd)
+	operatorsCmd.AddCommand(operatorsListCmd)
+	operatorsCmd.AddCommand(operatorsCreateCmd)
+	operatorsCmd.AddCommand(operatorsDeleteCmd)
+
+	operatorsListCmd.Flags().StringVarP(&operatorsFormat, "format", "f", "table", "Output format (table or json)")
+	operatorsListCmd.Flags().StringVar(&operatorsSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: name)")
+
+	operatorsCreateCmd.Flags().StringVar(&operatorSpec.Password, "password", "", "Password of the operator (generated if not given)")
+	operatorsCreateCmd.Flags().StringVar(&operatorSpec.Note, "note", "", "Note to keep with the operator, e.g. who uses it")
+
+	operatorsDeleteCmd.Flags().BoolVarP(&operatorsYes, "yes", "y", false, "Delete without asking for confirmation")
+}
+
+func runOperatorsList(cmd *cobra.Command, args []string) error {
+	if operatorsFormat != "table" && operatorsFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", operatorsFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	operators, err := apiClient.ListHotspotOperators()
+	if err != nil {
+		return fmt.Errorf("failed to list hotspot operators: %w", err)
+	}
+
+	api.SortHotspotOperators(operators)
+	if err := sorting.ByKeys(operators, operatorsSort); err != nil {
+		return err
+	}
+
+	if operatorsFormat == "json" {
+		// Passwords are left out, as in the table
+		for i := range operators {
+			operators[i].Password = ""
+		}
+		if operators == nil {
+			operators = []api.HotspotOperator{}
+		}
+		return output.PrintJSON(operators)
+	}
+
+	if len(operators) == 0 {
+		fmt.Println("No hotspot operators")
+		return nil
+	}
+
+	output.PrintHotspotOperatorsTable(operators)
+	return nil
+}
+
+func runOperatorsCreate(cmd *cobra.Command, args []string) error {
+	operator := operatorSpec
+	operator.Name = strings.TrimSpace(args[0])
+	if operator.Name == "" {
+		return fmt.Errorf("operator name must not be empty")
+	}
+
+	generated := operator.Password == ""
+	if generated {
+		var err error
+		if operator.Password, err = passphrase.Random(operatorPasswordLength); err != nil {
+			return err
+		}
+	}
+
+	apiClient := newAPIClient()
+
+	operators, err := apiClient.ListHotspotOperators()
+	if err != nil {
+		return fmt.Errorf("failed to list hotspot operators: %w", err)
+	}
+	if _, err := api.FindHotspotOperator(operators, operator.Name); err == nil {
+		return fmt.Errorf("a hotspot operator named %q already exists", operator.Name)
+	}
+
+	created, err := apiClient.CreateHotspotOperator(operator)
+	if err != nil {
+		return fmt.Errorf("failed to create hotspot operator: %w", err)
+	}
+
+	fmt.Printf("Created hotspot operator %s (%s)\n", created.Name, created.ID)
+	if generated {
+		fmt.Printf("Password: %s\n", operator.Password)
+	}
+	return nil
+}
+
+func runOperatorsDelete(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	operators, err := apiClient.ListHotspotOperators()
+	if err != nil {
+		return fmt.Errorf("failed to list hotspot operators: %w", err)
+	}
+
+	operator, err := api.FindHotspotOperator(operators, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !operatorsYes {
+		ok, err := confirm(fmt.Sprintf("Delete hotspot operator %s?", operator.Name))
+		if errors.Is(err, errNonInteractive) {
+			return fmt.Errorf("%w (use --yes to delete without asking)", err)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	if err := apiClient.DeleteHotspotOperator(operator.ID); err != nil {
+		return fmt.Errorf("failed to delete hotspot operator: %w", err)
+	}
+
+	fmt.Printf("Deleted hotspot operator %s\n", operator.Name)
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/pending"
+)
+
+// pendingStorePath is where deferred actions such as timed unblocks are kept
+func pendingStorePath() string {
+	return filepath.Join(config.GetDataDir(), "pending.json")
+}
+
+// runDuePendingActions completes actions scheduled by earlier invocations
+// against the configured controller. Failures are reported on stderr and the
+// action is kept so the next invocation retries it.
+func runDuePendingActions() {
+	store, err := pending.Load(pendingStorePath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+
+	cfg := config.Get()
+	due := store.Due(cfg.Host, time.Now())
+	if len(due) == 0 {
+		return
+	}
+
+	var completed []pending.Action
+	for _, action := range due {
+		apiClient := newAPIClient()
+		apiClient.Site = action.Site
+
+		var err error
+		switch action.Type {
+		case pending.ActionUnblock:
+			err = apiClient.UnblockClient(action.MAC)
+		default:
+			err = fmt.Errorf("unknown action type %q", action.Type)
+		}
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: pending %s of %s failed: %v\n", action.Type, action.MAC, err)
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "Completed pending %s of %s\n", action.Type, action.MAC)
+		completed = append(completed, action)
+	}
+
+	if len(completed) == 0 {
+		return
+	}
+
+	// Reload under the lock, so actions other invocations scheduled in the
+	// meantime are kept
+	err = pending.Update(pendingStorePath(), func(store *pending.Store) {
+		for _, action := range completed {
+			store.Complete(action)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portalTitle     string
+	portalTermsFile string
+	portalLogoFile  string
+)
+
+var portalCmd = &cobra.Command{
+	Use:   "portal",
+	Short: "Manage the guest hotspot portal",
+}
+
+var portalUploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Push portal branding settings",
+	Long: `Push hotspot portal branding to the controller so it can be kept under
+version control and deployed from CI.
+
+Only the settings given as flags are changed. The terms of service are read
+from a text or Markdown file and shown verbatim on the portal. The logo must be
+a PNG, JPEG or GIF image; it is uploaded like in the portal editor and then
+shown on the portal.`,
+	Example: `  unifi portal upload --title "Cafe WiFi" --terms terms.md
+  unifi portal upload --logo logo.png --title "Cafe WiFi" --terms terms.md`,
+	RunE: runPortalUpload,
+}
+
+func init() {
+	rootCmd.AddCommand(portalCmd)
+	portalCmd.AddCommand(portalUploadCmd)
+
+	portalUploadCmd.Flags().StringVar(&portalTitle, "title", "", "Portal title")
+	portalUploadCmd.Flags().StringVar(&portalTermsFile, "terms", "", "File containing the terms of service")
+	portalUploadCmd.Flags().StringVar(&portalLogoFile, "logo", "", "PNG, JPEG or GIF image to show as the portal logo")
+}
+
+func runPortalUpload(cmd *cobra.Command, args []string) error {
+	fields := map[string]interface{}{}
+
+	if cmd.Flags().Changed("title") {
+		fields["portal_customized_title"] = portalTitle
+	}
+	if portalTermsFile != "" {
+		terms, err := os.ReadFile(portalTermsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read terms file: %w", err)
+		}
+		fields["portal_customized_tos"] = string(terms)
+		fields["portal_customized_tos_enabled"] = true
+	}
+
+	// Read and check the logo before anything is changed
+	var logo []byte
+	if portalLogoFile != "" {
+		var err error
+		if logo, err = os.ReadFile(portalLogoFile); err != nil {
+			return fmt.Errorf("failed to read logo file: %w", err)
+		}
+		switch http.DetectContentType(logo) {
+		case "image/png", "image/jpeg", "image/gif":
+		default:
+			return fmt.Errorf("logo %s is not a PNG, JPEG or GIF image", portalLogoFile)
+		}
+	}
+
+	if len(fields) == 0 && logo == nil {
+		return fmt.Errorf("nothing to upload (use --title, --terms and/or --logo)")
+	}
+	fields["portal_customized"] = true
+
+	apiClient := newAPIClient()
+
+	if logo != nil {
+		file, err := apiClient.UploadPortalFile(filepath.Base(portalLogoFile), logo)
+		if err != nil {
+			return fmt.Errorf("failed to upload logo: %w", err)
+		}
+		fields["portal_customized_logo_enabled"] = true
+		fields["portal_customized_logo_file_id"] = file.ID
+	}
+
+	settings, err := apiClient.GetGuestAccessSettings()
+	if err != nil {
+		return fmt.Errorf("failed to get portal settings: %w", err)
+	}
+
+	if err := apiClient.UpdateGuestAccessSettings(settings.ID, fields); err != nil {
+		return fmt.Errorf("failed to update portal settings: %w", err)
+	}
+
+	updated, err := apiClient.GetGuestAccessSettings()
+	if err != nil {
+		return fmt.Errorf("failed to get portal settings: %w", err)
+	}
+
+	termsState := "disabled"
+	if updated.PortalTermsEnabled {
+		termsState = fmt.Sprintf("enabled (%d characters)", len(updated.PortalTerms))
+	}
+
+	logoState := "disabled"
+	if updated.PortalLogoEnabled {
+		logoState = "enabled"
+	}
+
+	fmt.Println("Portal settings updated")
+	output.PrintDetails([]output.Detail{
+		{Key: "Title", Value: updated.PortalTitle},
+		{Key: "Terms", Value: termsState},
+		{Key: "Logo", Value: logoState},
+	})
+	return nil
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/firewall"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portForwardFormat  string
+	portForwardSort    string
+	portForwardDstPort string
+	portForwardFwdIP   string
+	portForwardFwdPort string
+	portForwardProto   string
+	portForwardWAN     string
+	portForwardSrc     string
+	portForwardYes     bool
+)
+
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward",
+	Short: "Manage port forwards",
+	Long:  `List, create, toggle and delete port forwards from the WAN to hosts on the LAN.`,
+}
+
+var portForwardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List port forwards",
+	Example: `  unifi port-forward list
+  unifi port-forward list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runPortForwardList,
+}
+
+var portForwardCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a port forward",
+	Long: `Forward a WAN port, range or comma separated list of both to a host on the
+LAN. The forwarded port defaults to the WAN port.
+
+A warning is printed when an enabled forward already receives some of the same
+traffic (same WAN, overlapping protocol, source and port); the forward is
+created anyway.`,
+	Example: `  unifi port-forward create Web --dst-port 443 --fwd-ip 192.168.1.10 --fwd-port 8443 --proto tcp
+  unifi port-forward create Minecraft --dst-port 25565 --fwd-ip 192.168.1.20
+  unifi port-forward create RDP --dst-port 3389 --fwd-ip 192.168.1.30 --src 198.51.100.7 --wan wan2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPortForwardCreate,
+}
+
+var portForwardEnableCmd = &cobra.Command{
+	Use:   "enable <id|name>",
+	Short: "Enable a port forward",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPortForwardEnabled(args[0], true)
+	},
+}
+
+var portForwardDisableCmd = &cobra.Command{
+	Use:   "disable <id|name>",
+	Short: "Disable a port forward",
+	Long:  `Disable a port forward without deleting it.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPortForwardEnabled(args[0], false)
+	},
+}
+
+var portForwardDeleteCmd = &cobra.Command{
+	Use:   "delete <id|name>",
+	Short: "Delete a port forward",
+	Example: `  unifi port-forward delete Web
+  unifi port-forward delete 64b1f0c2e4b0a1a2b3c4d5e6 --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPortForwardDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(portForwardCmd)
+	portForwardCmd.AddCommand(portForwardListCmd)
+	portForwardCmd.AddCommand(portForwardCreateCmd)
+	portForwardCmd.AddCommand(portForwardEnableCmd)
+	portForwardCmd.AddCommand(portForwardDisableCmd)
+	portForwardCmd.AddCommand(portForwardDeleteCmd)
+
+	portForwardListCmd.Flags().StringVarP(&portForwardFormat, "format", "f", "table", "Output format (table or json)")
+	portForwardListCmd.Flags().StringVar(&portForwardSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: name)")
+
+	portForwardCreateCmd.Flags().StringVar(&portForwardDstPort, "dst-port", "", "WAN port, range or list (e.g. 443, 8000-8100)")
+	portForwardCreateCmd.Flags().StringVar(&portForwardFwdIP, "fwd-ip", "", "LAN address to forward to")
+	portForwardCreateCmd.Flags().StringVar(&portForwardFwdPort, "fwd-port", "", "LAN port, range or list (default: the WAN port)")
+	portForwardCreateCmd.Flags().StringVar(&portForwardProto, "proto", "tcp_udp", "Protocol (tcp, udp or tcp_udp)")
+	portForwardCreateCmd.Flags().StringVar(&portForwardWAN, "wan", "wan", "WAN interface to listen on (wan, wan2 or both)")
+	portForwardCreateCmd.Flags().StringVar(&portForwardSrc, "src", "any", "Only forward traffic from this source address")
+	portForwardCreateCmd.MarkFlagRequired("dst-port")
+	portForwardCreateCmd.MarkFlagRequired("fwd-ip")
+
+	portForwardDeleteCmd.Flags().BoolVarP(&portForwardYes, "yes", "y", false, "Delete without asking for confirmation")
+}
+
+func runPortForwardList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	forwards, err := apiClient.ListPortForwards()
+	if err != nil {
+		return fmt.Errorf("failed to list port forwards: %w", err)
+	}
+
+	api.SortPortForwards(forwards)
+	if err := sorting.ByKeys(forwards, portForwardSort); err != nil {
+		return err
+	}
+
+	switch portForwardFormat {
+	case "json":
+		if forwards == nil {
+			forwards = []api.PortForward{}
+		}
+		return output.PrintJSON(forwards)
+	case "table":
+		output.PrintPortForwardsTable(forwards)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", portForwardFormat)
+	}
+}
+
+func runPortForwardCreate(cmd *cobra.Command, args []string) error {
+	dstPort, err := firewall.NormalizePorts(portForwardDstPort)
+	if err != nil {
+		return fmt.Errorf("invalid --dst-port: %w", err)
+	}
+
+	fwdPort := dstPort
+	if portForwardFwdPort != "" {
+		if fwdPort, err = firewall.NormalizePorts(portForwardFwdPort); err != nil {
+			return fmt.Errorf("invalid --fwd-port: %w", err)
+		}
+	}
+
+	fwdIP, err := netip.ParseAddr(portForwardFwdIP)
+	if err != nil || !fwdIP.Is4() {
+		return fmt.Errorf("invalid --fwd-ip %q: expected an IPv4 address", portForwardFwdIP)
+	}
+
+	proto, err := firewall.ParseProto(portForwardProto)
+	if err != nil {
+		return err
+	}
+
+	wan := strings.ToLower(portForwardWAN)
+	if wan != "wan" && wan != "wan2" && wan != "both" {
+		return fmt.Errorf("invalid --wan %q (valid options: wan, wan2, both)", portForwardWAN)
+	}
+
+	src := portForwardSrc
+	if src != "any" {
+		addr, err := netip.ParseAddr(src)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("invalid --src %q: expected an IPv4 address or any", portForwardSrc)
+		}
+		src = addr.String()
+	}
+
+	forward := api.PortForward{
+		Name:      args[0],
+		Enabled:   true,
+		Interface: wan,
+		Src:       src,
+		DstPort:   dstPort,
+		FwdIP:     fwdIP.String(),
+		FwdPort:   fwdPort,
+		Proto:     proto,
+	}
+
+	apiClient := newAPIClient()
+
+	forwards, err := apiClient.ListPortForwards()
+	if err != nil {
+		return fmt.Errorf("failed to list port forwards: %w", err)
+	}
+	warnPortForwardConflicts(forwards, forward)
+
+	created, err := apiClient.CreatePortForward(forward)
+	if err != nil {
+		return fmt.Errorf("failed to create port forward: %w", err)
+	}
+
+	fmt.Printf("Created port forward %s (%s): %s %s -> %s:%s\n", created.Name, created.ID, proto, dstPort, forward.FwdIP, fwdPort)
+	return nil
+}
+
+// warnPortForwardConflicts warns about the enabled forwards that receive some
+// of the same traffic as forward
+func warnPortForwardConflicts(forwards []api.PortForward, forward api.PortForward) {
+	for _, other := range firewall.Conflicts(forwards, forward) {
+		fmt.Fprintf(os.Stderr, "Warning: conflicts with port forward %s (%s %s -> %s:%s)\n",
+			other.Name, other.Proto, other.DstPort, other.FwdIP, other.FwdPort)
+	}
+}
+
+// setPortForwardEnabled turns a port forward on or off. Enabling warns about
+// conflicts like create does.
+func setPortForwardEnabled(query string, enabled bool) error {
+	apiClient := newAPIClient()
+
+	forwards, err := apiClient.ListPortForwards()
+	if err != nil {
+		return fmt.Errorf("failed to list port forwards: %w", err)
+	}
+
+	forward, err := api.FindPortForward(forwards, query)
+	if err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	if forward.Enabled == enabled {
+		fmt.Printf("Port forward %s is already %s\n", forward.Name, state)
+		return nil
+	}
+
+	if enabled {
+		warnPortForwardConflicts(forwards, *forward)
+	}
+
+	if _, err := apiClient.SetPortForwardEnabled(forward.ID, enabled); err != nil {
+		return fmt.Errorf("failed to update port forward: %w", err)
+	}
+
+	fmt.Printf("Port forward %s %s\n", forward.Name, state)
+	return nil
+}
+
+func runPortForwardDelete(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	forwards, err := apiClient.ListPortForwards()
+	if err != nil {
+		return fmt.Errorf("failed to list port forwards: %w", err)
+	}
+
+	forward, err := api.FindPortForward(forwards, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !portForwardYes {
+		ok, err := confirm(fmt.Sprintf("Delete port forward %s (%s -> %s:%s)?", forward.Name, forward.DstPort, forward.FwdIP, forward.FwdPort))
+		if errors.Is(err, errNonInteractive) {
+			return fmt.Errorf("%w (use --yes to delete without asking)", err)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	if err := apiClient.DeletePortForward(forward.ID); err != nil {
+		return fmt.Errorf("failed to delete port forward: %w", err)
+	}
+
+	fmt.Printf("Deleted port forward %s\n", forward.Name)
+	return nil
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportWithin string
+	reportFormat string
+
+	trafficWithin string
+	trafficBy     string
+	trafficTop    int
+	trafficPie    bool
+	trafficOut    string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize controller data",
+	Long:  `Summarize controller data over time for review.`,
+}
+
+var reportDFSCmd = &cobra.Command{
+	Use:   "dfs",
+	Short: "Summarize DFS radar hits per AP and channel",
+	Long: `Count the DFS radar detections of each access point per 5 GHz channel. After
+a radar hit the AP has to leave its channel, so channels that are hit often are
+better replaced with non-DFS channels (36-48, 149-165).`,
+	Example: `  unifi report dfs
+  unifi report dfs --within 30d --format json`,
+	Args: cobra.NoArgs,
+	RunE: runReportDFS,
+}
+
+var reportTrafficCmd = &cobra.Command{
+	Use:   "traffic",
+	Short: "Show who used the bandwidth",
+	Long: `Break the traffic of a period down by client, vendor or SSID, largest share
+first, from the controller's hourly (up to 7 days back) or daily reports.
+
+--pie prints the breakdown as a proportional bar; --out writes it as an SVG pie
+chart. Shares beyond --top are merged into "Other". SSIDs are those of the
+clients' current connections; clients that are not connected now are counted
+as "(not connected)".`,
+	Example: `  unifi report traffic
+  unifi report traffic --within 7d --by vendor --pie
+  unifi report traffic --by ssid --out traffic.svg`,
+	Args: cobra.NoArgs,
+	RunE: runReportTraffic,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportDFSCmd)
+	reportCmd.AddCommand(reportTrafficCmd)
+
+	reportDFSCmd.Flags().StringVar(&reportWithin, "within", "7d", "How far back to look (e.g. 24h, 30d)")
+	reportDFSCmd.Flags().StringVarP(&reportFormat, "format", "f", "table", "Output format (table or json)")
+
+	reportTrafficCmd.Flags().StringVar(&trafficWithin, "within", "24h", "How far back to look (e.g. 24h, 30d)")
+	reportTrafficCmd.Flags().StringVar(&trafficBy, "by", "client", "Group traffic by client, vendor or ssid")
+	reportTrafficCmd.Flags().IntVar(&trafficTop, "top", 10, "Merge all but the N largest shares into Other (0 keeps all)")
+	reportTrafficCmd.Flags().BoolVar(&trafficPie, "pie", false, "Print the breakdown as a proportional bar")
+	reportTrafficCmd.Flags().StringVar(&trafficOut, "out", "", "Also write the breakdown as an SVG pie chart to this file")
+	reportTrafficCmd.Flags().StringVarP(&reportFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+// reportEventLimit caps the events fetched for a report
+const reportEventLimit = 10000
+
+func runReportDFS(cmd *cobra.Command, args []string) error {
+	within, err := parseAge(reportWithin)
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+
+	apiClient := newAPIClient()
+
+	events, err := apiClient.ListEvents(within, reportEventLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	summary := report.DFS(api.FilterEvents(events, "dfs"))
+
+	switch reportFormat {
+	case "json":
+		return output.PrintJSON(summary)
+	case "table":
+		if len(summary) == 0 {
+			fmt.Printf("No DFS radar hits in the last %s\n", reportWithin)
+			return nil
+		}
+		output.PrintDFSReport(summary)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", reportFormat)
+	}
+}
+
+func runReportTraffic(cmd *cobra.Command, args []string) error {
+	if reportFormat != "table" && reportFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", reportFormat)
+	}
+	if trafficBy != "client" && trafficBy != "vendor" && trafficBy != "ssid" {
+		return fmt.Errorf("invalid --by: %s (valid options: client, vendor, ssid)", trafficBy)
+	}
+
+	within, err := parseAge(trafficWithin)
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+
+	apiClient := newAPIClient()
+
+	end := time.Now()
+	traffic, err := apiClient.GetUserTraffic(api.ReportInterval(within), end.Add(-within), end)
+	if err != nil {
+		return fmt.Errorf("failed to get traffic report: %w", err)
+	}
+
+	group, err := trafficGroup(apiClient, trafficBy)
+	if err != nil {
+		return err
+	}
+
+	shares := report.TopShares(report.TrafficShares(traffic, group), trafficTop)
+
+	if trafficOut != "" {
+		f, err := os.Create(trafficOut)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", trafficOut, err)
+		}
+		title := fmt.Sprintf("Traffic by %s, last %s", trafficBy, trafficWithin)
+		if err := output.WriteTrafficSVG(f, shares, title); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", trafficOut, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", trafficOut, err)
+		}
+	}
+
+	switch {
+	case reportFormat == "json":
+		if shares == nil {
+			shares = []report.TrafficShare{}
+		}
+		return output.PrintJSON(shares)
+	case len(shares) == 0:
+		fmt.Printf("No traffic in the last %s\n", trafficWithin)
+	case trafficPie:
+		output.PrintTrafficPie(shares)
+	default:
+		output.PrintTrafficTable(shares)
+	}
+	return nil
+}
+
+// trafficGroup returns how report traffic names the group of a client MAC
+func trafficGroup(apiClient *api.APIClient, by string) (func(mac string) string, error) {
+	users, err := apiClient.ListUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list known clients: %w", err)
+	}
+	known := map[string]api.User{}
+	for _, u := range users {
+		known[strings.ToLower(u.MAC)] = u
+	}
+
+	switch by {
+	case "vendor":
+		return func(mac string) string {
+			if vendor := known[mac].OUI; vendor != "" {
+				return vendor
+			}
+			return "(unknown)"
+		}, nil
+	case "ssid":
+		clients, err := apiClient.ListClients()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clients: %w", err)
+		}
+		ssids := map[string]string{}
+		for _, c := range clients {
+			ssid := c.Essid
+			if c.IsWired {
+				ssid = "(wired)"
+			}
+			ssids[strings.ToLower(c.MAC)] = ssid
+		}
+		return func(mac string) string {
+			if ssid, ok := ssids[mac]; ok {
+				return ssid
+			}
+			return "(not connected)"
+		}, nil
+	default:
+		return func(mac string) string {
+			u := known[mac]
+			switch {
+			case u.Name != "":
+				return u.Name
+			case u.Hostname != "":
+				return u.Hostname
+			}
+			return mac
+		}, nil
+	}
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/output"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,28 +21,78 @@ var rootCmd = &cobra.Command{
 
 This tool allows you to interact with your Unifi controller to manage clients, devices, networks, and more.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return config.Validate()
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		if err := ensureTrustedCertificate(); err != nil {
+			return err
+		}
+
+		runDuePendingActions()
+		checkVersionDrift()
+		return nil
 	},
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	addHooks(rootCmd)
+
+	err := rootCmd.Execute()
+	closeTunnels()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
+
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// exitError makes the command exit with a code other than 1, such as a
+// monitoring plugin status
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.unifi-cli.yaml)")
+	rootCmd.PersistentFlags().StringP("profile", "p", "", "Use the named profile from the config file")
 	rootCmd.PersistentFlags().String("host", "", "Unifi controller host (e.g., https://unifi.example.com)")
 	rootCmd.PersistentFlags().String("site", "default", "Site ID")
 	rootCmd.PersistentFlags().BoolP("insecure", "k", true, "Skip TLS certificate verification")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "API request timeout (default 30s, 5s in minimal mode)")
+	rootCmd.PersistentFlags().Bool("tofu", false, "Pin the controller certificate on first connect (trust on first use)")
+	rootCmd.PersistentFlags().String("record", "", "Record sanitized API responses as fixtures to this directory")
+	rootCmd.PersistentFlags().String("save-raw", "", "Save raw, unsanitized API responses with request metadata to this directory")
+	rootCmd.PersistentFlags().Bool("minimal", false, "Minimal mode for running on the console itself (local endpoint, short timeouts, no colors, small buffers)")
+	rootCmd.PersistentFlags().Bool("non-interactive", false, "Fail instead of prompting (for cron and CI)")
+	rootCmd.PersistentFlags().String("ssh-tunnel", "", "Reach the controller through an SSH jump host (e.g., admin@bastion)")
+	rootCmd.PersistentFlags().Bool("strict", false, "Fail on deprecated flags and missing response fields instead of printing empty values")
+	rootCmd.PersistentFlags().String("theme", "", "Table theme ("+strings.Join(output.ThemeNames(), ", ")+", or one from the config file)")
 
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("host"))
 	viper.BindPFlag("site", rootCmd.PersistentFlags().Lookup("site"))
 	viper.BindPFlag("insecure", rootCmd.PersistentFlags().Lookup("insecure"))
+	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag("minimal", rootCmd.PersistentFlags().Lookup("minimal"))
+	viper.BindPFlag("tofu", rootCmd.PersistentFlags().Lookup("tofu"))
+	viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record"))
+	viper.BindPFlag("save_raw", rootCmd.PersistentFlags().Lookup("save-raw"))
+	viper.BindPFlag("theme", rootCmd.PersistentFlags().Lookup("theme"))
+	viper.BindPFlag("strict", rootCmd.PersistentFlags().Lookup("strict"))
+	viper.BindPFlag("non_interactive", rootCmd.PersistentFlags().Lookup("non-interactive"))
+	viper.BindPFlag("ssh_tunnel", rootCmd.PersistentFlags().Lookup("ssh-tunnel"))
 }
 
 func initConfig() {
@@ -47,4 +100,11 @@ func initConfig() {
 		fmt.Fprintf(os.Stderr, "Error initializing config: %v\n", err)
 		os.Exit(1)
 	}
+
+	if err := applyTheme(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	output.SetMinimal(config.Get().Minimal)
 }
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alertsFormat string
+	alertsAll    bool
+)
+
+var settingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Manage site settings",
+	Long:  `Inspect and change controller settings of the site.`,
+}
+
+var settingsAlertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Manage alert notifications",
+	Long: `Manage which alert categories send email and push notifications, so the
+alert policy can be kept the same across sites.`,
+}
+
+var settingsAlertsGetCmd = &cobra.Command{
+	Use:   "get [<category>...]",
+	Short: "Show the notification settings of alert categories",
+	Long: `Show whether email and push notifications are sent for every alert category,
+or only for the given ones. Categories are event keys such as
+EVT_AP_Lost_Contact; the EVT_ prefix may be left out.`,
+	Example: `  unifi settings alerts get
+  unifi settings alerts get AP_Lost_Contact --format json`,
+	RunE: runSettingsAlertsGet,
+}
+
+var settingsAlertsSetCmd = &cobra.Command{
+	Use:   "set [<category>...]",
+	Short: "Turn email or push notifications of alert categories on or off",
+	Long: `Turn email and/or push notifications of the given alert categories, or of
+every category with --all, on or off. Only the given flags are changed.`,
+	Example: `  unifi settings alerts set AP_Lost_Contact SW_Lost_Contact --email on
+  unifi settings alerts set --all --push off`,
+	RunE: runSettingsAlertsSet,
+}
+
+func init() {
+	rootCmd.AddCommand(settingsCmd)
+	settingsCmd.AddCommand(settingsAlertsCmd)
+	settingsAlertsCmd.AddCommand(settingsAlertsGetCmd)
+	settingsAlertsCmd.AddCommand(settingsAlertsSetCmd)
+
+	settingsAlertsGetCmd.Flags().StringVarP(&alertsFormat, "format", "f", "table", "Output format (table or json)")
+
+	settingsAlertsSetCmd.Flags().String("email", "", "Send email notifications (on|off)")
+	settingsAlertsSetCmd.Flags().String("push", "", "Send push notifications (on|off)")
+	settingsAlertsSetCmd.Flags().BoolVar(&alertsAll, "all", false, "Change every alert category")
+}
+
+// alertSetFlags maps settings alerts set flags to alert_setting fields
+var alertSetFlags = []struct {
+	flag  string
+	field string
+}{
+	{"email", "email_enabled"},
+	{"push", "push_enabled"},
+}
+
+// selectAlertSettings returns the settings of the given categories, or all of
+// them when none are given
+func selectAlertSettings(settings []api.AlertSetting, categories []string) ([]api.AlertSetting, error) {
+	if len(categories) == 0 {
+		return settings, nil
+	}
+
+	selected := make([]api.AlertSetting, 0, len(categories))
+	for _, category := range categories {
+		setting, err := api.FindAlertSetting(settings, category)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, *setting)
+	}
+	return selected, nil
+}
+
+func runSettingsAlertsGet(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	settings, err := apiClient.ListAlertSettings()
+	if err != nil {
+		return fmt.Errorf("failed to list alert settings: %w", err)
+	}
+
+	settings, err = selectAlertSettings(settings, args)
+	if err != nil {
+		return err
+	}
+
+	switch alertsFormat {
+	case "json":
+		return output.PrintJSON(settings)
+	case "table":
+		output.PrintAlertSettingsTable(settings)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", alertsFormat)
+	}
+}
+
+func runSettingsAlertsSet(cmd *cobra.Command, args []string) error {
+	if !alertsAll && len(args) == 0 {
+		return fmt.Errorf("specify alert categories or --all")
+	}
+	if alertsAll && len(args) > 0 {
+		return fmt.Errorf("--all cannot be combined with explicit categories")
+	}
+
+	fields := map[string]interface{}{}
+	for _, f := range alertSetFlags {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		value, _ := cmd.Flags().GetString(f.flag)
+		enabled, err := parseOnOff(value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", f.flag, err)
+		}
+		fields[f.field] = enabled
+	}
+
+	if len(fields) == 0 {
+		return fmt.Errorf("nothing to change (use --email or --push)")
+	}
+
+	apiClient := newAPIClient()
+
+	settings, err := apiClient.ListAlertSettings()
+	if err != nil {
+		return fmt.Errorf("failed to list alert settings: %w", err)
+	}
+
+	settings, err = selectAlertSettings(settings, args)
+	if err != nil {
+		return err
+	}
+
+	var results []output.ActionResult
+	var updated []api.AlertSetting
+	for _, setting := range settings {
+		result, err := apiClient.UpdateAlertSetting(setting.ID, fields)
+		results = append(results, output.ActionResult{Target: setting.Key, Err: err})
+		if err == nil {
+			updated = append(updated, *result)
+		}
+	}
+
+	if failures := output.CountFailures(results); failures > 0 {
+		output.PrintActionResults(results)
+		return fmt.Errorf("%d of %d alert categories failed", failures, len(results))
+	}
+
+	output.PrintAlertSettingsTable(updated)
+	return nil
+}
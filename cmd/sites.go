@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/filter"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sitesFormat string
+	sitesFilter string
+	sitesSort   string
+)
+
+var sitesCmd = &cobra.Command{
+	Use:   "sites",
+	Short: "Manage controller sites",
+	Long:  `List the sites of the controller and choose the default one.`,
+}
+
+var sitesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the sites of the controller",
+	Long: `List every site the API key has access to, with the role on the site, its
+health status and client and device counts. The site name is the ID to pass
+to --site. Sites are sorted by description unless --sort is given.`,
+	Example: `  unifi sites list
+  unifi sites list --filter "status != 'ok' OR disconnected > 0"
+  unifi sites list --sort -clients
+  unifi sites list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runSitesList,
+}
+
+var sitesStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the health of every site",
+	Long: `Show the state of the WWW (internet), WAN, WLAN and LAN subsystems of every
+site, with the internet latency, WAN address or number of offline devices.`,
+	Example: `  unifi sites status
+  unifi sites status --format json`,
+	Args: cobra.NoArgs,
+	RunE: runSitesStatus,
+}
+
+var sitesUseCmd = &cobra.Command{
+	Use:   "use <site>",
+	Short: "Set the default site",
+	Long: `Check that the site exists and save it as the default site in the config
+file, or in the selected profile, so later commands don't need --site. The site
+can be given by name (its ID) or description.`,
+	Example: `  unifi sites use default
+  unifi sites use "Branch Berlin" --profile office`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSitesUse,
+}
+
+func init() {
+	rootCmd.AddCommand(sitesCmd)
+	sitesCmd.AddCommand(sitesListCmd)
+	sitesCmd.AddCommand(sitesStatusCmd)
+	sitesCmd.AddCommand(sitesUseCmd)
+
+	sitesListCmd.Flags().StringVarP(&sitesFormat, "format", "f", "table", "Output format (table or json)")
+	sitesStatusCmd.Flags().StringVarP(&sitesFormat, "format", "f", "table", "Output format (table or json)")
+	sitesListCmd.Flags().StringVar(&sitesFilter, "filter", "", "SQL WHERE clause (e.g., \"status != 'ok'\")")
+	sitesListCmd.Flags().StringVar(&sitesSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: description)")
+}
+
+func runSitesList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	sites, err := apiClient.ListSites()
+	if err != nil {
+		return fmt.Errorf("failed to list sites: %w", err)
+	}
+
+	api.SortSites(sites)
+	summaries := api.SummarizeSites(sites)
+
+	if sitesFilter != "" {
+		filterEngine, err := filter.NewFilter(sitesFilter)
+		if err != nil {
+			return fmt.Errorf("failed to create filter: %w", err)
+		}
+		defer filterEngine.Close()
+
+		summaries, err = filterEngine.ApplySites(summaries)
+		if err != nil {
+			return fmt.Errorf("failed to apply filter: %w", err)
+		}
+	}
+
+	if err := sorting.ByKeys(summaries, sitesSort); err != nil {
+		return err
+	}
+
+	switch sitesFormat {
+	case "json":
+		return output.PrintJSON(summaries)
+	case "table":
+		output.PrintSitesTable(summaries)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", sitesFormat)
+	}
+}
+
+func runSitesStatus(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	sites, err := apiClient.ListSites()
+	if err != nil {
+		return fmt.Errorf("failed to list sites: %w", err)
+	}
+	api.SortSites(sites)
+
+	switch sitesFormat {
+	case "json":
+		return output.PrintJSON(sites)
+	case "table":
+		output.PrintSitesStatusTable(sites)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", sitesFormat)
+	}
+}
+
+func runSitesUse(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	sites, err := apiClient.ListSites()
+	if err != nil {
+		return fmt.Errorf("failed to list sites: %w", err)
+	}
+
+	site, err := api.FindSite(sites, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := config.SaveValue("site", site.Name); err != nil {
+		return fmt.Errorf("failed to save site: %w", err)
+	}
+
+	target := config.GetConfigPath()
+	if profile := config.Get().Profile; profile != "" {
+		target = fmt.Sprintf("profile %s in %s", profile, target)
+	}
+	fmt.Printf("Default site set to %s (%s) in %s\n", site.Name, site.Desc, target)
+
+	if os.Getenv("UNIFI_SITE") != "" {
+		fmt.Fprintln(os.Stderr, "Warning: UNIFI_SITE is set and takes precedence over the config file")
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsCLIFormat string
+	statsCLIReset  bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics about the CLI itself",
+	// Stats are read from local files, no controller is needed
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+}
+
+var statsCLICmd = &cobra.Command{
+	Use:   "cli",
+	Short: "Show which commands are slow and how fast the controller answers",
+	Long: `Show the locally recorded usage stats: how often each command ran, how long
+it took and the latencies of its API requests, slowest commands first. Use
+them to find slow workflows and to tune --timeout.
+
+Recording is off by default; set usage_stats: true in the config file to turn
+it on. The last 1000 runs are kept in usage.json in the data directory
+(~/.local/share/unifi-cli) and are never sent anywhere.`,
+	Example: `  unifi stats cli
+  unifi stats cli --format json
+  unifi stats cli --reset`,
+	Args: cobra.NoArgs,
+	RunE: runStatsCLI,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.AddCommand(statsCLICmd)
+
+	statsCLICmd.Flags().StringVarP(&statsCLIFormat, "format", "f", "table", "Output format (table or json)")
+	statsCLICmd.Flags().BoolVar(&statsCLIReset, "reset", false, "Delete the recorded stats")
+}
+
+// usagePath is the file usage stats are kept in
+func usagePath() string {
+	return filepath.Join(config.GetDataDir(), "usage.json")
+}
+
+func runStatsCLI(cmd *cobra.Command, args []string) error {
+	if statsCLIFormat != "table" && statsCLIFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", statsCLIFormat)
+	}
+
+	if statsCLIReset {
+		if err := os.Remove(usagePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete usage stats: %w", err)
+		}
+		fmt.Println("Usage stats deleted")
+		return nil
+	}
+
+	log, err := usage.Load(usagePath())
+	if err != nil {
+		return err
+	}
+	stats := usage.Summarize(log.Runs)
+
+	if statsCLIFormat == "json" {
+		return output.PrintJSON(stats)
+	}
+
+	if len(stats) == 0 {
+		if !config.Get().UsageStats {
+			fmt.Println("No usage stats recorded (set usage_stats: true in the config file to record them)")
+		} else {
+			fmt.Println("No usage stats recorded yet")
+		}
+		return nil
+	}
+
+	output.PrintUsageTable(stats)
+	return nil
+}
+
+// usageRun is the run of the current command while usage stats are recorded
+var (
+	usageRun   *usage.Run
+	usageMutex sync.Mutex
+)
+
+// startUsage begins recording the run of command, if usage stats are on.
+// Looking at the stats is not recorded, so a reset leaves them empty.
+func startUsage(command string) {
+	if !config.Get().UsageStats || command == "stats cli" {
+		return
+	}
+	usageRun = &usage.Run{Command: command, Time: time.Now()}
+}
+
+// recordRequest adds the latency of an API request to the current run
+func recordRequest(method, path string, elapsed time.Duration) {
+	usageMutex.Lock()
+	defer usageMutex.Unlock()
+
+	if usageRun != nil {
+		usageRun.Requests = append(usageRun.Requests, elapsed)
+	}
+}
+
+// finishUsage appends the current run to the usage stats. Failing to record
+// is reported but never fails the command.
+func finishUsage(err error) {
+	usageMutex.Lock()
+	defer usageMutex.Unlock()
+
+	if usageRun == nil {
+		return
+	}
+	usageRun.Duration = time.Since(usageRun.Time)
+	usageRun.Failed = err != nil
+
+	if err := usage.Append(usagePath(), *usageRun); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	usageRun = nil
+}
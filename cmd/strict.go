@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/drift"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// strictResponseCheck fails list responses that lack a field the CLI relies
+// on, the same fields that are probed after a controller upgrade, so that
+// scripts in strict mode never read empty columns as real values
+func strictResponseCheck(site string) func(method, path string, body []byte) error {
+	return func(method, path string, body []byte) error {
+		for _, probe := range drift.Probes {
+			if path != fmt.Sprintf("/proxy/network/api/s/%s/%s", site, probe.Endpoint) {
+				continue
+			}
+
+			missing, err := drift.MissingFields(body, probe.Fields)
+			if err != nil {
+				return err
+			}
+			switch len(missing) {
+			case 0:
+			case 1:
+				return fmt.Errorf("strict mode: %s field %s is missing from the controller's response", probe.Resource, missing[0])
+			default:
+				return fmt.Errorf("strict mode: %s fields %s are missing from the controller's response", probe.Resource, strings.Join(missing, ", "))
+			}
+		}
+		return nil
+	}
+}
+
+// checkDeprecatedFlags fails when a deprecated flag is used, on the command
+// line or through the configured defaults
+func checkDeprecatedFlags(cmd *cobra.Command) error {
+	var used []string
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if flag.Deprecated != "" {
+			used = append(used, fmt.Sprintf("--%s is deprecated, %s", flag.Name, flag.Deprecated))
+		}
+	})
+	if len(used) > 0 {
+		return fmt.Errorf("strict mode: %s", strings.Join(used, "; "))
+	}
+	return nil
+}
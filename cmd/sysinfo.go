@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var sysinfoFormat string
+
+var sysinfoCmd = &cobra.Command{
+	Use:   "sysinfo",
+	Short: "Show controller system information",
+	Long: `Show the controller's system information: Network Application version and
+whether an update is available, uptime, hostname, timezone and whether
+automatic backups are enabled.
+
+The JSON output carries the controller's fields as reported, for monitoring
+systems that track the controller version.`,
+	Example: `  unifi sysinfo
+  unifi sysinfo --format json | jq -r .version`,
+	Args: cobra.NoArgs,
+	RunE: runSysinfo,
+}
+
+func init() {
+	rootCmd.AddCommand(sysinfoCmd)
+
+	sysinfoCmd.Flags().StringVarP(&sysinfoFormat, "format", "f", "table", "Output format (table or json)")
+}
+
+func runSysinfo(cmd *cobra.Command, args []string) error {
+	if sysinfoFormat != "table" && sysinfoFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", sysinfoFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	info, err := apiClient.GetSysInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get system information: %w", err)
+	}
+
+	if sysinfoFormat == "json" {
+		return output.PrintJSON(info)
+	}
+
+	version := info.Version
+	if info.Build != "" {
+		version = fmt.Sprintf("%s (%s)", info.Version, info.Build)
+	}
+
+	update := "none"
+	switch {
+	case info.UpdateDownloaded:
+		update = "available, downloaded"
+	case info.UpdateAvailable:
+		update = "available"
+	}
+
+	uptime := ""
+	if info.Uptime > 0 {
+		uptime = api.FormatUptime(info.Uptime)
+	}
+
+	autobackup := "disabled"
+	if info.Autobackup {
+		autobackup = "enabled"
+	}
+
+	output.PrintDetails([]output.Detail{
+		{Key: "Name", Value: info.Name},
+		{Key: "Hostname", Value: info.Hostname},
+		{Key: "Addresses", Value: strings.Join(info.IPAddrs, ", ")},
+		{Key: "Version", Value: version},
+		{Key: "Previous Version", Value: info.PreviousVersion},
+		{Key: "Update", Value: update},
+		{Key: "Uptime", Value: uptime},
+		{Key: "Timezone", Value: info.Timezone},
+		{Key: "Autobackup", Value: autobackup},
+	})
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/output"
+)
+
+// applyTheme selects the table theme named by --theme or the config file
+func applyTheme() error {
+	cfg := config.Get()
+
+	name := cfg.Theme
+	if name == "" {
+		name = output.DefaultTheme
+	}
+
+	theme, err := resolveTheme(name, cfg.Themes)
+	if err != nil {
+		return err
+	}
+	return output.SetTheme(theme)
+}
+
+// resolveTheme returns a built-in theme, or a theme from the config file
+// applied on top of its built-in base
+func resolveTheme(name string, custom map[string]config.TableTheme) (output.Theme, error) {
+	settings, ok := custom[name]
+	if !ok {
+		return output.LookupTheme(name)
+	}
+
+	base := settings.Base
+	if base == "" {
+		base = output.DefaultTheme
+	}
+
+	theme, err := output.LookupTheme(base)
+	if err != nil {
+		return theme, fmt.Errorf("theme %q: %w", name, err)
+	}
+
+	if settings.Symbols != "" {
+		theme.Symbols = settings.Symbols
+	}
+	if settings.Borders != nil {
+		theme.Borders = *settings.Borders
+	}
+	if settings.Compact != nil {
+		theme.Compact = *settings.Compact
+	}
+	if settings.Header != "" {
+		theme.HeaderCase = settings.Header
+		if settings.Header == "none" {
+			theme.HeaderCase = ""
+		}
+	}
+	if settings.Zebra != nil {
+		theme.Zebra = *settings.Zebra
+	}
+	return theme, nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/topology"
+	"github.com/spf13/cobra"
+)
+
+var (
+	topologyFormat      string
+	topologyDevicesOnly bool
+)
+
+var topologyCmd = &cobra.Command{
+	Use:   "topology",
+	Short: "Export the network topology as a diagram",
+	Long: `Write the gateway, switch, access point and client topology as diagram
+source: a Mermaid flowchart (renders in GitHub, GitLab and most wikis) or a
+draw.io (diagrams.net) file. Links are labelled with the switch port, "mesh"
+or the SSID.
+
+Devices are linked by the uplink each one reports, which takes one request per
+device. Clients whose switch or access point is not known are drawn
+unconnected.`,
+	Example: `  unifi topology > docs/network.mmd
+  unifi topology --format drawio > network.drawio
+  unifi topology --devices-only`,
+	Args: cobra.NoArgs,
+	RunE: runTopology,
+}
+
+func init() {
+	rootCmd.AddCommand(topologyCmd)
+
+	topologyCmd.Flags().StringVarP(&topologyFormat, "format", "f", "mermaid", "Diagram format (mermaid or drawio)")
+	topologyCmd.Flags().BoolVar(&topologyDevicesOnly, "devices-only", false, "Leave clients out of the diagram")
+}
+
+func runTopology(cmd *cobra.Command, args []string) error {
+	if topologyFormat != "mermaid" && topologyFormat != "drawio" {
+		return fmt.Errorf("invalid output format: %s (valid options: mermaid, drawio)", topologyFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	devices, err := apiClient.ListDevices()
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	devices, err = apiClient.HydrateDevices(devices, detailWorkers)
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get details of %s\n", line)
+		}
+	}
+
+	var clients []api.Client
+	if !topologyDevicesOnly {
+		if clients, err = apiClient.ListClients(); err != nil {
+			return fmt.Errorf("failed to list clients: %w", err)
+		}
+	}
+
+	roots := topology.Build(devices, clients)
+
+	if topologyFormat == "drawio" {
+		diagram, err := topology.DrawIO(roots)
+		if err != nil {
+			return err
+		}
+		fmt.Print(diagram)
+		return nil
+	}
+
+	fmt.Print(topology.Mermaid(roots))
+	return nil
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/nkn/unifi-cli/internal/trafficroute"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trafficRoutesFormat string
+	trafficRoutesSort   string
+	trafficRouteSpec    trafficroute.Spec
+	trafficRouteYes     bool
+)
+
+var trafficRoutesCmd = &cobra.Command{
+	Use:   "traffic-routes",
+	Short: "Manage traffic routes (policy-based routing)",
+	Long: `List and manage traffic routes, which send the traffic of chosen clients,
+optionally only to some domains or addresses, out of a chosen WAN or VPN client
+interface. Traffic routes need a controller with the v2 API (Network 7 or
+later).`,
+	Annotations: map[string]string{minVersionAnnotation: "7.0"},
+}
+
+var trafficRoutesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List traffic routes",
+	Example: `  unifi traffic-routes list
+  unifi traffic-routes list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runTrafficRoutesList,
+}
+
+var trafficRoutesCreateCmd = &cobra.Command{
+	Use:   "create <description>",
+	Short: "Create a traffic route",
+	Long: `Route traffic out of a WAN or VPN client network (--interface, by name or ID).
+
+--client and --network pick the clients whose traffic is routed (all clients
+without either); --domain or --ip limit the route to some destinations (all
+traffic without either). With --kill-switch the traffic is blocked rather than
+sent out of another interface while the chosen one is down.`,
+	Example: `  unifi traffic-routes create "Work laptop via VPN" --interface "Work VPN" --client aa:bb:cc:00:00:01 --kill-switch
+  unifi traffic-routes create "Streaming via WAN2" --interface "Internet 2" --network IoT --domain netflix.com --domain nflxvideo.net
+  unifi traffic-routes create "Office subnet" --interface "Work VPN" --ip 10.1.0.0/16`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrafficRoutesCreate,
+}
+
+var trafficRoutesEnableCmd = &cobra.Command{
+	Use:   "enable <id|description>",
+	Short: "Enable a traffic route",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setTrafficRouteEnabled(args[0], true)
+	},
+}
+
+var trafficRoutesDisableCmd = &cobra.Command{
+	Use:   "disable <id|description>",
+	Short: "Disable a traffic route",
+	Long: `Disable a traffic route without deleting it, e.g. to fail traffic back to the
+default WAN from a script.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setTrafficRouteEnabled(args[0], false)
+	},
+}
+
+var trafficRoutesDeleteCmd = &cobra.Command{
+	Use:   "delete <id|description>",
+	Short: "Delete a traffic route",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTrafficRoutesDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(trafficRoutesCmd)
+	trafficRoutesCmd.AddCommand(trafficRoutesListCmd)
+	trafficRoutesCmd.AddCommand(trafficRoutesCreateCmd)
+	trafficRoutesCmd.AddCommand(trafficRoutesEnableCmd)
+	trafficRoutesCmd.AddCommand(trafficRoutesDisableCmd)
+	trafficRoutesCmd.AddCommand(trafficRoutesDeleteCmd)
+
+	trafficRoutesListCmd.Flags().StringVarP(&trafficRoutesFormat, "format", "f", "table", "Output format (table or json)")
+	trafficRoutesListCmd.Flags().StringVar(&trafficRoutesSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: description)")
+
+	flags := trafficRoutesCreateCmd.Flags()
+	flags.StringVar(&trafficRouteSpec.Interface, "interface", "", "WAN or VPN client network to route through (name or ID)")
+	flags.StringSliceVar(&trafficRouteSpec.Clients, "client", nil, "Route the traffic of this client MAC (repeatable)")
+	flags.StringSliceVar(&trafficRouteSpec.Networks, "network", nil, "Route the traffic of this network (repeatable)")
+	flags.StringSliceVar(&trafficRouteSpec.Domains, "domain", nil, "Only route traffic to this domain (repeatable)")
+	flags.StringSliceVar(&trafficRouteSpec.IPs, "ip", nil, "Only route traffic to this address or subnet (repeatable)")
+	flags.BoolVar(&trafficRouteSpec.KillSwitch, "kill-switch", false, "Block the traffic while the interface is down")
+	trafficRoutesCreateCmd.MarkFlagRequired("interface")
+
+	trafficRoutesDeleteCmd.Flags().BoolVarP(&trafficRouteYes, "yes", "y", false, "Delete without asking for confirmation")
+}
+
+func runTrafficRoutesList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	routes, err := apiClient.ListTrafficRoutes()
+	if err != nil {
+		return fmt.Errorf("failed to list traffic routes: %w", err)
+	}
+
+	api.SortTrafficRoutes(routes)
+	if err := sorting.ByKeys(routes, trafficRoutesSort); err != nil {
+		return err
+	}
+
+	switch trafficRoutesFormat {
+	case "json":
+		if routes == nil {
+			routes = []api.TrafficRoute{}
+		}
+		return output.PrintJSON(routes)
+	case "table":
+		names, err := targetNames(apiClient)
+		if err != nil {
+			return err
+		}
+
+		output.PrintTrafficRoutesTable(routes, names)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", trafficRoutesFormat)
+	}
+}
+
+// targetNames maps network IDs and lower case client MACs to names, for
+// showing the targets of traffic routes and rules
+func targetNames(apiClient *api.APIClient) (map[string]string, error) {
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	users, err := apiClient.ListUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list known clients: %w", err)
+	}
+
+	names := map[string]string{}
+	for _, n := range networks {
+		names[n.ID] = n.Name
+	}
+	for _, u := range users {
+		switch {
+		case u.Name != "":
+			names[strings.ToLower(u.MAC)] = u.Name
+		case u.Hostname != "":
+			names[strings.ToLower(u.MAC)] = u.Hostname
+		}
+	}
+	return names, nil
+}
+
+func runTrafficRoutesCreate(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	spec := trafficRouteSpec
+	spec.Description = args[0]
+	route, err := spec.Route(networks)
+	if err != nil {
+		return err
+	}
+
+	created, err := apiClient.CreateTrafficRoute(route)
+	if err != nil {
+		return fmt.Errorf("failed to create traffic route: %w", err)
+	}
+
+	fmt.Printf("Created traffic route %s (%s)\n", created.Description, created.ID)
+	return nil
+}
+
+func setTrafficRouteEnabled(query string, enabled bool) error {
+	apiClient := newAPIClient()
+
+	routes, err := apiClient.ListTrafficRoutes()
+	if err != nil {
+		return fmt.Errorf("failed to list traffic routes: %w", err)
+	}
+
+	route, err := api.FindTrafficRoute(routes, query)
+	if err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	if route.Enabled == enabled {
+		fmt.Printf("Traffic route %s is already %s\n", route.Description, state)
+		return nil
+	}
+
+	if _, err := apiClient.SetTrafficRouteEnabled(route.ID, enabled); err != nil {
+		return fmt.Errorf("failed to update traffic route: %w", err)
+	}
+
+	fmt.Printf("Traffic route %s %s\n", route.Description, state)
+	return nil
+}
+
+func runTrafficRoutesDelete(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	routes, err := apiClient.ListTrafficRoutes()
+	if err != nil {
+		return fmt.Errorf("failed to list traffic routes: %w", err)
+	}
+
+	route, err := api.FindTrafficRoute(routes, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !trafficRouteYes {
+		ok, err := confirm(fmt.Sprintf("Delete traffic route %s?", route.Description))
+		if errors.Is(err, errNonInteractive) {
+			return fmt.Errorf("%w (use --yes to delete without asking)", err)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	if err := apiClient.DeleteTrafficRoute(route.ID); err != nil {
+		return fmt.Errorf("failed to delete traffic route: %w", err)
+	}
+
+	fmt.Printf("Deleted traffic route %s\n", route.Description)
+	return nil
+}
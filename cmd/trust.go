@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+)
+
+// ensureTrustedCertificate implements trust-on-first-use: when enabled and no
+// fingerprint is pinned yet, it shows the controller's certificate
+// fingerprint, asks for confirmation and records it in the config file.
+// Later runs pin that fingerprint and fail if the certificate changes. The
+// prompt goes to stderr, as it comes before the output of the command.
+func ensureTrustedCertificate() error {
+	cfg := config.Get()
+	if !cfg.TOFU || cfg.Fingerprint != "" || !strings.HasPrefix(cfg.Host, "https://") {
+		return nil
+	}
+
+	fingerprint, err := api.FetchCertificateFingerprintVia(cfg.Host, tunnelDial(cfg), cfg.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to fetch controller certificate: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "First connection to %s\n", cfg.Host)
+	fmt.Fprintf(os.Stderr, "Certificate SHA-256 fingerprint: %s\n", fingerprint)
+
+	ok, err := confirmOn(os.Stderr, "Trust this certificate and pin it for future connections?")
+	if errors.Is(err, errNonInteractive) {
+		return fmt.Errorf("%w (set fingerprint: %s in the config file after checking it)", err, fingerprint)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("controller certificate not trusted")
+	}
+
+	if err := config.SaveValue("fingerprint", fingerprint); err != nil {
+		return fmt.Errorf("failed to record fingerprint: %w", err)
+	}
+	cfg.Fingerprint = fingerprint
+
+	fmt.Fprintf(os.Stderr, "Fingerprint recorded in %s\n", config.GetConfigPath())
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/tunnel"
+)
+
+// tunnels are the SSH tunnels opened by this run, by jump host and target.
+// They are opened on first use and closed when the command returns.
+var (
+	tunnelsMu sync.Mutex
+	tunnels   = map[string]*tunnel.Tunnel{}
+)
+
+// tunnelDial returns the dial function that reaches cfg's controller through
+// its SSH jump host, or nil when cfg connects directly
+func tunnelDial(cfg *config.Config) api.DialFunc {
+	if cfg.SSHTunnel == "" {
+		return nil
+	}
+
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		t, err := openTunnel(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return t.DialContext(ctx)
+	}
+}
+
+// openTunnel returns the tunnel to cfg's controller, connecting to the jump
+// host if needed
+func openTunnel(cfg *config.Config) (*tunnel.Tunnel, error) {
+	target, err := tunnel.Target(cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+
+	key := cfg.SSHTunnel + " " + target
+	if t, ok := tunnels[key]; ok {
+		return t, nil
+	}
+
+	t, err := tunnel.Open(cfg.SSHTunnel, target, cfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ssh tunnel: %w", err)
+	}
+	tunnels[key] = t
+	return t, nil
+}
+
+// closeTunnels stops every tunnel opened by this run
+func closeTunnels() {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+
+	for key, t := range tunnels {
+		t.Close()
+		delete(tunnels, key)
+	}
+}
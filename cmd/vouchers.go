@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/spf13/cobra"
+)
+
+var (
+	vouchersFormat    string
+	vouchersSort      string
+	vouchersRevokeYes bool
+	voucherSpec       api.VoucherSpec
+	voucherDuration   string
+)
+
+var vouchersCmd = &cobra.Command{
+	Use:   "vouchers",
+	Short: "Manage hotspot vouchers",
+	Long: `Create, list and revoke the vouchers guests enter on the hotspot portal to get
+network access for a limited time.`,
+}
+
+var vouchersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List vouchers",
+	Long: `List the site's vouchers, newest first, with how often they have been used.
+CSV output is meant for printing vouchers on cards or mail merges.`,
+	Example: `  unifi vouchers list
+  unifi vouchers list --format csv > vouchers.csv`,
+	Args: cobra.NoArgs,
+	RunE: runVouchersList,
+}
+
+var vouchersCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create vouchers",
+	Long: `Create a batch of vouchers and print their codes. --duration is how long a
+guest stays online after redeeming a voucher (e.g. 90m, 24h, 7d), --quota how
+many guests may redeem each voucher (0 for any number). Bandwidth and data
+limits apply to every guest using the voucher.`,
+	Example: `  unifi vouchers create --count 10 --duration 24h --quota 1
+  unifi vouchers create --count 50 --duration 3d --note "Conference" --down 10000 --format csv`,
+	Args: cobra.NoArgs,
+	RunE: runVouchersCreate,
+}
+
+var vouchersRevokeCmd = &cobra.Command{
+	Use:   "revoke <code|id>...",
+	Short: "Revoke vouchers",
+	Long: `Revoke vouchers by code (with or without the dash) or ID, so they can no
+longer be redeemed. Guests who already redeemed a voucher stay online until
+their time is up.`,
+	Example: `  unifi vouchers revoke 12345-67890
+  unifi vouchers revoke 12345-67890 0987654321 --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runVouchersRevoke,
+}
+
+func init() {
+	rootCmd.AddCommand(vouchersCmd)
+	vouchersCmd.AddCommand(vouchersListCmd)
+	vouchersCmd.AddCommand(vouchersCreateCmd)
+	vouchersCmd.AddCommand(vouchersRevokeCmd)
+
+	vouchersListCmd.Flags().StringVarP(&vouchersFormat, "format", "f", "table", "Output format (table, csv or json)")
+	vouchersListCmd.Flags().StringVar(&vouchersSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: -create_time,code)")
+
+	flags := vouchersCreateCmd.Flags()
+	flags.IntVar(&voucherSpec.Count, "count", 1, "Number of vouchers to create")
+	flags.StringVar(&voucherDuration, "duration", "24h", "How long guests stay online (e.g. 90m, 24h, 7d)")
+	flags.IntVar(&voucherSpec.Quota, "quota", 1, "How many guests may redeem each voucher (0 for any number)")
+	flags.StringVar(&voucherSpec.Note, "note", "", "Note to keep with the vouchers")
+	flags.IntVar(&voucherSpec.Up, "up", 0, "Upload limit in Kbps")
+	flags.IntVar(&voucherSpec.Down, "down", 0, "Download limit in Kbps")
+	flags.IntVar(&voucherSpec.DataLimit, "data-limit", 0, "Data limit in MB")
+	flags.StringVarP(&vouchersFormat, "format", "f", "table", "Output format (table, csv or json)")
+
+	vouchersRevokeCmd.Flags().BoolVarP(&vouchersRevokeYes, "yes", "y", false, "Revoke without asking for confirmation")
+}
+
+// printVouchers prints vouchers in the selected format
+func printVouchers(vouchers []api.Voucher) error {
+	switch vouchersFormat {
+	case "json":
+		if vouchers == nil {
+			vouchers = []api.Voucher{}
+		}
+		return output.PrintJSON(vouchers)
+	case "csv":
+		return output.PrintVouchersCSV(vouchers)
+	default:
+		output.PrintVouchersTable(vouchers)
+		return nil
+	}
+}
+
+func checkVouchersFormat() error {
+	if vouchersFormat != "table" && vouchersFormat != "csv" && vouchersFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, csv, json)", vouchersFormat)
+	}
+	return nil
+}
+
+func runVouchersList(cmd *cobra.Command, args []string) error {
+	if err := checkVouchersFormat(); err != nil {
+		return err
+	}
+
+	apiClient := newAPIClient()
+
+	vouchers, err := apiClient.ListVouchers()
+	if err != nil {
+		return fmt.Errorf("failed to list vouchers: %w", err)
+	}
+
+	api.SortVouchers(vouchers)
+	if err := sorting.ByKeys(vouchers, vouchersSort); err != nil {
+		return err
+	}
+
+	if len(vouchers) == 0 && vouchersFormat == "table" {
+		fmt.Println("No vouchers")
+		return nil
+	}
+	return printVouchers(vouchers)
+}
+
+func runVouchersCreate(cmd *cobra.Command, args []string) error {
+	if err := checkVouchersFormat(); err != nil {
+		return err
+	}
+
+	duration, err := parseAge(voucherDuration)
+	if err != nil {
+		return fmt.Errorf("invalid --duration: %w", err)
+	}
+	if duration < time.Minute {
+		return fmt.Errorf("invalid --duration: %s is less than a minute", voucherDuration)
+	}
+	voucherSpec.Duration = duration
+
+	switch {
+	case voucherSpec.Count < 1:
+		return fmt.Errorf("--count must be at least 1")
+	case voucherSpec.Quota < 0:
+		return fmt.Errorf("--quota must be 0 (any number of guests) or more")
+	case voucherSpec.Up < 0 || voucherSpec.Down < 0 || voucherSpec.DataLimit < 0:
+		return fmt.Errorf("limits must not be negative")
+	}
+
+	apiClient := newAPIClient()
+
+	vouchers, err := apiClient.CreateVouchers(voucherSpec)
+	if err != nil {
+		return fmt.Errorf("failed to create vouchers: %w", err)
+	}
+
+	api.SortVouchers(vouchers)
+	return printVouchers(vouchers)
+}
+
+func runVouchersRevoke(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	vouchers, err := apiClient.ListVouchers()
+	if err != nil {
+		return fmt.Errorf("failed to list vouchers: %w", err)
+	}
+
+	// Check every voucher first, so nothing is revoked after a typo
+	var targets []*api.Voucher
+	for _, query := range args {
+		v, err := api.FindVoucher(vouchers, query)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, v)
+	}
+
+	if !vouchersRevokeYes {
+		ok, err := confirm(fmt.Sprintf("Revoke %d vouchers?", len(targets)))
+		if errors.Is(err, errNonInteractive) {
+			return fmt.Errorf("%w (use --yes to revoke without asking)", err)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	var results []output.ActionResult
+	for _, v := range targets {
+		results = append(results, output.ActionResult{Target: v.FormattedCode(), Err: apiClient.RevokeVoucher(v.ID)})
+	}
+
+	output.PrintActionResults(results)
+
+	if failures := output.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d of %d vouchers failed", failures, len(results))
+	}
+	return nil
+}
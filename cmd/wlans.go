@@ -0,0 +1,491 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/notify"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/passphrase"
+	"github.com/nkn/unifi-cli/internal/qr"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/nkn/unifi-cli/internal/wlan"
+	"github.com/spf13/cobra"
+)
+
+var (
+	wlansFormat string
+	wlansSort   string
+
+	rotateLength     int
+	rotateWordlist   string
+	rotateQR         bool
+	rotateWebhook    string
+	rotatePassphrase string
+
+	createSpec     wlan.Spec
+	createFromFile string
+
+	qrPNG string
+	qrYes bool
+
+	rotateYes bool
+)
+
+var wlansCmd = &cobra.Command{
+	Use:     "wlans",
+	Aliases: []string{"wlan"},
+	Short:   "Manage wireless networks",
+	Long:    `Inspect and change wireless network (SSID) configuration.`,
+}
+
+var wlansListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List wireless networks",
+	Long: `List the configured wireless networks (SSIDs) with their security, bands,
+network and VLAN, guest flag and the number of connected clients. Networks are
+sorted by SSID unless --sort is given.`,
+	Example: `  unifi wlans list
+  unifi wlans list --sort -clients
+  unifi wlan list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runWLANsList,
+}
+
+var wlansCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a wireless network",
+	Long: `Create a new wireless network (SSID) from flags or from a YAML spec file with
+the same keys (name, passphrase, security, vlan, band, guest, hidden). Flags
+override values from the file.
+
+--vlan takes a VLAN ID or a network name; the network must exist. Without it
+the SSID is bridged to the default network. The passphrase is checked before
+anything is submitted: 8 to 63 characters, or a 64 digit hex key.`,
+	Example: `  unifi wlans create --name Office --passphrase 'correct horse' --vlan 30
+  unifi wlans create --name Cafe --security open --guest --band 2g
+  unifi wlans create --from-file office-wifi.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runWLANsCreate,
+}
+
+var wlansEnableCmd = &cobra.Command{
+	Use:   "enable <ssid|id>",
+	Short: "Turn a wireless network on",
+	Long:  `Turn a disabled wireless network back on.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setWLANEnabled(args[0], true)
+	},
+}
+
+var wlansDisableCmd = &cobra.Command{
+	Use:   "disable <ssid|id>",
+	Short: "Turn a wireless network off",
+	Long: `Turn a wireless network off, e.g. for a maintenance window or to cut off the
+kids' WiFi. Connected clients are disconnected. Only the enabled setting is
+changed; the rest of the configuration is kept for enable.`,
+	Example: `  unifi wlans disable Kids
+  unifi wlans enable Kids`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setWLANEnabled(args[0], false)
+	},
+}
+
+var wlansQRCmd = &cobra.Command{
+	Use:   "qr <ssid|id>",
+	Short: "Print a QR code for joining a wireless network",
+	Long: `Print a QR code that joins the wireless network when scanned with a phone
+camera, using the WLAN's current passphrase. With --png the code is written to
+an image file instead, e.g. for a printed sign.
+
+Anyone who sees the code can read the passphrase from it, so printing or
+writing it has to be confirmed like wlans get --show-passphrase; --yes skips
+the confirmation. PNG files are only readable by their owner. The passphrase
+is only returned to API keys with admin rights.`,
+	Example: `  unifi wlans qr Guest
+  unifi wlans qr Guest --png guest-wifi.png`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWLANsQR,
+}
+
+var wlansRotatePSKCmd = &cobra.Command{
+	Use:   "rotate-psk <ssid>",
+	Short: "Set a new random passphrase on a WLAN",
+	Long: `Generate a new passphrase for a WPA personal network, apply it and print it.
+
+By default the passphrase consists of random letters and digits, leaving out
+easily confused characters. With --wordlist it is made of random words from
+the given file (one word per line, diceware lists work too) instead, which is
+easier to read out to guests. --length is the minimum length in characters.
+--passphrase sets a passphrase of your choosing instead.
+
+With --webhook the new passphrase is posted as JSON to the given URL, e.g. a
+chat integration, once it has been applied.
+
+The new passphrase is printed, so the rotation has to be confirmed like
+wlans get --show-passphrase; --yes skips the confirmation. Without --yes the
+command fails when nobody can answer, e.g. under cron, rather than silently
+skipping the rotation.`,
+	Example: `  unifi wlans rotate-psk Guest
+  unifi wlans rotate-psk Guest --wordlist /usr/share/dict/words --qr
+  unifi wlans rotate-psk Guest --passphrase 'summer-party-2026'
+  unifi wlans rotate-psk Guest --webhook https://chat.example.com/hooks/wifi --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWLANsRotatePSK,
+}
+
+func init() {
+	rootCmd.AddCommand(wlansCmd)
+	wlansCmd.AddCommand(wlansListCmd)
+	wlansCmd.AddCommand(wlansCreateCmd)
+	wlansCmd.AddCommand(wlansEnableCmd)
+	wlansCmd.AddCommand(wlansDisableCmd)
+	wlansCmd.AddCommand(wlansQRCmd)
+	wlansCmd.AddCommand(wlansRotatePSKCmd)
+
+	wlansListCmd.Flags().StringVarP(&wlansFormat, "format", "f", "table", "Output format (table or json)")
+	wlansListCmd.Flags().StringVar(&wlansSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: name)")
+
+	wlansCreateCmd.Flags().StringVar(&createSpec.Name, "name", "", "SSID of the new network")
+	wlansCreateCmd.Flags().StringVar(&createSpec.Passphrase, "passphrase", "", "WPA passphrase")
+	wlansCreateCmd.Flags().StringVar(&createSpec.Security, "security", "wpapsk", "Security (wpapsk or open)")
+	wlansCreateCmd.Flags().StringVar(&createSpec.VLAN, "vlan", "", "VLAN ID or name of the network to bridge to")
+	wlansCreateCmd.Flags().StringVar(&createSpec.Band, "band", "both", "Radio band (2g, 5g or both)")
+	wlansCreateCmd.Flags().BoolVar(&createSpec.Guest, "guest", false, "Apply the guest policies")
+	wlansCreateCmd.Flags().BoolVar(&createSpec.Hidden, "hidden", false, "Do not broadcast the SSID")
+	wlansCreateCmd.Flags().StringVar(&createFromFile, "from-file", "", "Read the network from a YAML spec file")
+
+	wlansQRCmd.Flags().StringVar(&qrPNG, "png", "", "Write the QR code to this PNG file")
+	wlansQRCmd.Flags().BoolVarP(&qrYes, "yes", "y", false, "Print or write the QR code without asking for confirmation")
+
+	wlansRotatePSKCmd.Flags().IntVar(&rotateLength, "length", 16, "Passphrase length (minimum length with --wordlist)")
+	wlansRotatePSKCmd.Flags().StringVar(&rotateWordlist, "wordlist", "", "Build the passphrase from random words in this file")
+	wlansRotatePSKCmd.Flags().BoolVar(&rotateQR, "qr", false, "Also print a QR code for joining the network")
+	wlansRotatePSKCmd.Flags().StringVar(&rotatePassphrase, "passphrase", "", "Set this passphrase instead of generating one")
+	wlansRotatePSKCmd.Flags().StringVar(&rotateWebhook, "webhook", "", "POST the new passphrase to this URL")
+	wlansRotatePSKCmd.Flags().BoolVarP(&rotateYes, "yes", "y", false, "Rotate and print the passphrase without asking for confirmation")
+}
+
+func runWLANsList(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	api.SortWLANs(wlans)
+	statuses := api.WLANStatuses(wlans, networks, clients)
+	if err := sorting.ByKeys(statuses, wlansSort); err != nil {
+		return err
+	}
+
+	switch wlansFormat {
+	case "json":
+		// Passphrases are left out; wlans get and ppsk list --show-passphrase
+		// are the ways to get them
+		for i := range statuses {
+			statuses[i].Passphrase = ""
+			statuses[i].PrivatePSKs = nil
+		}
+		return output.PrintJSON(statuses)
+	case "table":
+		output.PrintWLANsTable(statuses)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", wlansFormat)
+	}
+}
+
+func runWLANsCreate(cmd *cobra.Command, args []string) error {
+	spec := createSpec
+	if createFromFile != "" {
+		var err error
+		if spec, err = loadWLANSpec(cmd, createFromFile); err != nil {
+			return err
+		}
+	}
+
+	apiClient := newAPIClient()
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	fields, err := spec.Fields(networks, wlans)
+	if err != nil {
+		return err
+	}
+
+	created, err := apiClient.CreateWLAN(fields)
+	if err != nil {
+		return fmt.Errorf("failed to create WLAN: %w", err)
+	}
+
+	fmt.Printf("Created WLAN %s (%s)\n", created.Name, created.ID)
+	return nil
+}
+
+// loadWLANSpec reads a spec file and applies the flags given on the command
+// line on top of it
+func loadWLANSpec(cmd *cobra.Command, path string) (wlan.Spec, error) {
+	spec, err := wlan.LoadSpec(path)
+	if err != nil {
+		return spec, err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		spec.Name = createSpec.Name
+	}
+	if flags.Changed("passphrase") {
+		spec.Passphrase = createSpec.Passphrase
+	}
+	if flags.Changed("security") {
+		spec.Security = createSpec.Security
+	}
+	if flags.Changed("vlan") {
+		spec.VLAN = createSpec.VLAN
+	}
+	if flags.Changed("band") {
+		spec.Band = createSpec.Band
+	}
+	if flags.Changed("guest") {
+		spec.Guest = createSpec.Guest
+	}
+	if flags.Changed("hidden") {
+		spec.Hidden = createSpec.Hidden
+	}
+	return spec, nil
+}
+
+// setWLANEnabled turns the WLAN with the given SSID or ID on or off
+func setWLANEnabled(query string, enabled bool) error {
+	apiClient := newAPIClient()
+
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	wlan, err := api.FindWLAN(wlans, query)
+	if err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	if wlan.Enabled == enabled {
+		fmt.Printf("WLAN %s is already %s\n", wlan.Name, state)
+		return nil
+	}
+
+	if _, err := apiClient.UpdateWLAN(wlan.ID, map[string]interface{}{"enabled": enabled}); err != nil {
+		return fmt.Errorf("failed to update WLAN: %w", err)
+	}
+
+	fmt.Printf("WLAN %s %s\n", wlan.Name, state)
+	return nil
+}
+
+func runWLANsQR(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	wlan, err := api.FindWLAN(wlans, args[0])
+	if err != nil {
+		return err
+	}
+
+	if wlan.Security == "wpaeap" {
+		return fmt.Errorf("WLAN %s uses enterprise security; there is no shared passphrase to encode", wlan.Name)
+	}
+	if wlan.Security != "open" && wlan.Passphrase == "" {
+		return fmt.Errorf("controller did not return the passphrase of %s; the API key needs admin rights", wlan.Name)
+	}
+
+	if wlan.Security != "open" {
+		ok, err := confirmShowPassphrase(wlan.Name, qrYes)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	if qrPNG == "" {
+		return printWiFiQR(wlan, wlan.Passphrase)
+	}
+
+	code, err := wifiQRCode(wlan, wlan.Passphrase)
+	if err != nil {
+		return err
+	}
+
+	// The image holds the passphrase, so only the owner may read it
+	f, err := os.OpenFile(qrPNG, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", qrPNG, err)
+	}
+	// An existing file keeps its mode when it is overwritten
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to create %s: %w", qrPNG, err)
+	}
+	if err := code.WritePNG(f, qrPNGScale); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", qrPNG, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", qrPNG, err)
+	}
+
+	fmt.Printf("Wrote QR code for %s to %s\n", wlan.Name, qrPNG)
+	return nil
+}
+
+func runWLANsRotatePSK(cmd *cobra.Command, args []string) error {
+	newPassphrase, err := generatePassphrase()
+	if err != nil {
+		return err
+	}
+
+	apiClient := newAPIClient()
+
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	wlan, err := api.FindWLAN(wlans, args[0])
+	if err != nil {
+		return err
+	}
+
+	if wlan.Security != "wpapsk" {
+		return fmt.Errorf("WLAN %s uses %s security; only WPA personal (wpapsk) networks have a passphrase", wlan.Name, wlan.Security)
+	}
+
+	// Ask before changing anything: a passphrase that was applied but not
+	// shown would lock everyone out
+	ok, err := confirmShowPassphrase(wlan.Name, rotateYes)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Aborted")
+		return nil
+	}
+
+	if _, err := apiClient.UpdateWLAN(wlan.ID, map[string]interface{}{"x_passphrase": newPassphrase}); err != nil {
+		return fmt.Errorf("failed to update passphrase: %w", err)
+	}
+
+	fmt.Printf("New passphrase for %s: %s\n", wlan.Name, newPassphrase)
+
+	if rotateQR {
+		if err := printWiFiQR(wlan, newPassphrase); err != nil {
+			return err
+		}
+	}
+
+	if rotateWebhook != "" {
+		event := notify.Event{
+			Event: "wlan.psk_rotated",
+			Time:  time.Now().UTC(),
+			Data:  map[string]string{"ssid": wlan.Name, "passphrase": newPassphrase},
+		}
+		if err := notify.Webhook(rotateWebhook, event, config.Get().Timeout); err != nil {
+			return fmt.Errorf("passphrase was changed but the webhook failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func generatePassphrase() (string, error) {
+	if rotatePassphrase != "" {
+		if rotateWordlist != "" {
+			return "", fmt.Errorf("--passphrase and --wordlist are mutually exclusive")
+		}
+		if err := wlan.ValidatePassphrase(rotatePassphrase); err != nil {
+			return "", err
+		}
+		return rotatePassphrase, nil
+	}
+
+	if rotateWordlist == "" {
+		return passphrase.Random(rotateLength)
+	}
+
+	words, err := passphrase.LoadWordlist(rotateWordlist)
+	if err != nil {
+		return "", err
+	}
+	return passphrase.Words(words, rotateLength)
+}
+
+// qrPNGScale is the size of a QR code module in PNG files, in pixels
+const qrPNGScale = 8
+
+// wifiQRCode encodes a QR code that joins wlan when scanned with a phone
+func wifiQRCode(wlan *api.WLAN, key string) (*qr.Code, error) {
+	code, err := qr.Encode(qr.WiFi(wlan.Name, wifiAuth(wlan), key, wlan.HideSSID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return code, nil
+}
+
+// printWiFiQR prints a QR code that joins wlan when scanned with a phone
+func printWiFiQR(wlan *api.WLAN, key string) error {
+	code, err := wifiQRCode(wlan, key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(code.Terminal())
+	return nil
+}
+
+// wifiAuth maps a WLAN's security setting to the WiFi QR authentication type
+func wifiAuth(wlan *api.WLAN) string {
+	switch wlan.Security {
+	case "open":
+		return qr.AuthNoPass
+	case "wep":
+		return qr.AuthWEP
+	default:
+		return qr.AuthWPA
+	}
+}
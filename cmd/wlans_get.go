@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/config"
+	"github.com/nkn/unifi-cli/internal/consent"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// showPassphraseAction keys the remembered confirmation for --show-passphrase
+const showPassphraseAction = "show-passphrase"
+
+var (
+	getFormat         string
+	getShowPassphrase bool
+	getYes            bool
+)
+
+var wlansGetCmd = &cobra.Command{
+	Use:   "get <ssid|id>",
+	Short: "Show a wireless network",
+	Long: `Show the settings of a wireless network: security, bands, network, schedule
+and connected clients.
+
+The passphrase is hidden unless --show-passphrase is given, e.g. to read it
+out when onboarding a device. It then has to be confirmed; the confirmation is
+remembered for 15 minutes for the controller and site in the OS keyring
+(Secret Service, macOS Keychain or Windows Credential Manager). Where no
+keyring is reachable, e.g. on a console or in a cron job, it is remembered in
+the data directory instead. --yes skips the confirmation for scripts. The
+passphrase is only returned to API keys with admin rights.`,
+	Example: `  unifi wlans get Office
+  unifi wlans get Office --show-passphrase
+  unifi wlans get Office --show-passphrase --yes --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWLANsGet,
+}
+
+func init() {
+	wlansCmd.AddCommand(wlansGetCmd)
+
+	wlansGetCmd.Flags().StringVarP(&getFormat, "format", "f", "table", "Output format (table or json)")
+	wlansGetCmd.Flags().BoolVar(&getShowPassphrase, "show-passphrase", false, "Include the passphrase")
+	wlansGetCmd.Flags().BoolVarP(&getYes, "yes", "y", false, "Show the passphrase without asking for confirmation")
+}
+
+// consentPath is where confirmations for sensitive actions are remembered
+// when there is no OS keyring
+func consentPath() string {
+	return filepath.Join(config.GetDataDir(), "consent.json")
+}
+
+func runWLANsGet(cmd *cobra.Command, args []string) error {
+	if getFormat != "table" && getFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", getFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	wlan, err := api.FindWLAN(wlans, args[0])
+	if err != nil {
+		return err
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	clients, err := apiClient.ListClients()
+	if err != nil {
+		return fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	status := api.WLANStatuses([]api.WLAN{*wlan}, networks, clients)[0]
+
+	show := false
+	if getShowPassphrase && status.Passphrase != "" {
+		if show, err = confirmShowPassphrase(status.Name, getYes); err != nil {
+			return err
+		}
+		if !show {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+	if getShowPassphrase && status.Passphrase == "" && status.Security == "wpapsk" {
+		return fmt.Errorf("controller did not return the passphrase of %s; the API key needs admin rights", status.Name)
+	}
+
+	if getFormat == "json" {
+		if !show {
+			status.Passphrase = ""
+			status.PrivatePSKs = nil
+		}
+		return output.PrintJSON(status)
+	}
+
+	output.PrintWLANDetails(status, show)
+	return nil
+}
+
+// confirmShowPassphrase asks before a passphrase is shown, unless yes is set
+// (--yes) or a confirmation for this controller and site is remembered
+func confirmShowPassphrase(ssid string, yes bool) (bool, error) {
+	if yes {
+		return true, nil
+	}
+
+	cfg := config.Get()
+	key := consent.Key(showPassphraseAction, cfg.Host, cfg.Site)
+
+	cache, _, err := consent.Open(consentPath())
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	if cache.Valid(key, now) {
+		return true, nil
+	}
+
+	ok, err := confirm(fmt.Sprintf("Show the passphrase of %s?", ssid))
+	if errors.Is(err, errNonInteractive) {
+		return false, fmt.Errorf("%w (use --yes to show it without asking)", err)
+	}
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if err := cache.Grant(key, now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/passphrase"
+	"github.com/nkn/unifi-cli/internal/sorting"
+	"github.com/nkn/unifi-cli/internal/wlan"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ppskFormat         string
+	ppskSort           string
+	ppskShowPassphrase bool
+	ppskYes            bool
+	ppskPassphrase     string
+	ppskVLAN           string
+)
+
+var wlansPPSKCmd = &cobra.Command{
+	Use:   "ppsk",
+	Short: "Manage private pre-shared keys",
+	Long: `Manage the private pre-shared keys (PPSK) of a WPA2 personal network: extra
+passphrases for the same SSID, each putting the clients that use it on its own
+network/VLAN.`,
+}
+
+var wlansPPSKListCmd = &cobra.Command{
+	Use:   "list <ssid|id>",
+	Short: "List the private passphrases of a WLAN",
+	Long: `List the private passphrases of a WLAN with the network each one puts its
+clients on.
+
+The passphrases are hidden unless --show-passphrase is given, which has to be
+confirmed like wlans get --show-passphrase; --yes skips the confirmation.`,
+	Example: `  unifi wlans ppsk list Home
+  unifi wlans ppsk list Home --show-passphrase
+  unifi wlans ppsk list Home --show-passphrase --yes --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWLANsPPSKList,
+}
+
+var wlansPPSKAddCmd = &cobra.Command{
+	Use:   "add <ssid|id>",
+	Short: "Add a private passphrase to a WLAN",
+	Long: `Add a private passphrase to a WLAN. Clients joining with it are put on the
+network given with --vlan (a VLAN ID or network name), or on the WLAN's own
+network. Without --passphrase a random one is generated and printed.`,
+	Example: `  unifi wlans ppsk add Home --vlan 20
+  unifi wlans ppsk add Home --vlan IoT --passphrase 'things-only-2026'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWLANsPPSKAdd,
+}
+
+var wlansPPSKRemoveCmd = &cobra.Command{
+	Use:     "remove <ssid|id> <passphrase>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a private passphrase from a WLAN",
+	Long: `Remove a private passphrase from a WLAN. Clients using it can no longer join.
+PPSK is turned off when the last private passphrase is removed.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runWLANsPPSKRemove,
+}
+
+func init() {
+	wlansCmd.AddCommand(wlansPPSKCmd)
+	wlansPPSKCmd.AddCommand(wlansPPSKListCmd)
+	wlansPPSKCmd.AddCommand(wlansPPSKAddCmd)
+	wlansPPSKCmd.AddCommand(wlansPPSKRemoveCmd)
+
+	wlansPPSKListCmd.Flags().StringVarP(&ppskFormat, "format", "f", "table", "Output format (table or json)")
+	wlansPPSKListCmd.Flags().StringVar(&ppskSort, "sort", "", "Comma separated sort keys, '-' prefix for descending (default: as configured)")
+	wlansPPSKListCmd.Flags().BoolVar(&ppskShowPassphrase, "show-passphrase", false, "Include the passphrases")
+	wlansPPSKListCmd.Flags().BoolVarP(&ppskYes, "yes", "y", false, "Show the passphrases without asking for confirmation")
+	wlansPPSKAddCmd.Flags().StringVar(&ppskPassphrase, "passphrase", "", "Passphrase to add (default: a random one)")
+	wlansPPSKAddCmd.Flags().StringVar(&ppskVLAN, "vlan", "", "VLAN ID or name of the network for clients using the passphrase")
+}
+
+// findPPSKWLAN looks up a WLAN and checks that it supports private keys
+func findPPSKWLAN(apiClient *api.APIClient, query string) (*api.WLAN, error) {
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WLANs: %w", err)
+	}
+
+	w, err := api.FindWLAN(wlans, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wlan.CheckPrivatePSK(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func runWLANsPPSKList(cmd *cobra.Command, args []string) error {
+	if ppskFormat != "table" && ppskFormat != "json" {
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", ppskFormat)
+	}
+
+	apiClient := newAPIClient()
+
+	w, err := findPPSKWLAN(apiClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	keys := append([]api.PrivatePSK{}, w.PrivatePSKs...)
+	if err := sorting.ByKeys(keys, ppskSort); err != nil {
+		return err
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	show := false
+	if ppskShowPassphrase && len(keys) > 0 {
+		if show, err = confirmShowPassphrase(w.Name, ppskYes); err != nil {
+			return err
+		}
+		if !show {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	if ppskFormat == "json" {
+		if !show {
+			for i := range keys {
+				keys[i].Password = ""
+			}
+		}
+		return output.PrintJSON(keys)
+	}
+
+	if len(keys) == 0 {
+		fmt.Printf("WLAN %s has no private passphrases\n", w.Name)
+		return nil
+	}
+	output.PrintPrivatePSKsTable(keys, networks, show)
+	return nil
+}
+
+func runWLANsPPSKAdd(cmd *cobra.Command, args []string) error {
+	key := ppskPassphrase
+	if key == "" {
+		var err error
+		if key, err = passphrase.Random(16); err != nil {
+			return err
+		}
+	}
+
+	apiClient := newAPIClient()
+
+	w, err := findPPSKWLAN(apiClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	networkID := w.NetworkConfID
+	if ppskVLAN != "" {
+		networks, err := apiClient.ListNetworks()
+		if err != nil {
+			return fmt.Errorf("failed to list networks: %w", err)
+		}
+		network, err := wlan.FindNetwork(networks, ppskVLAN)
+		if err != nil {
+			return err
+		}
+		networkID = network.ID
+	}
+
+	keys, err := wlan.AddPrivatePSK(w, api.PrivatePSK{Password: key, NetworkConfID: networkID})
+	if err != nil {
+		return err
+	}
+
+	if err := setPrivatePSKs(apiClient, w, keys); err != nil {
+		return err
+	}
+
+	fmt.Printf("Added private passphrase to %s: %s\n", w.Name, key)
+	return nil
+}
+
+func runWLANsPPSKRemove(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	w, err := findPPSKWLAN(apiClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	keys, err := wlan.RemovePrivatePSK(w, args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := setPrivatePSKs(apiClient, w, keys); err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed private passphrase from %s\n", w.Name)
+	return nil
+}
+
+// setPrivatePSKs replaces the WLAN's private keys, turning PPSK on while
+// there are any
+func setPrivatePSKs(apiClient *api.APIClient, w *api.WLAN, keys []api.PrivatePSK) error {
+	fields := map[string]interface{}{
+		"private_preshared_keys":         keys,
+		"private_preshared_keys_enabled": len(keys) > 0,
+	}
+
+	if _, err := apiClient.UpdateWLAN(w.ID, fields); err != nil {
+		return fmt.Errorf("failed to update WLAN: %w", err)
+	}
+	return nil
+}
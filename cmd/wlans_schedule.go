@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/output"
+	"github.com/nkn/unifi-cli/internal/wlan"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleFormat string
+	scheduleOn     string
+	scheduleOff    string
+	scheduleDays   string
+)
+
+var wlansScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage when wireless networks are on",
+	Long: `View and change the schedule of a wireless network. A scheduled WLAN is only
+on during the periods of its schedule.`,
+}
+
+var wlansScheduleShowCmd = &cobra.Command{
+	Use:   "show <ssid|id>",
+	Short: "Show the schedule of a WLAN",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWLANsScheduleShow,
+}
+
+var wlansScheduleSetCmd = &cobra.Command{
+	Use:   "set <ssid|id>",
+	Short: "Set the schedule of a WLAN",
+	Long: `Replace the schedule of a WLAN. Give either the time it should be on with --on,
+or the time it should be off with --off, on the days given with --days
+(default: daily). Ranges past midnight, such as 23:00-06:00, end on the next
+day.
+
+--days takes day names or ranges (mon-fri, sat,sun), weekdays, weekends or
+daily.`,
+	Example: `  unifi wlans schedule set Kids --off 21:00-07:00 --days sun-thu
+  unifi wlans schedule set Office --on 07:00-19:00 --days mon-fri`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWLANsScheduleSet,
+}
+
+var wlansScheduleClearCmd = &cobra.Command{
+	Use:   "clear <ssid|id>",
+	Short: "Remove the schedule of a WLAN",
+	Long:  `Turn the schedule of a WLAN off, so the WLAN is on all the time again.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWLANsScheduleClear,
+}
+
+func init() {
+	wlansCmd.AddCommand(wlansScheduleCmd)
+	wlansScheduleCmd.AddCommand(wlansScheduleShowCmd)
+	wlansScheduleCmd.AddCommand(wlansScheduleSetCmd)
+	wlansScheduleCmd.AddCommand(wlansScheduleClearCmd)
+
+	wlansScheduleShowCmd.Flags().StringVarP(&scheduleFormat, "format", "f", "table", "Output format (table or json)")
+	wlansScheduleSetCmd.Flags().StringVar(&scheduleOn, "on", "", "Time range the WLAN is on (HH:MM-HH:MM)")
+	wlansScheduleSetCmd.Flags().StringVar(&scheduleOff, "off", "", "Time range the WLAN is off (HH:MM-HH:MM)")
+	wlansScheduleSetCmd.Flags().StringVar(&scheduleDays, "days", "daily", "Days the range applies to")
+	wlansScheduleSetCmd.MarkFlagsMutuallyExclusive("on", "off")
+	wlansScheduleSetCmd.MarkFlagsOneRequired("on", "off")
+}
+
+// findWLAN looks up a single WLAN by SSID or ID
+func findWLAN(apiClient *api.APIClient, query string) (*api.WLAN, error) {
+	wlans, err := apiClient.ListWLANs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WLANs: %w", err)
+	}
+	return api.FindWLAN(wlans, query)
+}
+
+func runWLANsScheduleShow(cmd *cobra.Command, args []string) error {
+	w, err := findWLAN(newAPIClient(), args[0])
+	if err != nil {
+		return err
+	}
+
+	switch scheduleFormat {
+	case "json":
+		blocks := w.Schedule
+		if !w.ScheduleEnabled || blocks == nil {
+			blocks = []api.ScheduleBlock{}
+		}
+		return output.PrintJSON(blocks)
+	case "table":
+		if !w.ScheduleEnabled {
+			fmt.Printf("WLAN %s has no schedule and is on all the time\n", w.Name)
+			return nil
+		}
+		output.PrintScheduleTable(w.Schedule)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: table, json)", scheduleFormat)
+	}
+}
+
+func runWLANsScheduleSet(cmd *cobra.Command, args []string) error {
+	days, err := wlan.ParseDays(scheduleDays)
+	if err != nil {
+		return err
+	}
+
+	var blocks []api.ScheduleBlock
+	if scheduleOn != "" {
+		start, end, err := wlan.ParseTimeRange(scheduleOn)
+		if err != nil {
+			return err
+		}
+		blocks = wlan.OnSchedule(start, end, days)
+	} else {
+		start, end, err := wlan.ParseTimeRange(scheduleOff)
+		if err != nil {
+			return err
+		}
+		blocks = wlan.OffSchedule(start, end, days)
+	}
+
+	apiClient := newAPIClient()
+
+	w, err := findWLAN(apiClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"schedule_enabled":       true,
+		"schedule_with_duration": blocks,
+	}
+	if _, err := apiClient.UpdateWLAN(w.ID, fields); err != nil {
+		return fmt.Errorf("failed to update WLAN: %w", err)
+	}
+
+	fmt.Printf("Schedule of %s set; it is on:\n", w.Name)
+	output.PrintScheduleTable(blocks)
+	return nil
+}
+
+func runWLANsScheduleClear(cmd *cobra.Command, args []string) error {
+	apiClient := newAPIClient()
+
+	w, err := findWLAN(apiClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !w.ScheduleEnabled {
+		fmt.Printf("WLAN %s has no schedule\n", w.Name)
+		return nil
+	}
+
+	if _, err := apiClient.UpdateWLAN(w.ID, map[string]interface{}{"schedule_enabled": false}); err != nil {
+		return fmt.Errorf("failed to update WLAN: %w", err)
+	}
+
+	fmt.Printf("Schedule of %s removed\n", w.Name)
+	return nil
+}
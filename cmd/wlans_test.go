@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+)
+
+// closedStdin replaces stdin with one that is at EOF, as under cron
+func closedStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	previous := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = previous
+		r.Close()
+	})
+}
+
+func TestRunWLANsRotatePSK_EOFOnStdin(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	closedStdin(t)
+
+	var updated bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			updated = true
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"w1","name":"Guest","security":"wpapsk","x_passphrase":"old-passphrase"}]}`))
+	}))
+	defer server.Close()
+
+	viper.Set("host", server.URL)
+	viper.Set("api_key", "test-key")
+	viper.Set("site", "default")
+
+	err := runWLANsRotatePSK(wlansRotatePSKCmd, []string{"Guest"})
+	if !errors.Is(err, errNonInteractive) {
+		t.Errorf("Expected a non-interactive error, got %v", err)
+	}
+	if updated {
+		t.Error("Expected the passphrase to be left unchanged")
+	}
+}
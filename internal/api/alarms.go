@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Alarm severities, from most to least severe
+const (
+	AlarmSeverityHigh   = "high"
+	AlarmSeverityMedium = "medium"
+	AlarmSeverityLow    = "low"
+)
+
+// Alarm is an entry of the controller's alarm list, from stat/alarm: an
+// event that needs attention until it is archived
+type Alarm struct {
+	ID        string `json:"_id"`
+	Key       string `json:"key"`
+	Msg       string `json:"msg"`
+	Subsystem string `json:"subsystem"`
+	// Time is in milliseconds since the epoch
+	Time     int64  `json:"time"`
+	Archived bool   `json:"archived"`
+	AP       string `json:"ap,omitempty"`
+	APName   string `json:"ap_name,omitempty"`
+	GW       string `json:"gw,omitempty"`
+	GWName   string `json:"gw_name,omitempty"`
+	SW       string `json:"sw,omitempty"`
+	SWName   string `json:"sw_name,omitempty"`
+	// InnerAlertSeverity is set on threat management (IPS) alarms, 1 being
+	// the most severe
+	InnerAlertSeverity int `json:"inner_alert_severity,omitempty"`
+}
+
+type AlarmsResponse struct {
+	Meta Meta    `json:"meta"`
+	Data []Alarm `json:"data"`
+}
+
+// SortAlarms orders alarms newest first, then by ID
+func SortAlarms(alarms []Alarm) {
+	sort.SliceStable(alarms, func(i, j int) bool {
+		if alarms[i].Time != alarms[j].Time {
+			return alarms[i].Time > alarms[j].Time
+		}
+		return alarms[i].ID < alarms[j].ID
+	})
+}
+
+// ListAlarms returns the alarms; archived ones only with all
+func (c *APIClient) ListAlarms(all bool) ([]Alarm, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/alarm", c.Site)
+	if !all {
+		path += "?archived=false"
+	}
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AlarmsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	// Not every controller version honors the archived parameter
+	alarms := response.Data
+	if !all {
+		alarms = UnarchivedAlarms(alarms)
+	}
+
+	return alarms, nil
+}
+
+// ArchiveAlarm archives the alarm with the given ID
+func (c *APIClient) ArchiveAlarm(id string) error {
+	return c.sendCommand("evtmgr", map[string]string{"cmd": "archive-alarm", "_id": id})
+}
+
+// ArchiveAllAlarms archives every alarm of the site
+func (c *APIClient) ArchiveAllAlarms() error {
+	return c.sendCommand("evtmgr", map[string]string{"cmd": "archive-all-alarms"})
+}
+
+// UnarchivedAlarms returns the alarms that have not been archived
+func UnarchivedAlarms(alarms []Alarm) []Alarm {
+	var active []Alarm
+	for _, a := range alarms {
+		if !a.Archived {
+			active = append(active, a)
+		}
+	}
+	return active
+}
+
+// FindAlarm looks an alarm up by ID
+func FindAlarm(alarms []Alarm, id string) (*Alarm, error) {
+	for i := range alarms {
+		if strings.EqualFold(alarms[i].ID, id) {
+			return &alarms[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no alarm with ID %q", id)
+}
+
+// GetTime returns when the alarm was raised
+func (a *Alarm) GetTime() time.Time {
+	return time.UnixMilli(a.Time)
+}
+
+// Device returns the name (or MAC) of the device the alarm is about, if any
+func (a *Alarm) Device() string {
+	for _, d := range [][2]string{{a.APName, a.AP}, {a.GWName, a.GW}, {a.SWName, a.SW}} {
+		if d[0] != "" {
+			return d[0]
+		}
+		if d[1] != "" {
+			return d[1]
+		}
+	}
+	return ""
+}
+
+// Severity rates the alarm: threat management alarms by their own severity,
+// devices losing contact or being isolated and WAN failures as high, and
+// everything else as medium
+func (a *Alarm) Severity() string {
+	switch {
+	case a.InnerAlertSeverity == 1:
+		return AlarmSeverityHigh
+	case a.InnerAlertSeverity == 2:
+		return AlarmSeverityMedium
+	case a.InnerAlertSeverity > 2:
+		return AlarmSeverityLow
+	}
+
+	key := strings.ToLower(a.Key)
+	for _, marker := range []string{"lost_contact", "isolated", "wan_failover", "wantransition", "offline"} {
+		if strings.Contains(key, marker) {
+			return AlarmSeverityHigh
+		}
+	}
+	return AlarmSeverityMedium
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const alarmsBody = `{"meta":{"rc":"ok"},"data":[
+	{"_id":"a1","key":"EVT_AP_Lost_Contact","msg":"AP lost contact","time":1768211000000,"ap":"f0:9f:c2:00:00:01","ap_name":"Office AP"},
+	{"_id":"a2","key":"EVT_IPS_IpsAlert","msg":"IPS alert","time":1768212000000,"inner_alert_severity":3},
+	{"_id":"a3","key":"EVT_SW_Lost_Contact","msg":"old","time":1768210000000,"archived":true}]}`
+
+func TestAPIClient_ListAlarms(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/proxy/network/api/s/default/stat/alarm" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(alarmsBody))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+
+	alarms, err := client.ListAlarms(false)
+	if err != nil {
+		t.Fatalf("ListAlarms() returned error: %v", err)
+	}
+	if query != "archived=false" {
+		t.Errorf("Expected archived=false, got %q", query)
+	}
+	if len(alarms) != 2 || alarms[0].ID != "a1" || alarms[1].ID != "a2" {
+		t.Errorf("Expected the unarchived alarms, got %+v", alarms)
+	}
+
+	alarms, err = client.ListAlarms(true)
+	if err != nil {
+		t.Fatalf("ListAlarms() returned error: %v", err)
+	}
+	if query != "" || len(alarms) != 3 {
+		t.Errorf("Expected every alarm without a query, got %d with %q", len(alarms), query)
+	}
+}
+
+func TestAPIClient_ArchiveAlarms(t *testing.T) {
+	var payloads []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/proxy/network/api/s/default/cmd/evtmgr" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+
+	if err := client.ArchiveAlarm("a1"); err != nil {
+		t.Fatalf("ArchiveAlarm() returned error: %v", err)
+	}
+	if err := client.ArchiveAllAlarms(); err != nil {
+		t.Fatalf("ArchiveAllAlarms() returned error: %v", err)
+	}
+
+	if payloads[0]["cmd"] != "archive-alarm" || payloads[0]["_id"] != "a1" || payloads[1]["cmd"] != "archive-all-alarms" {
+		t.Errorf("Unexpected payloads %v", payloads)
+	}
+}
+
+func TestAlarm_Severity(t *testing.T) {
+	tests := []struct {
+		alarm Alarm
+		want  string
+	}{
+		{Alarm{Key: "EVT_AP_Lost_Contact"}, AlarmSeverityHigh},
+		{Alarm{Key: "EVT_GW_WANTransition"}, AlarmSeverityHigh},
+		{Alarm{Key: "EVT_IPS_IpsAlert", InnerAlertSeverity: 1}, AlarmSeverityHigh},
+		{Alarm{Key: "EVT_IPS_IpsAlert", InnerAlertSeverity: 3}, AlarmSeverityLow},
+		{Alarm{Key: "EVT_AP_RadarDetected"}, AlarmSeverityMedium},
+	}
+	for _, tt := range tests {
+		if got := tt.alarm.Severity(); got != tt.want {
+			t.Errorf("%s (%d): expected %s, got %s", tt.alarm.Key, tt.alarm.InnerAlertSeverity, tt.want, got)
+		}
+	}
+}
+
+func TestAlarm_Device(t *testing.T) {
+	if d := (&Alarm{AP: "f0:9f:c2:00:00:01", APName: "Office AP"}).Device(); d != "Office AP" {
+		t.Errorf("Expected the AP name, got %q", d)
+	}
+	if d := (&Alarm{SW: "f0:9f:c2:00:00:02"}).Device(); d != "f0:9f:c2:00:00:02" {
+		t.Errorf("Expected the switch MAC, got %q", d)
+	}
+	if d := (&Alarm{}).Device(); d != "" {
+		t.Errorf("Expected no device, got %q", d)
+	}
+}
+
+func TestSortAlarms(t *testing.T) {
+	alarms := []Alarm{
+		{ID: "a2", Time: 1768211000000},
+		{ID: "a3", Time: 1768212000000},
+		{ID: "a1", Time: 1768211000000},
+	}
+
+	SortAlarms(alarms)
+
+	expected := []string{"a3", "a1", "a2"}
+	for i, id := range expected {
+		if alarms[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, alarms[i].ID)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AlertSetting controls whether the controller sends email and push
+// notifications for one alert category (an event key such as
+// EVT_AP_Lost_Contact), from rest/alert_setting
+type AlertSetting struct {
+	ID           string `json:"_id"`
+	Key          string `json:"key"`
+	EmailEnabled bool   `json:"email_enabled"`
+	PushEnabled  bool   `json:"push_enabled"`
+}
+
+type AlertSettingsResponse struct {
+	Meta Meta           `json:"meta"`
+	Data []AlertSetting `json:"data"`
+}
+
+func (c *APIClient) parseAlertSettings(body []byte) ([]AlertSetting, error) {
+	var response AlertSettingsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ListAlertSettings returns the notification settings of every alert
+// category, ordered by key
+func (c *APIClient) ListAlertSettings() ([]AlertSetting, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/alert_setting", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := c.parseAlertSettings(body)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+// UpdateAlertSetting changes only the given fields of an alert category and
+// returns the updated setting
+func (c *APIClient) UpdateAlertSetting(id string, fields map[string]interface{}) (*AlertSetting, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/alert_setting/%s", c.Site, id)
+
+	body, err := c.doRequestWithBody("PUT", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := c.parseAlertSettings(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("controller returned no record for alert setting %s", id)
+	}
+
+	return &settings[0], nil
+}
+
+// FindAlertSetting looks an alert category up by case-insensitive key; the
+// EVT_ prefix may be left out
+func FindAlertSetting(settings []AlertSetting, key string) (*AlertSetting, error) {
+	for i := range settings {
+		if strings.EqualFold(settings[i].Key, key) || strings.EqualFold(settings[i].Key, "EVT_"+key) {
+			return &settings[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no alert category %q", key)
+}
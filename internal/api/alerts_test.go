@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListAlertSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/alert_setting"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"a2","key":"EVT_SW_Lost_Contact","email_enabled":true},
+			{"_id":"a1","key":"EVT_AP_Lost_Contact","email_enabled":true,"push_enabled":true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	settings, err := client.ListAlertSettings()
+
+	if err != nil {
+		t.Fatalf("ListAlertSettings() returned error: %v", err)
+	}
+	if len(settings) != 2 || settings[0].Key != "EVT_AP_Lost_Contact" || !settings[0].PushEnabled {
+		t.Errorf("Unexpected settings %+v", settings)
+	}
+}
+
+func TestAPIClient_UpdateAlertSetting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/alert_setting/a1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["push_enabled"] != false || len(payload) != 1 {
+			t.Errorf("Expected only push_enabled to be sent, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"a1","key":"EVT_AP_Lost_Contact","email_enabled":true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	setting, err := client.UpdateAlertSetting("a1", map[string]interface{}{"push_enabled": false})
+
+	if err != nil {
+		t.Fatalf("UpdateAlertSetting() returned error: %v", err)
+	}
+	if setting.PushEnabled || !setting.EmailEnabled {
+		t.Errorf("Unexpected setting %+v", setting)
+	}
+}
+
+func TestFindAlertSetting(t *testing.T) {
+	settings := []AlertSetting{{ID: "a1", Key: "EVT_AP_Lost_Contact"}, {ID: "a2", Key: "EVT_SW_Lost_Contact"}}
+
+	if s, err := FindAlertSetting(settings, "evt_sw_lost_contact"); err != nil || s.ID != "a2" {
+		t.Errorf("Expected to find EVT_SW_Lost_Contact, got %v, %v", s, err)
+	}
+	if s, err := FindAlertSetting(settings, "AP_Lost_Contact"); err != nil || s.ID != "a1" {
+		t.Errorf("Expected to find EVT_AP_Lost_Contact without prefix, got %v, %v", s, err)
+	}
+	if _, err := FindAlertSetting(settings, "GW_WAN_Transition"); err == nil {
+		t.Error("Expected error for unknown category")
+	}
+}
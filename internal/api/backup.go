@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// BackupsResponse is the answer to a backup command: the controller's
+// download link of the new backup
+type BackupsResponse struct {
+	Meta Meta `json:"meta"`
+	Data []struct {
+		URL string `json:"url"`
+	} `json:"data"`
+}
+
+// CreateBackup has the controller write a backup of its configuration with
+// the given days of statistics (0 for none, -1 for all) and returns the path
+// to download it from
+func (c *APIClient) CreateBackup(days int) (string, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/cmd/backup", c.Site)
+
+	body, err := c.doRequestWithBody("POST", path, map[string]interface{}{"cmd": "backup", "days": days})
+	if err != nil {
+		return "", err
+	}
+
+	var response BackupsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return "", err
+	}
+
+	if len(response.Data) == 0 || response.Data[0].URL == "" {
+		return "", fmt.Errorf("controller returned no backup to download")
+	}
+	return response.Data[0].URL, nil
+}
+
+// DownloadBackup copies the backup at url, as returned by CreateBackup, to w
+// and returns its size. The backup is streamed, as it can be large.
+func (c *APIClient) DownloadBackup(url string, w io.Writer) (int64, error) {
+	path := "/proxy/network" + url
+
+	req, err := http.NewRequest("GET", c.Host+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-KEY", c.APIKey)
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.requestDone("GET", path, start)
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.requestDone("GET", path, start)
+		return 0, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	c.requestDone("GET", path, start)
+	if err != nil {
+		return n, fmt.Errorf("failed to download backup: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_CreateBackup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/backup"
+		if r.Method != "POST" || r.URL.Path != expectedPath {
+			t.Errorf("Expected POST %s, got %s %s", expectedPath, r.Method, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "backup" || payload["days"] != float64(-1) {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"url":"/dl/backup/9.0.114.unf"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	url, err := client.CreateBackup(-1)
+
+	if err != nil {
+		t.Fatalf("CreateBackup() returned error: %v", err)
+	}
+	if url != "/dl/backup/9.0.114.unf" {
+		t.Errorf("Expected backup URL /dl/backup/9.0.114.unf, got %q", url)
+	}
+}
+
+func TestAPIClient_CreateBackup_NoURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.CreateBackup(0); err == nil {
+		t.Error("Expected error when the controller returns no backup")
+	}
+}
+
+func TestAPIClient_DownloadBackup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/proxy/network/dl/backup/missing.unf" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path != "/proxy/network/dl/backup/9.0.114.unf" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("X-API-KEY") != "test-key" {
+			t.Error("Expected the API key to be sent")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("backup data"))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+
+	var buf bytes.Buffer
+	n, err := client.DownloadBackup("/dl/backup/9.0.114.unf", &buf)
+	if err != nil {
+		t.Fatalf("DownloadBackup() returned error: %v", err)
+	}
+	if n != 11 || buf.String() != "backup data" {
+		t.Errorf("Expected 11 bytes of backup data, got %d: %q", n, buf.String())
+	}
+
+	if _, err := client.DownloadBackup("/dl/backup/missing.unf", &buf); err == nil {
+		t.Error("Expected error for a missing backup")
+	}
+}
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -15,7 +19,36 @@ type APIClient struct {
 	APIKey   string
 	Site     string
 	Insecure bool
-	client   *http.Client
+	// OnWarning, when set, receives soft errors the controller reports in
+	// meta.msg alongside an otherwise successful response
+	OnWarning func(msg string)
+	// OnResponse, when set, receives the raw body of every successful
+	// response, e.g. to record fixtures
+	OnResponse func(method, path string, body []byte)
+	// OnRequest, when set, receives how long every request took, whether
+	// it succeeded or not
+	OnRequest func(method, path string, elapsed time.Duration)
+	// CheckResponse, when set, vets the body of every successful response;
+	// an error fails the request
+	CheckResponse func(method, path string, body []byte) error
+	// OnExchange, when set, receives every request that got a response,
+	// with the raw response whatever its status
+	OnExchange func(Exchange)
+	client     *http.Client
+}
+
+// Exchange is a request and the raw response the controller sent for it
+type Exchange struct {
+	Method string
+	Path   string
+	// Request is the JSON body sent, nil when there was none or the body
+	// was not JSON (file uploads)
+	Request []byte
+	Status  int
+	Header  http.Header
+	Body    []byte
+	Start   time.Time
+	Elapsed time.Duration
 }
 
 func NewAPIClient(host, apiKey, site string, insecure bool) *APIClient {
@@ -42,33 +75,148 @@ func NewAPIClient(host, apiKey, site string, insecure bool) *APIClient {
 	}
 }
 
+// SetTimeout changes how long a single request may take
+func (c *APIClient) SetTimeout(timeout time.Duration) {
+	c.client.Timeout = timeout
+}
+
+// DialFunc opens the connections of a client, see SetDial
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// SetDial makes the client open its connections with dial instead of
+// connecting to the host directly, e.g. to go through a tunnel. TLS still
+// verifies (and pins) the certificate against the host.
+func (c *APIClient) SetDial(dial DialFunc) {
+	transport, ok := c.client.Transport.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport.DialContext = dial
+}
+
 func (c *APIClient) doRequest(method, path string) ([]byte, error) {
+	return c.doRequestWithBody(method, path, nil)
+}
+
+// doRequestWithBody sends payload JSON-encoded as the request body. A nil
+// payload sends no body at all.
+func (c *APIClient) doRequestWithBody(method, path string, payload interface{}) ([]byte, error) {
+	body, _, err := c.doRequestWithHeader(method, path, payload)
+	return body, err
+}
+
+// doRequestWithHeader is doRequestWithBody also returning the response
+// headers
+func (c *APIClient) doRequestWithHeader(method, path string, payload interface{}) ([]byte, http.Header, error) {
+	var reqBody io.Reader
+	var reqData []byte
+	if payload != nil {
+		var err error
+		if reqData, err = json.Marshal(payload); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(reqData)
+	}
+
+	return c.send(method, path, reqBody, "application/json", reqData)
+}
+
+// doRequestWithReader sends body as is with the given content type, e.g. a
+// multipart form for file uploads
+func (c *APIClient) doRequestWithReader(method, path string, body io.Reader, contentType string) ([]byte, error) {
+	respBody, _, err := c.send(method, path, body, contentType, nil)
+	return respBody, err
+}
+
+// send performs a request and runs the client's hooks on the response.
+// reqData is the JSON request body passed on to OnExchange, if any.
+func (c *APIClient) send(method, path string, reqBody io.Reader, contentType string, reqData []byte) ([]byte, http.Header, error) {
 	url := fmt.Sprintf("%s%s", c.Host, path)
 
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("X-API-KEY", c.APIKey)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 
+	start := time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		c.requestDone(method, path, start)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
+	c.requestDone(method, path, start)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if c.OnExchange != nil {
+		c.OnExchange(Exchange{
+			Method:  method,
+			Path:    path,
+			Request: reqData,
+			Status:  resp.StatusCode,
+			Header:  resp.Header,
+			Body:    body,
+			Start:   start,
+			Elapsed: time.Since(start),
+		})
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		// The classic API reports errors in meta, the v2 API in message
+		var response struct {
+			Meta    Meta   `json:"meta"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &response) == nil && response.Meta.Msg != "" {
+			return nil, nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, response.Meta.Msg)
+		}
+		if response.Message != "" {
+			return nil, nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, response.Message)
+		}
+		return nil, nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if c.OnResponse != nil {
+		c.OnResponse(method, path, body)
 	}
+	if c.CheckResponse != nil {
+		if err := c.CheckResponse(method, path, body); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return body, resp.Header, nil
+}
 
-	return body, nil
+// requestDone reports a request started at start to OnRequest
+func (c *APIClient) requestDone(method, path string, start time.Time) {
+	if c.OnRequest != nil {
+		c.OnRequest(method, path, time.Since(start))
+	}
+}
+
+// checkMeta turns an error meta into an error carrying the controller's
+// message, and forwards messages on successful responses to OnWarning
+func (c *APIClient) checkMeta(meta Meta) error {
+	if meta.RC != "ok" {
+		if meta.Msg != "" {
+			return fmt.Errorf("API returned error: %s (%s)", meta.RC, meta.Msg)
+		}
+		return fmt.Errorf("API returned error: %s", meta.RC)
+	}
+
+	if meta.Msg != "" && c.OnWarning != nil {
+		c.OnWarning(meta.Msg)
+	}
+
+	return nil
 }
 
 func (c *APIClient) ListClients() ([]Client, error) {
@@ -84,29 +232,109 @@ func (c *APIClient) ListClients() ([]Client, error) {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if response.Meta.RC != "ok" {
-		return nil, fmt.Errorf("API returned error: %s", response.Meta.RC)
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
 	}
 
 	return response.Data, nil
 }
 
-func (c *APIClient) ListSites() ([]interface{}, error) {
-	path := "/proxy/network/api/self/sites"
+// ListSites returns the sites of the controller with their health
+func (c *APIClient) ListSites() ([]Site, error) {
+	path := "/proxy/network/api/stat/sites"
 
 	body, err := c.doRequest("GET", path)
 	if err != nil {
 		return nil, err
 	}
 
-	var response APIResponse
+	var response SitesResponse
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if response.Meta.RC != "ok" {
-		return nil, fmt.Errorf("API returned error: %s", response.Meta.RC)
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
 	}
 
 	return response.Data, nil
 }
+
+// sendCommand posts a command payload to one of the controller's cmd/<manager>
+// endpoints (stamgr, devmgr, sitemgr, ...) and checks the response meta.
+func (c *APIClient) sendCommand(manager string, payload interface{}) error {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/cmd/%s", c.Site, manager)
+
+	body, err := c.doRequestWithBody("POST", path, payload)
+	if err != nil {
+		return err
+	}
+
+	var response APIResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return c.checkMeta(response.Meta)
+}
+
+// BlockClient prevents the client with the given MAC from connecting
+func (c *APIClient) BlockClient(mac string) error {
+	return c.sendCommand("stamgr", map[string]string{"cmd": "block-sta", "mac": strings.ToLower(mac)})
+}
+
+// UnblockClient lifts a block on the client with the given MAC
+func (c *APIClient) UnblockClient(mac string) error {
+	return c.sendCommand("stamgr", map[string]string{"cmd": "unblock-sta", "mac": strings.ToLower(mac)})
+}
+
+// KickClient disconnects a wireless client, forcing it to re-associate
+func (c *APIClient) KickClient(mac string) error {
+	return c.sendCommand("stamgr", map[string]string{"cmd": "kick-sta", "mac": strings.ToLower(mac)})
+}
+
+// WakeClient asks the gateway to send a Wake-on-LAN magic packet to the
+// client with the given MAC
+func (c *APIClient) WakeClient(mac string) error {
+	return c.sendCommand("devmgr", map[string]string{"cmd": "wol", "mac": strings.ToLower(mac)})
+}
+
+// ErrNotConnected is returned by GetClient for clients that are not
+// connected right now
+var ErrNotConnected = errors.New("not connected")
+
+// GetClient fetches a single connected client by MAC address
+func (c *APIClient) GetClient(mac string) (*Client, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/sta/%s", c.Site, strings.ToLower(mac))
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ClientsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("client %s is %w", mac, ErrNotConnected)
+	}
+
+	return &response.Data[0], nil
+}
+
+// FetchSiteRaw returns the unparsed response body of a site endpoint such as
+// "stat/health". The request is a GET, or a POST when payload is not nil.
+func (c *APIClient) FetchSiteRaw(endpoint string, payload interface{}) ([]byte, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/%s", c.Site, endpoint)
+
+	if payload == nil {
+		return c.doRequest("GET", path)
+	}
+	return c.doRequestWithBody("POST", path, payload)
+}
@@ -1,10 +1,15 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewAPIClient(t *testing.T) {
@@ -148,19 +153,23 @@ func TestAPIClient_ListClients_InvalidJSON(t *testing.T) {
 }
 
 func TestAPIClient_ListSites_Success(t *testing.T) {
-	mockResponse := APIResponse{
+	mockResponse := SitesResponse{
 		Meta: Meta{RC: "ok"},
-		Data: []interface{}{
-			map[string]interface{}{
-				"name": "default",
-				"desc": "Default Site",
+		Data: []Site{
+			{
+				Name: "default",
+				Desc: "Default Site",
+				Role: "admin",
+				Health: []SiteHealth{
+					{Subsystem: "wlan", Status: "ok", NumUser: 12, NumAP: 2, NumAdopted: 2},
+				},
 			},
 		},
 	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify path
-		expectedPath := "/proxy/network/api/self/sites"
+		expectedPath := "/proxy/network/api/stat/sites"
 		if r.URL.Path != expectedPath {
 			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
 		}
@@ -181,6 +190,10 @@ func TestAPIClient_ListSites_Success(t *testing.T) {
 	if len(sites) != 1 {
 		t.Fatalf("Expected 1 site, got %d", len(sites))
 	}
+
+	if sites[0].Desc != "Default Site" || sites[0].Role != "admin" || len(sites[0].Health) != 1 || sites[0].Health[0].NumUser != 12 {
+		t.Errorf("Unexpected site %+v", sites[0])
+	}
 }
 
 func TestAPIClient_ListSites_APIError(t *testing.T) {
@@ -232,3 +245,352 @@ func TestAPIClient_doRequest_Success(t *testing.T) {
 		t.Errorf("Expected body '%s', got '%s'", expectedBody, string(body))
 	}
 }
+
+func TestAPIClient_StationCommands(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(c *APIClient) error
+		expectedCmd string
+	}{
+		{"block", func(c *APIClient) error { return c.BlockClient("AA:BB:CC:DD:EE:FF") }, "block-sta"},
+		{"unblock", func(c *APIClient) error { return c.UnblockClient("aa:bb:cc:dd:ee:ff") }, "unblock-sta"},
+		{"kick", func(c *APIClient) error { return c.KickClient("Aa:Bb:Cc:Dd:Ee:Ff") }, "kick-sta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+
+				expectedPath := "/proxy/network/api/s/default/cmd/stamgr"
+				if r.URL.Path != expectedPath {
+					t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+				}
+
+				var payload map[string]string
+				json.NewDecoder(r.Body).Decode(&payload)
+				if payload["cmd"] != tt.expectedCmd {
+					t.Errorf("Expected cmd '%s', got '%s'", tt.expectedCmd, payload["cmd"])
+				}
+				if payload["mac"] != "aa:bb:cc:dd:ee:ff" {
+					t.Errorf("Expected mac 'aa:bb:cc:dd:ee:ff', got '%s'", payload["mac"])
+				}
+
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+			}))
+			defer server.Close()
+
+			client := NewAPIClient(server.URL, "test-key", "default", true)
+			if err := tt.call(client); err != nil {
+				t.Fatalf("%s returned error: %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestAPIClient_sendCommand_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"error","msg":"api.err.UnknownStation"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.BlockClient("aa:bb:cc:dd:ee:ff"); err == nil {
+		t.Error("Expected error for API error response")
+	}
+}
+
+func TestAPIClient_MetaMessages(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantErr      string
+		wantWarnings []string
+	}{
+		{
+			name:         "warning on success",
+			status:       http.StatusOK,
+			body:         `{"meta":{"rc":"ok","msg":"api.warn.PartialData"},"data":[]}`,
+			wantWarnings: []string{"api.warn.PartialData"},
+		},
+		{
+			name:    "message on error meta",
+			status:  http.StatusOK,
+			body:    `{"meta":{"rc":"error","msg":"api.err.NoPermission"},"data":[]}`,
+			wantErr: "API returned error: error (api.err.NoPermission)",
+		},
+		{
+			name:    "message on HTTP error",
+			status:  http.StatusUnauthorized,
+			body:    `{"meta":{"rc":"error","msg":"api.err.LoginRequired"},"data":[]}`,
+			wantErr: "API request failed with status 401: api.err.LoginRequired",
+		},
+		{
+			name:    "v2 message on HTTP error",
+			status:  http.StatusBadRequest,
+			body:    `{"code":"api.err.InvalidPayload","message":"Invalid network_id"}`,
+			wantErr: "API request failed with status 400: Invalid network_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			var warnings []string
+			client := NewAPIClient(server.URL, "test-key", "default", true)
+			client.OnWarning = func(msg string) { warnings = append(warnings, msg) }
+
+			_, err := client.ListClients()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Expected error %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("ListClients() returned error: %v", err)
+			}
+
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("Expected warnings %v, got %v", tt.wantWarnings, warnings)
+			}
+			for i := range warnings {
+				if warnings[i] != tt.wantWarnings[i] {
+					t.Errorf("Expected warning %q, got %q", tt.wantWarnings[i], warnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAPIClient_SetTimeout(t *testing.T) {
+	client := NewAPIClient("https://example.com", "test-key", "default", true)
+	client.SetTimeout(5 * time.Second)
+
+	if client.client.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout 5s, got %v", client.client.Timeout)
+	}
+}
+
+func TestAPIClient_SetDial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "unifi.internal:8443" {
+			t.Errorf("Expected the original host, got %s", r.Host)
+		}
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	var dialed string
+	client := NewAPIClient("http://unifi.internal:8443", "test-key", "default", true)
+	client.SetDial(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = addr
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	})
+
+	if _, err := client.ListClients(); err != nil {
+		t.Fatalf("ListClients() returned error: %v", err)
+	}
+	if dialed != "unifi.internal:8443" {
+		t.Errorf("Expected dial to unifi.internal:8443, got %q", dialed)
+	}
+}
+
+func TestAPIClient_GetClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/sta/aa:bb:cc:dd:ee:ff"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"mac":"aa:bb:cc:dd:ee:ff","name":"TestDevice","noise":-95}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	result, err := client.GetClient("AA:BB:CC:DD:EE:FF")
+
+	if err != nil {
+		t.Fatalf("GetClient() returned error: %v", err)
+	}
+	if result.Name != "TestDevice" || result.Noise != -95 {
+		t.Errorf("Unexpected client %+v", result)
+	}
+}
+
+func TestAPIClient_GetClient_NotConnected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.GetClient("aa:bb:cc:dd:ee:ff"); err == nil {
+		t.Error("Expected error for a client that is not connected")
+	}
+}
+
+func TestAPIClient_FetchSiteRaw(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        interface{}
+		expectedMethod string
+	}{
+		{"GET without payload", nil, "GET"},
+		{"POST with payload", map[string]int{"within": 1}, "POST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tt.expectedMethod {
+					t.Errorf("Expected %s request, got %s", tt.expectedMethod, r.Method)
+				}
+				if r.URL.Path != "/proxy/network/api/s/default/stat/health" {
+					t.Errorf("Unexpected path '%s'", r.URL.Path)
+				}
+
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"subsystem":"wan"}]}`))
+			}))
+			defer server.Close()
+
+			client := NewAPIClient(server.URL, "test-key", "default", true)
+			body, err := client.FetchSiteRaw("stat/health", tt.payload)
+			if err != nil {
+				t.Fatalf("FetchSiteRaw() returned error: %v", err)
+			}
+			if string(body) != `{"meta":{"rc":"ok"},"data":[{"subsystem":"wan"}]}` {
+				t.Errorf("Unexpected body %s", body)
+			}
+		})
+	}
+}
+
+func TestAPIClient_OnResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	var recorded []string
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	client.OnResponse = func(method, path string, body []byte) {
+		recorded = append(recorded, method+" "+path+" "+string(body))
+	}
+
+	if _, err := client.ListClients(); err != nil {
+		t.Fatalf("ListClients() returned error: %v", err)
+	}
+
+	expected := `GET /proxy/network/api/s/default/stat/sta {"meta":{"rc":"ok"},"data":[]}`
+	if len(recorded) != 1 || recorded[0] != expected {
+		t.Errorf("Expected %q to be recorded, got %v", expected, recorded)
+	}
+}
+
+func TestAPIClient_OnRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var elapsed []time.Duration
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	client.OnRequest = func(method, path string, d time.Duration) {
+		elapsed = append(elapsed, d)
+	}
+
+	// Failed requests are timed too
+	if _, err := client.ListClients(); err == nil {
+		t.Fatal("Expected ListClients() to fail")
+	}
+
+	if len(elapsed) != 1 || elapsed[0] < 10*time.Millisecond {
+		t.Errorf("Expected one request of at least 10ms, got %v", elapsed)
+	}
+}
+
+func TestAPIClient_CheckResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"mac":"aa:bb:cc:dd:ee:ff"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	client.CheckResponse = func(method, path string, body []byte) error {
+		return fmt.Errorf("%s %s rejected", method, path)
+	}
+
+	_, err := client.ListClients()
+	if err == nil || err.Error() != "GET /proxy/network/api/s/default/stat/sta rejected" {
+		t.Errorf("Expected the response to be rejected, got %v", err)
+	}
+}
+
+func TestAPIClient_OnExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"meta":{"rc":"error","msg":"api.err.Invalid"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	var exchanges []Exchange
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	client.OnExchange = func(ex Exchange) {
+		exchanges = append(exchanges, ex)
+	}
+
+	// Error responses are passed on too, with the request body
+	if err := client.KickClient("aa:bb:cc:dd:ee:ff"); err == nil {
+		t.Fatal("Expected KickClient() to fail")
+	}
+
+	if len(exchanges) != 1 {
+		t.Fatalf("Expected one exchange, got %d", len(exchanges))
+	}
+	ex := exchanges[0]
+	if ex.Method != "POST" || ex.Path != "/proxy/network/api/s/default/cmd/stamgr" || ex.Status != http.StatusBadRequest {
+		t.Errorf("Unexpected exchange %s %s %d", ex.Method, ex.Path, ex.Status)
+	}
+	if !strings.Contains(string(ex.Request), `"kick-sta"`) || !strings.Contains(string(ex.Body), "api.err.Invalid") {
+		t.Errorf("Expected request and response bodies, got %s and %s", ex.Request, ex.Body)
+	}
+}
+
+func TestAPIClient_WakeClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/devmgr"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "wol" || payload["mac"] != "aa:bb:cc:dd:ee:ff" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.WakeClient("aa:bb:cc:dd:ee:ff"); err != nil {
+		t.Fatalf("WakeClient() returned error: %v", err)
+	}
+}
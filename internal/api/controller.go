@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ControllerStatus is the status document served by the Network Application
+// at /status. It is reported in the response meta rather than in data.
+type ControllerStatus struct {
+	RC            string `json:"rc"`
+	Msg           string `json:"msg,omitempty"`
+	Up            bool   `json:"up"`
+	ServerVersion string `json:"server_version"`
+	UUID          string `json:"uuid"`
+}
+
+type StatusResponse struct {
+	Meta ControllerStatus `json:"meta"`
+}
+
+// GetControllerStatus returns whether the Network Application service is up
+// and which version it is running.
+func (c *APIClient) GetControllerStatus() (*ControllerStatus, error) {
+	body, err := c.doRequest("GET", "/proxy/network/status")
+	if err != nil {
+		return nil, err
+	}
+
+	var response StatusResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(Meta{RC: response.Meta.RC, Msg: response.Meta.Msg}); err != nil {
+		return nil, err
+	}
+
+	return &response.Meta, nil
+}
+
+// SysInfo is the controller's system information from stat/sysinfo
+type SysInfo struct {
+	Timezone        string   `json:"timezone"`
+	Version         string   `json:"version"`
+	Build           string   `json:"build"`
+	PreviousVersion string   `json:"previous_version"`
+	Hostname        string   `json:"hostname"`
+	Name            string   `json:"name"`
+	IPAddrs         []string `json:"ip_addrs"`
+	Uptime          int64    `json:"uptime"`
+	// UpdateAvailable is set when a newer Network Application release is
+	// available; UpdateDownloaded once it is ready to install
+	UpdateAvailable  bool `json:"update_available"`
+	UpdateDownloaded bool `json:"update_downloaded"`
+	// Autobackup is whether scheduled automatic backups are enabled
+	Autobackup bool `json:"autobackup"`
+	// Time is the controller's clock when it answered, from the Date header
+	// of the response. It is truncated to whole seconds and zero when the
+	// header is missing.
+	Time time.Time `json:"-"`
+}
+
+type SysInfoResponse struct {
+	Meta Meta      `json:"meta"`
+	Data []SysInfo `json:"data"`
+}
+
+// GetSysInfo returns the controller's system information
+func (c *APIClient) GetSysInfo() (*SysInfo, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/sysinfo", c.Site)
+
+	body, header, err := c.doRequestWithHeader("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SysInfoResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("controller returned no system information")
+	}
+
+	info := &response.Data[0]
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		info.Time = date
+	}
+	return info, nil
+}
+
+// RebootConsole asks the console hosting the Network Application to reboot.
+// This reboots the whole host: on a UniFi OS gateway (UDM, UCG) routing goes
+// down with it. The API has no way to restart only the application.
+func (c *APIClient) RebootConsole() error {
+	return c.sendCommand("system", map[string]string{"cmd": "reboot"})
+}
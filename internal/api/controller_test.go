@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIClient_GetControllerStatus_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/status"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok","up":true,"server_version":"9.1.105","uuid":"abc"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	status, err := client.GetControllerStatus()
+
+	if err != nil {
+		t.Fatalf("GetControllerStatus() returned error: %v", err)
+	}
+	if !status.Up {
+		t.Error("Expected controller to be up")
+	}
+	if status.ServerVersion != "9.1.105" {
+		t.Errorf("Expected version '9.1.105', got '%s'", status.ServerVersion)
+	}
+}
+
+func TestAPIClient_GetControllerStatus_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"error"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.GetControllerStatus(); err == nil {
+		t.Error("Expected error for API error response")
+	}
+}
+
+func TestAPIClient_RebootConsole(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/cmd/system"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if payload["cmd"] != "reboot" {
+			t.Errorf("Expected cmd 'reboot', got '%s'", payload["cmd"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.RebootConsole(); err != nil {
+		t.Fatalf("RebootConsole() returned error: %v", err)
+	}
+}
+
+func TestAPIClient_GetSysInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/sysinfo"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.Header().Set("Date", "Fri, 16 Oct 2026 09:30:00 GMT")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"timezone":"Europe/Berlin","version":"9.0.114","hostname":"udm","update_available":true,"autobackup":true,"ip_addrs":["192.168.1.1"]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	info, err := client.GetSysInfo()
+
+	if err != nil {
+		t.Fatalf("GetSysInfo() returned error: %v", err)
+	}
+	if info.Timezone != "Europe/Berlin" || info.Version != "9.0.114" {
+		t.Errorf("Unexpected system information %+v", info)
+	}
+	if !info.UpdateAvailable || !info.Autobackup || len(info.IPAddrs) != 1 {
+		t.Errorf("Expected update, autobackup and addresses in %+v", info)
+	}
+	if expected := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC); !info.Time.Equal(expected) {
+		t.Errorf("Expected time %v, got %v", expected, info.Time)
+	}
+}
@@ -0,0 +1,427 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Device is an adopted (or pending) UniFi network device: access point,
+// switch or gateway, from stat/device
+type Device struct {
+	ID                string `json:"_id"`
+	MAC               string `json:"mac"`
+	Name              string `json:"name"`
+	Model             string `json:"model"`
+	Type              string `json:"type"`
+	Serial            string `json:"serial"`
+	IP                string `json:"ip"`
+	Version           string `json:"version"`
+	Upgradable        bool   `json:"upgradable"`
+	UpgradeToFirmware string `json:"upgrade_to_firmware,omitempty"`
+	Adopted           bool   `json:"adopted"`
+	State             int    `json:"state"`
+	Uptime            int64  `json:"uptime"`
+	LastSeen          int64  `json:"last_seen"`
+	NumSta            int    `json:"num_sta"`
+	Satisfaction      int    `json:"satisfaction"`
+	TxBytes           int64  `json:"tx_bytes"`
+	RxBytes           int64  `json:"rx_bytes"`
+	PortTable         []Port `json:"port_table,omitempty"`
+	// SystemStats and Uplink are only complete in the device's own record,
+	// see HydrateDevices
+	SystemStats *SystemStats `json:"system-stats,omitempty"`
+	Uplink      *Uplink      `json:"uplink,omitempty"`
+	// EthernetOverrides assigns gateway interfaces to a network group (WAN,
+	// WAN2, LAN)
+	EthernetOverrides []EthernetOverride `json:"ethernet_overrides,omitempty"`
+	// AdoptedAt is when the device was adopted (Unix seconds); older
+	// controllers do not report it
+	AdoptedAt int64 `json:"adopted_at,omitempty"`
+	// ModelInLTS and ModelInEOL tell whether the model only gets long-term
+	// support firmware or has reached end of life
+	ModelInLTS bool `json:"model_in_lts"`
+	ModelInEOL bool `json:"model_in_eol"`
+	// RadioStats holds the live counters of an access point's radios
+	RadioStats []RadioStats `json:"radio_table_stats,omitempty"`
+	// Note is the device's free-form note, which also holds its tags
+	Note string `json:"note,omitempty"`
+	// Tags are the key=value words of Note, see ParseTags. They are not part
+	// of the controller's record.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// tagKeyPattern is what a tag key may look like, so it can be used as the
+// tag_<key> filter column
+var tagKeyPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ValidTagKey reports whether key can be used as a tag key
+func ValidTagKey(key string) bool {
+	return tagKeyPattern.MatchString(key)
+}
+
+// ParseTags returns the tags stored in a device note: its words of the form
+// key=value, e.g. "location=closet-2". Other words are not tags.
+func ParseTags(note string) map[string]string {
+	var tags map[string]string
+	for _, word := range strings.Fields(note) {
+		key, value, ok := strings.Cut(word, "=")
+		if !ok || !ValidTagKey(key) {
+			continue
+		}
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		tags[key] = value
+	}
+	return tags
+}
+
+// SetTag returns note with the tag key set to value, replacing an earlier
+// value. An empty value removes the tag. The words of the note are joined
+// with single spaces.
+func SetTag(note, key, value string) string {
+	var words []string
+	for _, word := range strings.Fields(note) {
+		if k, _, ok := strings.Cut(word, "="); ok && k == key {
+			continue
+		}
+		words = append(words, word)
+	}
+	if value != "" {
+		words = append(words, key+"="+value)
+	}
+	return strings.Join(words, " ")
+}
+
+// RadioStats are the live counters of one radio of an access point. Channel
+// utilization (CU) is the percentage of airtime the radio sees as busy;
+// CUSelfRx and CUSelfTx are the parts caused by the AP's own traffic.
+type RadioStats struct {
+	Name         string `json:"name"`
+	Radio        string `json:"radio"`
+	Channel      int    `json:"channel"`
+	TxPower      int    `json:"tx_power"`
+	NumSta       int    `json:"num_sta"`
+	Satisfaction int    `json:"satisfaction"`
+	CUTotal      int    `json:"cu_total"`
+	CUSelfRx     int    `json:"cu_self_rx"`
+	CUSelfTx     int    `json:"cu_self_tx"`
+}
+
+// GetBand returns the band of the radio ("2g", "5g" or "6g"), empty if
+// unknown
+func (r *RadioStats) GetBand() string {
+	return radioBands[r.Radio]
+}
+
+// Interference returns the part of the channel utilization that is not
+// caused by the AP itself: neighbouring networks and non-WiFi noise
+func (r *RadioStats) Interference() int {
+	return max(r.CUTotal-r.CUSelfRx-r.CUSelfTx, 0)
+}
+
+// SystemStats is a device's resource usage. The controller reports
+// percentages as decimal strings.
+type SystemStats struct {
+	CPU string `json:"cpu,omitempty"`
+	Mem string `json:"mem,omitempty"`
+}
+
+// Uplink is how a device connects to the rest of the network
+type Uplink struct {
+	// Type is "wire" or "wireless" (mesh)
+	Type       string `json:"type"`
+	Speed      int    `json:"speed,omitempty"`
+	UplinkMAC  string `json:"uplink_mac,omitempty"`
+	RemotePort int    `json:"uplink_remote_port,omitempty"`
+}
+
+// EthernetOverride assigns a gateway interface to a network group
+type EthernetOverride struct {
+	IfName       string `json:"ifname"`
+	NetworkGroup string `json:"networkgroup"`
+}
+
+// Port is a physical port of a switch or gateway, from a device's port_table
+type Port struct {
+	Index int    `json:"port_idx"`
+	Name  string `json:"name"`
+	Media string `json:"media,omitempty"`
+	// IfName, NetworkName and IP are only reported for gateway ports
+	IfName      string `json:"ifname,omitempty"`
+	NetworkName string `json:"network_name,omitempty"`
+	IP          string `json:"ip,omitempty"`
+	Up          bool   `json:"up"`
+	Enabled     bool   `json:"enable"`
+	Speed       int    `json:"speed"`
+	FullDuplex  bool   `json:"full_duplex"`
+	IsUplink    bool   `json:"is_uplink"`
+	PoE         bool   `json:"port_poe"`
+	PoEMode     string `json:"poe_mode,omitempty"`
+	PoEGood     bool   `json:"poe_good"`
+	// PoEPower is the current draw in watts, as a decimal string
+	PoEPower            string `json:"poe_power,omitempty"`
+	PortconfID          string `json:"portconf_id,omitempty"`
+	NativeNetworkconfID string `json:"native_networkconf_id,omitempty"`
+	RxBytes             int64  `json:"rx_bytes"`
+	TxBytes             int64  `json:"tx_bytes"`
+	RxErrors            int64  `json:"rx_errors"`
+	TxErrors            int64  `json:"tx_errors"`
+	RxDropped           int64  `json:"rx_dropped"`
+	TxDropped           int64  `json:"tx_dropped"`
+}
+
+type DevicesResponse struct {
+	Meta Meta     `json:"meta"`
+	Data []Device `json:"data"`
+}
+
+// deviceStates names the values of Device.State
+var deviceStates = map[int]string{
+	0:  "disconnected",
+	1:  "connected",
+	2:  "pending",
+	4:  "upgrading",
+	5:  "provisioning",
+	6:  "heartbeat-missed",
+	7:  "adopting",
+	9:  "adoption-failed",
+	10: "isolated",
+	11: "isolated",
+}
+
+// ListDevices returns all devices of the site
+func (c *APIClient) ListDevices() ([]Device, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/device", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DevicesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	for i := range response.Data {
+		response.Data[i].Tags = ParseTags(response.Data[i].Note)
+	}
+
+	return response.Data, nil
+}
+
+// GetDevice fetches a single device by MAC address
+func (c *APIClient) GetDevice(mac string) (*Device, error) {
+	raw, err := c.getDeviceRecord(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	var device Device
+	if err := json.Unmarshal(raw, &device); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	device.Tags = ParseTags(device.Note)
+
+	return &device, nil
+}
+
+// getDeviceRecord fetches the unparsed record of a single device
+func (c *APIClient) getDeviceRecord(mac string) (json.RawMessage, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/device/%s", c.Site, strings.ToLower(mac))
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Meta Meta              `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("no device with MAC %s", mac)
+	}
+
+	return response.Data[0], nil
+}
+
+// HydrateDevices merges each device's own record, which carries details the
+// site listing leaves out, into the listed device, fetching up to workers
+// devices at a time. Devices whose record cannot be fetched are kept as
+// listed and their errors returned together.
+func (c *APIClient) HydrateDevices(devices []Device, workers int) ([]Device, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	hydrated := make([]Device, len(devices))
+	errs := make([]error, len(devices))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, device := range devices {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			hydrated[i] = device
+
+			raw, err := c.getDeviceRecord(device.MAC)
+			if err == nil {
+				// Fields missing from the record keep their listed values
+				err = json.Unmarshal(raw, &hydrated[i])
+			}
+			if err != nil {
+				hydrated[i] = device
+				errs[i] = fmt.Errorf("%s: %w", device.MAC, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return hydrated, errors.Join(errs...)
+}
+
+// ListPendingDevices returns the devices that are waiting to be adopted
+func (c *APIClient) ListPendingDevices() ([]Device, error) {
+	devices, err := c.ListDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Device
+	for _, device := range devices {
+		if device.IsPending() {
+			pending = append(pending, device)
+		}
+	}
+	return pending, nil
+}
+
+// AdoptDevice adopts the pending device with the given MAC into the site
+func (c *APIClient) AdoptDevice(mac string) error {
+	return c.sendCommand("devmgr", map[string]string{"cmd": "adopt", "mac": strings.ToLower(mac)})
+}
+
+// ProvisionDevice forces the controller to push the current configuration to
+// the device
+func (c *APIClient) ProvisionDevice(mac string) error {
+	return c.sendCommand("devmgr", map[string]string{"cmd": "force-provision", "mac": strings.ToLower(mac)})
+}
+
+// UpgradeDevice starts a firmware upgrade of the device to the latest release
+// known to the controller
+func (c *APIClient) UpgradeDevice(mac string) error {
+	return c.sendCommand("devmgr", map[string]string{"cmd": "upgrade", "mac": strings.ToLower(mac)})
+}
+
+// UpgradeDeviceFromURL starts a firmware upgrade of the device to the image
+// at url
+func (c *APIClient) UpgradeDeviceFromURL(mac, url string) error {
+	return c.sendCommand("devmgr", map[string]string{"cmd": "upgrade-external", "mac": strings.ToLower(mac), "url": url})
+}
+
+// LocateDevice turns the device's locate mode (flashing LED) on or off
+func (c *APIClient) LocateDevice(mac string, on bool) error {
+	cmd := "unset-locate"
+	if on {
+		cmd = "set-locate"
+	}
+	return c.sendCommand("devmgr", map[string]string{"cmd": cmd, "mac": strings.ToLower(mac)})
+}
+
+// PowerCyclePort briefly cuts PoE power on a switch port, rebooting the
+// device powered by it
+func (c *APIClient) PowerCyclePort(mac string, portIdx int) error {
+	return c.sendCommand("devmgr", map[string]interface{}{"cmd": "power-cycle", "mac": strings.ToLower(mac), "port_idx": portIdx})
+}
+
+// UpdateDevice changes only the given fields of a device's configuration,
+// e.g. its name
+func (c *APIClient) UpdateDevice(id string, fields map[string]interface{}) error {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/device/%s", c.Site, id)
+
+	body, err := c.doRequestWithBody("PUT", path, fields)
+	if err != nil {
+		return err
+	}
+
+	var response APIResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return c.checkMeta(response.Meta)
+}
+
+// FindPort returns the port with the given index
+func (d *Device) FindPort(index int) (*Port, error) {
+	for i := range d.PortTable {
+		if d.PortTable[i].Index == index {
+			return &d.PortTable[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no port %d", d.GetDisplayName(), index)
+}
+
+// IsPending reports whether the device is waiting to be adopted
+func (d *Device) IsPending() bool {
+	return !d.Adopted && d.State == 2
+}
+
+// GetDisplayName returns the best available name for the device
+// Fallback order: Name -> Model -> MAC
+func (d *Device) GetDisplayName() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	if d.Model != "" {
+		return d.Model
+	}
+	return d.MAC
+}
+
+// IsUpgrading reports whether the device is installing firmware
+func (d *Device) IsUpgrading() bool {
+	return d.State == 4
+}
+
+// GetState returns the device state as a word, e.g. "connected"
+func (d *Device) GetState() string {
+	if name, ok := deviceStates[d.State]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", d.State)
+}
+
+// SortDevices orders devices by display name (case-insensitive), then MAC
+func SortDevices(devices []Device) {
+	sort.SliceStable(devices, func(i, j int) bool {
+		a, b := strings.ToLower(devices[i].GetDisplayName()), strings.ToLower(devices[j].GetDisplayName())
+		if a != b {
+			return a < b
+		}
+		return devices[i].MAC < devices[j].MAC
+	})
+}
@@ -0,0 +1,440 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPIClient_ListDevices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/device"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"d1","mac":"f0:9f:c2:00:00:01","name":"Office AP","model":"U7PG2","type":"uap","ip":"192.168.1.2","version":"6.6.77","state":1,"uptime":86400,"num_sta":12,"note":"location=closet-2"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	devices, err := client.ListDevices()
+
+	if err != nil {
+		t.Fatalf("ListDevices() returned error: %v", err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("Expected 1 device, got %d", len(devices))
+	}
+
+	device := devices[0]
+	if device.Model != "U7PG2" || device.Type != "uap" || device.NumSta != 12 || device.Version != "6.6.77" {
+		t.Errorf("Unexpected device %+v", device)
+	}
+	if device.GetState() != "connected" {
+		t.Errorf("Expected state 'connected', got '%s'", device.GetState())
+	}
+	if device.Tags["location"] != "closet-2" {
+		t.Errorf("Expected tags parsed from the note, got %v", device.Tags)
+	}
+}
+
+func TestDevice_GetDisplayName(t *testing.T) {
+	tests := []struct {
+		device   Device
+		expected string
+	}{
+		{Device{Name: "Office AP", Model: "U7PG2", MAC: "aa"}, "Office AP"},
+		{Device{Model: "U7PG2", MAC: "aa"}, "U7PG2"},
+		{Device{MAC: "aa"}, "aa"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.device.GetDisplayName(); got != tt.expected {
+			t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+		}
+	}
+}
+
+func TestRadioStats(t *testing.T) {
+	var device Device
+	err := json.Unmarshal([]byte(`{"mac":"f0:9f:c2:00:00:01","radio_table_stats":[{"name":"wifi0","radio":"ng","channel":6,"cu_total":60,"cu_self_rx":15,"cu_self_tx":10},{"name":"wifi1","radio":"na","channel":36,"cu_total":5,"cu_self_rx":4,"cu_self_tx":3}]}`), &device)
+	if err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if len(device.RadioStats) != 2 {
+		t.Fatalf("Expected 2 radios, got %d", len(device.RadioStats))
+	}
+
+	ng, na := device.RadioStats[0], device.RadioStats[1]
+	if ng.GetBand() != Band2G || ng.Channel != 6 || ng.Interference() != 35 {
+		t.Errorf("Unexpected 2.4 GHz radio %+v (interference %d)", ng, ng.Interference())
+	}
+	if na.GetBand() != Band5G || na.Interference() != 0 {
+		t.Errorf("Expected 5 GHz radio without negative interference, got %+v (interference %d)", na, na.Interference())
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tags := ParseTags("spare unit location=closet-2 rack=3 Bad=key =x")
+	if len(tags) != 2 || tags["location"] != "closet-2" || tags["rack"] != "3" {
+		t.Errorf("Unexpected tags %v", tags)
+	}
+	if ParseTags("no tags here") != nil {
+		t.Error("Expected no tags for a plain note")
+	}
+}
+
+func TestSetTag(t *testing.T) {
+	tests := []struct {
+		note, key, value, expected string
+	}{
+		{"", "location", "closet-2", "location=closet-2"},
+		{"spare unit location=closet-1", "location", "closet-2", "spare unit location=closet-2"},
+		{"location=closet-1 rack=3", "location", "", "rack=3"},
+	}
+
+	for _, tt := range tests {
+		if got := SetTag(tt.note, tt.key, tt.value); got != tt.expected {
+			t.Errorf("SetTag(%q, %q, %q) = %q, expected %q", tt.note, tt.key, tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestDevice_GetState(t *testing.T) {
+	if state := (&Device{State: 0}).GetState(); state != "disconnected" {
+		t.Errorf("Expected 'disconnected', got '%s'", state)
+	}
+	if state := (&Device{State: 42}).GetState(); state != "unknown (42)" {
+		t.Errorf("Expected 'unknown (42)', got '%s'", state)
+	}
+}
+
+func TestSortDevices(t *testing.T) {
+	devices := []Device{
+		{MAC: "aa:00", Name: "switch"},
+		{MAC: "bb:00", Name: "AP"},
+		{MAC: "cc:00", Model: "USW24"},
+	}
+
+	SortDevices(devices)
+
+	expected := []string{"bb:00", "aa:00", "cc:00"}
+	for i, mac := range expected {
+		if devices[i].MAC != mac {
+			t.Errorf("Position %d: expected %s, got %s", i, mac, devices[i].MAC)
+		}
+	}
+}
+
+func TestAPIClient_ListPendingDevices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"mac":"f0:9f:c2:00:00:01","adopted":true,"state":1},
+			{"mac":"f0:9f:c2:00:00:02","adopted":false,"state":2},
+			{"mac":"f0:9f:c2:00:00:03","adopted":true,"state":0}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	pending, err := client.ListPendingDevices()
+
+	if err != nil {
+		t.Fatalf("ListPendingDevices() returned error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].MAC != "f0:9f:c2:00:00:02" {
+		t.Errorf("Expected only the pending device, got %+v", pending)
+	}
+}
+
+func TestAPIClient_AdoptDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/devmgr"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "adopt" || payload["mac"] != "f0:9f:c2:00:00:02" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.AdoptDevice("F0:9F:C2:00:00:02"); err != nil {
+		t.Fatalf("AdoptDevice() returned error: %v", err)
+	}
+}
+
+func TestAPIClient_UpdateDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/device/d1"
+		if r.Method != "PUT" || r.URL.Path != expectedPath {
+			t.Errorf("Expected PUT '%s', got %s '%s'", expectedPath, r.Method, r.URL.Path)
+		}
+
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if len(payload) != 1 || payload["name"] != "U6-Lite-hq-01" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.UpdateDevice("d1", map[string]interface{}{"name": "U6-Lite-hq-01"}); err != nil {
+		t.Fatalf("UpdateDevice() returned error: %v", err)
+	}
+}
+
+func TestAPIClient_ProvisionDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/devmgr"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "force-provision" || payload["mac"] != "f0:9f:c2:00:00:02" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.ProvisionDevice("F0:9F:C2:00:00:02"); err != nil {
+		t.Fatalf("ProvisionDevice() returned error: %v", err)
+	}
+}
+
+func TestAPIClient_GetDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/device/f0:9f:c2:00:00:01"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"mac":"f0:9f:c2:00:00:01","state":4,"version":"6.6.77"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	device, err := client.GetDevice("F0:9F:C2:00:00:01")
+
+	if err != nil {
+		t.Fatalf("GetDevice() returned error: %v", err)
+	}
+	if !device.IsUpgrading() || device.GetState() != "upgrading" {
+		t.Errorf("Expected an upgrading device, got %+v", device)
+	}
+}
+
+func TestAPIClient_GetDevice_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.GetDevice("f0:9f:c2:00:00:01"); err == nil {
+		t.Error("Expected error for unknown device")
+	}
+}
+
+func TestAPIClient_HydrateDevices(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		mac := strings.TrimPrefix(r.URL.Path, "/proxy/network/api/s/default/stat/device/")
+		w.WriteHeader(http.StatusOK)
+		if mac == "f0:9f:c2:00:00:03" {
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+			return
+		}
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"mac":"` + mac + `","system-stats":{"cpu":"12.5","mem":"40.1"},"uplink":{"type":"wire","speed":1000}}]}`))
+	}))
+	defer server.Close()
+
+	devices := []Device{
+		{MAC: "f0:9f:c2:00:00:01", Name: "AP 1"},
+		{MAC: "f0:9f:c2:00:00:02", Name: "AP 2"},
+		{MAC: "f0:9f:c2:00:00:03", Name: "AP 3"},
+		{MAC: "f0:9f:c2:00:00:04", Name: "AP 4"},
+		{MAC: "f0:9f:c2:00:00:05", Name: "AP 5"},
+	}
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	hydrated, err := client.HydrateDevices(devices, 2)
+
+	if err == nil || !strings.Contains(err.Error(), "f0:9f:c2:00:00:03") {
+		t.Errorf("Expected an error for the missing device, got %v", err)
+	}
+	if len(hydrated) != len(devices) {
+		t.Fatalf("Expected %d devices, got %d", len(devices), len(hydrated))
+	}
+	for i, device := range hydrated {
+		if device.MAC != devices[i].MAC {
+			t.Errorf("Expected %s at position %d, got %s", devices[i].MAC, i, device.MAC)
+		}
+	}
+	if hydrated[0].SystemStats == nil || hydrated[0].SystemStats.CPU != "12.5" || hydrated[0].Uplink.Speed != 1000 || hydrated[0].Name != "AP 1" {
+		t.Errorf("Expected details to be merged into the listed device, got %+v", hydrated[0])
+	}
+	if hydrated[2].Name != "AP 3" || hydrated[2].SystemStats != nil {
+		t.Errorf("Expected the failed device to be kept as listed, got %+v", hydrated[2])
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestAPIClient_UpgradeDevice(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected map[string]string
+	}{
+		{"Latest", "", map[string]string{"cmd": "upgrade", "mac": "f0:9f:c2:00:00:01"}},
+		{"External", "https://fw.example.com/fw.bin", map[string]string{"cmd": "upgrade-external", "mac": "f0:9f:c2:00:00:01", "url": "https://fw.example.com/fw.bin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				expectedPath := "/proxy/network/api/s/default/cmd/devmgr"
+				if r.URL.Path != expectedPath {
+					t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+				}
+
+				var payload map[string]string
+				json.NewDecoder(r.Body).Decode(&payload)
+				if len(payload) != len(tt.expected) {
+					t.Errorf("Unexpected payload %v", payload)
+				}
+				for key, value := range tt.expected {
+					if payload[key] != value {
+						t.Errorf("Expected %s = %s, got %s", key, value, payload[key])
+					}
+				}
+
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+			}))
+			defer server.Close()
+
+			client := NewAPIClient(server.URL, "test-key", "default", true)
+
+			var err error
+			if tt.url == "" {
+				err = client.UpgradeDevice("F0:9F:C2:00:00:01")
+			} else {
+				err = client.UpgradeDeviceFromURL("F0:9F:C2:00:00:01", tt.url)
+			}
+			if err != nil {
+				t.Fatalf("upgrade returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIClient_LocateDevice(t *testing.T) {
+	var commands []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/devmgr"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["mac"] != "f0:9f:c2:00:00:01" {
+			t.Errorf("Unexpected MAC %s", payload["mac"])
+		}
+		commands = append(commands, payload["cmd"])
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.LocateDevice("F0:9F:C2:00:00:01", true); err != nil {
+		t.Fatalf("LocateDevice() returned error: %v", err)
+	}
+	if err := client.LocateDevice("F0:9F:C2:00:00:01", false); err != nil {
+		t.Fatalf("LocateDevice() returned error: %v", err)
+	}
+
+	if len(commands) != 2 || commands[0] != "set-locate" || commands[1] != "unset-locate" {
+		t.Errorf("Expected set-locate then unset-locate, got %v", commands)
+	}
+}
+
+func TestAPIClient_PowerCyclePort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/devmgr"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "power-cycle" || payload["mac"] != "f0:9f:c2:00:00:02" || payload["port_idx"] != float64(7) {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.PowerCyclePort("F0:9F:C2:00:00:02", 7); err != nil {
+		t.Fatalf("PowerCyclePort() returned error: %v", err)
+	}
+}
+
+func TestDevice_FindPort(t *testing.T) {
+	device := Device{Name: "Core Switch", PortTable: []Port{{Index: 1, Name: "Port 1"}, {Index: 7, Name: "Camera", PoE: true}}}
+
+	port, err := device.FindPort(7)
+	if err != nil {
+		t.Fatalf("FindPort() returned error: %v", err)
+	}
+	if port.Name != "Camera" || !port.PoE {
+		t.Errorf("Unexpected port %+v", port)
+	}
+
+	if _, err := device.FindPort(9); err == nil {
+		t.Error("Expected error for a missing port")
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DPIEntry is the traffic of one application (by_app) or category (by_cat)
+// as classified by deep packet inspection. Application IDs are only unique
+// within their category.
+type DPIEntry struct {
+	App       int   `json:"app"`
+	Cat       int   `json:"cat"`
+	RxBytes   int64 `json:"rx_bytes"`
+	TxBytes   int64 `json:"tx_bytes"`
+	RxPackets int64 `json:"rx_packets"`
+	TxPackets int64 `json:"tx_packets"`
+	// KnownClients is the number of clients that used the application
+	// (site statistics only)
+	KnownClients int `json:"known_clients,omitempty"`
+}
+
+// DPIStats holds DPI traffic by application for the site or, with MAC set,
+// for one client
+type DPIStats struct {
+	MAC   string     `json:"mac,omitempty"`
+	ByApp []DPIEntry `json:"by_app"`
+}
+
+type DPIStatsResponse struct {
+	Meta Meta       `json:"meta"`
+	Data []DPIStats `json:"data"`
+}
+
+// GetClientDPI returns the DPI traffic by application of one client. It is
+// empty when traffic identification is turned off or the client has no
+// classified traffic.
+func (c *APIClient) GetClientDPI(mac string) ([]DPIEntry, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/stadpi", c.Site)
+
+	payload := map[string]interface{}{
+		"type": "by_app",
+		"macs": []string{strings.ToLower(mac)},
+	}
+
+	body, err := c.doRequestWithBody("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DPIStatsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	for _, stats := range response.Data {
+		if strings.EqualFold(stats.MAC, mac) {
+			return stats.ByApp, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetSiteDPI returns the site's DPI traffic by application. It is empty when
+// traffic identification is turned off.
+func (c *APIClient) GetSiteDPI() ([]DPIEntry, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/sitedpi", c.Site)
+
+	body, err := c.doRequestWithBody("POST", path, map[string]string{"type": "by_app"})
+	if err != nil {
+		return nil, err
+	}
+
+	var response DPIStatsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	var entries []DPIEntry
+	for _, stats := range response.Data {
+		entries = append(entries, stats.ByApp...)
+	}
+	return entries, nil
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_GetSiteDPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/sitedpi"
+		if r.Method != "POST" || r.URL.Path != expectedPath {
+			t.Errorf("Expected POST %s, got %s %s", expectedPath, r.Method, r.URL.Path)
+		}
+
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["type"] != "by_app" {
+			t.Errorf("Expected by_app statistics, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"by_app":[{"app":94,"cat":4,"rx_bytes":5000,"tx_bytes":100,"known_clients":3},{"app":5,"cat":13,"rx_bytes":10,"tx_bytes":20}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	entries, err := client.GetSiteDPI()
+
+	if err != nil {
+		t.Fatalf("GetSiteDPI() returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].App != 94 || entries[0].Cat != 4 || entries[0].RxBytes != 5000 || entries[0].KnownClients != 3 {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+}
+
+func TestAPIClient_GetClientDPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/stadpi"
+		if r.Method != "POST" || r.URL.Path != expectedPath {
+			t.Errorf("Expected POST %s, got %s %s", expectedPath, r.Method, r.URL.Path)
+		}
+
+		var payload struct {
+			Type string   `json:"type"`
+			MACs []string `json:"macs"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload.Type != "by_app" || len(payload.MACs) != 1 || payload.MACs[0] != "aa:bb:cc:dd:ee:01" {
+			t.Errorf("Unexpected payload %+v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"mac":"aa:bb:cc:dd:ee:01","by_app":[{"app":94,"cat":4,"rx_bytes":5000,"tx_bytes":100}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	entries, err := client.GetClientDPI("AA:BB:CC:DD:EE:01")
+
+	if err != nil {
+		t.Fatalf("GetClientDPI() returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].App != 94 || entries[0].RxBytes != 5000 {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is an entry of the controller's event log, from stat/event
+type Event struct {
+	ID        string `json:"_id"`
+	Key       string `json:"key"`
+	Msg       string `json:"msg"`
+	Subsystem string `json:"subsystem"`
+	// Time is in milliseconds since the epoch
+	Time   int64  `json:"time"`
+	AP     string `json:"ap,omitempty"`
+	APName string `json:"ap_name,omitempty"`
+	// User, SSID and the switch fields are set on client events such as
+	// EVT_WU_Disconnected (wireless) or EVT_LU_Disconnected (wired)
+	User   string `json:"user,omitempty"`
+	SSID   string `json:"ssid,omitempty"`
+	SW     string `json:"sw,omitempty"`
+	SWName string `json:"sw_name,omitempty"`
+	Port   int    `json:"port,omitempty"`
+	// RawChannel is a number or a string depending on controller version
+	RawChannel json.RawMessage `json:"channel,omitempty"`
+}
+
+type EventsResponse struct {
+	Meta Meta    `json:"meta"`
+	Data []Event `json:"data"`
+}
+
+// channelInMsg finds the channel in messages such as
+// "AP[f0:9f:c2:00:00:01] detected radar on channel 100"
+var channelInMsg = regexp.MustCompile(`(?i)channel\s+(\d+)`)
+
+// eventsPageSize is how many events are requested at a time; controllers
+// cap a single stat/event request at a few thousand
+const eventsPageSize = 1000
+
+// EventsScanCap is how many events QueryEvents looks at before giving up on
+// reaching the start of the time window
+const EventsScanCap = 100 * eventsPageSize
+
+// EventQuery selects events from the log. Zero bounds are open; an empty
+// Type matches every event (see FilterEvents).
+type EventQuery struct {
+	Since time.Time
+	Until time.Time
+	Type  string
+	// Limit is the most matching events to return
+	Limit int
+}
+
+// EventsCutoff tells why QueryEvents stopped before the start of the time
+// window, so older matching events may be missing
+type EventsCutoff struct {
+	// Time is when the oldest event looked at happened
+	Time time.Time
+	// Limit is set when more events matched than the query's Limit;
+	// otherwise EventsScanCap was reached
+	Limit bool
+}
+
+// ListEvents returns the newest events of the last within, at most limit,
+// fetching them a page at a time
+func (c *APIClient) ListEvents(within time.Duration, limit int) ([]Event, error) {
+	hours := int(math.Ceil(within.Hours()))
+	if hours < 1 {
+		hours = 1
+	}
+
+	var events []Event
+	for len(events) < limit {
+		size := min(eventsPageSize, limit-len(events))
+
+		page, err := c.listEventsPage(hours, len(events), size)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page...)
+
+		if len(page) < size {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// QueryEvents returns the newest events of the last within that match q,
+// newest first. Pages are fetched until q.Limit events match or the events
+// are older than q.Since, so filters never leave the result short because
+// unrelated newer events used up the limit. The cutoff is nil when the
+// whole window was searched.
+func (c *APIClient) QueryEvents(within time.Duration, q EventQuery) ([]Event, *EventsCutoff, error) {
+	hours := int(math.Ceil(within.Hours()))
+	if hours < 1 {
+		hours = 1
+	}
+
+	var matched []Event
+	var oldest time.Time
+	for scanned := 0; scanned < EventsScanCap; {
+		page, err := c.listEventsPage(hours, scanned, eventsPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		scanned += len(page)
+
+		for _, e := range page {
+			t := e.GetTime()
+			oldest = t
+			if !q.Since.IsZero() && t.Before(q.Since) {
+				return matched, nil, nil
+			}
+			if !q.Until.IsZero() && t.After(q.Until) || !e.Matches(q.Type) {
+				continue
+			}
+			if len(matched) == q.Limit {
+				return matched, &EventsCutoff{Time: matched[len(matched)-1].GetTime(), Limit: true}, nil
+			}
+			matched = append(matched, e)
+		}
+
+		if len(page) < eventsPageSize {
+			return matched, nil, nil
+		}
+	}
+
+	return matched, &EventsCutoff{Time: oldest}, nil
+}
+
+// listEventsPage returns size events of the last hours, newest first,
+// skipping the first start
+func (c *APIClient) listEventsPage(hours, start, size int) ([]Event, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/event", c.Site)
+
+	payload := map[string]interface{}{
+		"within": hours,
+		"_start": start,
+		"_limit": size,
+		"_sort":  "-time",
+	}
+
+	body, err := c.doRequestWithBody("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response EventsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// GetTime returns when the event happened
+func (e *Event) GetTime() time.Time {
+	return time.UnixMilli(e.Time)
+}
+
+// GetChannel returns the radio channel the event refers to, from the channel
+// field or else the message; 0 if there is none
+func (e *Event) GetChannel() int {
+	raw := strings.Trim(string(e.RawChannel), `"`)
+	if channel, err := strconv.Atoi(raw); err == nil {
+		return channel
+	}
+
+	if m := channelInMsg.FindStringSubmatch(e.Msg); m != nil {
+		channel, _ := strconv.Atoi(m[1])
+		return channel
+	}
+	return 0
+}
+
+// IsDFS reports whether the event is a DFS radar detection, after which the
+// AP has to leave its 5 GHz channel
+func (e *Event) IsDFS() bool {
+	key, msg := strings.ToLower(e.Key), strings.ToLower(e.Msg)
+	return strings.Contains(key, "radar") || strings.Contains(msg, "radar") || strings.Contains(msg, "dfs")
+}
+
+// FilterEvents returns the events of the given type: "dfs" for radar
+// detections, otherwise an event key such as EVT_AP_Lost_Contact (case
+// insensitive). An empty type matches every event.
+func FilterEvents(events []Event, eventType string) []Event {
+	if eventType == "" {
+		return events
+	}
+
+	var matched []Event
+	for _, e := range events {
+		if e.Matches(eventType) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// Matches reports whether the event is of the given type, as FilterEvents
+// selects them
+func (e *Event) Matches(eventType string) bool {
+	return eventType == "" || strings.EqualFold(eventType, "dfs") && e.IsDFS() || strings.EqualFold(e.Key, eventType)
+}
+
+// EventsBetween returns the events from from to to, inclusive; a zero bound
+// is open
+func EventsBetween(events []Event, from, to time.Time) []Event {
+	var matched []Event
+	for _, e := range events {
+		t := e.GetTime()
+		if !from.IsZero() && t.Before(from) || !to.IsZero() && t.After(to) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	return matched
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIClient_ListEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/stat/event"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["within"] != float64(48) || payload["_limit"] != float64(500) {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"e1","key":"EVT_AP_RadarDetected","msg":"AP[f0:9f:c2:00:00:01] detected radar","time":1768212000000,"ap":"f0:9f:c2:00:00:01","ap_name":"Office AP","channel":"100"},
+			{"_id":"e2","key":"EVT_WU_Connected","msg":"User connected","time":1768211000000}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	events, err := client.ListEvents(48*time.Hour, 500)
+
+	if err != nil {
+		t.Fatalf("ListEvents() returned error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].APName != "Office AP" || events[0].GetChannel() != 100 {
+		t.Errorf("Unexpected event %+v", events[0])
+	}
+	if !events[0].GetTime().Equal(time.UnixMilli(1768212000000)) {
+		t.Errorf("Unexpected time %v", events[0].GetTime())
+	}
+}
+
+func TestEvent_GetChannel(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    Event
+		expected int
+	}{
+		{"Number", Event{RawChannel: json.RawMessage(`52`)}, 52},
+		{"String", Event{RawChannel: json.RawMessage(`"116"`)}, 116},
+		{"Message", Event{Msg: "AP[x] detected radar on Channel 124"}, 124},
+		{"None", Event{Msg: "AP[x] was restarted"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.GetChannel(); got != tt.expected {
+				t.Errorf("GetChannel() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterEvents(t *testing.T) {
+	events := []Event{
+		{ID: "1", Key: "EVT_AP_RadarDetected"},
+		{ID: "2", Key: "EVT_AP_ChannelChanged", Msg: "DFS channel change"},
+		{ID: "3", Key: "EVT_AP_Lost_Contact"},
+	}
+
+	if dfs := FilterEvents(events, "DFS"); len(dfs) != 2 || dfs[1].ID != "2" {
+		t.Errorf("Expected 2 DFS events, got %+v", dfs)
+	}
+	if lost := FilterEvents(events, "evt_ap_lost_contact"); len(lost) != 1 || lost[0].ID != "3" {
+		t.Errorf("Expected the lost contact event, got %+v", lost)
+	}
+	if all := FilterEvents(events, ""); len(all) != 3 {
+		t.Errorf("Expected all events, got %d", len(all))
+	}
+}
+
+func TestAPIClient_ListEventsPages(t *testing.T) {
+	var starts []float64
+	wantHours := float64(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		start, size := payload["_start"].(float64), payload["_limit"].(float64)
+		starts = append(starts, start)
+		if payload["within"] != wantHours {
+			t.Errorf("Expected within to be rounded up to %v hours, got %v", wantHours, payload["within"])
+		}
+
+		// 2500 events in all
+		var data []string
+		for i := start; i < start+size && i < 2500; i++ {
+			data = append(data, fmt.Sprintf(`{"_id":"e%d","key":"EVT_WU_Connected"}`, int(i)))
+		}
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[` + strings.Join(data, ",") + `]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+
+	events, err := client.ListEvents(90*time.Minute, 5000)
+	if err != nil {
+		t.Fatalf("ListEvents() returned error: %v", err)
+	}
+	if len(events) != 2500 || events[1000].ID != "e1000" || events[2499].ID != "e2499" {
+		t.Errorf("Expected 2500 events in order, got %d", len(events))
+	}
+	if fmt.Sprint(starts) != "[0 1000 2000]" {
+		t.Errorf("Unexpected pages %v", starts)
+	}
+
+	starts = nil
+	wantHours = 1
+	events, err = client.ListEvents(time.Hour, 1200)
+	if err != nil {
+		t.Fatalf("ListEvents() returned error: %v", err)
+	}
+	if len(events) != 1200 || fmt.Sprint(starts) != "[0 1000]" {
+		t.Errorf("Expected 1200 events from 2 pages, got %d from %v", len(events), starts)
+	}
+}
+
+func TestAPIClient_QueryEvents(t *testing.T) {
+	base := time.Date(2026, 1, 12, 12, 0, 0, 0, time.UTC)
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		start, size := int(payload["_start"].(float64)), int(payload["_limit"].(float64))
+		requests++
+
+		// 2500 events a minute apart, newest first; every 1000th is rare
+		var data []string
+		for i := start; i < start+size && i < 2500; i++ {
+			key := "EVT_WU_Connected"
+			if i%1000 == 999 {
+				key = "EVT_AP_Lost_Contact"
+			}
+			data = append(data, fmt.Sprintf(`{"_id":"e%d","key":%q,"time":%d}`, i, key, base.Add(-time.Duration(i)*time.Minute).UnixMilli()))
+		}
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[` + strings.Join(data, ",") + `]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+
+	// A rare type is searched for across pages instead of within the limit
+	events, cutoff, err := client.QueryEvents(48*time.Hour, EventQuery{Type: "evt_ap_lost_contact", Limit: 10})
+	if err != nil {
+		t.Fatalf("QueryEvents() returned error: %v", err)
+	}
+	if len(events) != 2 || events[0].ID != "e999" || events[1].ID != "e1999" || cutoff != nil {
+		t.Errorf("Expected both rare events and no cutoff, got %+v, %+v", events, cutoff)
+	}
+
+	// Paging stops once events are older than since
+	requests = 0
+	events, cutoff, err = client.QueryEvents(48*time.Hour, EventQuery{Since: base.Add(-1100 * time.Minute), Until: base.Add(-1000 * time.Minute), Limit: 1000})
+	if err != nil {
+		t.Fatalf("QueryEvents() returned error: %v", err)
+	}
+	if len(events) != 101 || events[0].ID != "e1000" || cutoff != nil || requests != 2 {
+		t.Errorf("Expected 101 events from 2 pages, got %d from %d with cutoff %+v", len(events), requests, cutoff)
+	}
+
+	// The limit applies after filtering and is reported as a cutoff
+	events, cutoff, _ = client.QueryEvents(48*time.Hour, EventQuery{Until: base.Add(-10 * time.Minute), Limit: 5})
+	if len(events) != 5 || events[0].ID != "e10" || cutoff == nil || !cutoff.Limit || !cutoff.Time.Equal(events[4].GetTime()) {
+		t.Errorf("Expected 5 events with a limit cutoff, got %d, %+v", len(events), cutoff)
+	}
+}
+
+func TestEventsBetween(t *testing.T) {
+	base := time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC)
+	events := []Event{
+		{ID: "new", Time: base.Add(2 * time.Hour).UnixMilli()},
+		{ID: "mid", Time: base.Add(time.Hour).UnixMilli()},
+		{ID: "old", Time: base.UnixMilli()},
+	}
+
+	ids := func(events []Event) string {
+		var s []string
+		for _, e := range events {
+			s = append(s, e.ID)
+		}
+		return strings.Join(s, ",")
+	}
+
+	if got := ids(EventsBetween(events, base.Add(time.Hour), time.Time{})); got != "new,mid" {
+		t.Errorf("Expected new,mid, got %s", got)
+	}
+	if got := ids(EventsBetween(events, time.Time{}, base.Add(time.Hour))); got != "mid,old" {
+		t.Errorf("Expected mid,old, got %s", got)
+	}
+	if got := ids(EventsBetween(events, time.Time{}, time.Time{})); got != "new,mid,old" {
+		t.Errorf("Expected all events, got %s", got)
+	}
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Firewall group types
+const (
+	FirewallGroupAddress     = "address-group"
+	FirewallGroupIPv6Address = "ipv6-address-group"
+	FirewallGroupPort        = "port-group"
+)
+
+// FirewallGroup is an address or port group from rest/firewallgroup, used by
+// firewall rules
+type FirewallGroup struct {
+	ID           string   `json:"_id"`
+	Name         string   `json:"name"`
+	GroupType    string   `json:"group_type"`
+	GroupMembers []string `json:"group_members"`
+}
+
+type FirewallGroupsResponse struct {
+	Meta Meta            `json:"meta"`
+	Data []FirewallGroup `json:"data"`
+}
+
+func (c *APIClient) parseFirewallGroups(body []byte) ([]FirewallGroup, error) {
+	var response FirewallGroupsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// SortFirewallGroups orders firewall groups by name (case-insensitive), then ID
+func SortFirewallGroups(groups []FirewallGroup) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := strings.ToLower(groups[i].Name), strings.ToLower(groups[j].Name)
+		if a != b {
+			return a < b
+		}
+		return groups[i].ID < groups[j].ID
+	})
+}
+
+// ListFirewallGroups returns the firewall groups of the site
+func (c *APIClient) ListFirewallGroups() ([]FirewallGroup, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/firewallgroup", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseFirewallGroups(body)
+}
+
+// CreateFirewallGroup adds a firewall group and returns it
+func (c *APIClient) CreateFirewallGroup(name, groupType string, members []string) (*FirewallGroup, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/firewallgroup", c.Site)
+
+	if members == nil {
+		members = []string{}
+	}
+	fields := map[string]interface{}{
+		"name":          name,
+		"group_type":    groupType,
+		"group_members": members,
+	}
+
+	body, err := c.doRequestWithBody("POST", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := c.parseFirewallGroups(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("controller returned no record for the new firewall group")
+	}
+
+	return &groups[0], nil
+}
+
+// SetFirewallGroupMembers replaces the members of a firewall group and
+// returns the updated group
+func (c *APIClient) SetFirewallGroupMembers(group FirewallGroup, members []string) (*FirewallGroup, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/firewallgroup/%s", c.Site, group.ID)
+
+	// The controller rejects updates without name and type
+	fields := map[string]interface{}{
+		"name":          group.Name,
+		"group_type":    group.GroupType,
+		"group_members": members,
+	}
+
+	body, err := c.doRequestWithBody("PUT", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := c.parseFirewallGroups(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("controller returned no record for firewall group %s", group.ID)
+	}
+
+	return &groups[0], nil
+}
+
+// FindFirewallGroup looks a firewall group up by ID or case-insensitive name
+func FindFirewallGroup(groups []FirewallGroup, query string) (*FirewallGroup, error) {
+	for i := range groups {
+		if groups[i].ID == query {
+			return &groups[i], nil
+		}
+	}
+
+	for i := range groups {
+		if strings.EqualFold(groups[i].Name, query) {
+			return &groups[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no firewall group named %q", query)
+}
+
+// FirewallRule is a rule from rest/firewallrule. Sources and destinations are
+// given as addresses, firewall groups or networks; empty means any.
+type FirewallRule struct {
+	ID      string `json:"_id"`
+	Name    string `json:"name"`
+	Ruleset string `json:"ruleset"`
+	Index   int    `json:"rule_index"`
+	// Action is "accept", "drop" or "reject"
+	Action   string `json:"action"`
+	Enabled  bool   `json:"enabled"`
+	Protocol string `json:"protocol,omitempty"`
+	Logging  bool   `json:"logging"`
+
+	SrcAddress        string   `json:"src_address,omitempty"`
+	SrcMACAddress     string   `json:"src_mac_address,omitempty"`
+	SrcFirewallGroups []string `json:"src_firewallgroup_ids,omitempty"`
+	SrcNetworkID      string   `json:"src_networkconf_id,omitempty"`
+	SrcPort           string   `json:"src_port,omitempty"`
+
+	DstAddress        string   `json:"dst_address,omitempty"`
+	DstFirewallGroups []string `json:"dst_firewallgroup_ids,omitempty"`
+	DstNetworkID      string   `json:"dst_networkconf_id,omitempty"`
+	DstPort           string   `json:"dst_port,omitempty"`
+}
+
+type FirewallRulesResponse struct {
+	Meta Meta           `json:"meta"`
+	Data []FirewallRule `json:"data"`
+}
+
+func (c *APIClient) parseFirewallRules(body []byte) ([]FirewallRule, error) {
+	var response FirewallRulesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// SortFirewallRules orders firewall rules by ruleset, then by their index in
+// it, which is the order the gateway evaluates them in
+func SortFirewallRules(rules []FirewallRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Ruleset != rules[j].Ruleset {
+			return rules[i].Ruleset < rules[j].Ruleset
+		}
+		if rules[i].Index != rules[j].Index {
+			return rules[i].Index < rules[j].Index
+		}
+		return rules[i].ID < rules[j].ID
+	})
+}
+
+// ListFirewallRules returns the firewall rules of the site
+func (c *APIClient) ListFirewallRules() ([]FirewallRule, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/firewallrule", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseFirewallRules(body)
+}
+
+// SetFirewallRuleEnabled turns a firewall rule on or off and returns the
+// updated rule
+func (c *APIClient) SetFirewallRuleEnabled(id string, enabled bool) (*FirewallRule, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/firewallrule/%s", c.Site, id)
+
+	body, err := c.putEnabled(path, "firewall rule", id, enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := c.parseFirewallRules(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("controller returned no record for firewall rule %s", id)
+	}
+
+	return &rules[0], nil
+}
+
+// putEnabled sets the enabled field of the record at path, a what with the
+// given ID. The controller expects the complete record, so it is read first
+// and sent back with every field it has.
+func (c *APIClient) putEnabled(path, what, id string, enabled bool) ([]byte, error) {
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Meta Meta                     `json:"meta"`
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("no %s with ID %s", what, id)
+	}
+
+	record := response.Data[0]
+	record["enabled"] = enabled
+
+	return c.doRequestWithBody("PUT", path, record)
+}
+
+// FindFirewallRule looks a firewall rule up by ID or case-insensitive name.
+// Names need not be unique, so an ambiguous name is an error.
+func FindFirewallRule(rules []FirewallRule, query string) (*FirewallRule, error) {
+	for i := range rules {
+		if rules[i].ID == query {
+			return &rules[i], nil
+		}
+	}
+
+	var found *FirewallRule
+	for i := range rules {
+		if strings.EqualFold(rules[i].Name, query) {
+			if found != nil {
+				return nil, fmt.Errorf("several firewall rules are named %q, use the rule ID", query)
+			}
+			found = &rules[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no firewall rule named %q", query)
+	}
+	return found, nil
+}
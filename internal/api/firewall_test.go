@@ -0,0 +1,230 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListFirewallGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/firewallgroup"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"g1","name":"Blocklist","group_type":"address-group","group_members":["192.0.2.1","198.51.100.0/24"]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	groups, err := client.ListFirewallGroups()
+
+	if err != nil {
+		t.Fatalf("ListFirewallGroups() returned error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].GroupType != FirewallGroupAddress || len(groups[0].GroupMembers) != 2 {
+		t.Errorf("Unexpected groups %+v", groups)
+	}
+}
+
+func TestAPIClient_CreateFirewallGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/firewallgroup"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["name"] != "Web Ports" || payload["group_type"] != FirewallGroupPort {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+		if members, ok := payload["group_members"].([]interface{}); !ok || len(members) != 0 {
+			t.Errorf("Expected an empty member list, got %v", payload["group_members"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"g3","name":"Web Ports","group_type":"port-group","group_members":[]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	group, err := client.CreateFirewallGroup("Web Ports", FirewallGroupPort, nil)
+
+	if err != nil {
+		t.Fatalf("CreateFirewallGroup() returned error: %v", err)
+	}
+	if group.ID != "g3" {
+		t.Errorf("Unexpected group %+v", group)
+	}
+}
+
+func TestAPIClient_SetFirewallGroupMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/firewallgroup/g1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["name"] != "Blocklist" || payload["group_type"] != FirewallGroupAddress {
+			t.Errorf("Expected name and group type to be sent, got %v", payload)
+		}
+		if members, ok := payload["group_members"].([]interface{}); !ok || len(members) != 1 || members[0] != "192.0.2.9" {
+			t.Errorf("Unexpected members %v", payload["group_members"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"g1","name":"Blocklist","group_type":"address-group","group_members":["192.0.2.9"]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	group := FirewallGroup{ID: "g1", Name: "Blocklist", GroupType: FirewallGroupAddress}
+	updated, err := client.SetFirewallGroupMembers(group, []string{"192.0.2.9"})
+
+	if err != nil {
+		t.Fatalf("SetFirewallGroupMembers() returned error: %v", err)
+	}
+	if len(updated.GroupMembers) != 1 {
+		t.Errorf("Unexpected members %v", updated.GroupMembers)
+	}
+}
+
+func TestFindFirewallGroup(t *testing.T) {
+	groups := []FirewallGroup{{ID: "g1", Name: "Blocklist"}, {ID: "g2", Name: "Web Ports"}}
+
+	if g, err := FindFirewallGroup(groups, "blocklist"); err != nil || g.ID != "g1" {
+		t.Errorf("Expected to find Blocklist by name, got %v, %v", g, err)
+	}
+	if g, err := FindFirewallGroup(groups, "g2"); err != nil || g.Name != "Web Ports" {
+		t.Errorf("Expected to find Web Ports by ID, got %v, %v", g, err)
+	}
+	if _, err := FindFirewallGroup(groups, "Other"); err == nil {
+		t.Error("Expected error for unknown group")
+	}
+}
+
+func TestAPIClient_ListFirewallRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/firewallrule"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"r1","name":"Block IoT","ruleset":"LAN_IN","rule_index":2000,"action":"drop","enabled":true,"src_networkconf_id":"n2","dst_firewallgroup_ids":["g1"]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	rules, err := client.ListFirewallRules()
+
+	if err != nil {
+		t.Fatalf("ListFirewallRules() returned error: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Ruleset != "LAN_IN" || rules[0].Index != 2000 || rules[0].DstFirewallGroups[0] != "g1" {
+		t.Errorf("Unexpected rules %+v", rules)
+	}
+}
+
+func TestAPIClient_SetFirewallRuleEnabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/firewallrule/r1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"r1","name":"Block IoT","enabled":true,"state_established":false,"icmp_typename":""}]}`))
+		case "PUT":
+			var payload map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload["enabled"] != false {
+				t.Errorf("Expected enabled to be false, got %v", payload["enabled"])
+			}
+			if _, ok := payload["state_established"]; !ok {
+				t.Errorf("Expected the complete rule to be sent, got %v", payload)
+			}
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"r1","name":"Block IoT","enabled":false}]}`))
+		default:
+			t.Errorf("Unexpected %s request", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	rule, err := client.SetFirewallRuleEnabled("r1", false)
+
+	if err != nil {
+		t.Fatalf("SetFirewallRuleEnabled() returned error: %v", err)
+	}
+	if rule.Enabled {
+		t.Error("Expected the rule to be disabled")
+	}
+}
+
+func TestFindFirewallRule(t *testing.T) {
+	rules := []FirewallRule{{ID: "r1", Name: "Block IoT"}, {ID: "r2", Name: "Allow DNS"}, {ID: "r3", Name: "Allow DNS"}}
+
+	if r, err := FindFirewallRule(rules, "block iot"); err != nil || r.ID != "r1" {
+		t.Errorf("Expected to find Block IoT by name, got %v, %v", r, err)
+	}
+	if r, err := FindFirewallRule(rules, "r3"); err != nil || r.Name != "Allow DNS" {
+		t.Errorf("Expected to find r3 by ID, got %v, %v", r, err)
+	}
+	if _, err := FindFirewallRule(rules, "Allow DNS"); err == nil {
+		t.Error("Expected error for an ambiguous name")
+	}
+	if _, err := FindFirewallRule(rules, "Other"); err == nil {
+		t.Error("Expected error for unknown rule")
+	}
+}
+
+func TestSortFirewallGroups(t *testing.T) {
+	groups := []FirewallGroup{
+		{ID: "g3", Name: "servers"},
+		{ID: "g2", Name: "admins"},
+		{ID: "g1", Name: "Admins"},
+	}
+
+	SortFirewallGroups(groups)
+
+	expected := []string{"g1", "g2", "g3"}
+	for i, id := range expected {
+		if groups[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, groups[i].ID)
+		}
+	}
+}
+
+func TestSortFirewallRules(t *testing.T) {
+	rules := []FirewallRule{
+		{ID: "r4", Ruleset: "WAN_IN", Index: 2000},
+		{ID: "r3", Ruleset: "LAN_IN", Index: 2001},
+		{ID: "r2", Ruleset: "LAN_IN", Index: 2000},
+		{ID: "r1", Ruleset: "LAN_IN", Index: 2001},
+	}
+
+	SortFirewallRules(rules)
+
+	expected := []string{"r2", "r1", "r3", "r4"}
+	for i, id := range expected {
+		if rules[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, rules[i].ID)
+		}
+	}
+}
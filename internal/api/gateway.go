@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// gatewayTypes are the Device.Type values of gateways and consoles with a
+// built-in gateway
+var gatewayTypes = map[string]bool{"ugw": true, "udm": true, "uxg": true}
+
+// GatewayPort is a physical gateway port with its role and the networks it
+// carries
+type GatewayPort struct {
+	Port
+	// Role is the network group the port is assigned to: WAN, WAN2, LAN...
+	Role     string   `json:"role"`
+	Networks []string `json:"networks,omitempty"`
+	VLANs    []int    `json:"vlans,omitempty"`
+}
+
+// IsGateway reports whether the device routes for the site
+func (d *Device) IsGateway() bool {
+	return gatewayTypes[d.Type]
+}
+
+// FindGateway returns the gateway among devices
+func FindGateway(devices []Device) (*Device, error) {
+	for i := range devices {
+		if devices[i].IsGateway() {
+			return &devices[i], nil
+		}
+	}
+	return nil, fmt.Errorf("site has no gateway")
+}
+
+// GatewayPorts describes the ports of a gateway in index order. A port's role
+// is its ethernet override or else the network the gateway reports for it.
+// WAN ports carry the WAN network of their group (tagged if a WAN VLAN is
+// set); LAN ports carry every network of the LAN group (the default group),
+// VLAN 0 meaning untagged.
+func GatewayPorts(gateway Device, networks []Network) []GatewayPort {
+	overrides := make(map[string]string, len(gateway.EthernetOverrides))
+	for _, o := range gateway.EthernetOverrides {
+		overrides[o.IfName] = strings.ToUpper(o.NetworkGroup)
+	}
+
+	ports := make([]GatewayPort, 0, len(gateway.PortTable))
+	for _, port := range gateway.PortTable {
+		role := overrides[port.IfName]
+		if role == "" {
+			role = strings.ToUpper(port.NetworkName)
+		}
+
+		gp := GatewayPort{Port: port, Role: role}
+		for _, network := range networks {
+			if !network.Enabled {
+				continue
+			}
+
+			group := network.NetworkGroup
+			if group == "" {
+				group = "LAN"
+			}
+
+			switch {
+			case network.Purpose == "wan" && strings.EqualFold(network.WANNetworkGroup, role):
+				gp.Networks = append(gp.Networks, network.Name)
+				if network.WANVLANEnabled {
+					gp.VLANs = append(gp.VLANs, network.WANVLAN)
+				}
+			case network.Purpose != "wan" && strings.EqualFold(group, role):
+				gp.Networks = append(gp.Networks, network.Name)
+				if network.VLANEnabled {
+					gp.VLANs = append(gp.VLANs, network.VLAN)
+				} else {
+					gp.VLANs = append(gp.VLANs, 0)
+				}
+			}
+		}
+		sort.Ints(gp.VLANs)
+
+		ports = append(ports, gp)
+	}
+
+	sort.Slice(ports, func(i, j int) bool { return ports[i].Index < ports[j].Index })
+	return ports
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindGateway(t *testing.T) {
+	devices := []Device{{MAC: "aa", Type: "uap"}, {MAC: "bb", Type: "usw"}, {MAC: "cc", Type: "udm"}}
+
+	gateway, err := FindGateway(devices)
+	if err != nil {
+		t.Fatalf("FindGateway() returned error: %v", err)
+	}
+	if gateway.MAC != "cc" {
+		t.Errorf("Expected gateway cc, got %s", gateway.MAC)
+	}
+
+	if _, err := FindGateway(devices[:2]); err == nil {
+		t.Error("Expected an error for a site without gateway")
+	}
+}
+
+func TestGatewayPorts(t *testing.T) {
+	gateway := Device{
+		Type: "udm",
+		PortTable: []Port{
+			{Index: 9, IfName: "eth8", NetworkName: "wan", IP: "203.0.113.7"},
+			{Index: 1, IfName: "eth0", NetworkName: "lan"},
+			{Index: 8, IfName: "eth7", NetworkName: "lan"},
+		},
+		EthernetOverrides: []EthernetOverride{{IfName: "eth7", NetworkGroup: "wan2"}},
+	}
+	networks := []Network{
+		{Name: "Internet 1", Purpose: "wan", Enabled: true, WANNetworkGroup: "WAN", WANVLAN: 7, WANVLANEnabled: true},
+		{Name: "Internet 2", Purpose: "wan", Enabled: true, WANNetworkGroup: "WAN2"},
+		{Name: "IoT", Purpose: "corporate", Enabled: true, NetworkGroup: "LAN", VLAN: 20, VLANEnabled: true},
+		{Name: "Default", Purpose: "corporate", Enabled: true},
+		{Name: "Old", Purpose: "corporate", NetworkGroup: "LAN", VLAN: 30, VLANEnabled: true},
+	}
+
+	ports := GatewayPorts(gateway, networks)
+
+	if len(ports) != 3 {
+		t.Fatalf("Expected 3 ports, got %d", len(ports))
+	}
+
+	if ports[0].Index != 1 || ports[0].Role != "LAN" {
+		t.Errorf("Unexpected first port %+v", ports[0])
+	}
+	if !reflect.DeepEqual(ports[0].Networks, []string{"IoT", "Default"}) || !reflect.DeepEqual(ports[0].VLANs, []int{0, 20}) {
+		t.Errorf("Unexpected LAN networks %v, VLANs %v", ports[0].Networks, ports[0].VLANs)
+	}
+
+	if ports[1].Role != "WAN2" || !reflect.DeepEqual(ports[1].Networks, []string{"Internet 2"}) || len(ports[1].VLANs) != 0 {
+		t.Errorf("Expected override to WAN2, got %+v", ports[1])
+	}
+
+	if ports[2].Role != "WAN" || ports[2].IP != "203.0.113.7" || !reflect.DeepEqual(ports[2].VLANs, []int{7}) {
+		t.Errorf("Unexpected WAN port %+v", ports[2])
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Guest is an authorization of a guest client from stat/guest, made through
+// the hotspot portal, a voucher or an operator
+type Guest struct {
+	ID       string `json:"_id"`
+	MAC      string `json:"mac"`
+	Hostname string `json:"hostname,omitempty"`
+	// Start and End are in seconds since the epoch
+	Start   int64 `json:"start"`
+	End     int64 `json:"end"`
+	Expired bool  `json:"expired"`
+	// AuthorizedBy is how the guest got access, e.g. voucher, password or
+	// api
+	AuthorizedBy string `json:"authorized_by,omitempty"`
+	VoucherCode  string `json:"voucher_code,omitempty"`
+	// QosRateMaxUp and QosRateMaxDown limit the guest's bandwidth in Kbps,
+	// QosUsageQuota its traffic in MB; zero means no limit
+	QosRateMaxUp   int `json:"qos_rate_max_up,omitempty"`
+	QosRateMaxDown int `json:"qos_rate_max_down,omitempty"`
+	QosUsageQuota  int `json:"qos_usage_quota,omitempty"`
+}
+
+type GuestsResponse struct {
+	Meta Meta    `json:"meta"`
+	Data []Guest `json:"data"`
+}
+
+// GuestSpec describes a guest authorization
+type GuestSpec struct {
+	MAC string
+	// Duration is how long the guest stays authorized, in whole minutes
+	Duration time.Duration
+	// Up and Down limit bandwidth in Kbps, DataLimit traffic in MB; zero
+	// means no limit
+	Up        int
+	Down      int
+	DataLimit int
+}
+
+// SortGuests orders guests by the end of their authorization, those expiring
+// first first, then by ID
+func SortGuests(guests []Guest) {
+	sort.SliceStable(guests, func(i, j int) bool {
+		if guests[i].End != guests[j].End {
+			return guests[i].End < guests[j].End
+		}
+		return guests[i].ID < guests[j].ID
+	})
+}
+
+// ListAuthorizedGuests returns the guests whose authorization has not run out
+// at now
+func (c *APIClient) ListAuthorizedGuests(now time.Time) ([]Guest, error) {
+	body, err := c.FetchSiteRaw("stat/guest", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GuestsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	var guests []Guest
+	for _, g := range response.Data {
+		if !g.Expired && g.GetEnd().After(now) {
+			guests = append(guests, g)
+		}
+	}
+
+	return guests, nil
+}
+
+// AuthorizeGuest lets a guest client onto the guest network without going
+// through the portal
+func (c *APIClient) AuthorizeGuest(spec GuestSpec) error {
+	payload := map[string]interface{}{
+		"cmd":     "authorize-guest",
+		"mac":     spec.MAC,
+		"minutes": int(spec.Duration / time.Minute),
+	}
+	if spec.Up > 0 {
+		payload["up"] = spec.Up
+	}
+	if spec.Down > 0 {
+		payload["down"] = spec.Down
+	}
+	if spec.DataLimit > 0 {
+		payload["bytes"] = spec.DataLimit
+	}
+	return c.sendCommand("stamgr", payload)
+}
+
+// UnauthorizeGuest ends the authorization of a guest client, which then has
+// to go through the portal again
+func (c *APIClient) UnauthorizeGuest(mac string) error {
+	return c.sendCommand("stamgr", map[string]string{"cmd": "unauthorize-guest", "mac": mac})
+}
+
+// GetEnd returns when the guest's authorization runs out
+func (g *Guest) GetEnd() time.Time {
+	return time.Unix(g.End, 0)
+}
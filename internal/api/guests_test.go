@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIClient_ListAuthorizedGuests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/guest"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"g1","mac":"aa:bb:cc:dd:ee:01","start":1700000000,"end":1700086400,"expired":false},
+			{"_id":"g2","mac":"aa:bb:cc:dd:ee:02","start":1700000000,"end":1700003600,"expired":false},
+			{"_id":"g3","mac":"aa:bb:cc:dd:ee:03","start":1600000000,"end":1600003600,"expired":true},
+			{"_id":"g4","mac":"aa:bb:cc:dd:ee:04","start":1700000000,"end":1700001000,"expired":false}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	guests, err := client.ListAuthorizedGuests(time.Unix(1700002000, 0))
+
+	if err != nil {
+		t.Fatalf("ListAuthorizedGuests() returned error: %v", err)
+	}
+	if len(guests) != 2 || guests[0].ID != "g1" || guests[1].ID != "g2" {
+		t.Errorf("Expected the unexpired guests, got %+v", guests)
+	}
+}
+
+func TestAPIClient_AuthorizeGuest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/stamgr"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "authorize-guest" || payload["mac"] != "aa:bb:cc:dd:ee:ff" || payload["minutes"] != float64(120) {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+		if payload["bytes"] != float64(500) {
+			t.Errorf("Expected data limit in payload %v", payload)
+		}
+		if _, ok := payload["up"]; ok {
+			t.Errorf("Expected no upload limit in payload %v", payload)
+		}
+
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	err := client.AuthorizeGuest(GuestSpec{MAC: "aa:bb:cc:dd:ee:ff", Duration: 2 * time.Hour, DataLimit: 500})
+	if err != nil {
+		t.Fatalf("AuthorizeGuest() returned error: %v", err)
+	}
+}
+
+func TestAPIClient_UnauthorizeGuest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "unauthorize-guest" || payload["mac"] != "aa:bb:cc:dd:ee:ff" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.UnauthorizeGuest("aa:bb:cc:dd:ee:ff"); err != nil {
+		t.Fatalf("UnauthorizeGuest() returned error: %v", err)
+	}
+}
+
+func TestSortGuests(t *testing.T) {
+	guests := []Guest{
+		{ID: "g2", End: 1700086400},
+		{ID: "g3", End: 1700003600},
+		{ID: "g1", End: 1700086400},
+	}
+
+	SortGuests(guests)
+
+	expected := []string{"g3", "g1", "g2"}
+	for i, id := range expected {
+		if guests[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, guests[i].ID)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HotspotOperator is an account from rest/hotspotop that can sign in to the
+// hotspot manager to issue vouchers and authorize guests, but not administer
+// the site
+type HotspotOperator struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+	// Password is only returned to API keys with admin rights
+	Password string `json:"x_password,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+type HotspotOperatorsResponse struct {
+	Meta Meta              `json:"meta"`
+	Data []HotspotOperator `json:"data"`
+}
+
+func (c *APIClient) parseHotspotOperators(body []byte) ([]HotspotOperator, error) {
+	var response HotspotOperatorsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// SortHotspotOperators orders hotspot operators by name (case-insensitive),
+// then ID
+func SortHotspotOperators(operators []HotspotOperator) {
+	sort.SliceStable(operators, func(i, j int) bool {
+		a, b := strings.ToLower(operators[i].Name), strings.ToLower(operators[j].Name)
+		if a != b {
+			return a < b
+		}
+		return operators[i].ID < operators[j].ID
+	})
+}
+
+// ListHotspotOperators returns the site's hotspot operators
+func (c *APIClient) ListHotspotOperators() ([]HotspotOperator, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/hotspotop", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseHotspotOperators(body)
+}
+
+// CreateHotspotOperator adds a hotspot operator and returns it
+func (c *APIClient) CreateHotspotOperator(operator HotspotOperator) (*HotspotOperator, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/hotspotop", c.Site)
+
+	fields := map[string]interface{}{
+		"name":       operator.Name,
+		"x_password": operator.Password,
+		"note":       operator.Note,
+	}
+
+	body, err := c.doRequestWithBody("POST", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	operators, err := c.parseHotspotOperators(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(operators) == 0 {
+		return nil, fmt.Errorf("controller returned no record for the new hotspot operator")
+	}
+
+	return &operators[0], nil
+}
+
+// DeleteHotspotOperator removes a hotspot operator
+func (c *APIClient) DeleteHotspotOperator(id string) error {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/hotspotop/%s", c.Site, id)
+
+	body, err := c.doRequest("DELETE", path)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.parseHotspotOperators(body)
+	return err
+}
+
+// FindHotspotOperator looks a hotspot operator up by ID or case-insensitive
+// name
+func FindHotspotOperator(operators []HotspotOperator, query string) (*HotspotOperator, error) {
+	for i := range operators {
+		if operators[i].ID == query || strings.EqualFold(operators[i].Name, query) {
+			return &operators[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no hotspot operator named %q", query)
+}
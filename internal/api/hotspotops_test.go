@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListHotspotOperators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/hotspotop"
+		if r.Method != "GET" || r.URL.Path != expectedPath {
+			t.Errorf("Expected GET %s, got %s %s", expectedPath, r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"op2","name":"reception","x_password":"secret","note":"Front desk"},
+			{"_id":"op1","name":"Bar"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	operators, err := client.ListHotspotOperators()
+
+	if err != nil {
+		t.Fatalf("ListHotspotOperators() returned error: %v", err)
+	}
+	if len(operators) != 2 || operators[1].ID != "op1" || operators[0].Password != "secret" || operators[0].Note != "Front desk" {
+		t.Errorf("Unexpected operators %+v", operators)
+	}
+}
+
+func TestAPIClient_CreateHotspotOperator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/proxy/network/api/s/default/rest/hotspotop" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["name"] != "reception" || payload["x_password"] != "secret" || payload["note"] != "Front desk" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+		if _, ok := payload["_id"]; ok {
+			t.Errorf("Expected no ID to be sent, got %v", payload)
+		}
+
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"op1","name":"reception","note":"Front desk"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	operator, err := client.CreateHotspotOperator(HotspotOperator{Name: "reception", Password: "secret", Note: "Front desk"})
+
+	if err != nil {
+		t.Fatalf("CreateHotspotOperator() returned error: %v", err)
+	}
+	if operator.ID != "op1" {
+		t.Errorf("Expected the created operator, got %+v", operator)
+	}
+}
+
+func TestAPIClient_DeleteHotspotOperator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/hotspotop/op1"
+		if r.Method != "DELETE" || r.URL.Path != expectedPath {
+			t.Errorf("Expected DELETE %s, got %s %s", expectedPath, r.Method, r.URL.Path)
+		}
+
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.DeleteHotspotOperator("op1"); err != nil {
+		t.Fatalf("DeleteHotspotOperator() returned error: %v", err)
+	}
+}
+
+func TestFindHotspotOperator(t *testing.T) {
+	operators := []HotspotOperator{{ID: "op1", Name: "Bar"}, {ID: "op2", Name: "reception"}}
+
+	for _, query := range []string{"op2", "reception", "Reception"} {
+		op, err := FindHotspotOperator(operators, query)
+		if err != nil || op.ID != "op2" {
+			t.Errorf("FindHotspotOperator(%q) = %v, %v; want op2", query, op, err)
+		}
+	}
+	if _, err := FindHotspotOperator(operators, "lobby"); err == nil {
+		t.Error("Expected error for unknown operator")
+	}
+}
+
+func TestSortHotspotOperators(t *testing.T) {
+	operators := []HotspotOperator{
+		{ID: "op3", Name: "reception"},
+		{ID: "op2", Name: "bar"},
+		{ID: "op1", Name: "Bar"},
+	}
+
+	SortHotspotOperators(operators)
+
+	expected := []string{"op1", "op2", "op3"}
+	for i, id := range expected {
+		if operators[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, operators[i].ID)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import "time"
+
+// InventoryItem is a device as recorded in a hardware inventory (asset
+// register)
+type InventoryItem struct {
+	Site     string `json:"site"`
+	Name     string `json:"name"`
+	Model    string `json:"model"`
+	Type     string `json:"type"`
+	Serial   string `json:"serial"`
+	MAC      string `json:"mac"`
+	Firmware string `json:"firmware"`
+	// Adopted is the adoption date (YYYY-MM-DD), empty if the controller
+	// does not report it
+	Adopted string `json:"adopted"`
+	LTS     bool   `json:"lts"`
+	EOL     bool   `json:"eol"`
+}
+
+// Inventory lists the devices of a site as inventory items, in the order of
+// SortDevices
+func Inventory(site string, devices []Device) []InventoryItem {
+	sorted := append([]Device(nil), devices...)
+	SortDevices(sorted)
+
+	items := make([]InventoryItem, len(sorted))
+	for i, device := range sorted {
+		adopted := ""
+		if device.AdoptedAt > 0 {
+			adopted = time.Unix(device.AdoptedAt, 0).UTC().Format(time.DateOnly)
+		}
+
+		items[i] = InventoryItem{
+			Site:     site,
+			Name:     device.GetDisplayName(),
+			Model:    device.Model,
+			Type:     device.Type,
+			Serial:   device.Serial,
+			MAC:      device.MAC,
+			Firmware: device.Version,
+			Adopted:  adopted,
+			LTS:      device.ModelInLTS,
+			EOL:      device.ModelInEOL,
+		}
+	}
+	return items
+}
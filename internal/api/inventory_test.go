@@ -0,0 +1,30 @@
+package api
+
+import "testing"
+
+func TestInventory(t *testing.T) {
+	devices := []Device{
+		{MAC: "f0:9f:c2:00:00:02", Name: "Switch", Model: "USW24", Type: "usw", Serial: "F09FC2000002", Version: "6.5.59"},
+		{MAC: "f0:9f:c2:00:00:01", Name: "Office AP", Model: "U7PG2", Type: "uap", Serial: "F09FC2000001", Version: "6.6.77", AdoptedAt: 1700000000, ModelInEOL: true},
+	}
+
+	items := Inventory("default", devices)
+
+	if len(items) != 2 || items[0].Name != "Office AP" || items[1].Name != "Switch" {
+		t.Fatalf("Expected the devices sorted by name, got %+v", items)
+	}
+
+	ap := items[0]
+	if ap.Site != "default" || ap.Serial != "F09FC2000001" || ap.Firmware != "6.6.77" || !ap.EOL || ap.LTS {
+		t.Errorf("Unexpected item %+v", ap)
+	}
+	if ap.Adopted != "2023-11-14" {
+		t.Errorf("Expected adoption date 2023-11-14, got %q", ap.Adopted)
+	}
+	if items[1].Adopted != "" {
+		t.Errorf("Expected no adoption date, got %q", items[1].Adopted)
+	}
+	if devices[0].Name != "Switch" {
+		t.Errorf("Expected the devices to be left in order")
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IsMAC reports whether s is a valid 6-octet MAC address in any of the
+// notations NormalizeMAC accepts
+func IsMAC(s string) bool {
+	_, ok := NormalizeMAC(s)
+	return ok
+}
+
+// NormalizeMAC returns a 6-octet MAC address in the lower-case, colon
+// separated form the controller uses, e.g. aa-bb-cc-dd-ee-ff and
+// aabb.ccdd.eeff both become aa:bb:cc:dd:ee:ff. Longer hardware addresses
+// such as EUI-64 are rejected.
+func NormalizeMAC(s string) (string, bool) {
+	hw, err := net.ParseMAC(s)
+	if err != nil || len(hw) != 6 {
+		return "", false
+	}
+	return hw.String(), true
+}
+
+// FindClient looks a client up by MAC address, alias or hostname. Names are
+// matched case-insensitively and must identify exactly one client.
+func FindClient(clients []Client, query string) (*Client, error) {
+	if mac, ok := NormalizeMAC(query); ok {
+		for i := range clients {
+			if strings.EqualFold(clients[i].MAC, mac) {
+				return &clients[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no client with MAC %s", query)
+	}
+
+	var matches []*Client
+	for i := range clients {
+		if strings.EqualFold(clients[i].Name, query) || strings.EqualFold(clients[i].Hostname, query) {
+			matches = append(matches, &clients[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no client named %q", query)
+	case 1:
+		return matches[0], nil
+	default:
+		macs := make([]string, len(matches))
+		for i, m := range matches {
+			macs[i] = m.MAC
+		}
+		return nil, fmt.Errorf("%q matches %d clients (%s); use a MAC address", query, len(matches), strings.Join(macs, ", "))
+	}
+}
+
+// FindUser looks a known client up by MAC address, alias or hostname, like
+// FindClient but including clients that are not connected
+func FindUser(users []User, query string) (*User, error) {
+	if mac, ok := NormalizeMAC(query); ok {
+		for i := range users {
+			if strings.EqualFold(users[i].MAC, mac) {
+				return &users[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no known client with MAC %s", query)
+	}
+
+	var matches []*User
+	for i := range users {
+		if strings.EqualFold(users[i].Name, query) || strings.EqualFold(users[i].Hostname, query) {
+			matches = append(matches, &users[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no known client named %q", query)
+	case 1:
+		return matches[0], nil
+	default:
+		macs := make([]string, len(matches))
+		for i, m := range matches {
+			macs[i] = m.MAC
+		}
+		return nil, fmt.Errorf("%q matches %d clients (%s); use a MAC address", query, len(matches), strings.Join(macs, ", "))
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsMAC(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"aa:bb:cc:dd:ee:ff", true},
+		{"AA-BB-CC-DD-EE-FF", true},
+		{"02:00:5e:10:00:00:00:01", false},
+		{"living-room-tv", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsMAC(tt.value); got != tt.expected {
+			t.Errorf("IsMAC(%q) = %v, expected %v", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestNormalizeMAC(t *testing.T) {
+	for _, value := range []string{"aa:bb:cc:dd:ee:ff", "AA:BB:CC:DD:EE:FF", "aa-bb-cc-dd-ee-ff", "aabb.ccdd.eeff"} {
+		got, ok := NormalizeMAC(value)
+		if !ok || got != "aa:bb:cc:dd:ee:ff" {
+			t.Errorf("NormalizeMAC(%q) = %q, %v, expected aa:bb:cc:dd:ee:ff", value, got, ok)
+		}
+	}
+
+	if _, ok := NormalizeMAC("00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01"); ok {
+		t.Error("Expected a 20-octet IPoIB address to be rejected")
+	}
+}
+
+func TestFindClient(t *testing.T) {
+	clients := []Client{
+		{MAC: "aa:bb:cc:dd:ee:01", Name: "Living Room TV", Hostname: "tv"},
+		{MAC: "aa:bb:cc:dd:ee:02", Hostname: "nas"},
+		{MAC: "aa:bb:cc:dd:ee:03", Hostname: "android"},
+		{MAC: "aa:bb:cc:dd:ee:04", Hostname: "android"},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedMAC string
+		wantErr     string
+	}{
+		{"MAC", "aa:bb:cc:dd:ee:02", "aa:bb:cc:dd:ee:02", ""},
+		{"MAC different case", "AA:BB:CC:DD:EE:02", "aa:bb:cc:dd:ee:02", ""},
+		{"MAC with dashes", "aa-bb-cc-dd-ee-02", "aa:bb:cc:dd:ee:02", ""},
+		{"Alias", "living room tv", "aa:bb:cc:dd:ee:01", ""},
+		{"Hostname", "NAS", "aa:bb:cc:dd:ee:02", ""},
+		{"Unknown MAC", "aa:bb:cc:dd:ee:99", "", "no client with MAC"},
+		{"Unknown name", "printer", "", "no client named"},
+		{"Ambiguous name", "android", "", "matches 2 clients"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := FindClient(clients, tt.query)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("FindClient returned error: %v", err)
+			}
+			if client.MAC != tt.expectedMAC {
+				t.Errorf("Expected MAC '%s', got '%s'", tt.expectedMAC, client.MAC)
+			}
+		})
+	}
+}
+
+func TestFindUser(t *testing.T) {
+	users := []User{
+		{MAC: "aa:bb:cc:dd:ee:01", Name: "Workstation"},
+		{MAC: "aa:bb:cc:dd:ee:02", Hostname: "nas"},
+	}
+
+	if user, err := FindUser(users, "workstation"); err != nil || user.MAC != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("Expected to find Workstation by alias, got %v, %v", user, err)
+	}
+	if user, err := FindUser(users, "AA:BB:CC:DD:EE:02"); err != nil || user.Hostname != "nas" {
+		t.Errorf("Expected to find nas by MAC, got %v, %v", user, err)
+	}
+	if _, err := FindUser(users, "printer"); err == nil || !strings.Contains(err.Error(), "no known client named") {
+		t.Errorf("Expected unknown name error, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Network is a network/VLAN configuration from rest/networkconf
+type Network struct {
+	ID                string `json:"_id"`
+	Name              string `json:"name"`
+	Purpose           string `json:"purpose"`
+	Enabled           bool   `json:"enabled"`
+	NetworkGroup      string `json:"networkgroup,omitempty"`
+	VLAN              int    `json:"vlan,omitempty"`
+	VLANEnabled       bool   `json:"vlan_enabled"`
+	IPSubnet          string `json:"ip_subnet,omitempty"`
+	DHCPEnabled       bool   `json:"dhcpd_enabled"`
+	DHCPStart         string `json:"dhcpd_start,omitempty"`
+	DHCPStop          string `json:"dhcpd_stop,omitempty"`
+	DomainName        string `json:"domain_name,omitempty"`
+	IGMPSnooping      bool   `json:"igmp_snooping"`
+	MDNSEnabled       bool   `json:"mdns_enabled"`
+	IPv6InterfaceType string `json:"ipv6_interface_type,omitempty"`
+	WANNetworkGroup   string `json:"wan_networkgroup,omitempty"`
+	WANVLAN           int    `json:"wan_vlan,omitempty"`
+	WANVLANEnabled    bool   `json:"wan_vlan_enabled"`
+}
+
+type NetworksResponse struct {
+	Meta Meta      `json:"meta"`
+	Data []Network `json:"data"`
+}
+
+func (c *APIClient) parseNetworks(body []byte) ([]Network, error) {
+	var response NetworksResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// SortNetworks orders networks by name (case-insensitive), then ID
+func SortNetworks(networks []Network) {
+	sort.SliceStable(networks, func(i, j int) bool {
+		a, b := strings.ToLower(networks[i].Name), strings.ToLower(networks[j].Name)
+		if a != b {
+			return a < b
+		}
+		return networks[i].ID < networks[j].ID
+	})
+}
+
+// ListNetworks returns all configured networks
+func (c *APIClient) ListNetworks() ([]Network, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/networkconf", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseNetworks(body)
+}
+
+// UpdateNetwork changes only the given fields of a network and returns the
+// updated configuration
+func (c *APIClient) UpdateNetwork(id string, fields map[string]interface{}) (*Network, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/networkconf/%s", c.Site, id)
+
+	body, err := c.doRequestWithBody("PUT", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	networks, err := c.parseNetworks(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("controller returned no record for network %s", id)
+	}
+
+	return &networks[0], nil
+}
+
+// CreateNetwork adds a network and returns the new configuration
+func (c *APIClient) CreateNetwork(fields map[string]interface{}) (*Network, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/networkconf", c.Site)
+
+	body, err := c.doRequestWithBody("POST", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	networks, err := c.parseNetworks(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("controller returned no record for the new network")
+	}
+
+	return &networks[0], nil
+}
+
+// FindNetwork looks a network up by ID or case-insensitive name
+func FindNetwork(networks []Network, query string) (*Network, error) {
+	for i := range networks {
+		if networks[i].ID == query {
+			return &networks[i], nil
+		}
+	}
+
+	for i := range networks {
+		if strings.EqualFold(networks[i].Name, query) {
+			return &networks[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no network named %q", query)
+}
+
+// JoinNetworkNames sets NetworkName on every client whose network ID is one of
+// networks
+func JoinNetworkNames(clients []Client, networks []Network) {
+	names := make(map[string]string, len(networks))
+	for _, n := range networks {
+		names[n.ID] = n.Name
+	}
+
+	for i := range clients {
+		if name, ok := names[clients[i].NetworkID]; ok {
+			clients[i].NetworkName = name
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/networkconf"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"n1","name":"IoT","purpose":"corporate","vlan":30,"vlan_enabled":true,"ip_subnet":"10.0.30.1/24","mdns_enabled":true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	networks, err := client.ListNetworks()
+
+	if err != nil {
+		t.Fatalf("ListNetworks() returned error: %v", err)
+	}
+	if len(networks) != 1 {
+		t.Fatalf("Expected 1 network, got %d", len(networks))
+	}
+	if networks[0].VLAN != 30 || networks[0].IPSubnet != "10.0.30.1/24" || !networks[0].MDNSEnabled {
+		t.Errorf("Unexpected network %+v", networks[0])
+	}
+}
+
+func TestAPIClient_UpdateNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/networkconf/n1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["igmp_snooping"] != true {
+			t.Errorf("Expected igmp_snooping to be sent, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"n1","name":"IoT","igmp_snooping":true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	network, err := client.UpdateNetwork("n1", map[string]interface{}{"igmp_snooping": true})
+
+	if err != nil {
+		t.Fatalf("UpdateNetwork() returned error: %v", err)
+	}
+	if !network.IGMPSnooping {
+		t.Error("Expected IGMP snooping to be enabled")
+	}
+}
+
+func TestAPIClient_CreateNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/networkconf"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["name"] != "Cameras" || payload["vlan"] != float64(40) {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"n5","name":"Cameras","vlan":40,"vlan_enabled":true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	network, err := client.CreateNetwork(map[string]interface{}{"name": "Cameras", "vlan": 40})
+
+	if err != nil {
+		t.Fatalf("CreateNetwork() returned error: %v", err)
+	}
+	if network.ID != "n5" || network.VLAN != 40 {
+		t.Errorf("Unexpected network %+v", network)
+	}
+}
+
+func TestFindNetwork(t *testing.T) {
+	networks := []Network{{ID: "n1", Name: "LAN"}, {ID: "n2", Name: "IoT"}}
+
+	if n, err := FindNetwork(networks, "iot"); err != nil || n.ID != "n2" {
+		t.Errorf("Expected to find IoT by name, got %v, %v", n, err)
+	}
+	if n, err := FindNetwork(networks, "n1"); err != nil || n.Name != "LAN" {
+		t.Errorf("Expected to find LAN by ID, got %v, %v", n, err)
+	}
+	if _, err := FindNetwork(networks, "Guest"); err == nil {
+		t.Error("Expected error for unknown network")
+	}
+}
+
+func TestJoinNetworkNames(t *testing.T) {
+	clients := []Client{
+		{MAC: "aa:bb:cc:dd:ee:01", NetworkID: "n2"},
+		{MAC: "aa:bb:cc:dd:ee:02", NetworkID: "gone", Network: "Old"},
+	}
+
+	JoinNetworkNames(clients, []Network{{ID: "n1", Name: "LAN"}, {ID: "n2", Name: "IoT"}})
+
+	if clients[0].NetworkName != "IoT" || clients[0].GetNetworkName() != "IoT" {
+		t.Errorf("Expected IoT, got %+v", clients[0])
+	}
+	if clients[1].NetworkName != "" || clients[1].GetNetworkName() != "Old" {
+		t.Errorf("Expected the controller's network name as fallback, got %+v", clients[1])
+	}
+}
+
+func TestSortNetworks(t *testing.T) {
+	networks := []Network{
+		{ID: "n3", Name: "lan"},
+		{ID: "n1", Name: "IoT"},
+		{ID: "n2", Name: "LAN"},
+	}
+
+	SortNetworks(networks)
+
+	expected := []string{"n1", "n2", "n3"}
+	for i, id := range expected {
+		if networks[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, networks[i].ID)
+		}
+	}
+}
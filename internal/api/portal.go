@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+)
+
+// PortalFile is an image uploaded for the hotspot portal, such as its logo
+type PortalFile struct {
+	ID       string `json:"_id"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
+
+// UploadPortalFile uploads an image for the hotspot portal the way the
+// portal editor does, as a multipart form with a single file field. The
+// returned file ID is what the portal settings refer to.
+func (c *APIClient) UploadPortalFile(filename string, data []byte) (*PortalFile, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/upload/portal-file", c.Site)
+
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	body, err := c.doRequestWithReader("POST", path, &form, writer.FormDataContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Meta Meta         `json:"meta"`
+		Data []PortalFile `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 || response.Data[0].ID == "" {
+		return nil, fmt.Errorf("controller returned no uploaded file")
+	}
+	return &response.Data[0], nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_UploadPortalFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/upload/portal-file"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("Expected a multipart file field: %v", err)
+		}
+		defer file.Close()
+
+		if header.Filename != "logo.png" {
+			t.Errorf("Expected filename 'logo.png', got '%s'", header.Filename)
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != "png-data" {
+			t.Errorf("Expected file content 'png-data', got '%s'", data)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"f1","filename":"logo.png","url":"/dl/portal/f1.png"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	var responses, exchanges int
+	client.OnResponse = func(method, path string, body []byte) { responses++ }
+	client.OnExchange = func(Exchange) { exchanges++ }
+	file, err := client.UploadPortalFile("logo.png", []byte("png-data"))
+
+	if err != nil {
+		t.Fatalf("UploadPortalFile() returned error: %v", err)
+	}
+	if file.ID != "f1" {
+		t.Errorf("Expected ID 'f1', got '%s'", file.ID)
+	}
+	if responses != 1 || exchanges != 1 {
+		t.Errorf("Expected the response and exchange hooks to run once, got %d and %d", responses, exchanges)
+	}
+}
+
+func TestAPIClient_UploadPortalFile_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"meta":{"rc":"error","msg":"api.err.InvalidFileType"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.UploadPortalFile("logo.txt", []byte("text")); err == nil {
+		t.Error("Expected error for rejected upload")
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Port forward protocols
+const (
+	ProtoTCP    = "tcp"
+	ProtoUDP    = "udp"
+	ProtoTCPUDP = "tcp_udp"
+)
+
+// PortForward forwards a port of the WAN to a host on the LAN, from
+// rest/portforward
+type PortForward struct {
+	ID      string `json:"_id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Interface is the WAN the forward listens on: "wan", "wan2" or "both"
+	Interface string `json:"pfwd_interface,omitempty"`
+	// Src limits the forward to a source address; "any" or empty for all
+	Src string `json:"src,omitempty"`
+	// DstPort and FwdPort are a port, a range ("8000-8100") or a comma
+	// separated list of both
+	DstPort string `json:"dst_port"`
+	FwdIP   string `json:"fwd"`
+	FwdPort string `json:"fwd_port"`
+	// Proto is "tcp", "udp" or "tcp_udp"
+	Proto string `json:"proto"`
+	Log   bool   `json:"log"`
+}
+
+type PortForwardsResponse struct {
+	Meta Meta          `json:"meta"`
+	Data []PortForward `json:"data"`
+}
+
+func (c *APIClient) parsePortForwards(body []byte) ([]PortForward, error) {
+	var response PortForwardsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// SortPortForwards orders port forwards by name (case-insensitive), then ID
+func SortPortForwards(forwards []PortForward) {
+	sort.SliceStable(forwards, func(i, j int) bool {
+		a, b := strings.ToLower(forwards[i].Name), strings.ToLower(forwards[j].Name)
+		if a != b {
+			return a < b
+		}
+		return forwards[i].ID < forwards[j].ID
+	})
+}
+
+// ListPortForwards returns the port forwards of the site
+func (c *APIClient) ListPortForwards() ([]PortForward, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/portforward", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parsePortForwards(body)
+}
+
+// CreatePortForward adds a port forward and returns it
+func (c *APIClient) CreatePortForward(forward PortForward) (*PortForward, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/portforward", c.Site)
+
+	fields := map[string]interface{}{
+		"name":           forward.Name,
+		"enabled":        forward.Enabled,
+		"pfwd_interface": forward.Interface,
+		"src":            forward.Src,
+		"dst_port":       forward.DstPort,
+		"fwd":            forward.FwdIP,
+		"fwd_port":       forward.FwdPort,
+		"proto":          forward.Proto,
+		"log":            forward.Log,
+	}
+
+	body, err := c.doRequestWithBody("POST", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	forwards, err := c.parsePortForwards(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(forwards) == 0 {
+		return nil, fmt.Errorf("controller returned no record for the new port forward")
+	}
+
+	return &forwards[0], nil
+}
+
+// SetPortForwardEnabled turns a port forward on or off and returns the
+// updated forward
+func (c *APIClient) SetPortForwardEnabled(id string, enabled bool) (*PortForward, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/portforward/%s", c.Site, id)
+
+	body, err := c.putEnabled(path, "port forward", id, enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	forwards, err := c.parsePortForwards(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(forwards) == 0 {
+		return nil, fmt.Errorf("controller returned no record for port forward %s", id)
+	}
+
+	return &forwards[0], nil
+}
+
+// DeletePortForward removes a port forward
+func (c *APIClient) DeletePortForward(id string) error {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/portforward/%s", c.Site, id)
+
+	body, err := c.doRequest("DELETE", path)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.parsePortForwards(body)
+	return err
+}
+
+// FindPortForward looks a port forward up by ID or case-insensitive name.
+// Names need not be unique, so an ambiguous name is an error.
+func FindPortForward(forwards []PortForward, query string) (*PortForward, error) {
+	for i := range forwards {
+		if forwards[i].ID == query {
+			return &forwards[i], nil
+		}
+	}
+
+	var found *PortForward
+	for i := range forwards {
+		if strings.EqualFold(forwards[i].Name, query) {
+			if found != nil {
+				return nil, fmt.Errorf("several port forwards are named %q, use the forward ID", query)
+			}
+			found = &forwards[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no port forward named %q", query)
+	}
+	return found, nil
+}
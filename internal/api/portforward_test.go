@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListPortForwards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/portforward"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"pf1","name":"Web","enabled":true,"pfwd_interface":"wan","src":"any","dst_port":"443","fwd":"192.168.1.10","fwd_port":"8443","proto":"tcp"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	forwards, err := client.ListPortForwards()
+
+	if err != nil {
+		t.Fatalf("ListPortForwards() returned error: %v", err)
+	}
+	if len(forwards) != 1 || forwards[0].FwdIP != "192.168.1.10" || forwards[0].FwdPort != "8443" || forwards[0].Proto != ProtoTCP {
+		t.Errorf("Unexpected port forwards %+v", forwards)
+	}
+}
+
+func TestAPIClient_CreatePortForward(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/portforward"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["name"] != "Minecraft" || payload["fwd"] != "192.168.1.20" || payload["proto"] != ProtoTCPUDP || payload["enabled"] != true {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+		if _, ok := payload["_id"]; ok {
+			t.Errorf("Expected no ID to be sent, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"pf2","name":"Minecraft","enabled":true,"dst_port":"25565","fwd":"192.168.1.20","fwd_port":"25565","proto":"tcp_udp"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	forward, err := client.CreatePortForward(PortForward{
+		Name: "Minecraft", Enabled: true, Interface: "wan", Src: "any",
+		DstPort: "25565", FwdIP: "192.168.1.20", FwdPort: "25565", Proto: ProtoTCPUDP,
+	})
+
+	if err != nil {
+		t.Fatalf("CreatePortForward() returned error: %v", err)
+	}
+	if forward.ID != "pf2" {
+		t.Errorf("Unexpected port forward %+v", forward)
+	}
+}
+
+func TestAPIClient_SetPortForwardEnabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/portforward/pf1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"pf1","name":"Web","enabled":false,"destination_ip":"any"}]}`))
+		case "PUT":
+			var payload map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload["enabled"] != true {
+				t.Errorf("Expected enabled to be true, got %v", payload["enabled"])
+			}
+			if _, ok := payload["destination_ip"]; !ok {
+				t.Errorf("Expected the complete forward to be sent, got %v", payload)
+			}
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"pf1","name":"Web","enabled":true}]}`))
+		default:
+			t.Errorf("Unexpected %s request", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	forward, err := client.SetPortForwardEnabled("pf1", true)
+
+	if err != nil {
+		t.Fatalf("SetPortForwardEnabled() returned error: %v", err)
+	}
+	if !forward.Enabled {
+		t.Error("Expected the forward to be enabled")
+	}
+}
+
+func TestAPIClient_DeletePortForward(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/portforward/pf1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.DeletePortForward("pf1"); err != nil {
+		t.Fatalf("DeletePortForward() returned error: %v", err)
+	}
+}
+
+func TestFindPortForward(t *testing.T) {
+	forwards := []PortForward{{ID: "pf1", Name: "Web"}, {ID: "pf2", Name: "Game"}, {ID: "pf3", Name: "Game"}}
+
+	if f, err := FindPortForward(forwards, "web"); err != nil || f.ID != "pf1" {
+		t.Errorf("Expected to find Web by name, got %v, %v", f, err)
+	}
+	if f, err := FindPortForward(forwards, "pf3"); err != nil || f.Name != "Game" {
+		t.Errorf("Expected to find pf3 by ID, got %v, %v", f, err)
+	}
+	if _, err := FindPortForward(forwards, "Game"); err == nil {
+		t.Error("Expected error for an ambiguous name")
+	}
+	if _, err := FindPortForward(forwards, "Other"); err == nil {
+		t.Error("Expected error for unknown port forward")
+	}
+}
+
+func TestSortPortForwards(t *testing.T) {
+	forwards := []PortForward{
+		{ID: "pf3", Name: "web"},
+		{ID: "pf2", Name: "ssh"},
+		{ID: "pf1", Name: "SSH"},
+	}
+
+	SortPortForwards(forwards)
+
+	expected := []string{"pf1", "pf2", "pf3"}
+	for i, id := range expected {
+		if forwards[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, forwards[i].ID)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PortProfile is a switch port profile from rest/portconf
+type PortProfile struct {
+	ID                  string `json:"_id"`
+	Name                string `json:"name"`
+	Forward             string `json:"forward"`
+	NativeNetworkconfID string `json:"native_networkconf_id,omitempty"`
+	PoEMode             string `json:"poe_mode,omitempty"`
+}
+
+type PortProfilesResponse struct {
+	Meta Meta          `json:"meta"`
+	Data []PortProfile `json:"data"`
+}
+
+// PortStatus is a port together with the profile, network and clients it
+// serves, for listing and filtering
+type PortStatus struct {
+	Port
+	Profile string `json:"profile"`
+	// Forward is the profile's VLAN mode: native (access port), all or
+	// customize (trunk) or disabled; ports without profile forward all
+	Forward   string  `json:"forward,omitempty"`
+	NetworkID string  `json:"network_id,omitempty"`
+	Network   string  `json:"network"`
+	VLAN      int     `json:"vlan,omitempty"`
+	PoEWatts  float64 `json:"poe_watts"`
+	Clients   int     `json:"clients"`
+}
+
+// ListPortProfiles returns the switch port profiles of the site
+func (c *APIClient) ListPortProfiles() ([]PortProfile, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/portconf", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response PortProfilesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// PortStatuses describes the ports of device in index order. A port's native
+// network is its own override or else its profile's; clients are the wired
+// clients the controller sees behind the port.
+func PortStatuses(device Device, profiles []PortProfile, networks []Network, clients []Client) []PortStatus {
+	profileByID := make(map[string]PortProfile, len(profiles))
+	for _, p := range profiles {
+		profileByID[p.ID] = p
+	}
+
+	networkByID := make(map[string]Network, len(networks))
+	for _, n := range networks {
+		networkByID[n.ID] = n
+	}
+
+	clientsPerPort := map[int]int{}
+	for _, client := range clients {
+		if client.IsWired && strings.EqualFold(client.SWMAC, device.MAC) {
+			clientsPerPort[client.SWPort]++
+		}
+	}
+
+	statuses := make([]PortStatus, 0, len(device.PortTable))
+	for _, port := range device.PortTable {
+		status := PortStatus{Port: port, Forward: "all", Clients: clientsPerPort[port.Index]}
+
+		networkID := port.NativeNetworkconfID
+		if profile, ok := profileByID[port.PortconfID]; ok {
+			status.Profile = profile.Name
+			if profile.Forward != "" {
+				status.Forward = profile.Forward
+			}
+			if networkID == "" {
+				networkID = profile.NativeNetworkconfID
+			}
+		}
+
+		if network, ok := networkByID[networkID]; ok {
+			status.NetworkID = network.ID
+			status.Network = network.Name
+			if network.VLANEnabled {
+				status.VLAN = network.VLAN
+			}
+		}
+
+		status.PoEWatts, _ = strconv.ParseFloat(port.PoEPower, 64)
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Index < statuses[j].Index })
+	return statuses
+}
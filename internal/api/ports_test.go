@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListPortProfiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/portconf"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"pc1","name":"Cameras","forward":"native","native_networkconf_id":"n2"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	profiles, err := client.ListPortProfiles()
+
+	if err != nil {
+		t.Fatalf("ListPortProfiles() returned error: %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].Name != "Cameras" || profiles[0].NativeNetworkconfID != "n2" {
+		t.Errorf("Unexpected profiles %+v", profiles)
+	}
+}
+
+func TestPortStatuses(t *testing.T) {
+	device := Device{
+		MAC: "f0:9f:c2:00:00:02",
+		PortTable: []Port{
+			{Index: 8, Name: "Port 8", PortconfID: "pc1", NativeNetworkconfID: "n1"},
+			{Index: 1, Name: "Uplink"},
+			{Index: 7, Name: "Camera", PortconfID: "pc1", PoE: true, PoEPower: "4.52"},
+		},
+	}
+	profiles := []PortProfile{{ID: "pc1", Name: "Cameras", Forward: "native", NativeNetworkconfID: "n2"}}
+	networks := []Network{
+		{ID: "n1", Name: "LAN"},
+		{ID: "n2", Name: "IoT", VLAN: 20, VLANEnabled: true},
+	}
+	clients := []Client{
+		{MAC: "aa", IsWired: true, SWMAC: "F0:9F:C2:00:00:02", SWPort: 7},
+		{MAC: "bb", IsWired: true, SWMAC: "f0:9f:c2:00:00:02", SWPort: 7},
+		{MAC: "cc", IsWired: true, SWMAC: "f0:9f:c2:00:00:99", SWPort: 7},
+		{MAC: "dd", IsWired: false, SWMAC: "f0:9f:c2:00:00:02", SWPort: 1},
+	}
+
+	statuses := PortStatuses(device, profiles, networks, clients)
+	if len(statuses) != 3 {
+		t.Fatalf("Expected 3 ports, got %d", len(statuses))
+	}
+
+	if statuses[0].Index != 1 || statuses[1].Index != 7 || statuses[2].Index != 8 {
+		t.Errorf("Expected ports in index order, got %d, %d, %d", statuses[0].Index, statuses[1].Index, statuses[2].Index)
+	}
+
+	uplink := statuses[0]
+	if uplink.Profile != "" || uplink.Forward != "all" || uplink.Network != "" || uplink.Clients != 0 {
+		t.Errorf("Expected a bare uplink, got %+v", uplink)
+	}
+
+	camera := statuses[1]
+	if camera.Profile != "Cameras" || camera.Forward != "native" || camera.NetworkID != "n2" || camera.Network != "IoT" || camera.VLAN != 20 || camera.Clients != 2 || camera.PoEWatts != 4.52 {
+		t.Errorf("Unexpected camera port %+v", camera)
+	}
+
+	// A port's own native network overrides its profile's
+	if statuses[2].Network != "LAN" || statuses[2].VLAN != 0 {
+		t.Errorf("Expected the port's network override, got %+v", statuses[2])
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Report intervals of stat/report; the controller keeps hourly data for
+// about a week and daily data for about a year by default
+const (
+	ReportHourly = "hourly"
+	ReportDaily  = "daily"
+)
+
+// UserTraffic is the traffic of one client in one interval of a report
+type UserTraffic struct {
+	User string `json:"user"`
+	// Time is the start of the interval in milliseconds since the epoch
+	Time int64 `json:"time"`
+	// Reports give byte counts as floating point numbers
+	RxBytes float64 `json:"rx_bytes"`
+	TxBytes float64 `json:"tx_bytes"`
+}
+
+// Bytes returns the client's traffic in both directions
+func (t UserTraffic) Bytes() int64 {
+	return int64(t.RxBytes + t.TxBytes)
+}
+
+type UserTrafficResponse struct {
+	Meta Meta          `json:"meta"`
+	Data []UserTraffic `json:"data"`
+}
+
+// GetUserTraffic returns the traffic of every client per interval (hourly or
+// daily) from start to end, from stat/report/<interval>.user
+func (c *APIClient) GetUserTraffic(interval string, start, end time.Time) ([]UserTraffic, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/report/%s.user", c.Site, interval)
+
+	payload := map[string]interface{}{
+		"attrs": []string{"rx_bytes", "tx_bytes", "time", "user"},
+		"start": start.UnixMilli(),
+		"end":   end.UnixMilli(),
+	}
+
+	body, err := c.doRequestWithBody("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response UserTrafficResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ReportInterval picks the finest report interval the controller keeps for a
+// period reaching back within from now
+func ReportInterval(within time.Duration) string {
+	if within <= 7*24*time.Hour {
+		return ReportHourly
+	}
+	return ReportDaily
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIClient_GetUserTraffic(t *testing.T) {
+	start := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/proxy/network/api/s/default/stat/report/hourly.user" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["start"] != float64(start.UnixMilli()) || payload["end"] != float64(end.UnixMilli()) {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"user":"aa:bb:cc:dd:ee:01","time":1768176000000,"rx_bytes":1000,"tx_bytes":200},
+			{"user":"aa:bb:cc:dd:ee:02","time":1768176000000,"rx_bytes":5.0E1,"tx_bytes":5}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+
+	traffic, err := client.GetUserTraffic(ReportHourly, start, end)
+	if err != nil {
+		t.Fatalf("GetUserTraffic() returned error: %v", err)
+	}
+	if len(traffic) != 2 || traffic[0].User != "aa:bb:cc:dd:ee:01" || traffic[0].Bytes() != 1200 || traffic[1].Bytes() != 55 {
+		t.Errorf("Unexpected traffic %+v", traffic)
+	}
+}
+
+func TestReportInterval(t *testing.T) {
+	if got := ReportInterval(24 * time.Hour); got != ReportHourly {
+		t.Errorf("Expected hourly for a day, got %s", got)
+	}
+	if got := ReportInterval(30 * 24 * time.Hour); got != ReportDaily {
+		t.Errorf("Expected daily for a month, got %s", got)
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GuestAccessSettings holds the hotspot portal branding fields of the
+// guest_access site setting
+type GuestAccessSettings struct {
+	ID                 string `json:"_id"`
+	Key                string `json:"key"`
+	PortalEnabled      bool   `json:"portal_enabled"`
+	PortalCustomized   bool   `json:"portal_customized"`
+	PortalTitle        string `json:"portal_customized_title"`
+	PortalTerms        string `json:"portal_customized_tos"`
+	PortalTermsEnabled bool   `json:"portal_customized_tos_enabled"`
+	PortalLogoEnabled  bool   `json:"portal_customized_logo_enabled"`
+	PortalLogoFileID   string `json:"portal_customized_logo_file_id"`
+}
+
+// getSetting fetches the site setting with the given key into out
+func (c *APIClient) getSetting(key string, out interface{}) error {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/get/setting/%s", c.Site, key)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Meta Meta              `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return err
+	}
+
+	if len(response.Data) == 0 {
+		return fmt.Errorf("setting %s not found", key)
+	}
+
+	if err := json.Unmarshal(response.Data[0], out); err != nil {
+		return fmt.Errorf("failed to parse setting %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// updateSetting sends only the given fields of a site setting
+func (c *APIClient) updateSetting(key, id string, fields map[string]interface{}) error {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/setting/%s/%s", c.Site, key, id)
+
+	body, err := c.doRequestWithBody("PUT", path, fields)
+	if err != nil {
+		return err
+	}
+
+	var response APIResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return c.checkMeta(response.Meta)
+}
+
+// GetGuestAccessSettings returns the current hotspot portal settings
+func (c *APIClient) GetGuestAccessSettings() (*GuestAccessSettings, error) {
+	var settings GuestAccessSettings
+	if err := c.getSetting("guest_access", &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateGuestAccessSettings applies the given guest_access fields
+func (c *APIClient) UpdateGuestAccessSettings(id string, fields map[string]interface{}) error {
+	return c.updateSetting("guest_access", id, fields)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_GetGuestAccessSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/get/setting/guest_access"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"s1","key":"guest_access","portal_customized_title":"Cafe WiFi"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	settings, err := client.GetGuestAccessSettings()
+
+	if err != nil {
+		t.Fatalf("GetGuestAccessSettings() returned error: %v", err)
+	}
+	if settings.ID != "s1" {
+		t.Errorf("Expected ID 's1', got '%s'", settings.ID)
+	}
+	if settings.PortalTitle != "Cafe WiFi" {
+		t.Errorf("Expected title 'Cafe WiFi', got '%s'", settings.PortalTitle)
+	}
+}
+
+func TestAPIClient_GetGuestAccessSettings_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.GetGuestAccessSettings(); err == nil {
+		t.Error("Expected error when setting is missing")
+	}
+}
+
+func TestAPIClient_UpdateGuestAccessSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/setting/guest_access/s1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if len(payload) != 1 || payload["portal_customized_title"] != "Cafe WiFi" {
+			t.Errorf("Expected only the title to be sent, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	err := client.UpdateGuestAccessSettings("s1", map[string]interface{}{"portal_customized_title": "Cafe WiFi"})
+	if err != nil {
+		t.Fatalf("UpdateGuestAccessSettings() returned error: %v", err)
+	}
+}
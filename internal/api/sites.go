@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Site is a site of the controller, from stat/sites
+type Site struct {
+	ID string `json:"_id"`
+	// Name is the short site ID used in API paths and --site, e.g. "default"
+	Name   string       `json:"name"`
+	Desc   string       `json:"desc"`
+	Role   string       `json:"role"`
+	Health []SiteHealth `json:"health,omitempty"`
+}
+
+// SiteHealth is the state of one subsystem of a site (wlan, lan, wan, www,
+// vpn) with its client and device counts
+type SiteHealth struct {
+	Subsystem       string `json:"subsystem"`
+	Status          string `json:"status"`
+	NumUser         int    `json:"num_user,omitempty"`
+	NumGuest        int    `json:"num_guest,omitempty"`
+	NumAP           int    `json:"num_ap,omitempty"`
+	NumSW           int    `json:"num_sw,omitempty"`
+	NumGW           int    `json:"num_gw,omitempty"`
+	NumAdopted      int    `json:"num_adopted,omitempty"`
+	NumDisconnected int    `json:"num_disconnected,omitempty"`
+	NumPending      int    `json:"num_pending,omitempty"`
+	// WANIP is set for the wan subsystem, Latency (ms) for www
+	WANIP   string `json:"wan_ip,omitempty"`
+	Latency int    `json:"latency,omitempty"`
+	// RxRate and TxRate are the current throughput in bytes per second
+	RxRate float64 `json:"rx_bytes-r,omitempty"`
+	TxRate float64 `json:"tx_bytes-r,omitempty"`
+}
+
+// GetHealth returns the state of the named subsystem, or nil if the site does
+// not report it
+func (s *Site) GetHealth(subsystem string) *SiteHealth {
+	for i := range s.Health {
+		if strings.EqualFold(s.Health[i].Subsystem, subsystem) {
+			return &s.Health[i]
+		}
+	}
+	return nil
+}
+
+// Healthy reports whether the subsystem is ok or not in use
+func (h *SiteHealth) Healthy() bool {
+	return h.Status == "ok" || h.Status == "unknown" || h.Status == ""
+}
+
+type SitesResponse struct {
+	Meta Meta   `json:"meta"`
+	Data []Site `json:"data"`
+}
+
+// HealthResponse is the response of stat/health
+type HealthResponse struct {
+	Meta Meta         `json:"meta"`
+	Data []SiteHealth `json:"data"`
+}
+
+// GetSiteHealth returns the state of the current site's subsystems, from
+// stat/health
+func (c *APIClient) GetSiteHealth() ([]SiteHealth, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/health", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response HealthResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// SiteSummary is a site with its health counts added up, for listing and
+// filtering
+type SiteSummary struct {
+	Site
+	// Status is the worst status of the site's subsystems: ok, warning or
+	// error, or unknown without health data
+	Status       string `json:"status"`
+	Clients      int    `json:"clients"`
+	Guests       int    `json:"guests"`
+	APs          int    `json:"aps"`
+	Switches     int    `json:"switches"`
+	Gateways     int    `json:"gateways"`
+	Devices      int    `json:"devices"`
+	Disconnected int    `json:"disconnected"`
+	Pending      int    `json:"pending"`
+}
+
+// statusRank orders subsystem states from best to worst
+var statusRank = map[string]int{"ok": 1, "warning": 2, "error": 3}
+
+// SummarizeSite adds up the client and device counts of a site's subsystems
+func SummarizeSite(site Site) SiteSummary {
+	summary := SiteSummary{Site: site, Status: "unknown"}
+
+	for _, health := range site.Health {
+		if statusRank[health.Status] > statusRank[summary.Status] {
+			summary.Status = health.Status
+		}
+
+		// www and vpn repeat the counts of the other subsystems
+		subsystem := strings.ToLower(health.Subsystem)
+		if subsystem != "wlan" && subsystem != "lan" && subsystem != "wan" {
+			continue
+		}
+
+		if subsystem != "wan" {
+			summary.Clients += health.NumUser
+			summary.Guests += health.NumGuest
+		}
+
+		summary.APs += health.NumAP
+		summary.Switches += health.NumSW
+		summary.Gateways += health.NumGW
+		summary.Devices += health.NumAdopted
+		summary.Disconnected += health.NumDisconnected
+		summary.Pending += health.NumPending
+	}
+
+	return summary
+}
+
+// SummarizeSites summarizes every site
+func SummarizeSites(sites []Site) []SiteSummary {
+	summaries := make([]SiteSummary, len(sites))
+	for i, site := range sites {
+		summaries[i] = SummarizeSite(site)
+	}
+	return summaries
+}
+
+// SortSites orders sites by description (case-insensitive), then name
+func SortSites(sites []Site) {
+	sort.SliceStable(sites, func(i, j int) bool {
+		a, b := strings.ToLower(sites[i].Desc), strings.ToLower(sites[j].Desc)
+		if a != b {
+			return a < b
+		}
+		return sites[i].Name < sites[j].Name
+	})
+}
+
+// FindSite looks a site up by name (its ID, e.g. "default") or
+// case-insensitive description
+func FindSite(sites []Site, query string) (*Site, error) {
+	for i := range sites {
+		if sites[i].Name == query {
+			return &sites[i], nil
+		}
+	}
+
+	for i := range sites {
+		if strings.EqualFold(sites[i].Desc, query) {
+			return &sites[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no site named %q", query)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSummarizeSite(t *testing.T) {
+	site := Site{
+		Name: "default",
+		Health: []SiteHealth{
+			{Subsystem: "wlan", Status: "ok", NumUser: 20, NumGuest: 3, NumAP: 3, NumAdopted: 3},
+			{Subsystem: "lan", Status: "warning", NumUser: 8, NumSW: 2, NumAdopted: 1, NumDisconnected: 1, NumPending: 1},
+			{Subsystem: "wan", Status: "ok", NumGW: 1, NumAdopted: 1},
+			{Subsystem: "www", Status: "ok", NumUser: 99},
+			{Subsystem: "vpn", Status: "unknown"},
+		},
+	}
+
+	summary := SummarizeSite(site)
+
+	if summary.Status != "warning" {
+		t.Errorf("Expected status warning, got %s", summary.Status)
+	}
+	if summary.Clients != 28 || summary.Guests != 3 {
+		t.Errorf("Expected 28 clients and 3 guests, got %d and %d", summary.Clients, summary.Guests)
+	}
+	if summary.APs != 3 || summary.Switches != 2 || summary.Gateways != 1 || summary.Devices != 5 {
+		t.Errorf("Unexpected device counts %+v", summary)
+	}
+	if summary.Disconnected != 1 || summary.Pending != 1 {
+		t.Errorf("Expected 1 disconnected and 1 pending, got %d and %d", summary.Disconnected, summary.Pending)
+	}
+
+	if s := SummarizeSite(Site{Name: "empty"}); s.Status != "unknown" {
+		t.Errorf("Expected unknown status without health, got %s", s.Status)
+	}
+
+	if s := SummarizeSites([]Site{site, {Name: "empty"}}); len(s) != 2 || s[1].Name != "empty" {
+		t.Errorf("Unexpected summaries %+v", s)
+	}
+}
+
+func TestFindSite(t *testing.T) {
+	sites := []Site{{Name: "default", Desc: "Head Office"}, {Name: "x7k2m9", Desc: "Branch Berlin"}}
+
+	if s, err := FindSite(sites, "x7k2m9"); err != nil || s.Desc != "Branch Berlin" {
+		t.Errorf("Expected to find Branch Berlin by name, got %v, %v", s, err)
+	}
+	if s, err := FindSite(sites, "head office"); err != nil || s.Name != "default" {
+		t.Errorf("Expected to find Head Office by description, got %v, %v", s, err)
+	}
+	if _, err := FindSite(sites, "Paris"); err == nil {
+		t.Error("Expected error for unknown site")
+	}
+}
+
+func TestSite_GetHealth(t *testing.T) {
+	site := Site{Health: []SiteHealth{{Subsystem: "wan", Status: "ok", WANIP: "203.0.113.7"}}}
+
+	if h := site.GetHealth("WAN"); h == nil || h.WANIP != "203.0.113.7" {
+		t.Errorf("Expected the wan subsystem, got %+v", h)
+	}
+	if h := site.GetHealth("vpn"); h != nil {
+		t.Errorf("Expected no vpn subsystem, got %+v", h)
+	}
+}
+
+func TestAPIClient_GetSiteHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/proxy/network/api/s/branch/stat/health" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"subsystem":"wan","status":"ok","wan_ip":"203.0.113.7","num_gw":1,"rx_bytes-r":125000.5,"tx_bytes-r":2500},
+			{"subsystem":"vpn","status":"unknown"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "branch", true)
+
+	health, err := client.GetSiteHealth()
+	if err != nil {
+		t.Fatalf("GetSiteHealth() returned error: %v", err)
+	}
+	if len(health) != 2 || health[0].WANIP != "203.0.113.7" || health[0].RxRate != 125000.5 || health[0].TxRate != 2500 {
+		t.Errorf("Unexpected health %+v", health)
+	}
+}
+
+func TestSiteHealth_Healthy(t *testing.T) {
+	for status, want := range map[string]bool{"ok": true, "unknown": true, "": true, "warning": false, "error": false} {
+		if got := (&SiteHealth{Status: status}).Healthy(); got != want {
+			t.Errorf("Healthy() for %q = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestSortSites(t *testing.T) {
+	sites := []Site{
+		{Name: "s3", Desc: "branch"},
+		{Name: "default", Desc: "Main Office"},
+		{Name: "s2", Desc: "Branch"},
+	}
+
+	SortSites(sites)
+
+	expected := []string{"s2", "s3", "default"}
+	for i, name := range expected {
+		if sites[i].Name != name {
+			t.Errorf("Position %d: expected %s, got %s", i, name, sites[i].Name)
+		}
+	}
+}
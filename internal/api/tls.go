@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CertificateFingerprint returns the SHA-256 fingerprint of a DER encoded
+// certificate as colon separated upper-case hex
+func CertificateFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// FetchCertificateFingerprint connects to the controller without verifying
+// its certificate and returns the fingerprint of the certificate it presents
+func FetchCertificateFingerprint(host string, timeout time.Duration) (string, error) {
+	return FetchCertificateFingerprintVia(host, nil, timeout)
+}
+
+// FetchCertificateFingerprintVia is FetchCertificateFingerprint connecting
+// with dial, e.g. through a tunnel. A nil dial connects directly.
+func FetchCertificateFingerprintVia(host string, dial DialFunc, timeout time.Duration) (string, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", host, err)
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	certs, err := FetchCertificates(addr, dial, timeout)
+	if err != nil {
+		return "", err
+	}
+	return CertificateFingerprint(certs[0].Raw), nil
+}
+
+// FetchCertificates connects to a TLS endpoint (host:port) with dial without
+// verifying it and returns the certificates it presents, leaf first. A nil
+// dial connects directly.
+func FetchCertificates(addr string, dial DialFunc, timeout time.Duration) ([]*x509.Certificate, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	raw, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	conn := tls.Client(raw, &tls.Config{InsecureSkipVerify: true, ServerName: host})
+	defer conn.Close()
+
+	if err := conn.HandshakeContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%s presented no certificate", addr)
+	}
+
+	return certs, nil
+}
+
+// PinCertificate makes every request fail unless the controller presents a
+// certificate with the given SHA-256 fingerprint. The pin replaces chain
+// verification when the client is insecure and adds to it otherwise.
+func (c *APIClient) PinCertificate(fingerprint string) {
+	transport, ok := c.client.Transport.(*http.Transport)
+	if !ok {
+		return
+	}
+
+	expected := strings.ToUpper(fingerprint)
+	transport.TLSClientConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("controller presented no certificate")
+		}
+
+		actual := CertificateFingerprint(rawCerts[0])
+		if actual != expected {
+			return fmt.Errorf("controller certificate fingerprint mismatch: expected %s, got %s "+
+				"(if the certificate was replaced on purpose, remove 'fingerprint' from the config file)", expected, actual)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTLSTestServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+}
+
+func TestCertificateFingerprint(t *testing.T) {
+	fingerprint := CertificateFingerprint([]byte("test"))
+
+	// SHA-256 is 32 bytes: 32 hex pairs joined by 31 colons
+	if len(fingerprint) != 32*2+31 {
+		t.Errorf("Unexpected fingerprint length %d: %s", len(fingerprint), fingerprint)
+	}
+	if !strings.HasPrefix(fingerprint, "9F:86:D0:81") {
+		t.Errorf("Unexpected fingerprint %s", fingerprint)
+	}
+}
+
+func TestFetchCertificateFingerprint(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	fingerprint, err := FetchCertificateFingerprint(server.URL, 5*time.Second)
+	if err != nil {
+		t.Fatalf("FetchCertificateFingerprint() returned error: %v", err)
+	}
+
+	expected := CertificateFingerprint(server.Certificate().Raw)
+	if fingerprint != expected {
+		t.Errorf("Expected fingerprint %s, got %s", expected, fingerprint)
+	}
+}
+
+func TestFetchCertificateFingerprintVia(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+
+	fingerprint, err := FetchCertificateFingerprintVia("https://unifi.internal", dial, 5*time.Second)
+	if err != nil {
+		t.Fatalf("FetchCertificateFingerprintVia() returned error: %v", err)
+	}
+	if expected := CertificateFingerprint(server.Certificate().Raw); fingerprint != expected {
+		t.Errorf("Expected fingerprint %s, got %s", expected, fingerprint)
+	}
+}
+
+func TestFetchCertificates(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	certs, err := FetchCertificates(server.Listener.Addr().String(), nil, 5*time.Second)
+	if err != nil {
+		t.Fatalf("FetchCertificates() returned error: %v", err)
+	}
+	if len(certs) == 0 || !certs[0].Equal(server.Certificate()) {
+		t.Errorf("Expected the server certificate, got %d certificates", len(certs))
+	}
+
+	if _, err := FetchCertificates("no-port", nil, time.Second); err == nil {
+		t.Error("Expected an address without port to fail")
+	}
+}
+
+func TestAPIClient_PinCertificate(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	t.Run("matching fingerprint", func(t *testing.T) {
+		client := NewAPIClient(server.URL, "test-key", "default", true)
+		client.PinCertificate(strings.ToLower(CertificateFingerprint(server.Certificate().Raw)))
+
+		if _, err := client.ListClients(); err != nil {
+			t.Fatalf("Expected request to succeed, got %v", err)
+		}
+	})
+
+	t.Run("changed fingerprint", func(t *testing.T) {
+		client := NewAPIClient(server.URL, "test-key", "default", true)
+		client.PinCertificate(CertificateFingerprint([]byte("other certificate")))
+
+		_, err := client.ListClients()
+		if err == nil || !strings.Contains(err.Error(), "fingerprint mismatch") {
+			t.Errorf("Expected fingerprint mismatch error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Traffic route matching targets
+const (
+	RouteTargetInternet = "INTERNET"
+	RouteTargetDomain   = "DOMAIN"
+	RouteTargetIP       = "IP"
+	RouteTargetRegion   = "REGION"
+)
+
+// Traffic route target device types
+const (
+	RouteDeviceClient     = "CLIENT"
+	RouteDeviceNetwork    = "NETWORK"
+	RouteDeviceAllClients = "ALL_CLIENTS"
+)
+
+// TrafficRoute sends the traffic of some clients, optionally only to some
+// domains, addresses or regions, out of a chosen WAN or VPN client
+// interface (policy-based routing). Traffic routes are only available
+// through the v2 API.
+type TrafficRoute struct {
+	ID          string `json:"_id,omitempty"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	// MatchingTarget is INTERNET (all traffic), DOMAIN, IP or REGION
+	MatchingTarget string `json:"matching_target"`
+	// NetworkID is the WAN or VPN client network the traffic leaves through
+	NetworkID         string              `json:"network_id"`
+	NextHop           string              `json:"next_hop,omitempty"`
+	KillSwitchEnabled bool                `json:"kill_switch_enabled"`
+	TargetDevices     []RouteTargetDevice `json:"target_devices"`
+	Domains           []RouteDomain       `json:"domains"`
+	IPAddresses       []RouteIPAddress    `json:"ip_addresses"`
+	Regions           []string            `json:"regions"`
+}
+
+// RouteTargetDevice is a client or network whose traffic a route applies to
+type RouteTargetDevice struct {
+	// Type is CLIENT, NETWORK or ALL_CLIENTS
+	Type      string `json:"type"`
+	ClientMAC string `json:"client_mac,omitempty"`
+	NetworkID string `json:"network_id,omitempty"`
+}
+
+// RouteDomain is a destination domain of a route
+type RouteDomain struct {
+	Domain string `json:"domain"`
+	Ports  []int  `json:"ports"`
+}
+
+// RouteIPAddress is a destination address or subnet of a route
+type RouteIPAddress struct {
+	IPOrSubnet string `json:"ip_or_subnet"`
+	IPVersion  string `json:"ip_version"`
+	Ports      []int  `json:"ports"`
+}
+
+// trafficRoutesPath is the v2 API path of the site's traffic routes
+func (c *APIClient) trafficRoutesPath() string {
+	return fmt.Sprintf("/proxy/network/v2/api/site/%s/trafficroutes", c.Site)
+}
+
+// SortTrafficRoutes orders traffic routes by description (case-insensitive),
+// then ID
+func SortTrafficRoutes(routes []TrafficRoute) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := strings.ToLower(routes[i].Description), strings.ToLower(routes[j].Description)
+		if a != b {
+			return a < b
+		}
+		return routes[i].ID < routes[j].ID
+	})
+}
+
+// ListTrafficRoutes returns the traffic routes of the site
+func (c *APIClient) ListTrafficRoutes() ([]TrafficRoute, error) {
+	body, err := c.doRequest("GET", c.trafficRoutesPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// The v2 API returns plain arrays without a meta envelope
+	var routes []TrafficRoute
+	if err := json.Unmarshal(body, &routes); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return routes, nil
+}
+
+// CreateTrafficRoute adds a traffic route and returns it
+func (c *APIClient) CreateTrafficRoute(route TrafficRoute) (*TrafficRoute, error) {
+	route.ID = ""
+
+	body, err := c.doRequestWithBody("POST", c.trafficRoutesPath(), route)
+	if err != nil {
+		return nil, err
+	}
+
+	var created TrafficRoute
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// SetTrafficRouteEnabled turns a traffic route on or off and returns the
+// updated route
+func (c *APIClient) SetTrafficRouteEnabled(id string, enabled bool) (*TrafficRoute, error) {
+	body, err := c.updateV2(c.trafficRoutesPath(), "traffic route", id, func(route map[string]interface{}) {
+		route["enabled"] = enabled
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var updated TrafficRoute
+	if err := json.Unmarshal(body, &updated); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// DeleteTrafficRoute removes a traffic route
+func (c *APIClient) DeleteTrafficRoute(id string) error {
+	_, err := c.doRequest("DELETE", c.trafficRoutesPath()+"/"+id)
+	return err
+}
+
+// FindTrafficRoute looks a traffic route up by ID or case-insensitive
+// description. Descriptions need not be unique, so an ambiguous one is an
+// error.
+func FindTrafficRoute(routes []TrafficRoute, query string) (*TrafficRoute, error) {
+	for i := range routes {
+		if routes[i].ID == query {
+			return &routes[i], nil
+		}
+	}
+
+	var found *TrafficRoute
+	for i := range routes {
+		if strings.EqualFold(routes[i].Description, query) {
+			if found != nil {
+				return nil, fmt.Errorf("several traffic routes are described as %q, use the route ID", query)
+			}
+			found = &routes[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no traffic route named %q", query)
+	}
+	return found, nil
+}
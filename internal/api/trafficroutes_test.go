@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testTrafficRoutes = `[
+	{"_id":"tr1","description":"Work VPN","enabled":true,"matching_target":"DOMAIN","network_id":"vpn1","kill_switch_enabled":true,
+	 "target_devices":[{"type":"CLIENT","client_mac":"aa:bb:cc:00:00:01"}],"domains":[{"domain":"corp.example.com","ports":[]}],"ip_addresses":[],"regions":[],"next_hop":""},
+	{"_id":"tr2","description":"Streaming","enabled":false,"matching_target":"INTERNET","network_id":"wan2",
+	 "target_devices":[{"type":"NETWORK","network_id":"n1"}],"domains":[],"ip_addresses":[],"regions":[]}
+]`
+
+func TestAPIClient_ListTrafficRoutes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/v2/api/site/default/trafficroutes"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testTrafficRoutes))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	routes, err := client.ListTrafficRoutes()
+
+	if err != nil {
+		t.Fatalf("ListTrafficRoutes() returned error: %v", err)
+	}
+	if len(routes) != 2 || routes[0].MatchingTarget != RouteTargetDomain || routes[0].Domains[0].Domain != "corp.example.com" {
+		t.Errorf("Unexpected routes %+v", routes)
+	}
+	if routes[1].TargetDevices[0].Type != RouteDeviceNetwork || routes[1].TargetDevices[0].NetworkID != "n1" {
+		t.Errorf("Unexpected target devices %+v", routes[1].TargetDevices)
+	}
+}
+
+func TestAPIClient_CreateTrafficRoute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["description"] != "Work VPN" || payload["network_id"] != "vpn1" || payload["matching_target"] != RouteTargetInternet {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+		if _, ok := payload["_id"]; ok {
+			t.Errorf("Expected no ID to be sent, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_id":"tr3","description":"Work VPN","enabled":true,"matching_target":"INTERNET","network_id":"vpn1"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	route, err := client.CreateTrafficRoute(TrafficRoute{
+		Description:    "Work VPN",
+		Enabled:        true,
+		MatchingTarget: RouteTargetInternet,
+		NetworkID:      "vpn1",
+		TargetDevices:  []RouteTargetDevice{{Type: RouteDeviceAllClients}},
+	})
+
+	if err != nil {
+		t.Fatalf("CreateTrafficRoute() returned error: %v", err)
+	}
+	if route.ID != "tr3" {
+		t.Errorf("Unexpected route %+v", route)
+	}
+}
+
+func TestAPIClient_SetTrafficRouteEnabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(testTrafficRoutes))
+		case "PUT":
+			expectedPath := "/proxy/network/v2/api/site/default/trafficroutes/tr2"
+			if r.URL.Path != expectedPath {
+				t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+			}
+
+			var payload map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload["enabled"] != true || payload["description"] != "Streaming" {
+				t.Errorf("Expected the complete route to be sent enabled, got %v", payload)
+			}
+			w.Write([]byte(`{"_id":"tr2","description":"Streaming","enabled":true}`))
+		default:
+			t.Errorf("Unexpected %s request", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	route, err := client.SetTrafficRouteEnabled("tr2", true)
+
+	if err != nil {
+		t.Fatalf("SetTrafficRouteEnabled() returned error: %v", err)
+	}
+	if !route.Enabled {
+		t.Error("Expected the route to be enabled")
+	}
+
+	if _, err := client.SetTrafficRouteEnabled("missing", true); err == nil {
+		t.Error("Expected error for an unknown route")
+	}
+}
+
+func TestAPIClient_DeleteTrafficRoute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/v2/api/site/default/trafficroutes/tr1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.DeleteTrafficRoute("tr1"); err != nil {
+		t.Fatalf("DeleteTrafficRoute() returned error: %v", err)
+	}
+}
+
+func TestFindTrafficRoute(t *testing.T) {
+	routes := []TrafficRoute{{ID: "tr1", Description: "Work VPN"}, {ID: "tr2", Description: "TV"}, {ID: "tr3", Description: "TV"}}
+
+	if r, err := FindTrafficRoute(routes, "work vpn"); err != nil || r.ID != "tr1" {
+		t.Errorf("Expected to find Work VPN by description, got %v, %v", r, err)
+	}
+	if r, err := FindTrafficRoute(routes, "tr3"); err != nil || r.Description != "TV" {
+		t.Errorf("Expected to find tr3 by ID, got %v, %v", r, err)
+	}
+	if _, err := FindTrafficRoute(routes, "TV"); err == nil {
+		t.Error("Expected error for an ambiguous description")
+	}
+	if _, err := FindTrafficRoute(routes, "Other"); err == nil {
+		t.Error("Expected error for unknown route")
+	}
+}
+
+func TestSortTrafficRoutes(t *testing.T) {
+	routes := []TrafficRoute{
+		{ID: "tr3", Description: "streaming via vpn"},
+		{ID: "tr2", Description: "kids"},
+		{ID: "tr1", Description: "Kids"},
+	}
+
+	SortTrafficRoutes(routes)
+
+	expected := []string{"tr1", "tr2", "tr3"}
+	for i, id := range expected {
+		if routes[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, routes[i].ID)
+		}
+	}
+}
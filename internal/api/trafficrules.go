@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Traffic rule actions
+const (
+	RuleActionBlock = "BLOCK"
+	RuleActionAllow = "ALLOW"
+)
+
+// Traffic rule matching targets (besides the ones shared with traffic
+// routes)
+const (
+	RuleTargetApp         = "APP"
+	RuleTargetAppCategory = "APP_CATEGORY"
+)
+
+// TrafficRule blocks, allows or rate-limits some traffic of some clients.
+// App rules match applications recognized by deep packet inspection, by
+// their combined category and application ID. Traffic rules are only
+// available through the v2 API.
+type TrafficRule struct {
+	ID          string `json:"_id,omitempty"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	// Action is BLOCK or ALLOW
+	Action string `json:"action"`
+	// MatchingTarget is APP, APP_CATEGORY, DOMAIN, IP, REGION or INTERNET
+	MatchingTarget string              `json:"matching_target"`
+	AppIDs         []int               `json:"app_ids"`
+	AppCategoryIDs []int               `json:"app_category_ids"`
+	TargetDevices  []RouteTargetDevice `json:"target_devices"`
+	NetworkIDs     []string            `json:"network_ids"`
+	Domains        []RouteDomain       `json:"domains"`
+	IPAddresses    []RouteIPAddress    `json:"ip_addresses"`
+	IPRanges       []json.RawMessage   `json:"ip_ranges"`
+	Regions        []string            `json:"regions"`
+	Schedule       RuleSchedule        `json:"schedule"`
+	BandwidthLimit RuleBandwidthLimit  `json:"bandwidth_limit"`
+}
+
+// RuleSchedule is when a traffic rule applies; mode ALWAYS needs no times
+type RuleSchedule struct {
+	Mode string `json:"mode"`
+}
+
+// RuleBandwidthLimit rate-limits the matched traffic when enabled
+type RuleBandwidthLimit struct {
+	Enabled           bool `json:"enabled"`
+	DownloadLimitKbps int  `json:"download_limit_kbps"`
+	UploadLimitKbps   int  `json:"upload_limit_kbps"`
+}
+
+// trafficRulesPath is the v2 API path of the site's traffic rules
+func (c *APIClient) trafficRulesPath() string {
+	return fmt.Sprintf("/proxy/network/v2/api/site/%s/trafficrules", c.Site)
+}
+
+// ListTrafficRules returns the traffic rules of the site
+func (c *APIClient) ListTrafficRules() ([]TrafficRule, error) {
+	body, err := c.doRequest("GET", c.trafficRulesPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []TrafficRule
+	if err := json.Unmarshal(body, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return rules, nil
+}
+
+// CreateTrafficRule adds a traffic rule and returns it
+func (c *APIClient) CreateTrafficRule(rule TrafficRule) (*TrafficRule, error) {
+	rule.ID = ""
+
+	body, err := c.doRequestWithBody("POST", c.trafficRulesPath(), rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var created TrafficRule
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// SetTrafficRuleTargets changes the applications and clients of a traffic
+// rule and returns the updated rule
+func (c *APIClient) SetTrafficRuleTargets(id string, appIDs []int, devices []RouteTargetDevice) (*TrafficRule, error) {
+	body, err := c.updateV2(c.trafficRulesPath(), "traffic rule", id, func(rule map[string]interface{}) {
+		rule["app_ids"] = appIDs
+		rule["target_devices"] = devices
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var updated TrafficRule
+	if err := json.Unmarshal(body, &updated); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// DeleteTrafficRule removes a traffic rule
+func (c *APIClient) DeleteTrafficRule(id string) error {
+	_, err := c.doRequest("DELETE", c.trafficRulesPath()+"/"+id)
+	return err
+}
+
+// updateV2 changes a record of a v2 collection at path. Like the classic
+// API, the v2 API expects the complete record, so it is sent back with
+// every field the controller listed.
+func (c *APIClient) updateV2(path, what, id string, change func(map[string]interface{})) ([]byte, error) {
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []map[string]interface{}
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var record map[string]interface{}
+	for _, r := range records {
+		if r["_id"] == id {
+			record = r
+			break
+		}
+	}
+	if record == nil {
+		return nil, fmt.Errorf("no %s with ID %s", what, id)
+	}
+	change(record)
+
+	return c.doRequestWithBody("PUT", path+"/"+id, record)
+}
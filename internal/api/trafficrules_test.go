@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testTrafficRules = `[
+	{"_id":"rule1","description":"Block BitTorrent","enabled":true,"action":"BLOCK","matching_target":"APP",
+	 "app_ids":[65537],"app_category_ids":[],"target_devices":[{"type":"CLIENT","client_mac":"aa:bb:cc:00:00:01"}],
+	 "network_ids":[],"domains":[],"ip_addresses":[],"ip_ranges":[],"regions":[],"schedule":{"mode":"ALWAYS"},
+	 "bandwidth_limit":{"enabled":false,"download_limit_kbps":1024,"upload_limit_kbps":1024},"custom_field":"kept"}
+]`
+
+func TestAPIClient_ListTrafficRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/v2/api/site/default/trafficrules"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testTrafficRules))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	rules, err := client.ListTrafficRules()
+
+	if err != nil {
+		t.Fatalf("ListTrafficRules() returned error: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Action != RuleActionBlock || rules[0].MatchingTarget != RuleTargetApp || rules[0].AppIDs[0] != 65537 {
+		t.Errorf("Unexpected rules %+v", rules)
+	}
+	if rules[0].Schedule.Mode != "ALWAYS" || rules[0].TargetDevices[0].ClientMAC != "aa:bb:cc:00:00:01" {
+		t.Errorf("Unexpected rule details %+v", rules[0])
+	}
+}
+
+func TestAPIClient_CreateTrafficRule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/proxy/network/v2/api/site/default/trafficrules" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["action"] != RuleActionBlock || payload["matching_target"] != RuleTargetApp {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+		if _, ok := payload["_id"]; ok {
+			t.Errorf("Expected no ID to be sent, got %v", payload)
+		}
+		// The controller rejects rules with missing lists
+		if domains, ok := payload["domains"].([]interface{}); !ok || len(domains) != 0 {
+			t.Errorf("Expected an empty domain list, got %v", payload["domains"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_id":"rule2","description":"Block BitTorrent","enabled":true,"action":"BLOCK","matching_target":"APP","app_ids":[65537]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	rule := TrafficRule{
+		ID:             "ignored",
+		Description:    "Block BitTorrent",
+		Enabled:        true,
+		Action:         RuleActionBlock,
+		MatchingTarget: RuleTargetApp,
+		AppIDs:         []int{65537},
+		Domains:        []RouteDomain{},
+	}
+
+	created, err := client.CreateTrafficRule(rule)
+	if err != nil {
+		t.Fatalf("CreateTrafficRule() returned error: %v", err)
+	}
+	if created.ID != "rule2" {
+		t.Errorf("Unexpected rule %+v", created)
+	}
+}
+
+func TestAPIClient_SetTrafficRuleTargets(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/proxy/network/v2/api/site/default/trafficrules":
+			w.Write([]byte(testTrafficRules))
+		case r.Method == "PUT" && r.URL.Path == "/proxy/network/v2/api/site/default/trafficrules/rule1":
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.Write([]byte(`{"_id":"rule1","app_ids":[65537,65541],"target_devices":[]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	updated, err := client.SetTrafficRuleTargets("rule1", []int{65537, 65541}, []RouteTargetDevice{})
+	if err != nil {
+		t.Fatalf("SetTrafficRuleTargets() returned error: %v", err)
+	}
+	if len(updated.AppIDs) != 2 {
+		t.Errorf("Unexpected rule %+v", updated)
+	}
+	if sent["custom_field"] != "kept" || len(sent["app_ids"].([]interface{})) != 2 {
+		t.Errorf("Expected the complete rule with new targets, got %v", sent)
+	}
+
+	if _, err := client.SetTrafficRuleTargets("missing", nil, nil); err == nil {
+		t.Error("Expected error for an unknown rule")
+	}
+}
+
+func TestAPIClient_DeleteTrafficRule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/proxy/network/v2/api/site/default/trafficrules/rule1" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.DeleteTrafficRule("rule1"); err != nil {
+		t.Fatalf("DeleteTrafficRule() returned error: %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -11,7 +13,8 @@ type APIResponse struct {
 }
 
 type Meta struct {
-	RC string `json:"rc"`
+	RC  string `json:"rc"`
+	Msg string `json:"msg,omitempty"`
 }
 
 type ClientsResponse struct {
@@ -20,48 +23,56 @@ type ClientsResponse struct {
 }
 
 type Client struct {
-	ID               string  `json:"_id"`
-	MAC              string  `json:"mac"`
-	SiteID           string  `json:"site_id"`
-	AssocTime        int64   `json:"assoc_time"`
-	LatestAssocTime  int64   `json:"latest_assoc_time"`
-	OUI              string  `json:"oui"`
-	UserID           string  `json:"user_id"`
-	Uptime           int64   `json:"uptime"`
-	LastSeen         int64   `json:"last_seen"`
-	IsWired          bool    `json:"is_wired"`
-	Hostname         string  `json:"hostname"`
-	Name             string  `json:"name"`
-	IP               string  `json:"ip"`
-	Essid            string  `json:"essid"`
-	BSSID            string  `json:"bssid"`
-	Channel          int     `json:"channel"`
-	Radio            string  `json:"radio"`
-	RadioName        string  `json:"radio_name"`
-	RadioProto       string  `json:"radio_proto"`
-	RSSI             int     `json:"rssi"`
-	Signal           int     `json:"signal"`
-	Noise            int     `json:"noise"`
-	TxRate           int     `json:"tx_rate"`
-	RxRate           int     `json:"rx_rate"`
-	TxBytes          int64   `json:"tx_bytes"`
-	RxBytes          int64   `json:"rx_bytes"`
-	TxPackets        int64   `json:"tx_packets"`
-	RxPackets        int64   `json:"rx_packets"`
-	TxBytesR         float64 `json:"tx_bytes-r"`
-	RxBytesR         float64 `json:"rx_bytes-r"`
-	Satisfaction     int     `json:"satisfaction"`
-	Note             string  `json:"note"`
-	ApMAC            string  `json:"ap_mac"`
-	SWMAC            string  `json:"sw_mac"`
-	SWPort           int     `json:"sw_port"`
-	Network          string  `json:"network"`
-	NetworkID        string  `json:"network_id"`
-	UseFixedIP       bool    `json:"use_fixedip"`
-	FixedIP          string  `json:"fixed_ip"`
-	DeviceIDOverride int     `json:"deviceIdOverride"`
-	Blocked          bool    `json:"blocked"`
-	QOSPolicyApplied bool    `json:"qos_policy_applied"`
+	ID              string  `json:"_id"`
+	MAC             string  `json:"mac"`
+	SiteID          string  `json:"site_id"`
+	AssocTime       int64   `json:"assoc_time"`
+	LatestAssocTime int64   `json:"latest_assoc_time"`
+	OUI             string  `json:"oui"`
+	UserID          string  `json:"user_id"`
+	Uptime          int64   `json:"uptime"`
+	LastSeen        int64   `json:"last_seen"`
+	FirstSeen       int64   `json:"first_seen"`
+	IsWired         bool    `json:"is_wired"`
+	IsGuest         bool    `json:"is_guest"`
+	Hostname        string  `json:"hostname"`
+	Name            string  `json:"name"`
+	IP              string  `json:"ip"`
+	Essid           string  `json:"essid"`
+	BSSID           string  `json:"bssid"`
+	Channel         int     `json:"channel"`
+	Radio           string  `json:"radio"`
+	RadioName       string  `json:"radio_name"`
+	RadioProto      string  `json:"radio_proto"`
+	RSSI            int     `json:"rssi"`
+	Signal          int     `json:"signal"`
+	Noise           int     `json:"noise"`
+	TxRate          int     `json:"tx_rate"`
+	RxRate          int     `json:"rx_rate"`
+	TxBytes         int64   `json:"tx_bytes"`
+	RxBytes         int64   `json:"rx_bytes"`
+	TxPackets       int64   `json:"tx_packets"`
+	RxPackets       int64   `json:"rx_packets"`
+	TxBytesR        float64 `json:"tx_bytes-r"`
+	RxBytesR        float64 `json:"rx_bytes-r"`
+	Satisfaction    int     `json:"satisfaction"`
+	Note            string  `json:"note"`
+	ApMAC           string  `json:"ap_mac"`
+	SWMAC           string  `json:"sw_mac"`
+	SWPort          int     `json:"sw_port"`
+	Network         string  `json:"network"`
+	NetworkID       string  `json:"network_id"`
+	// NetworkName is joined in from the network configuration by
+	// JoinNetworkNames; the controller does not always fill in Network
+	NetworkName string `json:"network_name,omitempty"`
+	UsergroupID string `json:"usergroup_id,omitempty"`
+	// UsergroupName is joined in from the user groups by JoinUserGroupNames
+	UsergroupName    string `json:"usergroup_name,omitempty"`
+	UseFixedIP       bool   `json:"use_fixedip"`
+	FixedIP          string `json:"fixed_ip"`
+	DeviceIDOverride int    `json:"deviceIdOverride"`
+	Blocked          bool   `json:"blocked"`
+	QOSPolicyApplied bool   `json:"qos_policy_applied"`
 }
 
 // GetDisplayName returns the best available name for the client
@@ -79,6 +90,52 @@ func (c *Client) GetDisplayName() string {
 	return c.MAC
 }
 
+// SortClients orders clients by display name (case-insensitive), then MAC,
+// so repeated listings come out in the same order
+func SortClients(clients []Client) {
+	sort.SliceStable(clients, func(i, j int) bool {
+		a, b := strings.ToLower(clients[i].GetDisplayName()), strings.ToLower(clients[j].GetDisplayName())
+		if a != b {
+			return a < b
+		}
+		return clients[i].MAC < clients[j].MAC
+	})
+}
+
+// DiffClients compares two listings by MAC and returns the clients that are
+// only in current (connected) and only in previous (disconnected)
+func DiffClients(previous, current []Client) (connected, disconnected []Client) {
+	before := make(map[string]bool, len(previous))
+	for _, client := range previous {
+		before[client.MAC] = true
+	}
+
+	now := make(map[string]bool, len(current))
+	for _, client := range current {
+		now[client.MAC] = true
+		if !before[client.MAC] {
+			connected = append(connected, client)
+		}
+	}
+
+	for _, client := range previous {
+		if !now[client.MAC] {
+			disconnected = append(disconnected, client)
+		}
+	}
+
+	return connected, disconnected
+}
+
+// GetNetworkName returns the name of the client's network, preferring the
+// name joined in from the network configuration
+func (c *Client) GetNetworkName() string {
+	if c.NetworkName != "" {
+		return c.NetworkName
+	}
+	return c.Network
+}
+
 // GetConnectionType returns "Wired" or "Wireless"
 func (c *Client) GetConnectionType() string {
 	if c.IsWired {
@@ -95,6 +152,42 @@ func (c *Client) GetSSID() string {
 	return ""
 }
 
+// Radio bands as used by clients steer
+const (
+	Band2G = "2g"
+	Band5G = "5g"
+	Band6G = "6g"
+)
+
+// radioBands maps the radio field of wireless clients to bands
+var radioBands = map[string]string{
+	"ng": Band2G,
+	"na": Band5G,
+	"6e": Band6G,
+}
+
+// GetBand returns the radio band of a wireless client ("2g", "5g" or "6g"),
+// empty if unknown or wired
+func (c *Client) GetBand() string {
+	if c.IsWired {
+		return ""
+	}
+	return radioBands[c.Radio]
+}
+
+// BandName returns a band in words, e.g. "5 GHz"
+func BandName(band string) string {
+	switch band {
+	case Band2G:
+		return "2.4 GHz"
+	case Band5G:
+		return "5 GHz"
+	case Band6G:
+		return "6 GHz"
+	}
+	return "an unknown band"
+}
+
 // GetSignal returns the signal strength for wireless clients
 func (c *Client) GetSignal() string {
 	if !c.IsWired && c.Signal != 0 {
@@ -105,7 +198,12 @@ func (c *Client) GetSignal() string {
 
 // GetUptime returns a human-readable uptime duration
 func (c *Client) GetUptime() string {
-	d := time.Duration(c.Uptime) * time.Second
+	return FormatUptime(c.Uptime)
+}
+
+// FormatUptime formats a number of seconds as e.g. "2d 3h 15m"
+func FormatUptime(seconds int64) string {
+	d := time.Duration(seconds) * time.Second
 
 	days := d / (24 * time.Hour)
 	d -= days * 24 * time.Hour
@@ -179,6 +179,32 @@ func TestClient_GetSignal(t *testing.T) {
 	}
 }
 
+func TestClient_GetBand(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   Client
+		expected string
+	}{
+		{"2.4 GHz", Client{Radio: "ng"}, Band2G},
+		{"5 GHz", Client{Radio: "na"}, Band5G},
+		{"6 GHz", Client{Radio: "6e"}, Band6G},
+		{"unknown radio", Client{Radio: "xx"}, ""},
+		{"wired", Client{IsWired: true, Radio: "na"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.client.GetBand(); result != tt.expected {
+				t.Errorf("GetBand() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	if BandName(Band2G) != "2.4 GHz" || BandName("") != "an unknown band" {
+		t.Errorf("Unexpected band names %q, %q", BandName(Band2G), BandName(""))
+	}
+}
+
 func TestClient_GetUptime(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -367,3 +393,36 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestSortClients(t *testing.T) {
+	clients := []Client{
+		{MAC: "aa:bb:cc:dd:ee:03", Hostname: "nas"},
+		{MAC: "aa:bb:cc:dd:ee:02", Name: "android"},
+		{MAC: "aa:bb:cc:dd:ee:01", Name: "Android"},
+		{MAC: "aa:bb:cc:dd:ee:04", Name: "Zebra"},
+		{MAC: "aa:bb:cc:dd:ee:05"},
+	}
+
+	SortClients(clients)
+
+	expected := []string{"aa:bb:cc:dd:ee:05", "aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02", "aa:bb:cc:dd:ee:03", "aa:bb:cc:dd:ee:04"}
+	for i, mac := range expected {
+		if clients[i].MAC != mac {
+			t.Errorf("Position %d: expected %s, got %s (%s)", i, mac, clients[i].MAC, clients[i].GetDisplayName())
+		}
+	}
+}
+
+func TestDiffClients(t *testing.T) {
+	previous := []Client{{MAC: "aa:bb:cc:dd:ee:01"}, {MAC: "aa:bb:cc:dd:ee:02"}}
+	current := []Client{{MAC: "aa:bb:cc:dd:ee:02"}, {MAC: "aa:bb:cc:dd:ee:03"}}
+
+	connected, disconnected := DiffClients(previous, current)
+
+	if len(connected) != 1 || connected[0].MAC != "aa:bb:cc:dd:ee:03" {
+		t.Errorf("Expected aa:bb:cc:dd:ee:03 to be connected, got %v", connected)
+	}
+	if len(disconnected) != 1 || disconnected[0].MAC != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("Expected aa:bb:cc:dd:ee:01 to be disconnected, got %v", disconnected)
+	}
+}
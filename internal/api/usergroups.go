@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// UserGroup is a client group with bandwidth limits (QoS), from
+// rest/usergroup. Clients without a group are in the default group.
+type UserGroup struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+	// QOSRateMaxDown and QOSRateMaxUp are in Kbps, -1 for unlimited
+	QOSRateMaxDown int `json:"qos_rate_max_down"`
+	QOSRateMaxUp   int `json:"qos_rate_max_up"`
+	// HiddenID is "Default" for the site's default group
+	HiddenID string `json:"attr_hidden_id,omitempty"`
+}
+
+type UserGroupsResponse struct {
+	Meta Meta        `json:"meta"`
+	Data []UserGroup `json:"data"`
+}
+
+// ListUserGroups returns the user groups of the site
+func (c *APIClient) ListUserGroups() ([]UserGroup, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/usergroup", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response UserGroupsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// FindUserGroup looks a user group up by ID or case-insensitive name
+func FindUserGroup(groups []UserGroup, query string) (*UserGroup, error) {
+	for i := range groups {
+		if groups[i].ID == query {
+			return &groups[i], nil
+		}
+	}
+
+	for i := range groups {
+		if strings.EqualFold(groups[i].Name, query) {
+			return &groups[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no user group named %q", query)
+}
+
+// JoinUserGroupNames sets UsergroupName on every client from its user group
+// ID. Clients without one get the name of the default group.
+func JoinUserGroupNames(clients []Client, groups []UserGroup) {
+	names := make(map[string]string, len(groups))
+	for _, g := range groups {
+		names[g.ID] = g.Name
+		if g.HiddenID == "Default" {
+			names[""] = g.Name
+		}
+	}
+
+	for i := range clients {
+		if name, ok := names[clients[i].UsergroupID]; ok {
+			clients[i].UsergroupName = name
+		}
+	}
+}
+
+// Limits describes the group's bandwidth limits, e.g.
+// "down 10 Mbps, up unlimited"
+func (g UserGroup) Limits() string {
+	return "down " + formatKbps(g.QOSRateMaxDown) + ", up " + formatKbps(g.QOSRateMaxUp)
+}
+
+func formatKbps(kbps int) string {
+	switch {
+	case kbps < 0:
+		return "unlimited"
+	case kbps >= 1000 && kbps%1000 == 0:
+		return fmt.Sprintf("%d Mbps", kbps/1000)
+	default:
+		return fmt.Sprintf("%d Kbps", kbps)
+	}
+}
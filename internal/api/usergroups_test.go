@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListUserGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/usergroup"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"g1","name":"Default","qos_rate_max_down":-1,"qos_rate_max_up":-1,"attr_hidden_id":"Default"},{"_id":"g2","name":"Kids","qos_rate_max_down":10000,"qos_rate_max_up":2000}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	groups, err := client.ListUserGroups()
+
+	if err != nil {
+		t.Fatalf("ListUserGroups() returned error: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 user groups, got %d", len(groups))
+	}
+	if groups[1].QOSRateMaxDown != 10000 || groups[1].QOSRateMaxUp != 2000 || groups[0].HiddenID != "Default" {
+		t.Errorf("Unexpected user groups %+v", groups)
+	}
+}
+
+func TestFindUserGroup(t *testing.T) {
+	groups := []UserGroup{{ID: "g1", Name: "Default"}, {ID: "g2", Name: "Kids"}}
+
+	if g, err := FindUserGroup(groups, "kids"); err != nil || g.ID != "g2" {
+		t.Errorf("Expected to find Kids by name, got %v, %v", g, err)
+	}
+	if g, err := FindUserGroup(groups, "g1"); err != nil || g.Name != "Default" {
+		t.Errorf("Expected to find Default by ID, got %v, %v", g, err)
+	}
+	if _, err := FindUserGroup(groups, "Guests"); err == nil {
+		t.Error("Expected error for unknown user group")
+	}
+}
+
+func TestJoinUserGroupNames(t *testing.T) {
+	clients := []Client{
+		{MAC: "aa:bb:cc:dd:ee:01", UsergroupID: "g2"},
+		{MAC: "aa:bb:cc:dd:ee:02"},
+		{MAC: "aa:bb:cc:dd:ee:03", UsergroupID: "gone"},
+	}
+
+	JoinUserGroupNames(clients, []UserGroup{{ID: "g1", Name: "Default", HiddenID: "Default"}, {ID: "g2", Name: "Kids"}})
+
+	if clients[0].UsergroupName != "Kids" {
+		t.Errorf("Expected Kids, got %+v", clients[0])
+	}
+	if clients[1].UsergroupName != "Default" {
+		t.Errorf("Expected the default group for a client without one, got %+v", clients[1])
+	}
+	if clients[2].UsergroupName != "" {
+		t.Errorf("Expected no name for an unknown group, got %+v", clients[2])
+	}
+}
+
+func TestUserGroup_Limits(t *testing.T) {
+	tests := []struct {
+		group UserGroup
+		want  string
+	}{
+		{UserGroup{QOSRateMaxDown: -1, QOSRateMaxUp: -1}, "down unlimited, up unlimited"},
+		{UserGroup{QOSRateMaxDown: 10000, QOSRateMaxUp: 2500}, "down 10 Mbps, up 2500 Kbps"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.group.Limits(); got != tt.want {
+			t.Errorf("Limits() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// User is the controller's persistent record of a client ("known client"),
+// which outlives individual connections and holds the alias, note and
+// fixed IP configuration
+type User struct {
+	ID          string `json:"_id"`
+	MAC         string `json:"mac"`
+	SiteID      string `json:"site_id"`
+	Name        string `json:"name"`
+	Hostname    string `json:"hostname"`
+	OUI         string `json:"oui"`
+	Note        string `json:"note"`
+	Noted       bool   `json:"noted"`
+	UseFixedIP  bool   `json:"use_fixedip"`
+	FixedIP     string `json:"fixed_ip"`
+	NetworkID   string `json:"network_id"`
+	UsergroupID string `json:"usergroup_id"`
+	Blocked     bool   `json:"blocked"`
+	IsGuest     bool   `json:"is_guest"`
+	IsWired     bool   `json:"is_wired"`
+	FirstSeen   int64  `json:"first_seen"`
+	LastSeen    int64  `json:"last_seen"`
+}
+
+type UsersResponse struct {
+	Meta Meta   `json:"meta"`
+	Data []User `json:"data"`
+}
+
+func (c *APIClient) parseUsers(body []byte) ([]User, error) {
+	var response UsersResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ListUsers returns every known client of the site, connected or not
+func (c *APIClient) ListUsers() ([]User, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/user", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseUsers(body)
+}
+
+// ListUserRecords returns every known client of the site as the controller's
+// unmodified JSON, including fields that User does not model
+func (c *APIClient) ListUserRecords() ([]json.RawMessage, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/user", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Meta Meta              `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// GetUser looks up the known-client record for a MAC address
+func (c *APIClient) GetUser(mac string) (*User, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/stat/user/%s", c.Site, strings.ToLower(mac))
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := c.parseUsers(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no known client with MAC %s", mac)
+	}
+
+	return &users[0], nil
+}
+
+// UpdateUser changes only the given fields of a known-client record and
+// returns the updated record
+func (c *APIClient) UpdateUser(id string, fields map[string]interface{}) (*User, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/user/%s", c.Site, id)
+
+	body, err := c.doRequestWithBody("PUT", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := c.parseUsers(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, fmt.Errorf("controller returned no record for user %s", id)
+	}
+
+	return &users[0], nil
+}
+
+// CreateUser adds a known-client record, e.g. to name a client before it has
+// ever connected, and returns the new record
+func (c *APIClient) CreateUser(fields map[string]interface{}) (*User, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/user", c.Site)
+
+	body, err := c.doRequestWithBody("POST", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := c.parseUsers(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, fmt.Errorf("controller returned no record for the new user")
+	}
+
+	return &users[0], nil
+}
+
+// ForgetClients removes the known-client records (alias, note, history) of the
+// given MACs from the controller
+func (c *APIClient) ForgetClients(macs []string) error {
+	return c.sendCommand("stamgr", map[string]interface{}{"cmd": "forget-sta", "macs": macs})
+}
+
+// DisconnectedSince returns the users that are not currently connected and
+// were last seen before cutoff
+func DisconnectedSince(users []User, connected []Client, cutoff time.Time) []User {
+	online := make(map[string]bool, len(connected))
+	for _, client := range connected {
+		online[strings.ToLower(client.MAC)] = true
+	}
+
+	var stale []User
+	for _, user := range users {
+		if online[strings.ToLower(user.MAC)] {
+			continue
+		}
+		if time.Unix(user.LastSeen, 0).Before(cutoff) {
+			stale = append(stale, user)
+		}
+	}
+	return stale
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIClient_GetUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/user/aa:bb:cc:dd:ee:ff"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"u1","mac":"aa:bb:cc:dd:ee:ff","name":"TV"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	user, err := client.GetUser("AA:BB:CC:DD:EE:FF")
+
+	if err != nil {
+		t.Fatalf("GetUser() returned error: %v", err)
+	}
+	if user.ID != "u1" || user.Name != "TV" {
+		t.Errorf("Unexpected user %+v", user)
+	}
+}
+
+func TestAPIClient_GetUser_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.GetUser("aa:bb:cc:dd:ee:ff"); err == nil {
+		t.Error("Expected error for unknown client")
+	}
+}
+
+func TestAPIClient_UpdateUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/user/u1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if len(payload) != 1 || payload["name"] != "Living Room TV" {
+			t.Errorf("Expected only the name to be sent, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"u1","name":"Living Room TV"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	user, err := client.UpdateUser("u1", map[string]interface{}{"name": "Living Room TV"})
+
+	if err != nil {
+		t.Fatalf("UpdateUser() returned error: %v", err)
+	}
+	if user.Name != "Living Room TV" {
+		t.Errorf("Expected name 'Living Room TV', got '%s'", user.Name)
+	}
+}
+
+func TestAPIClient_ListUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/user"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"u1","mac":"aa:bb:cc:dd:ee:01","last_seen":1700000000},{"_id":"u2","mac":"aa:bb:cc:dd:ee:02"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	users, err := client.ListUsers()
+
+	if err != nil {
+		t.Fatalf("ListUsers() returned error: %v", err)
+	}
+	if len(users) != 2 || users[0].LastSeen != 1700000000 {
+		t.Errorf("Unexpected users %+v", users)
+	}
+}
+
+func TestAPIClient_ListUserRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/user"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"u1","mac":"aa:bb:cc:dd:ee:01","fingerprint_override":true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	records, err := client.ListUserRecords()
+
+	if err != nil {
+		t.Fatalf("ListUserRecords() returned error: %v", err)
+	}
+	if len(records) != 1 || string(records[0]) != `{"_id":"u1","mac":"aa:bb:cc:dd:ee:01","fingerprint_override":true}` {
+		t.Errorf("Expected the record unmodified, got %s", records)
+	}
+}
+
+func TestAPIClient_ForgetClients(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/stamgr"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload struct {
+			Cmd  string   `json:"cmd"`
+			MACs []string `json:"macs"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload.Cmd != "forget-sta" {
+			t.Errorf("Expected cmd 'forget-sta', got '%s'", payload.Cmd)
+		}
+		if len(payload.MACs) != 2 || payload.MACs[1] != "aa:bb:cc:dd:ee:02" {
+			t.Errorf("Unexpected macs %v", payload.MACs)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.ForgetClients([]string{"aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02"}); err != nil {
+		t.Fatalf("ForgetClients() returned error: %v", err)
+	}
+}
+
+func TestDisconnectedSince(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	users := []User{
+		{MAC: "aa:bb:cc:dd:ee:01", LastSeen: now.Add(-40 * 24 * time.Hour).Unix()},
+		{MAC: "aa:bb:cc:dd:ee:02", LastSeen: now.Add(-time.Hour).Unix()},
+		{MAC: "AA:BB:CC:DD:EE:03", LastSeen: now.Add(-40 * 24 * time.Hour).Unix()},
+	}
+	connected := []Client{{MAC: "aa:bb:cc:dd:ee:03"}}
+
+	stale := DisconnectedSince(users, connected, now.Add(-30*24*time.Hour))
+	if len(stale) != 1 || stale[0].MAC != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("Expected only aa:bb:cc:dd:ee:01, got %+v", stale)
+	}
+}
+
+func TestAPIClient_CreateUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/user"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["mac"] != "aa:bb:cc:dd:ee:ff" || payload["name"] != "TV" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"u1","mac":"aa:bb:cc:dd:ee:ff","name":"TV"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	user, err := client.CreateUser(map[string]interface{}{"mac": "aa:bb:cc:dd:ee:ff", "name": "TV"})
+
+	if err != nil {
+		t.Fatalf("CreateUser() returned error: %v", err)
+	}
+	if user.ID != "u1" {
+		t.Errorf("Unexpected user %+v", user)
+	}
+}
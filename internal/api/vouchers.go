@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Voucher is a hotspot voucher from stat/voucher: a code that grants a guest
+// network access for a time
+type Voucher struct {
+	ID   string `json:"_id"`
+	Code string `json:"code"`
+	// CreateTime is in seconds since the epoch
+	CreateTime int64 `json:"create_time"`
+	// Duration is how long a guest stays authorized, in minutes
+	Duration int `json:"duration"`
+	// Quota is how many guests may use the voucher, 0 for any number
+	Quota  int    `json:"quota"`
+	Used   int    `json:"used"`
+	Note   string `json:"note,omitempty"`
+	Status string `json:"status,omitempty"`
+	// QosRateMaxUp and QosRateMaxDown limit guests' bandwidth in Kbps,
+	// QosUsageQuota their traffic in MB; zero means no limit
+	QosRateMaxUp   int `json:"qos_rate_max_up,omitempty"`
+	QosRateMaxDown int `json:"qos_rate_max_down,omitempty"`
+	QosUsageQuota  int `json:"qos_usage_quota,omitempty"`
+}
+
+type VouchersResponse struct {
+	Meta Meta      `json:"meta"`
+	Data []Voucher `json:"data"`
+}
+
+// VoucherSpec describes a batch of vouchers to create
+type VoucherSpec struct {
+	Count int
+	// Duration is how long each guest stays authorized, in whole minutes
+	Duration time.Duration
+	// Quota is how many guests may use each voucher, 0 for any number
+	Quota int
+	Note  string
+	// Up and Down limit bandwidth in Kbps, DataLimit traffic in MB; zero
+	// means no limit
+	Up        int
+	Down      int
+	DataLimit int
+}
+
+// SortVouchers orders vouchers newest first, then by code
+func SortVouchers(vouchers []Voucher) {
+	sort.SliceStable(vouchers, func(i, j int) bool {
+		if vouchers[i].CreateTime != vouchers[j].CreateTime {
+			return vouchers[i].CreateTime > vouchers[j].CreateTime
+		}
+		return vouchers[i].Code < vouchers[j].Code
+	})
+}
+
+// ListVouchers returns the site's vouchers
+func (c *APIClient) ListVouchers() ([]Voucher, error) {
+	return c.listVouchers(nil)
+}
+
+func (c *APIClient) listVouchers(payload interface{}) ([]Voucher, error) {
+	body, err := c.FetchSiteRaw("stat/voucher", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response VouchersResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// CreateVouchers creates a batch of vouchers and returns them with their
+// codes. The controller only reports when the batch was created, so the
+// vouchers are then looked up by that time.
+func (c *APIClient) CreateVouchers(spec VoucherSpec) ([]Voucher, error) {
+	payload := map[string]interface{}{
+		"cmd":    "create-voucher",
+		"n":      spec.Count,
+		"expire": int(spec.Duration / time.Minute),
+		"quota":  spec.Quota,
+	}
+	if spec.Note != "" {
+		payload["note"] = spec.Note
+	}
+	if spec.Up > 0 {
+		payload["up"] = spec.Up
+	}
+	if spec.Down > 0 {
+		payload["down"] = spec.Down
+	}
+	if spec.DataLimit > 0 {
+		payload["bytes"] = spec.DataLimit
+	}
+
+	path := fmt.Sprintf("/proxy/network/api/s/%s/cmd/hotspot", c.Site)
+	body, err := c.doRequestWithBody("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Meta Meta `json:"meta"`
+		Data []struct {
+			CreateTime int64 `json:"create_time"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("controller did not report the created vouchers")
+	}
+
+	return c.listVouchers(map[string]int64{"create_time": response.Data[0].CreateTime})
+}
+
+// RevokeVoucher deletes the voucher with the given ID; guests who already
+// used it stay authorized until their time is up
+func (c *APIClient) RevokeVoucher(id string) error {
+	return c.sendCommand("hotspot", map[string]string{"cmd": "delete-voucher", "_id": id})
+}
+
+// FindVoucher looks a voucher up by ID or code, with or without the dash
+// shown between its halves
+func FindVoucher(vouchers []Voucher, query string) (*Voucher, error) {
+	code := strings.ReplaceAll(query, "-", "")
+	for i := range vouchers {
+		if strings.EqualFold(vouchers[i].ID, query) || vouchers[i].Code == code {
+			return &vouchers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no voucher with code or ID %q", query)
+}
+
+// FormattedCode returns the code split in two halves like the controller
+// shows it, e.g. 12345-67890
+func (v *Voucher) FormattedCode() string {
+	if len(v.Code) != 10 {
+		return v.Code
+	}
+	return v.Code[:5] + "-" + v.Code[5:]
+}
+
+// GetCreateTime returns when the voucher was created
+func (v *Voucher) GetCreateTime() time.Time {
+	return time.Unix(v.CreateTime, 0)
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIClient_ListVouchers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/stat/voucher"
+		if r.Method != "GET" || r.URL.Path != expectedPath {
+			t.Errorf("Expected GET %s, got %s %s", expectedPath, r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"v1","code":"1111122222","create_time":1700000000,"duration":1440,"quota":1},
+			{"_id":"v3","code":"5555566666","create_time":1700000100,"duration":60,"quota":0},
+			{"_id":"v2","code":"3333344444","create_time":1700000000,"duration":1440,"quota":1}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	vouchers, err := client.ListVouchers()
+
+	if err != nil {
+		t.Fatalf("ListVouchers() returned error: %v", err)
+	}
+	if len(vouchers) != 3 || vouchers[1].ID != "v3" || vouchers[1].Code != "5555566666" || vouchers[1].Quota != 0 {
+		t.Errorf("Unexpected vouchers %+v", vouchers)
+	}
+}
+
+func TestAPIClient_CreateVouchers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		switch r.URL.Path {
+		case "/proxy/network/api/s/default/cmd/hotspot":
+			if payload["cmd"] != "create-voucher" || payload["n"] != float64(10) || payload["expire"] != float64(1440) || payload["quota"] != float64(1) {
+				t.Errorf("Unexpected payload %v", payload)
+			}
+			if payload["note"] != "conference" || payload["down"] != float64(5000) {
+				t.Errorf("Expected note and download limit in payload %v", payload)
+			}
+			if _, ok := payload["up"]; ok {
+				t.Errorf("Expected no upload limit in payload %v", payload)
+			}
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"create_time":1700000000}]}`))
+		case "/proxy/network/api/s/default/stat/voucher":
+			if r.Method != "POST" || payload["create_time"] != float64(1700000000) {
+				t.Errorf("Expected vouchers to be looked up by creation time, got %s %v", r.Method, payload)
+			}
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"v1","code":"1111122222","create_time":1700000000}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	vouchers, err := client.CreateVouchers(VoucherSpec{Count: 10, Duration: 24 * time.Hour, Quota: 1, Note: "conference", Down: 5000})
+
+	if err != nil {
+		t.Fatalf("CreateVouchers() returned error: %v", err)
+	}
+	if len(vouchers) != 1 || vouchers[0].Code != "1111122222" {
+		t.Errorf("Expected the created voucher, got %+v", vouchers)
+	}
+}
+
+func TestAPIClient_RevokeVoucher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/cmd/hotspot"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["cmd"] != "delete-voucher" || payload["_id"] != "v1" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if err := client.RevokeVoucher("v1"); err != nil {
+		t.Fatalf("RevokeVoucher() returned error: %v", err)
+	}
+}
+
+func TestFindVoucher(t *testing.T) {
+	vouchers := []Voucher{{ID: "v1", Code: "1111122222"}, {ID: "v2", Code: "3333344444"}}
+
+	for _, query := range []string{"v2", "3333344444", "33333-44444"} {
+		v, err := FindVoucher(vouchers, query)
+		if err != nil || v.ID != "v2" {
+			t.Errorf("FindVoucher(%q) = %v, %v; want v2", query, v, err)
+		}
+	}
+	if _, err := FindVoucher(vouchers, "99999-99999"); err == nil {
+		t.Error("Expected error for unknown voucher")
+	}
+}
+
+func TestVoucher_FormattedCode(t *testing.T) {
+	if got := (&Voucher{Code: "1234567890"}).FormattedCode(); got != "12345-67890" {
+		t.Errorf("Expected 12345-67890, got %s", got)
+	}
+	if got := (&Voucher{Code: "12345"}).FormattedCode(); got != "12345" {
+		t.Errorf("Expected codes of other lengths unchanged, got %s", got)
+	}
+}
+
+func TestSortVouchers(t *testing.T) {
+	vouchers := []Voucher{
+		{ID: "v1", Code: "3333344444", CreateTime: 1700000000},
+		{ID: "v2", Code: "1111122222", CreateTime: 1700000000},
+		{ID: "v3", Code: "5555566666", CreateTime: 1700000100},
+	}
+
+	SortVouchers(vouchers)
+
+	expected := []string{"v3", "v2", "v1"}
+	for i, id := range expected {
+		if vouchers[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, vouchers[i].ID)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WLAN is a wireless network (SSID) configuration from rest/wlanconf
+type WLAN struct {
+	ID             string   `json:"_id"`
+	Name           string   `json:"name"`
+	Enabled        bool     `json:"enabled"`
+	Security       string   `json:"security"`
+	WPAMode        string   `json:"wpa_mode"`
+	WPA3Support    bool     `json:"wpa3_support"`
+	WPA3Transition bool     `json:"wpa3_transition"`
+	PMFMode        string   `json:"pmf_mode"`
+	Passphrase     string   `json:"x_passphrase,omitempty"`
+	IsGuest        bool     `json:"is_guest"`
+	L2Isolation    bool     `json:"l2_isolation"`
+	HideSSID       bool     `json:"hide_ssid"`
+	NetworkConfID  string   `json:"networkconf_id"`
+	VLAN           string   `json:"vlan,omitempty"`
+	VLANEnabled    bool     `json:"vlan_enabled"`
+	WLANBand       string   `json:"wlan_band"`
+	WLANBands      []string `json:"wlan_bands,omitempty"`
+	UsergroupID    string   `json:"usergroup_id"`
+	APGroupIDs     []string `json:"ap_group_ids,omitempty"`
+	// PrivatePSKs are the per-user passphrases (PPSK) of a WPA2 personal
+	// network, each putting its clients on its own network
+	PrivatePSKs        []PrivatePSK `json:"private_preshared_keys,omitempty"`
+	PrivatePSKsEnabled bool         `json:"private_preshared_keys_enabled"`
+	// Schedule lists the periods the WLAN is on when ScheduleEnabled is set
+	ScheduleEnabled bool            `json:"schedule_enabled"`
+	Schedule        []ScheduleBlock `json:"schedule_with_duration,omitempty"`
+}
+
+// ScheduleBlock is a period a scheduled WLAN is on, starting at the same time
+// on each of the given days ("mon" to "sun")
+type ScheduleBlock struct {
+	Days        []string `json:"start_days_of_week"`
+	StartHour   int      `json:"start_hour"`
+	StartMinute int      `json:"start_minute"`
+	Duration    int      `json:"duration_minutes"`
+	Name        string   `json:"name,omitempty"`
+}
+
+// PrivatePSK is one private pre-shared key of a WLAN
+type PrivatePSK struct {
+	Password      string `json:"password"`
+	NetworkConfID string `json:"networkconf_id"`
+}
+
+// WLANStatus is a wireless network with its network, VLAN and the number of
+// connected clients, for listing
+type WLANStatus struct {
+	WLAN
+	Network string `json:"network,omitempty"`
+	// VLANID is the VLAN the SSID is bridged to, 0 for untagged
+	VLANID  int `json:"vlan_id,omitempty"`
+	Clients int `json:"clients"`
+}
+
+type WLANsResponse struct {
+	Meta Meta   `json:"meta"`
+	Data []WLAN `json:"data"`
+}
+
+func (c *APIClient) parseWLANs(body []byte) ([]WLAN, error) {
+	var response WLANsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := c.checkMeta(response.Meta); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ListWLANs returns all configured wireless networks
+func (c *APIClient) ListWLANs() ([]WLAN, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/wlanconf", c.Site)
+
+	body, err := c.doRequest("GET", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseWLANs(body)
+}
+
+// UpdateWLAN changes only the given fields of a wireless network and returns
+// the updated configuration
+func (c *APIClient) UpdateWLAN(id string, fields map[string]interface{}) (*WLAN, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/wlanconf/%s", c.Site, id)
+
+	body, err := c.doRequestWithBody("PUT", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	wlans, err := c.parseWLANs(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wlans) == 0 {
+		return nil, fmt.Errorf("controller returned no record for WLAN %s", id)
+	}
+
+	return &wlans[0], nil
+}
+
+// CreateWLAN adds a wireless network and returns the new configuration
+func (c *APIClient) CreateWLAN(fields map[string]interface{}) (*WLAN, error) {
+	path := fmt.Sprintf("/proxy/network/api/s/%s/rest/wlanconf", c.Site)
+
+	body, err := c.doRequestWithBody("POST", path, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	wlans, err := c.parseWLANs(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wlans) == 0 {
+		return nil, fmt.Errorf("controller returned no record for the new WLAN")
+	}
+
+	return &wlans[0], nil
+}
+
+// FindWLAN looks a wireless network up by ID or case-insensitive SSID
+func FindWLAN(wlans []WLAN, query string) (*WLAN, error) {
+	for i := range wlans {
+		if wlans[i].ID == query {
+			return &wlans[i], nil
+		}
+	}
+
+	for i := range wlans {
+		if strings.EqualFold(wlans[i].Name, query) {
+			return &wlans[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no WLAN with SSID %q", query)
+}
+
+// GetSecurity describes the WLAN's security, e.g. "WPA2/WPA3" or "Open"
+func (w *WLAN) GetSecurity() string {
+	switch w.Security {
+	case "open":
+		return "Open"
+	case "wep":
+		return "WEP"
+	case "wpaeap":
+		return "Enterprise"
+	case "wpapsk":
+		switch {
+		case w.WPA3Support && w.WPA3Transition:
+			return "WPA2/WPA3"
+		case w.WPA3Support:
+			return "WPA3"
+		case w.WPAMode == "wpa1":
+			return "WPA"
+		case w.WPAMode == "auto":
+			return "WPA/WPA2"
+		}
+		return "WPA2"
+	}
+	return w.Security
+}
+
+// bandNames maps wlan_band(s) values to frequencies
+var bandNames = map[string]string{"2g": "2.4", "5g": "5", "6g": "6"}
+
+// GetBands describes the radio bands the WLAN is broadcast on, e.g.
+// "2.4/5 GHz"
+func (w *WLAN) GetBands() string {
+	bands := w.WLANBands
+	if len(bands) == 0 {
+		switch w.WLANBand {
+		case "", "both":
+			bands = []string{"2g", "5g"}
+		default:
+			bands = []string{w.WLANBand}
+		}
+	}
+
+	names := make([]string, 0, len(bands))
+	for _, band := range bands {
+		if name, ok := bandNames[band]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, band)
+		}
+	}
+	return strings.Join(names, "/") + " GHz"
+}
+
+// SortWLANs orders wireless networks by SSID (case-insensitive), then ID
+func SortWLANs(wlans []WLAN) {
+	sort.SliceStable(wlans, func(i, j int) bool {
+		a, b := strings.ToLower(wlans[i].Name), strings.ToLower(wlans[j].Name)
+		if a != b {
+			return a < b
+		}
+		return wlans[i].ID < wlans[j].ID
+	})
+}
+
+// WLANStatuses joins WLANs with the network they are bridged to and counts
+// the wireless clients connected to each SSID
+func WLANStatuses(wlans []WLAN, networks []Network, clients []Client) []WLANStatus {
+	networkByID := make(map[string]Network, len(networks))
+	for _, n := range networks {
+		networkByID[n.ID] = n
+	}
+
+	clientsPerSSID := map[string]int{}
+	for _, client := range clients {
+		if !client.IsWired {
+			clientsPerSSID[client.Essid]++
+		}
+	}
+
+	statuses := make([]WLANStatus, len(wlans))
+	for i, wlan := range wlans {
+		status := WLANStatus{WLAN: wlan, Clients: clientsPerSSID[wlan.Name]}
+
+		if network, ok := networkByID[wlan.NetworkConfID]; ok {
+			status.Network = network.Name
+			if network.VLANEnabled {
+				status.VLANID = network.VLAN
+			}
+		}
+		// Older controllers tag the SSID itself instead of using a network
+		if wlan.VLANEnabled && wlan.VLAN != "" {
+			status.VLANID, _ = strconv.Atoi(wlan.VLAN)
+		}
+
+		statuses[i] = status
+	}
+	return statuses
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_ListWLANs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/proxy/network/api/s/default/rest/wlanconf"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"w1","name":"Home","enabled":true,"security":"wpapsk","wpa_mode":"wpa2","x_passphrase":"secret","pmf_mode":"optional"},
+			{"_id":"w2","name":"Guest","enabled":false,"security":"open","is_guest":true}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	wlans, err := client.ListWLANs()
+
+	if err != nil {
+		t.Fatalf("ListWLANs() returned error: %v", err)
+	}
+	if len(wlans) != 2 {
+		t.Fatalf("Expected 2 WLANs, got %d", len(wlans))
+	}
+	if wlans[0].Passphrase != "secret" || wlans[0].PMFMode != "optional" {
+		t.Errorf("Unexpected first WLAN %+v", wlans[0])
+	}
+	if !wlans[1].IsGuest || wlans[1].Enabled {
+		t.Errorf("Unexpected second WLAN %+v", wlans[1])
+	}
+}
+
+func TestAPIClient_ListWLANs_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"error"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	if _, err := client.ListWLANs(); err == nil {
+		t.Error("Expected error for API error response")
+	}
+}
+
+func TestAPIClient_UpdateWLAN(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/wlanconf/w1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["x_passphrase"] != "new-secret" || len(payload) != 1 {
+			t.Errorf("Expected only x_passphrase to be sent, got %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"w1","name":"Guest","x_passphrase":"new-secret"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	wlan, err := client.UpdateWLAN("w1", map[string]interface{}{"x_passphrase": "new-secret"})
+
+	if err != nil {
+		t.Fatalf("UpdateWLAN() returned error: %v", err)
+	}
+	if wlan.Passphrase != "new-secret" {
+		t.Errorf("Expected updated passphrase, got %q", wlan.Passphrase)
+	}
+}
+
+func TestAPIClient_CreateWLAN(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		expectedPath := "/proxy/network/api/s/default/rest/wlanconf"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["name"] != "Office" || payload["security"] != "wpapsk" {
+			t.Errorf("Unexpected payload %v", payload)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"w3","name":"Office","security":"wpapsk"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL, "test-key", "default", true)
+	wlan, err := client.CreateWLAN(map[string]interface{}{"name": "Office", "security": "wpapsk"})
+
+	if err != nil {
+		t.Fatalf("CreateWLAN() returned error: %v", err)
+	}
+	if wlan.ID != "w3" {
+		t.Errorf("Unexpected WLAN %+v", wlan)
+	}
+}
+
+func TestFindWLAN(t *testing.T) {
+	wlans := []WLAN{{ID: "w1", Name: "Home"}, {ID: "w2", Name: "Guest"}}
+
+	if wlan, err := FindWLAN(wlans, "guest"); err != nil || wlan.ID != "w2" {
+		t.Errorf("Expected to find Guest by SSID, got %v, %v", wlan, err)
+	}
+	if wlan, err := FindWLAN(wlans, "w1"); err != nil || wlan.Name != "Home" {
+		t.Errorf("Expected to find Home by ID, got %v, %v", wlan, err)
+	}
+	if _, err := FindWLAN(wlans, "Office"); err == nil {
+		t.Error("Expected error for unknown SSID")
+	}
+}
+
+func TestWLAN_GetSecurity(t *testing.T) {
+	tests := []struct {
+		name     string
+		wlan     WLAN
+		expected string
+	}{
+		{"open", WLAN{Security: "open"}, "Open"},
+		{"wep", WLAN{Security: "wep"}, "WEP"},
+		{"enterprise", WLAN{Security: "wpaeap", WPAMode: "wpa2"}, "Enterprise"},
+		{"wpa2", WLAN{Security: "wpapsk", WPAMode: "wpa2"}, "WPA2"},
+		{"wpa1", WLAN{Security: "wpapsk", WPAMode: "wpa1"}, "WPA"},
+		{"wpa3", WLAN{Security: "wpapsk", WPAMode: "wpa2", WPA3Support: true}, "WPA3"},
+		{"transition", WLAN{Security: "wpapsk", WPAMode: "wpa2", WPA3Support: true, WPA3Transition: true}, "WPA2/WPA3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.wlan.GetSecurity(); result != tt.expected {
+				t.Errorf("GetSecurity() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWLAN_GetBands(t *testing.T) {
+	tests := []struct {
+		name     string
+		wlan     WLAN
+		expected string
+	}{
+		{"both", WLAN{WLANBand: "both"}, "2.4/5 GHz"},
+		{"unset", WLAN{}, "2.4/5 GHz"},
+		{"single band", WLAN{WLANBand: "5g"}, "5 GHz"},
+		{"band list", WLAN{WLANBand: "both", WLANBands: []string{"2g", "5g", "6g"}}, "2.4/5/6 GHz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.wlan.GetBands(); result != tt.expected {
+				t.Errorf("GetBands() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWLANStatuses(t *testing.T) {
+	wlans := []WLAN{
+		{ID: "w1", Name: "Home", NetworkConfID: "n1"},
+		{ID: "w2", Name: "IoT", NetworkConfID: "n2"},
+		{ID: "w3", Name: "Legacy", VLAN: "40", VLANEnabled: true},
+	}
+	networks := []Network{
+		{ID: "n1", Name: "LAN"},
+		{ID: "n2", Name: "IoT", VLAN: 20, VLANEnabled: true},
+	}
+	clients := []Client{
+		{MAC: "aa", Essid: "Home"},
+		{MAC: "bb", Essid: "Home"},
+		{MAC: "cc", Essid: "IoT"},
+		{MAC: "dd", Essid: "Home", IsWired: true},
+	}
+
+	statuses := WLANStatuses(wlans, networks, clients)
+
+	if statuses[0].Network != "LAN" || statuses[0].VLANID != 0 || statuses[0].Clients != 2 {
+		t.Errorf("Unexpected Home status %+v", statuses[0])
+	}
+	if statuses[1].Network != "IoT" || statuses[1].VLANID != 20 || statuses[1].Clients != 1 {
+		t.Errorf("Unexpected IoT status %+v", statuses[1])
+	}
+	if statuses[2].Network != "" || statuses[2].VLANID != 40 || statuses[2].Clients != 0 {
+		t.Errorf("Unexpected Legacy status %+v", statuses[2])
+	}
+}
+
+func TestSortWLANs(t *testing.T) {
+	wlans := []WLAN{
+		{ID: "w3", Name: "guest"},
+		{ID: "w1", Name: "Home"},
+		{ID: "w2", Name: "Guest"},
+	}
+
+	SortWLANs(wlans)
+
+	expected := []string{"w2", "w3", "w1"}
+	for i, id := range expected {
+		if wlans[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, wlans[i].ID)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package archive keeps the records of forgotten clients in a JSON Lines file
+// so their alias, note and fixed IP can be restored if they come back.
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// Entry is one archived known-client record. Record is the controller's full
+// JSON for the client at the time it was forgotten.
+type Entry struct {
+	ArchivedAt time.Time       `json:"archived_at"`
+	Host       string          `json:"host"`
+	Site       string          `json:"site"`
+	Record     json.RawMessage `json:"record"`
+}
+
+// User decodes the archived record
+func (e Entry) User() (api.User, error) {
+	var user api.User
+	if err := json.Unmarshal(e.Record, &user); err != nil {
+		return user, fmt.Errorf("failed to parse archived record: %w", err)
+	}
+	return user, nil
+}
+
+// Append adds entries to the archive at path, creating it if needed
+func Append(path string, entries []Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode archive entry: %w", err)
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+
+	return f.Close()
+}
+
+// Find returns the most recently archived entry for mac
+func Find(path, mac string) (*Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("archive %s does not exist", path)
+		}
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	var found *Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+
+		user, err := entry.User()
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		if strings.EqualFold(user.MAC, mac) {
+			found = &entry
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no archived record for %s in %s", mac, path)
+	}
+	return found, nil
+}
+
+// RestoreFields returns the fields to re-apply to a returning client: its
+// alias, note and fixed IP. Nothing is returned for settings the archived
+// record did not have.
+func RestoreFields(user api.User) map[string]interface{} {
+	fields := map[string]interface{}{}
+
+	if user.Name != "" {
+		fields["name"] = user.Name
+	}
+	if user.Note != "" {
+		fields["note"] = user.Note
+		fields["noted"] = true
+	}
+	if user.UseFixedIP && user.FixedIP != "" {
+		fields["use_fixedip"] = true
+		fields["fixed_ip"] = user.FixedIP
+		fields["network_id"] = user.NetworkID
+	}
+
+	return fields
+}
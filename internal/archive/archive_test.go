@@ -0,0 +1,102 @@
+package archive
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestAppendAndFind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "archive.jsonl")
+	when := time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC)
+
+	err := Append(path, []Entry{
+		{ArchivedAt: when, Host: "https://a", Site: "default", Record: json.RawMessage(`{"_id":"u1","mac":"aa:bb:cc:dd:ee:01","name":"TV"}`)},
+		{ArchivedAt: when, Host: "https://a", Site: "default", Record: json.RawMessage(`{"_id":"u2","mac":"aa:bb:cc:dd:ee:02","name":"Laptop"}`)},
+	})
+	if err != nil {
+		t.Fatalf("Append() returned error: %v", err)
+	}
+
+	// A later archive of the same client wins
+	err = Append(path, []Entry{
+		{ArchivedAt: when.Add(time.Hour), Host: "https://a", Site: "default", Record: json.RawMessage(`{"_id":"u3","mac":"aa:bb:cc:dd:ee:01","name":"Living Room TV"}`)},
+	})
+	if err != nil {
+		t.Fatalf("Append() returned error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("Expected 3 lines, got %d", lines)
+	}
+
+	entry, err := Find(path, "AA:BB:CC:DD:EE:01")
+	if err != nil {
+		t.Fatalf("Find() returned error: %v", err)
+	}
+
+	user, err := entry.User()
+	if err != nil {
+		t.Fatalf("User() returned error: %v", err)
+	}
+	if user.Name != "Living Room TV" || !entry.ArchivedAt.Equal(when.Add(time.Hour)) {
+		t.Errorf("Expected the latest entry, got %+v", entry)
+	}
+
+	if _, err := Find(path, "aa:bb:cc:dd:ee:99"); err == nil {
+		t.Error("Expected error for a MAC that was never archived")
+	}
+}
+
+func TestFind_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := Find(filepath.Join(dir, "missing.jsonl"), "aa:bb:cc:dd:ee:01"); err == nil {
+		t.Error("Expected error for a missing archive")
+	}
+
+	path := filepath.Join(dir, "broken.jsonl")
+	os.WriteFile(path, []byte("{not json}\n"), 0600)
+	if _, err := Find(path, "aa:bb:cc:dd:ee:01"); err == nil || !strings.Contains(err.Error(), ":1:") {
+		t.Errorf("Expected error with line number, got %v", err)
+	}
+}
+
+func TestRestoreFields(t *testing.T) {
+	fields := RestoreFields(api.User{
+		MAC:        "aa:bb:cc:dd:ee:01",
+		Name:       "TV",
+		Note:       "wall mount",
+		UseFixedIP: true,
+		FixedIP:    "192.168.1.50",
+		NetworkID:  "n1",
+		Blocked:    true,
+	})
+
+	expected := map[string]interface{}{
+		"name":        "TV",
+		"note":        "wall mount",
+		"noted":       true,
+		"use_fixedip": true,
+		"fixed_ip":    "192.168.1.50",
+		"network_id":  "n1",
+	}
+	if len(fields) != len(expected) {
+		t.Fatalf("Expected %d fields, got %v", len(expected), fields)
+	}
+	for key, value := range expected {
+		if fields[key] != value {
+			t.Errorf("Expected %s = %v, got %v", key, value, fields[key])
+		}
+	}
+
+	if fields := RestoreFields(api.User{MAC: "aa:bb:cc:dd:ee:02"}); len(fields) != 0 {
+		t.Errorf("Expected no fields for a bare record, got %v", fields)
+	}
+}
@@ -0,0 +1,40 @@
+package audit
+
+import "sort"
+
+// Severity levels, ordered from most to least severe
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+var severityRank = map[string]int{
+	SeverityCritical: 0,
+	SeverityHigh:     1,
+	SeverityMedium:   2,
+	SeverityLow:      3,
+}
+
+// Finding is a single issue discovered by an audit
+type Finding struct {
+	Severity string `json:"severity"`
+	Target   string `json:"target"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+// SortFindings orders findings by severity, then target and check
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if severityRank[a.Severity] != severityRank[b.Severity] {
+			return severityRank[a.Severity] < severityRank[b.Severity]
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Check < b.Check
+	})
+}
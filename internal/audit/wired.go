@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// Wired flags wired clients that got their address on a different network
+// than the native network of the switch port they are connected to, which
+// usually means a cable was moved to a port with another profile. On access
+// ports this is almost certainly wrong; trunk ports may carry the client's
+// network tagged, so those findings are less severe. Uplinks and ports whose
+// native network is unknown are skipped.
+func Wired(clients []api.Client, devices []api.Device, profiles []api.PortProfile, networks []api.Network) []Finding {
+	var findings []Finding
+
+	type portKey struct {
+		mac   string
+		index int
+	}
+	ports := map[portKey]api.PortStatus{}
+	switchNames := map[string]string{}
+	for _, device := range devices {
+		mac := strings.ToLower(device.MAC)
+		switchNames[mac] = device.GetDisplayName()
+		for _, port := range api.PortStatuses(device, profiles, networks, clients) {
+			ports[portKey{mac, port.Index}] = port
+		}
+	}
+
+	networkByID := make(map[string]api.Network, len(networks))
+	for _, n := range networks {
+		networkByID[n.ID] = n
+	}
+
+	for _, client := range clients {
+		if !client.IsWired || client.NetworkID == "" || client.SWMAC == "" {
+			continue
+		}
+
+		mac := strings.ToLower(client.SWMAC)
+		port, ok := ports[portKey{mac, client.SWPort}]
+		if !ok || port.IsUplink || port.NetworkID == "" || port.NetworkID == client.NetworkID {
+			continue
+		}
+
+		severity := SeverityLow
+		kind := "trunk port"
+		if port.Forward == "native" {
+			severity = SeverityMedium
+			kind = "access port"
+		}
+
+		portName := fmt.Sprintf("%s port %d", switchNames[mac], port.Index)
+		if port.Profile != "" {
+			portName += " (" + port.Profile + ")"
+		}
+
+		findings = append(findings, Finding{
+			Severity: severity,
+			Target:   fmt.Sprintf("%s (%s)", client.GetDisplayName(), client.MAC),
+			Check:    "vlan-mismatch",
+			Message: fmt.Sprintf("on network %s but %s %s has native network %s",
+				networkLabel(networkByID[client.NetworkID], client.Network), kind, portName, networkLabel(networkByID[port.NetworkID], port.Network)),
+		})
+	}
+
+	SortFindings(findings)
+	return findings
+}
+
+// networkLabel names a network with its VLAN, falling back to name when the
+// network is not configured
+func networkLabel(network api.Network, name string) string {
+	if network.Name != "" {
+		name = network.Name
+	}
+	if network.VLANEnabled && network.VLAN > 0 {
+		return fmt.Sprintf("%s (VLAN %d)", name, network.VLAN)
+	}
+	return name
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestWired(t *testing.T) {
+	devices := []api.Device{{
+		MAC:  "f0:9f:c2:00:00:02",
+		Name: "Core Switch",
+		PortTable: []api.Port{
+			{Index: 1, IsUplink: true, NativeNetworkconfID: "n1"},
+			{Index: 5, PortconfID: "pc1"},
+			{Index: 6},
+			{Index: 7, PortconfID: "pc2"},
+		},
+	}}
+	profiles := []api.PortProfile{
+		{ID: "pc1", Name: "Cameras", Forward: "native", NativeNetworkconfID: "n2"},
+		{ID: "pc2", Name: "Trunk", Forward: "all", NativeNetworkconfID: "n1"},
+	}
+	networks := []api.Network{
+		{ID: "n1", Name: "LAN"},
+		{ID: "n2", Name: "IoT", VLAN: 20, VLANEnabled: true},
+	}
+	clients := []api.Client{
+		{MAC: "aa:00:00:00:00:01", Name: "Camera", IsWired: true, SWMAC: "F0:9F:C2:00:00:02", SWPort: 5, NetworkID: "n2"},
+		{MAC: "aa:00:00:00:00:02", Name: "Desk PC", IsWired: true, SWMAC: "f0:9f:c2:00:00:02", SWPort: 5, NetworkID: "n1", Network: "LAN"},
+		{MAC: "aa:00:00:00:00:03", Name: "Phone", IsWired: true, SWMAC: "f0:9f:c2:00:00:02", SWPort: 7, NetworkID: "n2"},
+		{MAC: "aa:00:00:00:00:04", Name: "Behind uplink", IsWired: true, SWMAC: "f0:9f:c2:00:00:02", SWPort: 1, NetworkID: "n2"},
+		{MAC: "aa:00:00:00:00:05", Name: "No native", IsWired: true, SWMAC: "f0:9f:c2:00:00:02", SWPort: 6, NetworkID: "n2"},
+		{MAC: "aa:00:00:00:00:06", Name: "Laptop", SWMAC: "f0:9f:c2:00:00:02", SWPort: 5, NetworkID: "n1"},
+	}
+
+	findings := Wired(clients, devices, profiles, networks)
+
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+
+	access := findings[0]
+	if access.Severity != SeverityMedium || access.Target != "Desk PC (aa:00:00:00:00:02)" || access.Check != "vlan-mismatch" {
+		t.Errorf("Unexpected access port finding %+v", access)
+	}
+	if !strings.Contains(access.Message, "on network LAN but access port Core Switch port 5 (Cameras) has native network IoT (VLAN 20)") {
+		t.Errorf("Unexpected message %q", access.Message)
+	}
+
+	trunk := findings[1]
+	if trunk.Severity != SeverityLow || trunk.Target != "Phone (aa:00:00:00:00:03)" || !strings.Contains(trunk.Message, "trunk port") {
+		t.Errorf("Unexpected trunk port finding %+v", trunk)
+	}
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// minPassphraseLength is the shortest WPA passphrase not flagged as weak
+const minPassphraseLength = 12
+
+// commonPassphrases are default-ish passphrases that should never be used
+var commonPassphrases = []string{
+	"password", "password1", "password123", "12345678", "123456789", "1234567890",
+	"qwertyuiop", "iloveyou", "letmein123", "welcome1", "changeme", "guestguest",
+}
+
+// WLANs checks wireless network configurations for weak security settings
+func WLANs(wlans []api.WLAN) []Finding {
+	var findings []Finding
+
+	for _, w := range wlans {
+		add := func(severity, check, message string) {
+			findings = append(findings, Finding{Severity: severity, Target: w.Name, Check: check, Message: message})
+		}
+
+		switch w.Security {
+		case "open":
+			if w.IsGuest {
+				add(SeverityMedium, "open-network", "guest network has no encryption")
+			} else {
+				add(SeverityHigh, "open-network", "network has no encryption")
+			}
+		case "wep":
+			add(SeverityCritical, "wep", "WEP encryption is broken; use WPA2 or WPA3")
+		}
+
+		if w.Security == "wpapsk" || w.Security == "wpaeap" {
+			if w.WPAMode == "wpa1" {
+				add(SeverityHigh, "wpa1", "WPA1 (TKIP) is deprecated; use WPA2 or WPA3")
+			}
+			if w.PMFMode == "" || w.PMFMode == "disabled" {
+				add(SeverityLow, "pmf", "protected management frames are disabled")
+			}
+		}
+
+		if w.Security == "wpapsk" {
+			findings = append(findings, passphraseFindings(w)...)
+		}
+
+		if w.IsGuest && !w.L2Isolation {
+			add(SeverityMedium, "guest-isolation", "guest clients are not isolated from each other")
+		}
+	}
+
+	SortFindings(findings)
+	return findings
+}
+
+func passphraseFindings(w api.WLAN) []Finding {
+	if w.Passphrase == "" {
+		return nil
+	}
+
+	lower := strings.ToLower(w.Passphrase)
+	for _, common := range commonPassphrases {
+		if lower == common || lower == strings.ToLower(w.Name) {
+			return []Finding{{Severity: SeverityHigh, Target: w.Name, Check: "passphrase", Message: "passphrase is a common default or the SSID itself"}}
+		}
+	}
+
+	if len(w.Passphrase) < minPassphraseLength {
+		return []Finding{{Severity: SeverityMedium, Target: w.Name, Check: "passphrase", Message: "passphrase is shorter than 12 characters"}}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func checks(findings []Finding, target string) map[string]string {
+	result := map[string]string{}
+	for _, f := range findings {
+		if f.Target == target {
+			result[f.Check] = f.Severity
+		}
+	}
+	return result
+}
+
+func TestWLANs(t *testing.T) {
+	wlans := []api.WLAN{
+		{Name: "Secure", Security: "wpapsk", WPAMode: "wpa2", PMFMode: "required", Passphrase: "correct horse battery staple"},
+		{Name: "Legacy", Security: "wpapsk", WPAMode: "wpa1", PMFMode: "disabled", Passphrase: "short"},
+		{Name: "Open", Security: "open"},
+		{Name: "Guest", Security: "open", IsGuest: true},
+		{Name: "Ancient", Security: "wep"},
+		{Name: "Default", Security: "wpapsk", WPAMode: "wpa2", PMFMode: "optional", Passphrase: "Password123"},
+		{Name: "IsolatedGuest", Security: "wpapsk", WPAMode: "wpa2", PMFMode: "optional", Passphrase: "a long guest passphrase", IsGuest: true, L2Isolation: true},
+	}
+
+	findings := WLANs(wlans)
+
+	tests := []struct {
+		target   string
+		expected map[string]string
+	}{
+		{"Secure", map[string]string{}},
+		{"Legacy", map[string]string{"wpa1": SeverityHigh, "pmf": SeverityLow, "passphrase": SeverityMedium}},
+		{"Open", map[string]string{"open-network": SeverityHigh}},
+		{"Guest", map[string]string{"open-network": SeverityMedium, "guest-isolation": SeverityMedium}},
+		{"Ancient", map[string]string{"wep": SeverityCritical}},
+		{"Default", map[string]string{"passphrase": SeverityHigh}},
+		{"IsolatedGuest", map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			got := checks(findings, tt.target)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected findings %v, got %v", tt.expected, got)
+			}
+			for check, severity := range tt.expected {
+				if got[check] != severity {
+					t.Errorf("Expected %s finding with severity %s, got %q", check, severity, got[check])
+				}
+			}
+		})
+	}
+
+	// Most severe findings come first
+	if findings[0].Severity != SeverityCritical {
+		t.Errorf("Expected the critical finding first, got %+v", findings[0])
+	}
+}
+
+func TestSortFindings(t *testing.T) {
+	findings := []Finding{
+		{Severity: SeverityLow, Target: "a"},
+		{Severity: SeverityHigh, Target: "b"},
+		{Severity: SeverityHigh, Target: "a"},
+		{Severity: SeverityCritical, Target: "z"},
+	}
+
+	SortFindings(findings)
+
+	expected := []string{"z", "a", "b", "a"}
+	for i, target := range expected {
+		if findings[i].Target != target {
+			t.Errorf("Position %d: expected target %s, got %+v", i, target, findings[i])
+		}
+	}
+}
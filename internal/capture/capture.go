@@ -0,0 +1,111 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// timeFormat orders file names by when the request was sent
+const timeFormat = "20060102T150405.000Z"
+
+// seq numbers the responses of a run across all archives, so clients for
+// several controllers saving to the same directory never share a file name
+var seq atomic.Int64
+
+// Archive writes raw API responses to a directory exactly as received, one
+// body file and one metadata file per response. Unlike fixtures nothing is
+// sanitized, so the files can hold secrets such as passphrases.
+type Archive struct {
+	Dir  string
+	Host string
+	Site string
+}
+
+// Meta describes the request behind a saved response body
+type Meta struct {
+	Time      time.Time `json:"time"`
+	Host      string    `json:"host"`
+	Site      string    `json:"site"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	ElapsedMS int64     `json:"elapsed_ms"`
+	// Request is the JSON body sent with the request, if any
+	Request json.RawMessage `json:"request,omitempty"`
+	Header  http.Header     `json:"response_header,omitempty"`
+	// Body is the name of the file holding the response body
+	Body string `json:"body"`
+}
+
+// New creates the archive directory if needed
+func New(dir, host, site string) (*Archive, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create raw response directory: %w", err)
+	}
+	return &Archive{Dir: dir, Host: host, Site: site}, nil
+}
+
+// Save writes the response body of ex and its metadata. Files are named
+// after the time and order of the request, e.g.
+// 20261016T025233.941Z-003-GET_api_s_default_stat_sta.json with
+// 20261016T025233.941Z-003-GET_api_s_default_stat_sta.meta.json next to it.
+func (a *Archive) Save(ex api.Exchange) error {
+	base := FileBase(ex.Start, seq.Add(1), ex.Method, ex.Path)
+	bodyFile := base + bodyExtension(ex)
+
+	if err := os.WriteFile(filepath.Join(a.Dir, bodyFile), ex.Body, 0600); err != nil {
+		return fmt.Errorf("failed to write raw response: %w", err)
+	}
+
+	meta := Meta{
+		Time:      ex.Start.UTC(),
+		Host:      a.Host,
+		Site:      a.Site,
+		Method:    ex.Method,
+		Path:      ex.Path,
+		Status:    ex.Status,
+		ElapsedMS: ex.Elapsed.Milliseconds(),
+		Header:    ex.Header,
+		Body:      bodyFile,
+	}
+	if json.Valid(ex.Request) {
+		meta.Request = ex.Request
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode request metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(a.Dir, base+".meta.json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write request metadata: %w", err)
+	}
+	return nil
+}
+
+// FileBase names the files of a response after when the request was sent,
+// its sequence number in the run and the request itself
+func FileBase(start time.Time, n int64, method, path string) string {
+	path = strings.TrimPrefix(path, "/proxy/network")
+	path = strings.Trim(path, "/")
+	path = strings.NewReplacer("/", "_", "?", "_", "&", "_", "=", "-").Replace(path)
+
+	return fmt.Sprintf("%s-%03d-%s_%s", start.UTC().Format(timeFormat), n, strings.ToUpper(method), path)
+}
+
+// bodyExtension is .json for JSON responses and .bin for anything else
+func bodyExtension(ex api.Exchange) string {
+	trimmed := bytes.TrimSpace(ex.Body)
+	if strings.Contains(ex.Header.Get("Content-Type"), "json") || (len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')) {
+		return ".json"
+	}
+	return ".bin"
+}
@@ -0,0 +1,90 @@
+package capture
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestFileBase(t *testing.T) {
+	start := time.Date(2026, 10, 16, 2, 52, 33, 941000000, time.UTC)
+
+	got := FileBase(start, 3, "get", "/proxy/network/api/s/default/stat/sta")
+	if want := "20261016T025233.941Z-003-GET_api_s_default_stat_sta"; got != want {
+		t.Errorf("FileBase() = %q, want %q", got, want)
+	}
+
+	got = FileBase(start, 12, "GET", "/proxy/network/v2/api/site/default/trafficroutes?type=all")
+	if want := "20261016T025233.941Z-012-GET_v2_api_site_default_trafficroutes_type-all"; got != want {
+		t.Errorf("FileBase() = %q, want %q", got, want)
+	}
+}
+
+func TestArchive_Save(t *testing.T) {
+	seq.Store(0)
+	dir := filepath.Join(t.TempDir(), "raw")
+	archive, err := New(dir, "https://unifi.example.com", "default")
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	start := time.Date(2026, 10, 16, 2, 52, 33, 0, time.UTC)
+	body := []byte(`{"meta":{"rc":"error","msg":"api.err.Invalid"},"data":[]}`)
+	err = archive.Save(api.Exchange{
+		Method:  "POST",
+		Path:    "/proxy/network/api/s/default/cmd/stamgr",
+		Request: []byte(`{"cmd":"kick-sta"}`),
+		Status:  http.StatusBadRequest,
+		Header:  http.Header{"Content-Type": []string{"application/json"}},
+		Body:    body,
+		Start:   start,
+		Elapsed: 42 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	base := filepath.Join(dir, "20261016T025233.000Z-001-POST_api_s_default_cmd_stamgr")
+
+	saved, err := os.ReadFile(base + ".json")
+	if err != nil {
+		t.Fatalf("Expected the body to be saved: %v", err)
+	}
+	if string(saved) != string(body) {
+		t.Errorf("Expected the body byte for byte, got %s", saved)
+	}
+
+	data, err := os.ReadFile(base + ".meta.json")
+	if err != nil {
+		t.Fatalf("Expected the metadata to be saved: %v", err)
+	}
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("Failed to parse metadata: %v", err)
+	}
+	var request map[string]string
+	json.Unmarshal(meta.Request, &request)
+	if meta.Status != http.StatusBadRequest || meta.ElapsedMS != 42 || meta.Host != "https://unifi.example.com" || request["cmd"] != "kick-sta" {
+		t.Errorf("Unexpected metadata %+v", meta)
+	}
+	if meta.Body != filepath.Base(base)+".json" {
+		t.Errorf("Expected metadata to name the body file, got %q", meta.Body)
+	}
+}
+
+func TestArchive_SaveBinary(t *testing.T) {
+	dir := t.TempDir()
+	archive, _ := New(dir, "https://h", "default")
+
+	archive.Save(api.Exchange{Method: "GET", Path: "/proxy/network/dl/backup/1.unf", Status: 200, Body: []byte{0x00, 0x01}, Start: time.Unix(0, 0)})
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.bin"))
+	if len(matches) != 1 {
+		t.Errorf("Expected a .bin body file, got %v", matches)
+	}
+}
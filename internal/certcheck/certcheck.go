@@ -0,0 +1,125 @@
+// Package certcheck checks how long certificates remain valid, with
+// monitoring plugin (Nagios) statuses and exit codes.
+package certcheck
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"os"
+	"time"
+)
+
+// Status is the outcome of a check, ordered from best to worst
+type Status int
+
+// Statuses, numbered as monitoring plugin exit codes
+const (
+	OK       Status = 0
+	Warning  Status = 1
+	Critical Status = 2
+	Unknown  Status = 3
+)
+
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "OK"
+	case Warning:
+		return "WARNING"
+	case Critical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// MarshalText encodes the status by name in JSON
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Result is the check of one certificate, or of a source whose certificate
+// could not be read
+type Result struct {
+	Name     string    `json:"name"`
+	Source   string    `json:"source"`
+	Subject  string    `json:"subject,omitempty"`
+	Issuer   string    `json:"issuer,omitempty"`
+	NotAfter time.Time `json:"not_after,omitzero"`
+	DaysLeft int       `json:"days_left"`
+	Status   Status    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Check rates a certificate: critical once it expires within critical (or
+// has expired), a warning within warning
+func Check(name, source string, cert *x509.Certificate, now time.Time, warning, critical time.Duration) Result {
+	left := cert.NotAfter.Sub(now)
+
+	status := OK
+	switch {
+	case left <= critical:
+		status = Critical
+	case left <= warning:
+		status = Warning
+	}
+
+	return Result{
+		Name:     name,
+		Source:   source,
+		Subject:  cert.Subject.CommonName,
+		Issuer:   cert.Issuer.CommonName,
+		NotAfter: cert.NotAfter,
+		DaysLeft: int(math.Floor(left.Hours() / 24)),
+		Status:   status,
+	}
+}
+
+// Failed is the result for a source whose certificate could not be read
+func Failed(name, source string, err error) Result {
+	return Result{Name: name, Source: source, Status: Unknown, Error: err.Error()}
+}
+
+// Worst returns the worst status of the results, OK for none
+func Worst(results []Result) Status {
+	worst := OK
+	for _, r := range results {
+		if r.Status > worst {
+			worst = r.Status
+		}
+	}
+	return worst
+}
+
+// LoadPEM reads the certificates of a PEM file, skipping other blocks such
+// as private keys
+func LoadPEM(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate file: %w", err)
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate in %s: %w", path, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates in %s", path)
+	}
+	return certs, nil
+}
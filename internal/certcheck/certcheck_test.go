@@ -0,0 +1,111 @@
+package certcheck
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newCert(t *testing.T, notAfter time.Time) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "radius.example.com"},
+		NotBefore:    now.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		notAfter time.Time
+		status   Status
+		days     int
+	}{
+		{"valid", now.AddDate(0, 0, 90), OK, 90},
+		{"warning", now.AddDate(0, 0, 20), Warning, 20},
+		{"critical", now.AddDate(0, 0, 3), Critical, 3},
+		{"expired", now.AddDate(0, 0, -2), Critical, -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Check("RADIUS", "radius.pem", newCert(t, tt.notAfter), now, 30*24*time.Hour, 7*24*time.Hour)
+			if r.Status != tt.status || r.DaysLeft != tt.days || r.Subject != "radius.example.com" {
+				t.Errorf("Unexpected result %+v", r)
+			}
+		})
+	}
+}
+
+func TestWorst(t *testing.T) {
+	if Worst(nil) != OK {
+		t.Error("Expected no results to be OK")
+	}
+	results := []Result{{Status: Warning}, Failed("portal", "host:8843", errors.New("refused")), {Status: Critical}}
+	if Worst(results) != Unknown {
+		t.Errorf("Expected UNKNOWN, got %s", Worst(results))
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	data, err := json.Marshal(Failed("portal", "host:8843", errors.New("refused")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"status":"UNKNOWN"`) || strings.Contains(string(data), "not_after") {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+}
+
+func TestLoadPEM(t *testing.T) {
+	cert := newCert(t, now.AddDate(1, 0, 0))
+	path := filepath.Join(t.TempDir(), "radius.pem")
+	data := append(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := LoadPEM(path)
+	if err != nil {
+		t.Fatalf("LoadPEM() returned error: %v", err)
+	}
+	if len(certs) != 1 || !certs[0].Equal(cert) {
+		t.Errorf("Expected the certificate, got %d", len(certs))
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	os.WriteFile(empty, []byte("nothing"), 0o600)
+	if _, err := LoadPEM(empty); err == nil {
+		t.Error("Expected a file without certificates to fail")
+	}
+}
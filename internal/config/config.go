@@ -1,11 +1,16 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
 )
 
 type Config struct {
@@ -13,8 +18,80 @@ type Config struct {
 	APIKey   string
 	Site     string
 	Insecure bool
+	Timeout  time.Duration
+	Minimal  bool
+	// Fingerprint pins the controller's TLS certificate (SHA-256)
+	Fingerprint string
+	// TOFU records the certificate fingerprint on first connect
+	TOFU bool
+	// Record is a directory to write sanitized API responses to
+	Record string
+	// SaveRaw is a directory to write raw, unsanitized API responses and
+	// their request metadata to
+	SaveRaw string
+	// SSHTunnel is an ssh destination (e.g. user@bastion) to reach the
+	// controller through
+	SSHTunnel string
+	// NonInteractive turns every prompt into an error, for cron and CI
+	NonInteractive bool
+	// Profile is the name of the selected profile, if any
+	Profile string
+	// HistorySize is how many change events watch mode keeps on disk
+	HistorySize int
+	// Hooks are shell commands run around specific commands
+	Hooks Hooks
+	// Defaults are flag values by command path (e.g. "clients list") and
+	// flag name, used for flags not given on the command line
+	Defaults map[string]map[string]string
+	// Theme is the name of the table theme, built in or from Themes
+	Theme string
+	// Themes are user-defined table themes by name
+	Themes map[string]TableTheme
+	// DPIApps names DPI applications by "category:app", adding to or
+	// correcting the bundled names
+	DPIApps map[string]string
+	// Strict fails on deprecated flags and on responses missing fields the
+	// CLI relies on, rather than printing empty values
+	Strict bool
+	// UsageStats records commands, their durations and API latencies in a
+	// local file for "stats cli"
+	UsageStats bool
 }
 
+// TableTheme is a user-defined table theme: a built-in base theme with some
+// of its settings changed. Unset settings keep the base theme's.
+type TableTheme struct {
+	Base    string `mapstructure:"base"`
+	Symbols string `mapstructure:"symbols"`
+	Borders *bool  `mapstructure:"borders"`
+	Compact *bool  `mapstructure:"compact"`
+	Header  string `mapstructure:"header"`
+	Zebra   *bool  `mapstructure:"zebra"`
+}
+
+// Hooks map command paths (e.g. "clients block") to shell commands run before
+// (Pre) or after (Post) them
+type Hooks struct {
+	Pre  map[string]string
+	Post map[string]string
+}
+
+const (
+	// DefaultTimeout bounds each API request
+	DefaultTimeout = 30 * time.Second
+
+	// MinimalTimeout is the default timeout in minimal mode, where the
+	// controller is expected to be on the same host
+	MinimalTimeout = 5 * time.Second
+
+	// MinimalHost is the default controller in minimal mode, for running
+	// directly on a UniFi console or a small board next to it
+	MinimalHost = "https://127.0.0.1"
+
+	// DefaultHistorySize is how many change events are kept for replay
+	DefaultHistorySize = 1000
+)
+
 var cfg *Config
 
 func Init(cfgFile string) error {
@@ -45,21 +122,150 @@ func Init(cfgFile string) error {
 		}
 	}
 
+	// A selected profile overrides the top-level settings of the config
+	// file; flags and environment variables still take precedence
+	if name := viper.GetString("profile"); name != "" {
+		if err := mergeProfile(viper.GetViper(), name); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func Get() *Config {
 	if cfg == nil {
-		cfg = &Config{
-			Host:     viper.GetString("host"),
-			APIKey:   viper.GetString("api_key"),
-			Site:     viper.GetString("site"),
-			Insecure: viper.GetBool("insecure"),
-		}
+		cfg = fromViper(viper.GetViper())
+		cfg.Profile = viper.GetString("profile")
 	}
 	return cfg
 }
 
+// ForProfile returns the configuration of a named profile: the top-level
+// settings of the config file overlaid with the profile's own. Flags and
+// environment variables do not apply, so that several profiles can be used
+// side by side.
+func ForProfile(name string) (*Config, error) {
+	v := viper.New()
+	v.SetDefault("site", "default")
+	v.SetDefault("insecure", true)
+
+	if path := viper.ConfigFileUsed(); path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	if err := mergeProfile(v, name); err != nil {
+		return nil, err
+	}
+
+	c := fromViper(v)
+	c.Profile = name
+
+	if c.Host == "" || c.APIKey == "" {
+		return nil, fmt.Errorf("profile %q needs both host and api_key", name)
+	}
+	return c, nil
+}
+
+// ProfileNames returns the names of all profiles in the config file
+func ProfileNames() []string {
+	var names []string
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func mergeProfile(v *viper.Viper, name string) error {
+	settings := v.GetStringMap("profiles." + name)
+	if len(settings) == 0 {
+		return fmt.Errorf("profile %q not found in config file", name)
+	}
+
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to apply profile %q: %w", name, err)
+	}
+	return nil
+}
+
+func fromViper(v *viper.Viper) *Config {
+	c := &Config{
+		Host:     v.GetString("host"),
+		APIKey:   v.GetString("api_key"),
+		Site:     v.GetString("site"),
+		Insecure: v.GetBool("insecure"),
+		Timeout:  v.GetDuration("timeout"),
+		Minimal:  v.GetBool("minimal"),
+
+		Fingerprint: v.GetString("fingerprint"),
+		TOFU:        v.GetBool("tofu"),
+		Record:      v.GetString("record"),
+		SaveRaw:     v.GetString("save_raw"),
+		HistorySize: v.GetInt("history_size"),
+		Hooks: Hooks{
+			Pre:  v.GetStringMapString("hooks.pre"),
+			Post: v.GetStringMapString("hooks.post"),
+		},
+		Defaults: flagDefaults(v.GetStringMap("defaults")),
+		Theme:    v.GetString("theme"),
+		DPIApps:  v.GetStringMapString("dpi_apps"),
+
+		Strict:     v.GetBool("strict"),
+		UsageStats: v.GetBool("usage_stats"),
+
+		NonInteractive: v.GetBool("non_interactive"),
+		SSHTunnel:      v.GetString("ssh_tunnel"),
+	}
+
+	// A malformed themes section leaves Themes empty, so selecting one of
+	// its themes fails as unknown
+	_ = v.UnmarshalKey("themes", &c.Themes)
+
+	if c.Minimal && c.Host == "" {
+		c.Host = MinimalHost
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+		if c.Minimal {
+			c.Timeout = MinimalTimeout
+		}
+	}
+	if c.HistorySize <= 0 {
+		c.HistorySize = DefaultHistorySize
+	}
+	return c
+}
+
+// flagDefaults reads the defaults section. Lists become comma separated
+// values, as slice flags take them.
+func flagDefaults(section map[string]interface{}) map[string]map[string]string {
+	defaults := make(map[string]map[string]string, len(section))
+	for command, value := range section {
+		flags, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		defaults[command] = make(map[string]string, len(flags))
+		for name, value := range flags {
+			if list, ok := value.([]interface{}); ok {
+				values := make([]string, len(list))
+				for i, item := range list {
+					values[i] = fmt.Sprint(item)
+				}
+				defaults[command][name] = strings.Join(values, ",")
+				continue
+			}
+			defaults[command][name] = fmt.Sprint(value)
+		}
+	}
+	return defaults
+}
+
 func Validate() error {
 	cfg := Get()
 
@@ -82,3 +288,107 @@ func GetConfigPath() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".unifi-cli.yaml")
 }
+
+// GetDataDir returns the directory used for state the CLI keeps between runs,
+// honouring XDG_DATA_HOME and falling back to ~/.local/share/unifi-cli
+func GetDataDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "unifi-cli")
+	}
+
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "unifi-cli")
+}
+
+// SaveValue writes a single key into the config file in use (or the default
+// config file), leaving every other setting in the file untouched. With a
+// profile selected the key is written into that profile. Values coming from
+// flags or the environment are never persisted. The file is edited as a YAML
+// node tree, so comments and the order of keys are kept; only the indentation
+// is normalized to two spaces. Config files in other formats (JSON, TOML, ...)
+// are refused rather than rewritten as YAML.
+func SaveValue(key string, value interface{}) error {
+	path := GetConfigPath()
+
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".yaml" && ext != ".yml" {
+		return fmt.Errorf("cannot save %s to %s: only YAML config files can be updated, set it by hand", key, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if doc.Kind == 0 {
+		// Empty or missing file
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse config file: %s is not a mapping of settings", path)
+	}
+
+	target := doc.Content[0]
+	if name := Get().Profile; name != "" {
+		target = mappingValue(mappingValue(target, "profiles"), name)
+		if target == nil || target.Kind != yaml.MappingNode {
+			return fmt.Errorf("profile %q not found in config file", name)
+		}
+	}
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	setMappingValue(target, key, &valueNode)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	viper.Set(key, value)
+	return nil
+}
+
+// mappingValue returns the value of key in a YAML mapping node, or nil when
+// the node is not a mapping or has no such key
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of key in a YAML mapping node, keeping
+// the comments of the old value, or appends the key when it is missing
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			old := mapping.Content[i+1]
+			value.HeadComment = old.HeadComment
+			value.LineComment = old.LineComment
+			value.FootComment = old.FootComment
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
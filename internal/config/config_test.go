@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -113,6 +115,9 @@ func TestGet(t *testing.T) {
 	if config.Insecure != true {
 		t.Errorf("Expected insecure 'true', got '%v'", config.Insecure)
 	}
+	if config.HistorySize != DefaultHistorySize {
+		t.Errorf("Expected history size %d, got %d", DefaultHistorySize, config.HistorySize)
+	}
 
 	// Test singleton behavior
 	config2 := Get()
@@ -181,3 +186,432 @@ func TestGetConfigPath(t *testing.T) {
 		t.Errorf("Expected config path '%s', got '%s'", expected, path)
 	}
 }
+
+func TestGetDataDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/tmp/xdg-data")
+	if dir := GetDataDir(); dir != "/tmp/xdg-data/unifi-cli" {
+		t.Errorf("Expected data dir '/tmp/xdg-data/unifi-cli', got '%s'", dir)
+	}
+
+	t.Setenv("XDG_DATA_HOME", "")
+	home, _ := os.UserHomeDir()
+	expected := filepath.Join(home, ".local", "share", "unifi-cli")
+	if dir := GetDataDir(); dir != expected {
+		t.Errorf("Expected data dir '%s', got '%s'", expected, dir)
+	}
+}
+
+func TestGet_MinimalMode(t *testing.T) {
+	tests := []struct {
+		name            string
+		settings        map[string]interface{}
+		expectedHost    string
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "defaults",
+			settings:        map[string]interface{}{"host": "https://example.com"},
+			expectedHost:    "https://example.com",
+			expectedTimeout: DefaultTimeout,
+		},
+		{
+			name:            "minimal defaults",
+			settings:        map[string]interface{}{"minimal": true},
+			expectedHost:    MinimalHost,
+			expectedTimeout: MinimalTimeout,
+		},
+		{
+			name:            "minimal with explicit values",
+			settings:        map[string]interface{}{"minimal": true, "host": "https://10.0.0.1", "timeout": "12s"},
+			expectedHost:    "https://10.0.0.1",
+			expectedTimeout: 12 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			cfg = nil
+
+			for key, value := range tt.settings {
+				viper.Set(key, value)
+			}
+
+			config := Get()
+			if config.Host != tt.expectedHost {
+				t.Errorf("Expected host '%s', got '%s'", tt.expectedHost, config.Host)
+			}
+			if config.Timeout != tt.expectedTimeout {
+				t.Errorf("Expected timeout %v, got %v", tt.expectedTimeout, config.Timeout)
+			}
+		})
+	}
+}
+
+func TestGet_NonInteractive(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	if Get().NonInteractive {
+		t.Error("Expected prompts to be allowed by default")
+	}
+
+	cfg = nil
+	viper.Set("non_interactive", true)
+	if !Get().NonInteractive {
+		t.Error("Expected non_interactive to be read")
+	}
+}
+
+func TestGet_SSHTunnel(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	viper.Set("ssh_tunnel", "admin@bastion")
+	if got := Get().SSHTunnel; got != "admin@bastion" {
+		t.Errorf("Expected ssh_tunnel admin@bastion, got %q", got)
+	}
+}
+
+func TestSaveValue(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("host: https://test.example.com\napi_key: secret\n"), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	// Settings from the environment must not leak into the file
+	t.Setenv("UNIFI_SITE", "from-env")
+
+	if err := SaveValue("fingerprint", "AA:BB"); err != nil {
+		t.Fatalf("SaveValue() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	content := string(data)
+
+	for _, want := range []string{"host: https://test.example.com", "api_key: secret", "fingerprint: AA:BB"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected config file to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "from-env") {
+		t.Errorf("Expected environment values not to be written, got:\n%s", content)
+	}
+	if viper.GetString("fingerprint") != "AA:BB" {
+		t.Error("Expected saved value to be visible through viper")
+	}
+}
+
+func TestSaveValue_RefusesOtherFormats(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	original := `{"host": "https://test.example.com", "api_key": "secret"}`
+	if err := os.WriteFile(configFile, []byte(original), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	err := SaveValue("site", "branch1")
+	if err == nil || !strings.Contains(err.Error(), "only YAML config files") {
+		t.Fatalf("Expected an error for a JSON config file, got %v", err)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if string(data) != original {
+		t.Errorf("Expected the config file to be left alone, got:\n%s", data)
+	}
+}
+
+const profilesConfig = `host: https://default.example.com
+api_key: default-key
+profiles:
+  office:
+    host: https://office.example.com
+    api_key: office-key
+    site: branch
+  lab:
+    host: https://lab.example.com
+  broken:
+    site: other
+`
+
+func writeProfilesConfig(t *testing.T) string {
+	t.Helper()
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte(profilesConfig), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	return configFile
+}
+
+func TestInit_Profile(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+	t.Setenv("UNIFI_HOST", "")
+	t.Setenv("UNIFI_API_KEY", "")
+
+	configFile := writeProfilesConfig(t)
+	viper.Set("profile", "office")
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	config := Get()
+	if config.Host != "https://office.example.com" || config.APIKey != "office-key" || config.Site != "branch" {
+		t.Errorf("Expected office profile settings, got %+v", config)
+	}
+	if config.Profile != "office" {
+		t.Errorf("Expected profile 'office', got '%s'", config.Profile)
+	}
+}
+
+func TestInit_UnknownProfile(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := writeProfilesConfig(t)
+	viper.Set("profile", "missing")
+	if err := Init(configFile); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
+
+func TestForProfile(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	if err := Init(writeProfilesConfig(t)); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	// Flags of the current invocation must not leak into other profiles
+	viper.Set("site", "from-flag")
+
+	office, err := ForProfile("office")
+	if err != nil {
+		t.Fatalf("ForProfile() failed: %v", err)
+	}
+	if office.Host != "https://office.example.com" || office.Site != "branch" || office.Timeout != DefaultTimeout {
+		t.Errorf("Unexpected office config %+v", office)
+	}
+
+	// Settings missing from a profile come from the top level of the file
+	lab, err := ForProfile("lab")
+	if err != nil {
+		t.Fatalf("ForProfile() failed: %v", err)
+	}
+	if lab.Host != "https://lab.example.com" || lab.APIKey != "default-key" || lab.Site != "default" {
+		t.Errorf("Unexpected lab config %+v", lab)
+	}
+
+	if _, err := ForProfile("missing"); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+
+	if names := ProfileNames(); strings.Join(names, ",") != "broken,lab,office" {
+		t.Errorf("Unexpected profile names %v", names)
+	}
+}
+
+func TestSaveValue_KeepsComments(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	original := `# Controller at the office
+host: https://test.example.com
+site: default # changed by sites use
+api_key: secret
+`
+	if err := os.WriteFile(configFile, []byte(original), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	if err := SaveValue("site", "branch1"); err != nil {
+		t.Fatalf("SaveValue() failed: %v", err)
+	}
+	if err := SaveValue("fingerprint", "AA:BB"); err != nil {
+		t.Fatalf("SaveValue() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+
+	expected := `# Controller at the office
+host: https://test.example.com
+site: branch1 # changed by sites use
+api_key: secret
+fingerprint: AA:BB
+`
+	if string(data) != expected {
+		t.Errorf("Expected comments and key order to be kept, got:\n%s", data)
+	}
+}
+
+func TestSaveValue_Profile(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := writeProfilesConfig(t)
+	viper.Set("profile", "office")
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	if err := SaveValue("fingerprint", "AA:BB"); err != nil {
+		t.Fatalf("SaveValue() failed: %v", err)
+	}
+
+	office, err := ForProfile("office")
+	if err != nil {
+		t.Fatalf("ForProfile() failed: %v", err)
+	}
+	if office.Fingerprint != "AA:BB" {
+		t.Errorf("Expected fingerprint to be saved in the profile, got %+v", office)
+	}
+
+	lab, _ := ForProfile("lab")
+	if lab.Fingerprint != "" {
+		t.Errorf("Expected other profiles to be unchanged, got %+v", lab)
+	}
+}
+
+func TestGet_Hooks(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `hooks:
+  pre:
+    clients forget: "confirm-change.sh {{.Args}}"
+  post:
+    clients block: "notify.sh {{.Args}}"
+`
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	hooks := Get().Hooks
+	if hooks.Pre["clients forget"] != "confirm-change.sh {{.Args}}" {
+		t.Errorf("Unexpected pre hooks %v", hooks.Pre)
+	}
+	if hooks.Post["clients block"] != "notify.sh {{.Args}}" || len(hooks.Post) != 1 {
+		t.Errorf("Unexpected post hooks %v", hooks.Post)
+	}
+}
+
+func TestGet_Themes(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `theme: mine
+themes:
+  mine:
+    base: compact
+    header: title
+    zebra: true
+`
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	config := Get()
+	if config.Theme != "mine" {
+		t.Errorf("Expected theme 'mine', got '%s'", config.Theme)
+	}
+
+	mine, ok := config.Themes["mine"]
+	if !ok {
+		t.Fatalf("Expected theme 'mine' in %v", config.Themes)
+	}
+	if mine.Base != "compact" || mine.Header != "title" || mine.Zebra == nil || !*mine.Zebra {
+		t.Errorf("Unexpected theme %+v", mine)
+	}
+	if mine.Borders != nil || mine.Compact != nil {
+		t.Errorf("Expected unset settings to stay nil, got %+v", mine)
+	}
+}
+
+func TestGet_DPIApps(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `dpi_apps:
+  "13:200": Intranet
+`
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	if apps := Get().DPIApps; apps["13:200"] != "Intranet" || len(apps) != 1 {
+		t.Errorf("Unexpected DPI application names %v", apps)
+	}
+}
+
+func TestGet_Defaults(t *testing.T) {
+	viper.Reset()
+	cfg = nil
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `defaults:
+  clients list:
+    sort: name
+  devices list:
+    format: json
+profiles:
+  home:
+    defaults:
+      clients list:
+        sort: -signal
+        wireless: true
+        vendor: [apple, espressif]
+`
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	viper.Set("profile", "home")
+	if err := Init(configFile); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	defaults := Get().Defaults
+	clients := defaults["clients list"]
+	if clients["sort"] != "-signal" || clients["wireless"] != "true" || clients["vendor"] != "apple,espressif" {
+		t.Errorf("Expected the profile's clients list defaults, got %v", clients)
+	}
+	if defaults["devices list"]["format"] != "json" {
+		t.Errorf("Expected top-level defaults to apply, got %v", defaults)
+	}
+}
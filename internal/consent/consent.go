@@ -0,0 +1,80 @@
+package consent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/datafile"
+)
+
+// TTL is how long a confirmation is remembered
+const TTL = 15 * time.Minute
+
+// Store is a JSON file of confirmations given for sensitive actions, keyed
+// by action and controller, with when each one runs out
+type Store struct {
+	path   string
+	Grants map[string]time.Time
+}
+
+// Load reads the store at path; a missing file yields an empty store
+func Load(path string) (*Store, error) {
+	store := &Store{path: path, Grants: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read confirmations: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &store.Grants); err != nil {
+		return nil, fmt.Errorf("failed to parse confirmations: %w", err)
+	}
+	if store.Grants == nil {
+		store.Grants = make(map[string]time.Time)
+	}
+
+	return store, nil
+}
+
+// Key identifies the confirmation of action against host and site
+func Key(action, host, site string) string {
+	return action + " " + host + " " + site
+}
+
+// Valid reports whether a confirmation for key was given and has not run out
+// at now
+func (s *Store) Valid(key string, now time.Time) bool {
+	until, ok := s.Grants[key]
+	return ok && now.Before(until)
+}
+
+// Grant remembers a confirmation for key for TTL from now and drops those
+// that have run out
+func (s *Store) Grant(key string, now time.Time) {
+	for k, until := range s.Grants {
+		if !now.Before(until) {
+			delete(s.Grants, k)
+		}
+	}
+	s.Grants[key] = now.Add(TTL)
+}
+
+// Save writes the store back to disk, creating its directory if needed
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s.Grants, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode confirmations: %w", err)
+	}
+
+	if err := datafile.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write confirmations: %w", err)
+	}
+
+	return nil
+}
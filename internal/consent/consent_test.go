@@ -0,0 +1,119 @@
+package consent
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	store, err := Load(filepath.Join(t.TempDir(), "consent.json"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if store.Valid(Key("show-passphrase", "https://h", "default"), time.Now()) {
+		t.Error("Expected no confirmation in an empty store")
+	}
+}
+
+func TestStore_GrantExpires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "consent.json")
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := Key("show-passphrase", "https://h", "default")
+
+	store, _ := Load(path)
+	store.Grant(key, now)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !loaded.Valid(key, now.Add(TTL-time.Second)) {
+		t.Error("Expected the confirmation to be valid within the TTL")
+	}
+	if loaded.Valid(key, now.Add(TTL)) {
+		t.Error("Expected the confirmation to run out after the TTL")
+	}
+	if loaded.Valid(Key("show-passphrase", "https://other", "default"), now) {
+		t.Error("Expected the confirmation to apply to its controller only")
+	}
+}
+
+func TestStore_GrantDropsExpired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store, _ := Load(filepath.Join(t.TempDir(), "consent.json"))
+	store.Grant("old", now)
+	store.Grant("new", now.Add(TTL))
+
+	if _, ok := store.Grants["old"]; ok {
+		t.Error("Expected the run out confirmation to be dropped")
+	}
+	if len(store.Grants) != 1 {
+		t.Errorf("Expected 1 confirmation, got %v", store.Grants)
+	}
+}
+
+func TestKeyring_GrantExpires(t *testing.T) {
+	keyring.MockInit()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := Key("show-passphrase", "https://h", "default")
+
+	cache, inKeyring, err := Open(filepath.Join(t.TempDir(), "consent.json"))
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	if !inKeyring {
+		t.Fatal("Expected the keyring to be used when it is available")
+	}
+
+	if cache.Valid(key, now) {
+		t.Error("Expected no confirmation before granting one")
+	}
+	if err := cache.Grant(key, now); err != nil {
+		t.Fatalf("Grant() returned error: %v", err)
+	}
+	if !cache.Valid(key, now.Add(TTL-time.Second)) {
+		t.Error("Expected the confirmation to be valid within the TTL")
+	}
+	if cache.Valid(key, now.Add(TTL)) {
+		t.Error("Expected the confirmation to run out after the TTL")
+	}
+	if _, err := keyring.Get(KeyringService, key); !errors.Is(err, keyring.ErrNotFound) {
+		t.Errorf("Expected the expired confirmation to be removed, got %v", err)
+	}
+}
+
+func TestOpen_FallsBackToFile(t *testing.T) {
+	keyring.MockInitWithError(errors.New("no secret service"))
+
+	path := filepath.Join(t.TempDir(), "consent.json")
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := Key("show-passphrase", "https://h", "default")
+
+	cache, inKeyring, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	if inKeyring {
+		t.Fatal("Expected the file store without a keyring")
+	}
+	if err := cache.Grant(key, now); err != nil {
+		t.Fatalf("Grant() returned error: %v", err)
+	}
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !store.Valid(key, now) {
+		t.Error("Expected the confirmation to be saved to the file")
+	}
+}
@@ -0,0 +1,76 @@
+package consent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+// KeyringService is the name confirmations are stored under in the OS
+// keyring
+const KeyringService = "unifi-cli"
+
+// Cache remembers confirmations for TTL
+type Cache interface {
+	// Valid reports whether a confirmation for key was given and has not run
+	// out at now
+	Valid(key string, now time.Time) bool
+	// Grant remembers a confirmation for key for TTL from now
+	Grant(key string, now time.Time) error
+}
+
+// Keyring remembers confirmations in the OS keyring (Secret Service, macOS
+// Keychain or Windows Credential Manager), so they are bound to the user's
+// login session and go away when the keyring is locked
+type Keyring struct{}
+
+// Valid reports whether the keyring holds an unexpired confirmation for key.
+// A confirmation that has run out is removed.
+func (Keyring) Valid(key string, now time.Time) bool {
+	value, err := keyring.Get(KeyringService, key)
+	if err != nil {
+		return false
+	}
+
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil || !now.Before(until) {
+		keyring.Delete(KeyringService, key)
+		return false
+	}
+	return true
+}
+
+// Grant stores a confirmation for key in the keyring
+func (Keyring) Grant(key string, now time.Time) error {
+	if err := keyring.Set(KeyringService, key, now.Add(TTL).Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("failed to store confirmation in the keyring: %w", err)
+	}
+	return nil
+}
+
+// fileCache is a Store that is saved after every grant
+type fileCache struct {
+	*Store
+}
+
+func (f fileCache) Grant(key string, now time.Time) error {
+	f.Store.Grant(key, now)
+	return f.Save()
+}
+
+// Open returns the OS keyring when one is reachable. Hosts without one, such
+// as consoles and cron jobs without a desktop session, get the store at path
+// instead; the second result tells which one is used.
+func Open(path string) (Cache, bool, error) {
+	if _, err := keyring.Get(KeyringService, "probe"); err == nil || errors.Is(err, keyring.ErrNotFound) {
+		return Keyring{}, true, nil
+	}
+
+	store, err := Load(path)
+	if err != nil {
+		return nil, false, err
+	}
+	return fileCache{store}, false, nil
+}
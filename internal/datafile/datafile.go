@@ -0,0 +1,75 @@
+// Package datafile writes the JSON files in the data directory safely when
+// several invocations run at once, e.g. under fleet exec: updates take an
+// exclusive lock on a .lock file next to the data file, and files are
+// replaced atomically so readers never see a partly written one.
+package datafile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Lock is an exclusive lock on a data file, held until Unlock
+type Lock struct {
+	file *os.File
+}
+
+// LockFile waits for and takes the lock of the data file at path, creating its
+// directory if needed
+func LockFile(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := lock(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return &Lock{file: f}, nil
+}
+
+// Unlock releases the lock
+func (l *Lock) Unlock() error {
+	unlock(l.file)
+	return l.file.Close()
+}
+
+// WriteFile replaces the file at path with data, creating its directory if
+// needed. The data is written to a temporary file that is renamed over path,
+// so the file is either the old or the new version, never a mix.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(perm)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
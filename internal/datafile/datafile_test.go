@@ -0,0 +1,78 @@
+package datafile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "data.json")
+
+	if err := WriteFile(path, []byte("first"), 0600); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+	if err := WriteFile(path, []byte("second"), 0600); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "second" {
+		t.Errorf("Expected 'second', got %q", data)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files to be left, got %d entries", len(entries))
+	}
+}
+
+func TestLockFile_Exclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+
+	first, err := LockFile(path)
+	if err != nil {
+		t.Fatalf("LockFile() returned error: %v", err)
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		second, err := LockFile(path)
+		if err != nil {
+			t.Errorf("LockFile() returned error: %v", err)
+			close(locked)
+			return
+		}
+		close(locked)
+		second.Unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("Expected the second lock to wait for the first")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := first.Unlock(); err != nil {
+		t.Fatalf("Unlock() returned error: %v", err)
+	}
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the second lock after the first was released")
+	}
+}
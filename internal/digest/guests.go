@@ -0,0 +1,122 @@
+// Package digest summarizes the state of a site as short human-readable
+// messages, e.g. for a daily chat post.
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/history"
+)
+
+// Consumer is a client with the traffic it used
+type Consumer struct {
+	Name  string `json:"name"`
+	MAC   string `json:"mac"`
+	Bytes int64  `json:"bytes"`
+}
+
+// Guests summarizes the clients on guest networks
+type Guests struct {
+	Time time.Time `json:"time"`
+	// Connected is the number of guests connected now
+	Connected int `json:"connected"`
+	// NewToday are the connected guests first seen today
+	NewToday []string `json:"new_today"`
+	// Left is the number of guests that connected today but are gone now,
+	// as recorded by watch mode
+	Left int `json:"left"`
+	// TopConsumers are the connected guests that used the most traffic
+	TopConsumers []Consumer `json:"top_consumers"`
+	// AverageSignal is the mean signal of the wireless guests in dBm, 0 if
+	// there are none
+	AverageSignal int `json:"average_signal"`
+}
+
+// BuildGuests summarizes the guests among clients at now. events are the
+// watch mode history of the same controller; top is how many consumers to
+// list.
+func BuildGuests(clients []api.Client, events []history.Event, now time.Time, top int) Guests {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	digest := Guests{Time: now, NewToday: []string{}, TopConsumers: []Consumer{}}
+
+	connected := map[string]bool{}
+	signalSum, signalCount := 0, 0
+	for _, c := range clients {
+		if !c.IsGuest {
+			continue
+		}
+		connected[strings.ToLower(c.MAC)] = true
+		digest.Connected++
+
+		if c.FirstSeen > 0 && !time.Unix(c.FirstSeen, 0).Before(midnight) {
+			digest.NewToday = append(digest.NewToday, c.GetDisplayName())
+		}
+		if !c.IsWired && c.Signal != 0 {
+			signalSum += c.Signal
+			signalCount++
+		}
+		digest.TopConsumers = append(digest.TopConsumers, Consumer{
+			Name:  c.GetDisplayName(),
+			MAC:   c.MAC,
+			Bytes: c.TxBytes + c.RxBytes,
+		})
+	}
+	sort.Strings(digest.NewToday)
+
+	if signalCount > 0 {
+		digest.AverageSignal = signalSum / signalCount
+	}
+
+	sort.SliceStable(digest.TopConsumers, func(i, j int) bool {
+		return digest.TopConsumers[i].Bytes > digest.TopConsumers[j].Bytes
+	})
+	if len(digest.TopConsumers) > top {
+		digest.TopConsumers = digest.TopConsumers[:top]
+	}
+
+	left := map[string]bool{}
+	for _, e := range events {
+		mac := strings.ToLower(e.MAC)
+		if e.Guest && e.Type == history.EventConnected && !e.Time.Before(midnight) && !connected[mac] {
+			left[mac] = true
+		}
+	}
+	digest.Left = len(left)
+
+	return digest
+}
+
+// Text renders the digest as a few lines of plain text
+func (g Guests) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Guest network, %s\n", g.Time.Format("Mon 2 Jan 15:04"))
+
+	fmt.Fprintf(&b, "Connected now: %d", g.Connected)
+	if len(g.NewToday) > 0 {
+		fmt.Fprintf(&b, " (%d new today: %s)", len(g.NewToday), strings.Join(g.NewToday, ", "))
+	}
+	b.WriteString("\n")
+
+	if g.Left > 0 {
+		fmt.Fprintf(&b, "Left since midnight: %d\n", g.Left)
+	}
+
+	if len(g.TopConsumers) > 0 {
+		consumers := make([]string, len(g.TopConsumers))
+		for i, c := range g.TopConsumers {
+			consumers[i] = c.Name + " " + api.FormatBytes(c.Bytes)
+		}
+		fmt.Fprintf(&b, "Top consumers: %s\n", strings.Join(consumers, ", "))
+	}
+
+	if g.AverageSignal != 0 {
+		fmt.Fprintf(&b, "Average signal: %d dBm\n", g.AverageSignal)
+	}
+
+	return b.String()
+}
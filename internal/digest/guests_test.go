@@ -0,0 +1,77 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/history"
+)
+
+func TestBuildGuests(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	today := now.Add(-2 * time.Hour)
+	yesterday := now.Add(-30 * time.Hour)
+
+	clients := []api.Client{
+		{MAC: "aa:00:00:00:00:01", Hostname: "pixel", IsGuest: true, FirstSeen: today.Unix(), Signal: -60, TxBytes: 100, RxBytes: 900},
+		{MAC: "aa:00:00:00:00:02", Hostname: "ipad", IsGuest: true, FirstSeen: yesterday.Unix(), Signal: -70, RxBytes: 5000},
+		{MAC: "aa:00:00:00:00:03", Hostname: "printer", IsGuest: true, IsWired: true, FirstSeen: today.Unix(), TxBytes: 10},
+		{MAC: "bb:00:00:00:00:01", Hostname: "nas", IsWired: true, TxBytes: 1 << 40},
+	}
+	events := []history.Event{
+		{Time: today, Type: history.EventConnected, MAC: "cc:00:00:00:00:01", Guest: true},
+		{Time: today, Type: history.EventConnected, MAC: "cc:00:00:00:00:01", Guest: true},
+		{Time: yesterday, Type: history.EventConnected, MAC: "cc:00:00:00:00:02", Guest: true},
+		{Time: today, Type: history.EventConnected, MAC: "AA:00:00:00:00:01", Guest: true},
+		{Time: today, Type: history.EventConnected, MAC: "bb:00:00:00:00:02"},
+	}
+
+	digest := BuildGuests(clients, events, now, 2)
+
+	if digest.Connected != 3 {
+		t.Errorf("Expected 3 connected guests, got %d", digest.Connected)
+	}
+	if strings.Join(digest.NewToday, ",") != "pixel,printer" {
+		t.Errorf("Expected pixel and printer to be new today, got %v", digest.NewToday)
+	}
+	if digest.Left != 1 {
+		t.Errorf("Expected 1 guest that left today, got %d", digest.Left)
+	}
+	if len(digest.TopConsumers) != 2 || digest.TopConsumers[0].Name != "ipad" || digest.TopConsumers[1].Bytes != 1000 {
+		t.Errorf("Unexpected top consumers %+v", digest.TopConsumers)
+	}
+	if digest.AverageSignal != -65 {
+		t.Errorf("Expected average signal -65, got %d", digest.AverageSignal)
+	}
+}
+
+func TestGuests_Text(t *testing.T) {
+	digest := Guests{
+		Time:          time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC),
+		Connected:     3,
+		NewToday:      []string{"pixel", "printer"},
+		Left:          1,
+		TopConsumers:  []Consumer{{Name: "ipad", Bytes: 5000}},
+		AverageSignal: -65,
+	}
+
+	text := digest.Text()
+	for _, want := range []string{
+		"Guest network, Fri 16 Oct 18:00\n",
+		"Connected now: 3 (2 new today: pixel, printer)\n",
+		"Left since midnight: 1\n",
+		"Top consumers: ipad 4.88 KB\n",
+		"Average signal: -65 dBm\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in digest:\n%s", want, text)
+		}
+	}
+
+	quiet := Guests{Time: digest.Time}.Text()
+	if strings.Contains(quiet, "Left") || strings.Contains(quiet, "Top consumers") || strings.Contains(quiet, "signal") {
+		t.Errorf("Expected only the count for an empty guest network:\n%s", quiet)
+	}
+}
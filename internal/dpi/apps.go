@@ -0,0 +1,70 @@
+package dpi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// appKey identifies an application: its ID is only unique within its
+// category
+type appKey struct{ cat, app int }
+
+// apps names common applications. Applications not listed here show as
+// their category and number; the dpi_apps section of the config file adds
+// or corrects names.
+var apps = map[appKey]string{
+	{0, 1}:   "MSN Messenger",
+	{0, 5}:   "Yahoo Messenger",
+	{0, 23}:  "WhatsApp",
+	{0, 29}:  "Telegram",
+	{1, 1}:   "BitTorrent",
+	{1, 5}:   "eDonkey",
+	{3, 8}:   "Dropbox",
+	{3, 19}:  "Google Drive",
+	{3, 21}:  "OneDrive",
+	{4, 7}:   "Spotify",
+	{4, 21}:  "YouTube",
+	{4, 94}:  "Netflix",
+	{4, 105}: "Twitch",
+	{4, 130}: "Disney+",
+	{5, 2}:   "Gmail",
+	{5, 14}:  "Outlook",
+	{6, 1}:   "Skype",
+	{6, 30}:  "Zoom",
+	{8, 7}:   "Steam",
+	{8, 44}:  "Xbox Live",
+	{8, 46}:  "PlayStation Network",
+	{13, 7}:  "HTTP",
+	{13, 63}: "Google",
+	{13, 84}: "Amazon",
+	{14, 1}:  "Windows Update",
+	{14, 5}:  "Apple Update",
+	{24, 1}:  "Facebook",
+	{24, 5}:  "Twitter",
+	{24, 33}: "Instagram",
+	{24, 64}: "TikTok",
+}
+
+// AddAppNames adds or replaces application names, keyed by "category:app"
+// such as "4:94"
+func AddAppNames(names map[string]string) error {
+	for key, name := range names {
+		k, err := parseAppKey(key)
+		if err != nil {
+			return err
+		}
+		apps[k] = name
+	}
+	return nil
+}
+
+func parseAppKey(key string) (appKey, error) {
+	cat, app, ok := strings.Cut(key, ":")
+	c, catErr := strconv.Atoi(strings.TrimSpace(cat))
+	a, appErr := strconv.Atoi(strings.TrimSpace(app))
+	if !ok || catErr != nil || appErr != nil {
+		return appKey{}, fmt.Errorf("invalid DPI application %q (use category:app, e.g. 4:94)", key)
+	}
+	return appKey{c, a}, nil
+}
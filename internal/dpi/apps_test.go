@@ -0,0 +1,36 @@
+package dpi
+
+import "testing"
+
+func TestAppName(t *testing.T) {
+	if got := AppName(4, 94); got != "Netflix" {
+		t.Errorf("AppName(4, 94) = %q, want Netflix", got)
+	}
+	if got := AppName(4, 9999); got != "Media streaming services #9999" {
+		t.Errorf("AppName(4, 9999) = %q", got)
+	}
+}
+
+func TestAddAppNames(t *testing.T) {
+	saved := apps[appKey{4, 94}]
+	t.Cleanup(func() {
+		apps[appKey{4, 94}] = saved
+		delete(apps, appKey{13, 200})
+	})
+
+	if err := AddAppNames(map[string]string{"13:200": "Intranet", " 4 : 94 ": "Netflix (4K)"}); err != nil {
+		t.Fatalf("AddAppNames() returned error: %v", err)
+	}
+	if got := AppName(13, 200); got != "Intranet" {
+		t.Errorf("Expected added name, got %q", got)
+	}
+	if got := AppName(4, 94); got != "Netflix (4K)" {
+		t.Errorf("Expected replaced name, got %q", got)
+	}
+
+	for _, key := range []string{"94", "a:b", "4:"} {
+		if err := AddAppNames(map[string]string{key: "x"}); err == nil {
+			t.Errorf("Expected error for %q", key)
+		}
+	}
+}
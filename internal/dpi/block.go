@@ -0,0 +1,170 @@
+package dpi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// AppID combines a category and an application into the ID traffic rules
+// use
+func AppID(cat, app int) int {
+	return cat<<16 | app
+}
+
+// SplitAppID splits a combined application ID into category and application
+func SplitAppID(id int) (cat, app int) {
+	return id >> 16, id & 0xffff
+}
+
+// FindApp looks an application up by name (case insensitive) or as
+// category:app, such as 1:1, and returns its combined ID
+func FindApp(query string) (int, error) {
+	if k, err := parseAppKey(query); err == nil {
+		return AppID(k.cat, k.app), nil
+	}
+
+	var found []appKey
+	for k, name := range apps {
+		if strings.EqualFold(name, query) {
+			found = append(found, k)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return 0, fmt.Errorf("unknown application %q (use its name, or category:app for one without a name)", query)
+	case 1:
+		return AppID(found[0].cat, found[0].app), nil
+	}
+
+	ids := make([]string, len(found))
+	for i, k := range found {
+		ids[i] = fmt.Sprintf("%d:%d", k.cat, k.app)
+	}
+	sort.Strings(ids)
+	return 0, fmt.Errorf("several applications are named %q (%s), use category:app", query, strings.Join(ids, ", "))
+}
+
+// AppIDName names a combined application ID
+func AppIDName(id int) string {
+	return AppName(SplitAppID(id))
+}
+
+// IsAppBlock reports whether a traffic rule blocks applications
+func IsAppBlock(rule api.TrafficRule) bool {
+	return rule.Action == api.RuleActionBlock && rule.MatchingTarget == api.RuleTargetApp
+}
+
+// AppBlocks returns the traffic rules that block applications
+func AppBlocks(rules []api.TrafficRule) []api.TrafficRule {
+	var blocks []api.TrafficRule
+	for _, rule := range rules {
+		if IsAppBlock(rule) {
+			blocks = append(blocks, rule)
+		}
+	}
+	return blocks
+}
+
+// Blocks reports whether an enabled rule blocks the application for the
+// client, as one of its clients or for all clients
+func Blocks(rule api.TrafficRule, appID int, mac string) bool {
+	if !rule.Enabled || !IsAppBlock(rule) || !containsInt(rule.AppIDs, appID) {
+		return false
+	}
+	return Targets(rule, mac)
+}
+
+// Targets reports whether a rule applies to the client, as one of its
+// clients or for all clients
+func Targets(rule api.TrafficRule, mac string) bool {
+	if len(rule.TargetDevices) == 0 {
+		return true
+	}
+	for _, d := range rule.TargetDevices {
+		if d.Type == api.RouteDeviceAllClients || d.Type == api.RouteDeviceClient && strings.EqualFold(d.ClientMAC, mac) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAppBlock returns an enabled rule that blocks an application for one
+// client, at all times
+func NewAppBlock(appID int, mac string) api.TrafficRule {
+	return api.TrafficRule{
+		Description:    fmt.Sprintf("Block %s for %s", AppIDName(appID), strings.ToLower(mac)),
+		Enabled:        true,
+		Action:         api.RuleActionBlock,
+		MatchingTarget: api.RuleTargetApp,
+		AppIDs:         []int{appID},
+		AppCategoryIDs: []int{},
+		TargetDevices:  []api.RouteTargetDevice{{Type: api.RouteDeviceClient, ClientMAC: strings.ToLower(mac)}},
+		NetworkIDs:     []string{},
+		Domains:        []api.RouteDomain{},
+		IPAddresses:    []api.RouteIPAddress{},
+		IPRanges:       []json.RawMessage{},
+		Regions:        []string{},
+		Schedule:       api.RuleSchedule{Mode: "ALWAYS"},
+		BandwidthLimit: api.RuleBandwidthLimit{DownloadLimitKbps: 1024, UploadLimitKbps: 1024},
+	}
+}
+
+// Unblocking is how a rule changes to stop blocking an application for a
+// client: it is deleted, or kept with the remaining applications and clients
+type Unblocking struct {
+	Delete  bool
+	AppIDs  []int
+	Devices []api.RouteTargetDevice
+}
+
+// Unblock works out how to stop rule from blocking the application for the
+// client without changing what it does for other applications and clients.
+// Rules for all clients, and rules for several applications and several
+// clients, cannot be narrowed that way and are left to the controller.
+func Unblock(rule api.TrafficRule, appID int, mac string) (Unblocking, error) {
+	var others []api.RouteTargetDevice
+	for _, d := range rule.TargetDevices {
+		if d.Type != api.RouteDeviceClient {
+			return Unblocking{}, fmt.Errorf("rule %q blocks %s for all clients or whole networks, change it in the controller", rule.Description, AppIDName(appID))
+		}
+		if !strings.EqualFold(d.ClientMAC, mac) {
+			others = append(others, d)
+		}
+	}
+	if len(rule.TargetDevices) == 0 {
+		return Unblocking{}, fmt.Errorf("rule %q blocks %s for all clients, change it in the controller", rule.Description, AppIDName(appID))
+	}
+
+	var apps []int
+	for _, id := range rule.AppIDs {
+		if id != appID {
+			apps = append(apps, id)
+		}
+	}
+
+	switch {
+	case len(others) == 0 && len(apps) == 0:
+		return Unblocking{Delete: true}, nil
+	case len(others) == 0:
+		// Only this client: keep blocking the other applications
+		return Unblocking{AppIDs: apps, Devices: rule.TargetDevices}, nil
+	case len(apps) == 0:
+		// Only this application: keep blocking it for the other clients
+		return Unblocking{AppIDs: rule.AppIDs, Devices: others}, nil
+	}
+	return Unblocking{}, fmt.Errorf("rule %q blocks several applications for several clients, change it in the controller", rule.Description)
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
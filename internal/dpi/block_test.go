@@ -0,0 +1,120 @@
+package dpi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestAppID(t *testing.T) {
+	id := AppID(4, 94)
+	if id != 262238 {
+		t.Errorf("Expected 262238, got %d", id)
+	}
+	if cat, app := SplitAppID(id); cat != 4 || app != 94 {
+		t.Errorf("Expected 4:94, got %d:%d", cat, app)
+	}
+	if name := AppIDName(id); name != "Netflix" {
+		t.Errorf("Expected Netflix, got %q", name)
+	}
+}
+
+func TestFindApp(t *testing.T) {
+	if id, err := FindApp("bittorrent"); err != nil || id != AppID(1, 1) {
+		t.Errorf("Expected BitTorrent to be 1:1, got %d, %v", id, err)
+	}
+	if id, err := FindApp("13:200"); err != nil || id != AppID(13, 200) {
+		t.Errorf("Expected 13:200, got %d, %v", id, err)
+	}
+	if _, err := FindApp("No Such App"); err == nil {
+		t.Error("Expected an unknown application to fail")
+	}
+}
+
+func rule(apps []int, macs ...string) api.TrafficRule {
+	r := NewAppBlock(apps[0], "00:00:00:00:00:00")
+	r.Description = "test"
+	r.AppIDs = apps
+	r.TargetDevices = nil
+	for _, mac := range macs {
+		r.TargetDevices = append(r.TargetDevices, api.RouteTargetDevice{Type: api.RouteDeviceClient, ClientMAC: mac})
+	}
+	return r
+}
+
+func TestNewAppBlock(t *testing.T) {
+	r := NewAppBlock(AppID(1, 1), "AA:BB:CC:DD:EE:FF")
+
+	if r.Description != "Block BitTorrent for aa:bb:cc:dd:ee:ff" || !r.Enabled || !IsAppBlock(r) {
+		t.Errorf("Unexpected rule %+v", r)
+	}
+	if !Blocks(r, AppID(1, 1), "aa:bb:cc:dd:ee:ff") {
+		t.Error("Expected the rule to block BitTorrent for the client")
+	}
+	if Blocks(r, AppID(1, 1), "11:22:33:44:55:66") || Blocks(r, AppID(4, 94), "aa:bb:cc:dd:ee:ff") {
+		t.Error("Expected the rule to block nothing else")
+	}
+
+	r.Enabled = false
+	if Blocks(r, AppID(1, 1), "aa:bb:cc:dd:ee:ff") {
+		t.Error("Expected a disabled rule to block nothing")
+	}
+}
+
+func TestBlocksAllClients(t *testing.T) {
+	r := rule([]int{1})
+	if !Blocks(r, 1, "aa:bb:cc:dd:ee:ff") {
+		t.Error("Expected a rule without targets to block every client")
+	}
+
+	r.TargetDevices = []api.RouteTargetDevice{{Type: api.RouteDeviceAllClients}}
+	if !Blocks(r, 1, "aa:bb:cc:dd:ee:ff") {
+		t.Error("Expected an all clients rule to block every client")
+	}
+}
+
+func TestUnblock(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    api.TrafficRule
+		delete  bool
+		apps    []int
+		clients int
+		err     string
+	}{
+		{name: "only rule", rule: rule([]int{1}, "aa"), delete: true},
+		{name: "other apps", rule: rule([]int{1, 2}, "aa"), apps: []int{2}, clients: 1},
+		{name: "other clients", rule: rule([]int{1}, "AA", "bb"), apps: []int{1}, clients: 1},
+		{name: "apps and clients", rule: rule([]int{1, 2}, "aa", "bb"), err: "several applications"},
+		{name: "all clients", rule: rule([]int{1}), err: "all clients"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := Unblock(tt.rule, 1, "aa")
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if u.Delete != tt.delete || len(u.AppIDs) != len(tt.apps) || len(u.Devices) != tt.clients {
+				t.Errorf("Unexpected unblocking %+v", u)
+			}
+			for i, id := range tt.apps {
+				if u.AppIDs[i] != id {
+					t.Errorf("Expected apps %v, got %v", tt.apps, u.AppIDs)
+				}
+			}
+			for _, d := range u.Devices {
+				if strings.EqualFold(d.ClientMAC, "aa") && tt.apps[0] == 1 {
+					t.Errorf("Expected the client to be removed, got %+v", u.Devices)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package dpi names and aggregates deep packet inspection statistics.
+package dpi
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// categories are the names of the controller's DPI categories
+var categories = map[int]string{
+	0:   "Instant messengers",
+	1:   "Peer-to-peer networks",
+	3:   "File sharing",
+	4:   "Media streaming services",
+	5:   "Email messaging services",
+	6:   "VoIP services",
+	7:   "Database tools",
+	8:   "Online games",
+	9:   "Management protocols",
+	10:  "Remote access terminals",
+	11:  "Tunneling and proxy services",
+	12:  "Investment sites",
+	13:  "Web services",
+	14:  "Security update",
+	15:  "Web IM",
+	17:  "Business tools",
+	18:  "Network protocols",
+	19:  "Network protocols",
+	20:  "Network protocols",
+	23:  "Private protocols",
+	24:  "Social networks",
+	255: "Unknown",
+}
+
+// CategoryName returns the name of a DPI category
+func CategoryName(cat int) string {
+	if name, ok := categories[cat]; ok {
+		return name
+	}
+	return fmt.Sprintf("Category %d", cat)
+}
+
+// AppName returns the name of an application within its category, or the
+// category and number of an unknown application
+func AppName(cat, app int) string {
+	if name, ok := apps[appKey{cat, app}]; ok {
+		return name
+	}
+	return fmt.Sprintf("%s #%d", CategoryName(cat), app)
+}
+
+// Usage is the traffic of an application or of a whole category
+type Usage struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// Cat and App identify the application; App is -1 for a category
+	Cat     int   `json:"cat"`
+	App     int   `json:"app"`
+	RxBytes int64 `json:"rx_bytes"`
+	TxBytes int64 `json:"tx_bytes"`
+	// Apps is the number of applications seen in a category
+	Apps int `json:"apps,omitempty"`
+	// Clients is the number of clients that used an application, if known
+	Clients int `json:"clients,omitempty"`
+}
+
+// Total returns the traffic in both directions
+func (u Usage) Total() int64 {
+	return u.RxBytes + u.TxBytes
+}
+
+// ByApp returns the traffic of every application, most bytes first
+func ByApp(entries []api.DPIEntry) []Usage {
+	type key struct{ cat, app int }
+
+	apps := map[key]*Usage{}
+	for _, e := range entries {
+		k := key{e.Cat, e.App}
+		usage, ok := apps[k]
+		if !ok {
+			usage = &Usage{Name: AppName(e.Cat, e.App), Category: CategoryName(e.Cat), Cat: e.Cat, App: e.App}
+			apps[k] = usage
+		}
+		usage.RxBytes += e.RxBytes
+		usage.TxBytes += e.TxBytes
+		usage.Clients += e.KnownClients
+	}
+
+	usages := make([]Usage, 0, len(apps))
+	for _, usage := range apps {
+		usages = append(usages, *usage)
+	}
+	sortUsages(usages)
+	return usages
+}
+
+// ByCategory returns the traffic of every category, most bytes first
+func ByCategory(entries []api.DPIEntry) []Usage {
+	cats := map[int]*Usage{}
+	for _, app := range ByApp(entries) {
+		usage, ok := cats[app.Cat]
+		if !ok {
+			usage = &Usage{Name: app.Category, Category: app.Category, Cat: app.Cat, App: -1}
+			cats[app.Cat] = usage
+		}
+		usage.RxBytes += app.RxBytes
+		usage.TxBytes += app.TxBytes
+		usage.Apps++
+	}
+
+	usages := make([]Usage, 0, len(cats))
+	for _, usage := range cats {
+		usages = append(usages, *usage)
+	}
+	sortUsages(usages)
+	return usages
+}
+
+// Top returns the first n usages, or all of them for n <= 0
+func Top(usages []Usage, n int) []Usage {
+	if n > 0 && len(usages) > n {
+		return usages[:n]
+	}
+	return usages
+}
+
+// sortUsages orders by total bytes, descending, then by name
+func sortUsages(usages []Usage) {
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Total() != usages[j].Total() {
+			return usages[i].Total() > usages[j].Total()
+		}
+		return usages[i].Name < usages[j].Name
+	})
+}
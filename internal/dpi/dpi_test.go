@@ -0,0 +1,62 @@
+package dpi
+
+import (
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var entries = []api.DPIEntry{
+	{Cat: 4, App: 94, RxBytes: 5000, TxBytes: 100, KnownClients: 3},
+	{Cat: 13, App: 5, RxBytes: 10, TxBytes: 20, KnownClients: 1},
+	{Cat: 4, App: 7, RxBytes: 900, TxBytes: 50},
+	{Cat: 13, App: 5, RxBytes: 30, TxBytes: 40, KnownClients: 2},
+}
+
+func TestByApp(t *testing.T) {
+	usages := ByApp(entries)
+
+	if len(usages) != 3 {
+		t.Fatalf("Expected 3 applications, got %+v", usages)
+	}
+	if usages[0].App != 94 || usages[0].Total() != 5100 || usages[0].Clients != 3 || usages[0].Category != "Media streaming services" {
+		t.Errorf("Unexpected top application %+v", usages[0])
+	}
+	if usages[2].App != 5 || usages[2].RxBytes != 40 || usages[2].TxBytes != 60 || usages[2].Clients != 3 {
+		t.Errorf("Expected repeated entries to be summed, got %+v", usages[2])
+	}
+}
+
+func TestByCategory(t *testing.T) {
+	usages := ByCategory(entries)
+
+	if len(usages) != 2 {
+		t.Fatalf("Expected 2 categories, got %+v", usages)
+	}
+	if usages[0].Name != "Media streaming services" || usages[0].Total() != 6050 || usages[0].Apps != 2 || usages[0].App != -1 {
+		t.Errorf("Unexpected top category %+v", usages[0])
+	}
+	if usages[1].Name != "Web services" || usages[1].Total() != 100 || usages[1].Apps != 1 {
+		t.Errorf("Unexpected second category %+v", usages[1])
+	}
+}
+
+func TestTop(t *testing.T) {
+	usages := ByApp(entries)
+
+	if got := Top(usages, 2); len(got) != 2 || got[0].App != 94 {
+		t.Errorf("Unexpected top 2 %+v", got)
+	}
+	if got := Top(usages, 0); len(got) != 3 {
+		t.Errorf("Expected all usages for 0, got %d", len(got))
+	}
+}
+
+func TestNames(t *testing.T) {
+	if got := CategoryName(999); got != "Category 999" {
+		t.Errorf("CategoryName(999) = %q", got)
+	}
+	if got := AppName(13, 5); got != "Web services #5" {
+		t.Errorf("AppName(13, 5) = %q", got)
+	}
+}
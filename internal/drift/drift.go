@@ -0,0 +1,166 @@
+// Package drift notices controller upgrades and fields that stop being
+// populated after one.
+package drift
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/datafile"
+)
+
+// Entry is the controller version last seen for a host
+type Entry struct {
+	Version string    `json:"version"`
+	Checked time.Time `json:"checked"`
+}
+
+// Cache is a JSON file of the controller versions seen per host
+type Cache struct {
+	path     string
+	Versions map[string]Entry
+}
+
+// LoadCache reads the cache at path; a missing file yields an empty cache
+func LoadCache(path string) (*Cache, error) {
+	cache := &Cache{path: path, Versions: map[string]Entry{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read version cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cache.Versions); err != nil {
+		return nil, fmt.Errorf("failed to parse version cache: %w", err)
+	}
+	if cache.Versions == nil {
+		cache.Versions = map[string]Entry{}
+	}
+
+	return cache, nil
+}
+
+// Due reports whether host's version was last checked more than interval
+// before now
+func (c *Cache) Due(host string, now time.Time, interval time.Duration) bool {
+	entry, ok := c.Versions[host]
+	return !ok || now.Sub(entry.Checked) >= interval
+}
+
+// Update records the version seen for host and returns the previously seen
+// version if it differs. A host seen for the first time is not a change.
+func (c *Cache) Update(host, version string, now time.Time) (previous string, changed bool) {
+	entry, ok := c.Versions[host]
+	c.Versions[host] = Entry{Version: version, Checked: now}
+
+	if !ok || entry.Version == "" || entry.Version == version {
+		return "", false
+	}
+	return entry.Version, true
+}
+
+// Save writes the cache back to disk, creating its directory if needed
+func (c *Cache) Save() error {
+	data, err := json.MarshalIndent(c.Versions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode version cache: %w", err)
+	}
+
+	if err := datafile.WriteFile(c.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write version cache: %w", err)
+	}
+
+	return nil
+}
+
+// Field is a record field the CLI relies on
+type Field struct {
+	Name string
+	// Only limits the check to records where this boolean field is true, or
+	// false when prefixed with "!", e.g. "!is_wired" for wireless-only fields
+	Only string
+}
+
+// Probe lists the fields to check in the records of a site endpoint
+type Probe struct {
+	Resource string
+	Endpoint string
+	Fields   []Field
+}
+
+// Probes are the endpoints and fields checked after a controller upgrade:
+// the ones whose columns would otherwise silently go empty
+var Probes = []Probe{
+	{
+		Resource: "client",
+		Endpoint: "stat/sta",
+		Fields: []Field{
+			{Name: "hostname"},
+			{Name: "uptime"},
+			{Name: "network"},
+			{Name: "essid", Only: "!is_wired"},
+			{Name: "signal", Only: "!is_wired"},
+			{Name: "satisfaction", Only: "!is_wired"},
+			{Name: "sw_port", Only: "is_wired"},
+		},
+	},
+	{
+		Resource: "device",
+		Endpoint: "stat/device",
+		Fields: []Field{
+			{Name: "version"},
+			{Name: "uptime", Only: "adopted"},
+			{Name: "num_sta", Only: "adopted"},
+			{Name: "system-stats", Only: "adopted"},
+			{Name: "uplink", Only: "adopted"},
+		},
+	},
+}
+
+// MissingFields parses a list response and returns the fields that none of
+// the records they apply to have. An empty list proves nothing and yields no
+// fields.
+func MissingFields(body []byte, fields []Field) ([]string, error) {
+	var response struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var missing []string
+	for _, field := range fields {
+		applicable, populated := 0, false
+		for _, record := range response.Data {
+			if !field.appliesTo(record) {
+				continue
+			}
+			applicable++
+			if value, ok := record[field.Name]; ok && value != nil {
+				populated = true
+				break
+			}
+		}
+		if applicable > 0 && !populated {
+			missing = append(missing, field.Name)
+		}
+	}
+	return missing, nil
+}
+
+func (f Field) appliesTo(record map[string]interface{}) bool {
+	if f.Only == "" {
+		return true
+	}
+
+	name, want := strings.TrimPrefix(f.Only, "!"), !strings.HasPrefix(f.Only, "!")
+	value, _ := record[name].(bool)
+	return value == want
+}
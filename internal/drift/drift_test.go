@@ -0,0 +1,100 @@
+package drift
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.json")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	cache, err := LoadCache(path)
+	if err != nil {
+		t.Fatalf("LoadCache() returned error: %v", err)
+	}
+	if !cache.Due("https://unifi", now, time.Hour) {
+		t.Error("Expected an unknown host to be due")
+	}
+	if _, changed := cache.Update("https://unifi", "9.0.114", now); changed {
+		t.Error("Expected the first version seen not to be a change")
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	cache, err = LoadCache(path)
+	if err != nil {
+		t.Fatalf("LoadCache() returned error: %v", err)
+	}
+	if cache.Due("https://unifi", now.Add(30*time.Minute), time.Hour) {
+		t.Error("Expected a recently checked host not to be due")
+	}
+	if !cache.Due("https://unifi", now.Add(time.Hour), time.Hour) {
+		t.Error("Expected the host to be due after the interval")
+	}
+	if _, changed := cache.Update("https://unifi", "9.0.114", now); changed {
+		t.Error("Expected the same version not to be a change")
+	}
+	if previous, changed := cache.Update("https://unifi", "9.1.120", now); !changed || previous != "9.0.114" {
+		t.Errorf("Expected a change from 9.0.114, got %q, %v", previous, changed)
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	body := []byte(`{"meta":{"rc":"ok"},"data":[
+		{"hostname":"nas","is_wired":true,"sw_port":3,"essid":null},
+		{"hostname":"phone","is_wired":false,"signal":-60}
+	]}`)
+	fields := []Field{
+		{Name: "hostname"},
+		{Name: "uptime"},
+		{Name: "essid", Only: "!is_wired"},
+		{Name: "signal", Only: "!is_wired"},
+		{Name: "sw_port", Only: "is_wired"},
+		{Name: "num_sta", Only: "adopted"},
+	}
+
+	missing, err := MissingFields(body, fields)
+	if err != nil {
+		t.Fatalf("MissingFields() returned error: %v", err)
+	}
+	if want := []string{"uptime", "essid"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("Expected %v, got %v", want, missing)
+	}
+
+	if missing, _ := MissingFields([]byte(`{"data":[]}`), fields); len(missing) != 0 {
+		t.Errorf("Expected no missing fields for an empty list, got %v", missing)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9.0.114", "9.0.114", 0},
+		{"9.0.114", "7.0", 1},
+		{"6.5.55", "7.0", -1},
+		{"7", "7.0.0", 0},
+		{"8.10.1", "8.9.2", 1},
+		{"7.0.23-beta", "7.0.23", 0},
+		{"7.1rc2", "7.1.1", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	if !AtLeast("9.0.114", "7.0") || AtLeast("6.5.55", "7.0") {
+		t.Error("Expected versions to be compared against the minimum")
+	}
+	if !AtLeast("", "7.0") {
+		t.Error("Expected an unknown version to be recent enough")
+	}
+}
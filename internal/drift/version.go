@@ -0,0 +1,57 @@
+package drift
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two controller versions such as "9.0.114"
+// number by number and returns -1, 0 or 1. Missing numbers count as zero and
+// anything after the numbers (e.g. "-beta") is ignored.
+func CompareVersions(a, b string) int {
+	as, bs := versionNumbers(a), versionNumbers(b)
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether version is min or later. An unknown (empty)
+// version is assumed to be recent enough.
+func AtLeast(version, min string) bool {
+	return version == "" || CompareVersions(version, min) >= 0
+}
+
+func versionNumbers(version string) []int {
+	var numbers []int
+	for _, part := range strings.Split(version, ".") {
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end == 0 {
+			break
+		}
+		if end > 0 {
+			part = part[:end]
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		if end > 0 {
+			break
+		}
+	}
+	return numbers
+}
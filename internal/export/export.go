@@ -0,0 +1,172 @@
+// Package export writes controller records to files in formats suited for
+// archiving and diffing: indented JSON and CSV, in a stable order.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Formats are the file formats records can be exported in
+var Formats = []string{"json", "csv"}
+
+// Dataset is a collection of records exported to its own file, named after
+// the dataset and the format
+type Dataset struct {
+	Name     string
+	Endpoint string
+}
+
+// Datasets are the collections "export all" writes, by site endpoint
+var Datasets = []Dataset{
+	{Name: "clients", Endpoint: "stat/sta"},
+	{Name: "devices", Endpoint: "stat/device"},
+	{Name: "networks", Endpoint: "rest/networkconf"},
+	{Name: "wlans", Endpoint: "rest/wlanconf"},
+	{Name: "firewall-rules", Endpoint: "rest/firewallrule"},
+	{Name: "settings", Endpoint: "rest/setting"},
+}
+
+// Record is a controller record with all its fields
+type Record map[string]interface{}
+
+// ParseFormats checks a list of format names, dropping duplicates
+func ParseFormats(names []string) ([]string, error) {
+	var formats []string
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		valid := false
+		for _, format := range Formats {
+			valid = valid || name == format
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid format: %s (valid options: %s)", name, strings.Join(Formats, ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			formats = append(formats, name)
+		}
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no formats given (valid options: %s)", strings.Join(Formats, ", "))
+	}
+	return formats, nil
+}
+
+// ParseRecords reads the records of a classic API response and sorts them,
+// so consecutive exports list unchanged records in the same place
+func ParseRecords(body []byte) ([]Record, error) {
+	var response struct {
+		Data []Record `json:"data"`
+	}
+	// Numbers are kept as written, large counters would lose digits as
+	// floats
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	records := response.Data
+	sort.SliceStable(records, func(i, j int) bool {
+		return sortKey(records[i]) < sortKey(records[j])
+	})
+	return records, nil
+}
+
+// sortKey identifies a record: by ID, or by MAC address for records without
+// one, such as connected clients
+func sortKey(r Record) string {
+	for _, field := range []string{"_id", "mac"} {
+		if s, ok := r[field].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Write writes records to w in format
+func Write(w io.Writer, format string, records []Record) error {
+	switch format {
+	case "json":
+		return WriteJSON(w, records)
+	case "csv":
+		return WriteCSV(w, records)
+	default:
+		return fmt.Errorf("invalid format: %s (valid options: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// WriteJSON writes records as an indented JSON array with sorted keys, which
+// diffs line by line
+func WriteJSON(w io.Writer, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes records as CSV with a column for every field any record
+// has, sorted by name. Nested objects and lists are written as JSON.
+func WriteCSV(w io.Writer, records []Record) error {
+	columnSet := map[string]bool{}
+	for _, r := range records {
+		for key := range r {
+			columnSet[key] = true
+		}
+	}
+	columns := make([]string, 0, len(columnSet))
+	for key := range columnSet {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, r := range records {
+		row := make([]string, len(columns))
+		for i, key := range columns {
+			row[i] = csvValue(r[key])
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// csvValue formats a field for a CSV cell; missing fields are left empty
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseFormats(t *testing.T) {
+	formats, err := ParseFormats([]string{"JSON", " csv", "json"})
+	if err != nil {
+		t.Fatalf("ParseFormats() returned error: %v", err)
+	}
+	if strings.Join(formats, ",") != "json,csv" {
+		t.Errorf("Expected json,csv, got %v", formats)
+	}
+
+	if _, err := ParseFormats([]string{"xml"}); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+	if _, err := ParseFormats(nil); err == nil {
+		t.Error("Expected error for no formats")
+	}
+}
+
+func TestParseRecords(t *testing.T) {
+	body := []byte(`{"meta":{"rc":"ok"},"data":[
+		{"_id":"b","name":"IoT","rx_bytes":9007199254740993},
+		{"_id":"a","name":"Default"}
+	]}`)
+
+	records, err := ParseRecords(body)
+	if err != nil {
+		t.Fatalf("ParseRecords() returned error: %v", err)
+	}
+	if len(records) != 2 || records[0]["_id"] != "a" || records[1]["_id"] != "b" {
+		t.Errorf("Expected records sorted by ID, got %v", records)
+	}
+
+	// Connected clients have no ID
+	clients, err := ParseRecords([]byte(`{"data":[{"mac":"aa:bb:cc:dd:ee:02"},{"mac":"aa:bb:cc:dd:ee:01"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRecords() returned error: %v", err)
+	}
+	if clients[0]["mac"] != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("Expected clients sorted by MAC, got %v", clients)
+	}
+
+	var out bytes.Buffer
+	if err := WriteJSON(&out, records[1:2]); err != nil {
+		t.Fatalf("WriteJSON() returned error: %v", err)
+	}
+	want := "[\n  {\n    \"_id\": \"b\",\n    \"name\": \"IoT\",\n    \"rx_bytes\": 9007199254740993\n  }\n]\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+
+	if _, err := ParseRecords([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid response")
+	}
+}
+
+func TestWriteJSON_Empty(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteJSON(&out, nil); err != nil {
+		t.Fatalf("WriteJSON() returned error: %v", err)
+	}
+	if out.String() != "[]\n" {
+		t.Errorf("Expected an empty array, got %q", out.String())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	records, err := ParseRecords([]byte(`{"data":[
+		{"_id":"1","name":"Office","enabled":true,"vlan":10,"dhcp":{"start":"10.0.0.6"}},
+		{"_id":"2","name":"Guest, \"free\"","tags":["a","b"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("ParseRecords() returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := Write(&out, "csv", records); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	want := `_id,dhcp,enabled,name,tags,vlan
+1,"{""start"":""10.0.0.6""}",true,Office,,10
+2,,,"Guest, ""free""","[""a"",""b""]",
+`
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+
+	if err := Write(&out, "xml", records); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
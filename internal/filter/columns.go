@@ -0,0 +1,105 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Datasets are the data sets WHERE clauses can be tested against, by the
+// name of their table
+var Datasets = []string{"clients", "devices", "ports", "sites", "networks"}
+
+// ColumnsOf returns the names of the columns available to WHERE clauses on
+// a dataset
+func (f *Filter) ColumnsOf(dataset string) ([]string, error) {
+	if !contains(Datasets, dataset) {
+		return nil, fmt.Errorf("unknown dataset %q (valid options: %s)", dataset, strings.Join(Datasets, ", "))
+	}
+
+	rows, err := f.db.Query(fmt.Sprintf("SELECT name FROM pragma_table_info('%s_view') WHERE name != 'data'", dataset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		columns = append(columns, name)
+	}
+
+	return columns, rows.Err()
+}
+
+// UsedColumns returns the columns of a dataset the WHERE clause refers to,
+// in order of first use. Words inside string literals are not columns.
+func (f *Filter) UsedColumns(dataset string) ([]string, error) {
+	columns, err := f.ColumnsOf(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	var used []string
+	for _, word := range identifiers(f.whereClause) {
+		for _, column := range columns {
+			if strings.EqualFold(word, column) && !contains(used, column) {
+				used = append(used, column)
+			}
+		}
+	}
+	return used, nil
+}
+
+// identifiers splits a WHERE clause into its bare and double-quoted words,
+// skipping single-quoted string literals and numbers
+func identifiers(whereClause string) []string {
+	var words []string
+	for i := 0; i < len(whereClause); {
+		c := whereClause[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := closingQuote(whereClause, i)
+			if c == '"' {
+				words = append(words, strings.ReplaceAll(whereClause[i+1:end], `""`, `"`))
+			}
+			i = end + 1
+		case isWordChar(c):
+			end := i
+			for end < len(whereClause) && (isWordChar(whereClause[end]) || whereClause[end] == '.') {
+				end++
+			}
+			// Numbers such as 5e3 are not identifiers
+			if c < '0' || c > '9' {
+				words = append(words, whereClause[i:end])
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return words
+}
+
+// closingQuote returns the index of the quote closing the one at start, or
+// the length of s if it is unterminated. A doubled quote is an escaped quote.
+func closingQuote(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		if s[i] != quote {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			i++
+			continue
+		}
+		return i
+	}
+	return len(s)
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,60 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumnsOf(t *testing.T) {
+	f, err := NewFilter("1")
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	defer f.Close()
+
+	for _, dataset := range Datasets {
+		columns, err := f.ColumnsOf(dataset)
+		if err != nil || len(columns) == 0 {
+			t.Errorf("Expected columns for %s, got %v, %v", dataset, columns, err)
+		}
+		if contains(columns, "data") {
+			t.Errorf("Expected the data column to be hidden for %s", dataset)
+		}
+	}
+
+	if _, err := f.ColumnsOf("users"); err == nil {
+		t.Error("Expected error for an unknown dataset")
+	}
+}
+
+func TestUsedColumns(t *testing.T) {
+	tests := []struct {
+		where    string
+		dataset  string
+		expected []string
+	}{
+		{"signal < -70 AND essid = 'Guest'", "clients", []string{"signal", "essid"}},
+		{"name LIKE '%signal%' OR \"Hostname\" = 'it''s'", "clients", []string{"name", "hostname"}},
+		{"ip_in('10.0.0.0/8') AND SIGNAL > -50 and signal < 5e3", "clients", []string{"ip", "signal"}},
+		{"type = 'uap' AND num_sta > 20", "devices", []string{"type", "num_sta"}},
+		{"1 = 1", "clients", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.where, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			used, err := f.UsedColumns(tt.dataset)
+			if err != nil {
+				t.Fatalf("UsedColumns failed: %v", err)
+			}
+			if !reflect.DeepEqual(used, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, used)
+			}
+		})
+	}
+}
@@ -4,13 +4,15 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	_ "modernc.org/sqlite"
 
 	"github.com/nkn/unifi-cli/internal/api"
 )
 
-// Filter applies SQL WHERE clause to clients using JSON storage
+// Filter applies SQL WHERE clause to clients, devices and ports using JSON
+// storage
 type Filter struct {
 	db          *sql.DB
 	whereClause string
@@ -23,13 +25,15 @@ func NewFilter(whereClause string) (*Filter, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Create table and view
-	if _, err := db.Exec(clientTableSchema); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to create schema: %w", err)
+	// Create tables and views
+	for _, schema := range []string{clientTableSchema, deviceTableSchema, portTableSchema, siteTableSchema, networkTableSchema} {
+		if _, err := db.Exec(schema); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create schema: %w", err)
+		}
 	}
 
-	return &Filter{db: db, whereClause: whereClause}, nil
+	return &Filter{db: db, whereClause: expandShorthands(whereClause)}, nil
 }
 
 // Apply filters clients using SQL WHERE clause
@@ -43,57 +47,119 @@ func (f *Filter) Apply(clients []api.Client) ([]api.Client, error) {
 	return f.queryClients()
 }
 
-// insertClients inserts all clients as JSON into the database
+// ApplyDevices filters devices using SQL WHERE clause. Devices that were not
+// listed through the API (e.g. loaded from fixtures) get their tags parsed
+// from the note first, so tag_<key> columns work for them too.
+func (f *Filter) ApplyDevices(devices []api.Device) ([]api.Device, error) {
+	tagged := make([]api.Device, len(devices))
+	for i, device := range devices {
+		if device.Tags == nil {
+			device.Tags = api.ParseTags(device.Note)
+		}
+		tagged[i] = device
+	}
+
+	if err := insertRows(f.db, "devices", tagged); err != nil {
+		return nil, err
+	}
+
+	return queryRows[api.Device](f.db, "devices_view", f.whereClause)
+}
+
+// ApplyPorts filters switch ports using SQL WHERE clause
+func (f *Filter) ApplyPorts(ports []api.PortStatus) ([]api.PortStatus, error) {
+	if err := insertRows(f.db, "ports", ports); err != nil {
+		return nil, err
+	}
+
+	return queryRows[api.PortStatus](f.db, "ports_view", f.whereClause)
+}
+
+// ApplySites filters site summaries using SQL WHERE clause
+func (f *Filter) ApplySites(sites []api.SiteSummary) ([]api.SiteSummary, error) {
+	if err := insertRows(f.db, "sites", sites); err != nil {
+		return nil, err
+	}
+
+	return queryRows[api.SiteSummary](f.db, "sites_view", f.whereClause)
+}
+
+// ApplyNetworks filters network configurations using SQL WHERE clause
+func (f *Filter) ApplyNetworks(networks []api.Network) ([]api.Network, error) {
+	if err := insertRows(f.db, "networks", networks); err != nil {
+		return nil, err
+	}
+
+	return queryRows[api.Network](f.db, "networks_view", f.whereClause)
+}
+
+// insertClients inserts all clients as JSON into the database, replacing the
+// clients of any earlier call so one Filter can be applied repeatedly
 func (f *Filter) insertClients(clients []api.Client) error {
-	tx, err := f.db.Begin()
+	return insertRows(f.db, "clients", clients)
+}
+
+// queryClients executes SELECT with WHERE clause on the view
+func (f *Filter) queryClients() ([]api.Client, error) {
+	return queryRows[api.Client](f.db, "clients_view", f.whereClause)
+}
+
+// insertRows replaces the contents of table with items stored as JSON
+func insertRows[T any](db *sql.DB, table string, items []T) error {
+	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	stmt, err := tx.Prepare("INSERT INTO clients (data) VALUES (?)")
+	if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", table, err)
+	}
+
+	stmt, err := tx.Prepare(fmt.Sprintf("INSERT INTO %s (data) VALUES (?)", table))
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	for _, client := range clients {
-		jsonData, err := json.Marshal(client)
+	for _, item := range items {
+		jsonData, err := json.Marshal(item)
 		if err != nil {
-			return fmt.Errorf("failed to marshal client: %w", err)
+			return fmt.Errorf("failed to marshal row: %w", err)
 		}
 
 		if _, err := stmt.Exec(string(jsonData)); err != nil {
-			return fmt.Errorf("failed to insert client: %w", err)
+			return fmt.Errorf("failed to insert row: %w", err)
 		}
 	}
 
 	return tx.Commit()
 }
 
-// queryClients executes SELECT with WHERE clause on the view
-func (f *Filter) queryClients() ([]api.Client, error) {
-	query := fmt.Sprintf("SELECT data FROM clients_view WHERE %s", f.whereClause)
+// queryRows selects the rows of view matching the WHERE clause and decodes
+// their JSON data
+func queryRows[T any](db *sql.DB, view, whereClause string) ([]T, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE %s", view, whereClause)
 
-	rows, err := f.db.Query(query)
+	rows, err := db.Query(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query clients: %w", err)
+		return nil, fmt.Errorf("failed to query %s: %w", strings.TrimSuffix(view, "_view"), err)
 	}
 	defer rows.Close()
 
-	var result []api.Client
+	var result []T
 	for rows.Next() {
 		var jsonData string
 		if err := rows.Scan(&jsonData); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		var client api.Client
-		if err := json.Unmarshal([]byte(jsonData), &client); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal client: %w", err)
+		var item T
+		if err := json.Unmarshal([]byte(jsonData), &item); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal row: %w", err)
 		}
 
-		result = append(result, client)
+		result = append(result, item)
 	}
 
 	return result, rows.Err()
@@ -383,3 +383,288 @@ func TestApply_EmptyClientList(t *testing.T) {
 		t.Errorf("Expected 0 clients for empty input, got %d", len(result))
 	}
 }
+
+func TestApply_ReusedAcrossCalls(t *testing.T) {
+	clients := createTestClients()
+	f, err := NewFilter("is_wired = 0")
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Apply(clients); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	// A second call must only see the clients passed to it
+	result, err := f.Apply(clients[:1])
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if len(result) != 1 {
+		t.Errorf("Expected 1 client after reapplying, got %d", len(result))
+	}
+}
+
+func TestApplyDevices(t *testing.T) {
+	devices := []api.Device{
+		{MAC: "f0:9f:c2:00:00:01", Name: "Office AP", Model: "U7PG2", Type: "uap", IP: "192.168.1.2", State: 1, NumSta: 12},
+		{MAC: "f0:9f:c2:00:00:02", Name: "Core Switch", Model: "USW24", Type: "usw", IP: "192.168.1.3", State: 1, NumSta: 30},
+		{MAC: "f0:9f:c2:00:00:03", Name: "Garage AP", Model: "U6LR", Type: "uap", IP: "10.0.0.4", State: 0},
+	}
+
+	tests := []struct {
+		name     string
+		where    string
+		expected int
+	}{
+		{"By type", "type = 'uap'", 2},
+		{"By state name", "state = 'disconnected'", 1},
+		{"By client count", "num_sta >= 12", 2},
+		{"Subnet shorthand", "ip_in('192.168.1.0/24')", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			result, err := f.ApplyDevices(devices)
+			if err != nil {
+				t.Fatalf("ApplyDevices failed: %v", err)
+			}
+
+			if len(result) != tt.expected {
+				t.Errorf("Expected %d devices, got %d", tt.expected, len(result))
+			}
+		})
+	}
+}
+
+func TestApplyDevices_Tags(t *testing.T) {
+	devices := []api.Device{
+		{MAC: "f0:9f:c2:00:00:01", Note: "location=branch-north rack=2"},
+		{MAC: "f0:9f:c2:00:00:02", Note: "location=hq"},
+		{MAC: "f0:9f:c2:00:00:03", Note: "spare"},
+	}
+
+	tests := []struct {
+		name     string
+		where    string
+		expected int
+	}{
+		{"Tag pattern", "tag_location LIKE 'branch-%'", 1},
+		{"Upper case column", "TAG_LOCATION = 'hq'", 1},
+		{"Untagged devices are NULL", "tag_location IS NULL", 1},
+		{"Quoted column name untouched", "tag_rack = '2' AND note != 'tag_rack'", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			result, err := f.ApplyDevices(devices)
+			if err != nil {
+				t.Fatalf("ApplyDevices failed: %v", err)
+			}
+
+			if len(result) != tt.expected {
+				t.Errorf("Expected %d devices, got %d", tt.expected, len(result))
+			}
+		})
+	}
+}
+
+func TestApplyDevices_Details(t *testing.T) {
+	devices := []api.Device{
+		{MAC: "f0:9f:c2:00:00:01", SystemStats: &api.SystemStats{CPU: "85.5", Mem: "40.1"}, Uplink: &api.Uplink{Type: "wireless"}},
+		{MAC: "f0:9f:c2:00:00:02", SystemStats: &api.SystemStats{CPU: "9.5", Mem: "91"}, Uplink: &api.Uplink{Type: "wire", Speed: 100}},
+		{MAC: "f0:9f:c2:00:00:03"},
+	}
+
+	tests := []struct {
+		where    string
+		expected string
+	}{
+		{"cpu > 80", "f0:9f:c2:00:00:01"},
+		{"mem > 90", "f0:9f:c2:00:00:02"},
+		{"uplink_type = 'wireless'", "f0:9f:c2:00:00:01"},
+		{"uplink_speed < 1000", "f0:9f:c2:00:00:02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.where, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			result, err := f.ApplyDevices(devices)
+			if err != nil {
+				t.Fatalf("ApplyDevices failed: %v", err)
+			}
+
+			if len(result) != 1 || result[0].MAC != tt.expected {
+				t.Errorf("Expected only %s, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestApplyDevices_ClientColumnsUnknown(t *testing.T) {
+	f, err := NewFilter("essid = 'Home'")
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := f.ApplyDevices([]api.Device{{MAC: "aa"}}); err == nil {
+		t.Error("Expected error for a client-only column")
+	}
+}
+
+func TestApplyPorts(t *testing.T) {
+	ports := []api.PortStatus{
+		{Port: api.Port{Index: 1, Name: "Uplink", Up: true, Speed: 10000, IsUplink: true}},
+		{Port: api.Port{Index: 7, Name: "Camera", Up: true, Speed: 100, PoE: true, PoEMode: "auto", PoEGood: false, RxErrors: 12, TxErrors: 3}, Profile: "Cameras", PoEWatts: 0},
+		{Port: api.Port{Index: 8, Name: "Printer", Up: false, PoE: true, PoEMode: "auto", PoEGood: true}, Clients: 1},
+		{Port: api.Port{Index: 9, Name: "AP", Up: true, Speed: 1000, PoE: true, PoEMode: "auto", PoEGood: true}, PoEWatts: 6.5, Clients: 14},
+	}
+
+	tests := []struct {
+		name     string
+		where    string
+		expected []int
+	}{
+		{"Errors", "errors > 10", []int{7}},
+		{"PoE faults", "poe AND poe_mode = 'auto' AND up AND NOT poe_good", []int{7}},
+		{"Down ports", "NOT up", []int{8}},
+		{"Profile", "profile = 'Cameras'", []int{7}},
+		{"Power draw", "poe_watts > 5", []int{9}},
+		{"Clients", "clients >= 1 ORDER BY idx", []int{8, 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			result, err := f.ApplyPorts(ports)
+			if err != nil {
+				t.Fatalf("ApplyPorts failed: %v", err)
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected ports %v, got %d ports", tt.expected, len(result))
+			}
+			for i, port := range result {
+				if port.Index != tt.expected[i] {
+					t.Errorf("Expected port %d at position %d, got %d", tt.expected[i], i, port.Index)
+				}
+			}
+		})
+	}
+}
+
+func TestApplySites(t *testing.T) {
+	sites := []api.SiteSummary{
+		{Site: api.Site{Name: "default", Desc: "Head Office", Role: "admin"}, Status: "ok", Clients: 40, Devices: 6},
+		{Site: api.Site{Name: "b1", Desc: "Branch Berlin", Role: "admin"}, Status: "warning", Clients: 5, Devices: 3, Disconnected: 1},
+		{Site: api.Site{Name: "b2", Desc: "Branch Paris", Role: "readonly"}, Status: "ok", Clients: 0, Devices: 2, Pending: 1},
+	}
+
+	tests := []struct {
+		name     string
+		where    string
+		expected []string
+	}{
+		{"Status", "status != 'ok'", []string{"b1"}},
+		{"Description", "description LIKE 'Branch%' ORDER BY name", []string{"b1", "b2"}},
+		{"Role", "role = 'readonly'", []string{"b2"}},
+		{"Device problems", "disconnected > 0 OR pending > 0 ORDER BY name", []string{"b1", "b2"}},
+		{"Clients", "clients >= 10", []string{"default"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			result, err := f.ApplySites(sites)
+			if err != nil {
+				t.Fatalf("ApplySites failed: %v", err)
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected sites %v, got %d sites", tt.expected, len(result))
+			}
+			for i, site := range result {
+				if site.Name != tt.expected[i] {
+					t.Errorf("Expected site %s at position %d, got %s", tt.expected[i], i, site.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyNetworks(t *testing.T) {
+	networks := []api.Network{
+		{ID: "n1", Name: "LAN", Purpose: "corporate", Enabled: true, IPSubnet: "192.168.1.1/24", DHCPEnabled: true, DomainName: "lan"},
+		{ID: "n2", Name: "IoT", Purpose: "corporate", Enabled: true, VLANEnabled: true, VLAN: 30, IGMPSnooping: true},
+		{ID: "n3", Name: "Guests", Purpose: "guest", VLAN: 40, MDNSEnabled: true, IPv6InterfaceType: "pd"},
+		{ID: "n4", Name: "Internet", Purpose: "wan"},
+	}
+
+	tests := []struct {
+		name     string
+		where    string
+		expected []string
+	}{
+		{"Purpose", "purpose != 'wan' AND enabled = 1", []string{"LAN", "IoT"}},
+		{"Tagged", "vlan > 0", []string{"IoT"}},
+		{"VLAN not enabled", "vlan = 0 AND purpose = 'guest'", []string{"Guests"}},
+		{"DHCP", "dhcp_enabled = 1 AND domain = 'lan'", []string{"LAN"}},
+		{"Multicast", "igmp_snooping = 1 OR mdns = 1", []string{"IoT", "Guests"}},
+		{"IPv6", "ipv6 != ''", []string{"Guests"}},
+		{"Subnet", "subnet LIKE '192.168.%'", []string{"LAN"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			result, err := f.ApplyNetworks(networks)
+			if err != nil {
+				t.Fatalf("ApplyNetworks failed: %v", err)
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected networks %v, got %d networks", tt.expected, len(result))
+			}
+			for i, network := range result {
+				if network.Name != tt.expected[i] {
+					t.Errorf("Expected network %s at position %d, got %s", tt.expected[i], i, network.Name)
+				}
+			}
+		})
+	}
+}
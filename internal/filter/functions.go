@@ -0,0 +1,149 @@
+package filter
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"modernc.org/sqlite"
+)
+
+// ipInCall matches the start of an ip_in call up to its first argument
+var ipInCall = regexp.MustCompile(`(?i)^ip_in\s*\(\s*`)
+
+// tagColumn matches the virtual tag_<key> columns of devices, which read the
+// tags parsed from the device note
+var tagColumn = regexp.MustCompile(`(?i)\btag_([a-z0-9_]+)\b`)
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("ip_in", -1, ipIn)
+}
+
+// ipIn implements ip_in(addr, cidr [, cidr ...]), returning 1 when addr falls
+// inside any of the given IPv4/IPv6 prefixes. Empty or unparsable addresses
+// never match; an invalid prefix is reported as an error.
+func ipIn(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("ip_in requires an address and at least one CIDR")
+	}
+
+	addrText, _ := args[0].(string)
+	addr, err := netip.ParseAddr(addrText)
+	if err != nil {
+		return int64(0), nil
+	}
+
+	for _, arg := range args[1:] {
+		cidr, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("ip_in: CIDR must be a string, got %v", arg)
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("ip_in: invalid CIDR %q", cidr)
+		}
+
+		if prefix.Contains(addr.Unmap()) {
+			return int64(1), nil
+		}
+	}
+
+	return int64(0), nil
+}
+
+// expandShorthands rewrites helper calls that omit their column argument into
+// the full form understood by SQLite, e.g. ip_in('10.0.0.0/8') becomes
+// ip_in(ip, '10.0.0.0/8'), and tag_<key> columns into the JSON lookup of the
+// tag
+func expandShorthands(whereClause string) string {
+	whereClause = expandIPIn(whereClause)
+	return outsideQuotes(whereClause, func(sql string) string {
+		return tagColumn.ReplaceAllStringFunc(sql, func(column string) string {
+			key := strings.ToLower(tagColumn.FindStringSubmatch(column)[1])
+			return fmt.Sprintf(`json_extract(data, '$.tags."%s"')`, key)
+		})
+	})
+}
+
+// expandIPIn adds the ip column to ip_in calls that only have a single string
+// literal argument, the CIDR. Calls with more arguments already name what to
+// test, e.g. ip_in(fixed_ip, '10.0.0.0/8') or ip_in('10.0.0.1', '10.0.0.0/8'),
+// and ip_in in quoted text is not a call at all.
+func expandIPIn(whereClause string) string {
+	var b strings.Builder
+	for i := 0; i < len(whereClause); {
+		if whereClause[i] == '\'' {
+			end := literalEnd(whereClause, i)
+			b.WriteString(whereClause[i:end])
+			i = end
+			continue
+		}
+
+		call := ""
+		if i == 0 || !isIdentByte(whereClause[i-1]) {
+			call = ipInCall.FindString(whereClause[i:])
+		}
+		if call == "" {
+			b.WriteByte(whereClause[i])
+			i++
+			continue
+		}
+
+		arg := i + len(call)
+		if arg < len(whereClause) && whereClause[arg] == '\'' {
+			rest := strings.TrimLeft(whereClause[literalEnd(whereClause, arg):], " \t\r\n")
+			if strings.HasPrefix(rest, ")") {
+				call = "ip_in(ip, "
+			}
+		}
+		b.WriteString(call)
+		i = arg
+	}
+	return b.String()
+}
+
+// isIdentByte reports whether c can be part of an SQL identifier
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// literalEnd returns the index just past the string literal starting at the
+// quote at start. A literal ends at the next single quote that is not
+// doubled (a doubled quote is an escaped one); an unterminated literal runs
+// to the end of the clause.
+func literalEnd(whereClause string, start int) int {
+	end := start + 1
+	for {
+		next := strings.IndexByte(whereClause[end:], '\'')
+		if next < 0 {
+			return len(whereClause)
+		}
+		end += next + 1
+		if end < len(whereClause) && whereClause[end] == '\'' {
+			end++
+			continue
+		}
+		return end
+	}
+}
+
+// outsideQuotes applies rewrite to the parts of a WHERE clause that are not
+// inside string literals, so quoted values are never changed
+func outsideQuotes(whereClause string, rewrite func(string) string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(whereClause, '\'')
+		if start < 0 {
+			b.WriteString(rewrite(whereClause))
+			return b.String()
+		}
+		b.WriteString(rewrite(whereClause[:start]))
+
+		end := literalEnd(whereClause, start)
+		b.WriteString(whereClause[start:end])
+		whereClause = whereClause[end:]
+	}
+}
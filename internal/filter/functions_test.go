@@ -0,0 +1,88 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestExpandShorthands(t *testing.T) {
+	tests := []struct {
+		name     string
+		where    string
+		expected string
+	}{
+		{"Shorthand form", "ip_in('10.0.0.0/8')", "ip_in(ip, '10.0.0.0/8')"},
+		{"Shorthand with spaces", "ip_in( '10.0.0.0/8')", "ip_in(ip, '10.0.0.0/8')"},
+		{"Explicit column untouched", "ip_in(fixed_ip, '10.0.0.0/8')", "ip_in(fixed_ip, '10.0.0.0/8')"},
+		{"Literal address untouched", "ip_in('10.0.0.1', '10.0.0.0/8')", "ip_in('10.0.0.1', '10.0.0.0/8')"},
+		{"Quoted call untouched", "note = 'ip_in(''10.0.0.0/8'')'", "note = 'ip_in(''10.0.0.0/8'')'"},
+		{"Shorthand next to quoted call", "ip_in('10.0.0.0/8') OR note = 'ip_in('''", "ip_in(ip, '10.0.0.0/8') OR note = 'ip_in('''"},
+		{"Other function untouched", "my_ip_in('10.0.0.0/8')", "my_ip_in('10.0.0.0/8')"},
+		{"Unrelated clause untouched", "signal >= -65", "signal >= -65"},
+		{"Tag column", "tag_location = 'x'", `json_extract(data, '$.tags."location"') = 'x'`},
+		{"Tag name in literal untouched", "name = 'tag_location' OR name = 'it''s tag_x'", "name = 'tag_location' OR name = 'it''s tag_x'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandShorthands(tt.where); got != tt.expected {
+				t.Errorf("expandShorthands(%q) = %q, expected %q", tt.where, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApply_IPIn(t *testing.T) {
+	clients := []api.Client{
+		{MAC: "aa:bb:cc:dd:ee:01", IP: "192.168.30.10"},
+		{MAC: "aa:bb:cc:dd:ee:02", IP: "192.168.30.250"},
+		{MAC: "aa:bb:cc:dd:ee:03", IP: "192.168.3.10"},
+		{MAC: "aa:bb:cc:dd:ee:04", IP: "10.0.0.5"},
+		{MAC: "aa:bb:cc:dd:ee:05", IP: ""},
+	}
+
+	tests := []struct {
+		name     string
+		where    string
+		expected int
+	}{
+		{"Single subnet shorthand", "ip_in('192.168.30.0/24')", 2},
+		{"Explicit column", "ip_in(ip, '192.168.30.0/24')", 2},
+		{"Multiple subnets", "ip_in(ip, '192.168.30.0/24', '10.0.0.0/8')", 3},
+		{"Literal address", "ip_in('192.168.30.10', '192.168.30.0/24')", 5},
+		{"Negated", "NOT ip_in('192.168.30.0/24')", 3},
+		{"Host prefix", "ip_in('192.168.3.10/32')", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(tt.where)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			defer f.Close()
+
+			result, err := f.Apply(clients)
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+
+			if len(result) != tt.expected {
+				t.Errorf("Expected %d clients, got %d", tt.expected, len(result))
+			}
+		})
+	}
+}
+
+func TestApply_IPIn_InvalidCIDR(t *testing.T) {
+	f, err := NewFilter("ip_in('192.168.30.0/33')")
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Apply([]api.Client{{MAC: "aa:bb:cc:dd:ee:01", IP: "192.168.30.1"}}); err == nil {
+		t.Error("Expected error for invalid CIDR")
+	}
+}
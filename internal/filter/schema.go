@@ -11,7 +11,10 @@ CREATE VIEW clients_view AS
     json_extract(data, '$.mac') as mac,
     json_extract(data, '$.name') as name,
     json_extract(data, '$.hostname') as hostname,
+    json_extract(data, '$.oui') as oui,
     json_extract(data, '$.ip') as ip,
+    coalesce(json_extract(data, '$.network_name'), json_extract(data, '$.network')) as network_name,
+    json_extract(data, '$.usergroup_name') as usergroup,
     json_extract(data, '$.is_wired') as is_wired,
     json_extract(data, '$.blocked') as blocked,
     json_extract(data, '$.essid') as essid,
@@ -29,3 +32,129 @@ CREATE VIEW clients_view AS
     json_extract(data, '$.rx_bytes') as rx_bytes
   FROM clients;
 `
+
+// deviceTableSchema is the devices counterpart of clientTableSchema. The
+// state column holds the state name (e.g. 'connected') rather than the number.
+// cpu, mem and the uplink columns are only set for hydrated devices.
+const deviceTableSchema = `
+CREATE TABLE devices (data TEXT);
+
+CREATE VIEW devices_view AS
+  SELECT
+    data,
+    json_extract(data, '$.mac') as mac,
+    json_extract(data, '$.name') as name,
+    json_extract(data, '$.model') as model,
+    json_extract(data, '$.type') as type,
+    json_extract(data, '$.serial') as serial,
+    json_extract(data, '$.note') as note,
+    json_extract(data, '$.ip') as ip,
+    json_extract(data, '$.version') as version,
+    json_extract(data, '$.upgradable') as upgradable,
+    json_extract(data, '$.adopted') as adopted,
+    CASE json_extract(data, '$.state')
+      WHEN 0 THEN 'disconnected'
+      WHEN 1 THEN 'connected'
+      WHEN 2 THEN 'pending'
+      WHEN 4 THEN 'upgrading'
+      WHEN 5 THEN 'provisioning'
+      WHEN 6 THEN 'heartbeat-missed'
+      WHEN 7 THEN 'adopting'
+      WHEN 9 THEN 'adoption-failed'
+      WHEN 10 THEN 'isolated'
+      WHEN 11 THEN 'isolated'
+      ELSE 'unknown'
+    END as state,
+    json_extract(data, '$.uptime') as uptime,
+    json_extract(data, '$.num_sta') as num_sta,
+    json_extract(data, '$.satisfaction') as satisfaction,
+    json_extract(data, '$.tx_bytes') as tx_bytes,
+    json_extract(data, '$.rx_bytes') as rx_bytes,
+    CAST(json_extract(data, '$."system-stats".cpu') AS REAL) as cpu,
+    CAST(json_extract(data, '$."system-stats".mem') AS REAL) as mem,
+    json_extract(data, '$.uplink.type') as uplink_type,
+    json_extract(data, '$.uplink.speed') as uplink_speed
+  FROM devices;
+`
+
+// portTableSchema is the switch ports counterpart of clientTableSchema. The
+// errors column sums receive and transmit errors.
+const portTableSchema = `
+CREATE TABLE ports (data TEXT);
+
+CREATE VIEW ports_view AS
+  SELECT
+    data,
+    json_extract(data, '$.port_idx') as idx,
+    json_extract(data, '$.name') as name,
+    json_extract(data, '$.media') as media,
+    json_extract(data, '$.up') as up,
+    json_extract(data, '$.enable') as enabled,
+    json_extract(data, '$.speed') as speed,
+    json_extract(data, '$.full_duplex') as full_duplex,
+    json_extract(data, '$.is_uplink') as is_uplink,
+    json_extract(data, '$.port_poe') as poe,
+    json_extract(data, '$.poe_mode') as poe_mode,
+    json_extract(data, '$.poe_good') as poe_good,
+    json_extract(data, '$.poe_watts') as poe_watts,
+    json_extract(data, '$.profile') as profile,
+    json_extract(data, '$.network') as network,
+    json_extract(data, '$.vlan') as vlan,
+    json_extract(data, '$.clients') as clients,
+    json_extract(data, '$.rx_bytes') as rx_bytes,
+    json_extract(data, '$.tx_bytes') as tx_bytes,
+    json_extract(data, '$.rx_errors') as rx_errors,
+    json_extract(data, '$.tx_errors') as tx_errors,
+    json_extract(data, '$.rx_errors') + json_extract(data, '$.tx_errors') as errors,
+    json_extract(data, '$.rx_dropped') as rx_dropped,
+    json_extract(data, '$.tx_dropped') as tx_dropped
+  FROM ports;
+`
+
+// siteTableSchema is the sites counterpart of clientTableSchema, over site
+// summaries with their health counts added up. The site description is
+// called description, as desc is an SQL keyword.
+const siteTableSchema = `
+CREATE TABLE sites (data TEXT);
+
+CREATE VIEW sites_view AS
+  SELECT
+    data,
+    json_extract(data, '$.name') as name,
+    json_extract(data, '$.desc') as description,
+    json_extract(data, '$.role') as role,
+    json_extract(data, '$.status') as status,
+    json_extract(data, '$.clients') as clients,
+    json_extract(data, '$.guests') as guests,
+    json_extract(data, '$.aps') as aps,
+    json_extract(data, '$.switches') as switches,
+    json_extract(data, '$.gateways') as gateways,
+    json_extract(data, '$.devices') as devices,
+    json_extract(data, '$.disconnected') as disconnected,
+    json_extract(data, '$.pending') as pending
+  FROM sites;
+`
+
+// networkTableSchema is the networks counterpart of clientTableSchema. vlan is
+// 0 for untagged networks; subnet, dhcp_start/dhcp_stop and domain are empty
+// where not configured.
+const networkTableSchema = `
+CREATE TABLE networks (data TEXT);
+
+CREATE VIEW networks_view AS
+  SELECT
+    data,
+    json_extract(data, '$.name') as name,
+    json_extract(data, '$.purpose') as purpose,
+    json_extract(data, '$.enabled') as enabled,
+    CASE WHEN json_extract(data, '$.vlan_enabled') THEN coalesce(json_extract(data, '$.vlan'), 0) ELSE 0 END as vlan,
+    coalesce(json_extract(data, '$.ip_subnet'), '') as subnet,
+    json_extract(data, '$.dhcpd_enabled') as dhcp_enabled,
+    coalesce(json_extract(data, '$.dhcpd_start'), '') as dhcp_start,
+    coalesce(json_extract(data, '$.dhcpd_stop'), '') as dhcp_stop,
+    coalesce(json_extract(data, '$.domain_name'), '') as domain,
+    json_extract(data, '$.igmp_snooping') as igmp_snooping,
+    json_extract(data, '$.mdns_enabled') as mdns,
+    coalesce(json_extract(data, '$.ipv6_interface_type'), '') as ipv6
+  FROM networks;
+`
@@ -0,0 +1,139 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// ColumnStats summarises the numeric values of one column
+type ColumnStats struct {
+	Column      string       `json:"column"`
+	Count       int          `json:"count"`
+	Min         float64      `json:"min"`
+	Max         float64      `json:"max"`
+	Avg         float64      `json:"avg"`
+	Percentiles []Percentile `json:"percentiles"`
+	Histogram   []Bucket     `json:"histogram"`
+}
+
+// Percentile is the nearest-rank value below which P percent of values fall
+type Percentile struct {
+	P     int     `json:"p"`
+	Value float64 `json:"value"`
+}
+
+// Bucket counts values in [Low, High); the last bucket includes High
+type Bucket struct {
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	Count int     `json:"count"`
+}
+
+var statsPercentiles = []int{50, 90, 95, 99}
+
+// Stats computes statistics for a numeric column over the clients matching
+// the filter. Non-numeric and NULL values are ignored.
+func (f *Filter) Stats(clients []api.Client, column string, buckets int) (*ColumnStats, error) {
+	if buckets < 1 {
+		return nil, fmt.Errorf("bucket count must be at least 1")
+	}
+
+	columns, err := f.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !contains(columns, column) {
+		return nil, fmt.Errorf("unknown column %q", column)
+	}
+
+	if err := f.insertClients(clients); err != nil {
+		return nil, err
+	}
+
+	// The column name is validated above, so it is safe to interpolate
+	numeric := fmt.Sprintf("SELECT %s AS v FROM clients_view WHERE (%s) AND typeof(%s) IN ('integer', 'real')",
+		column, f.whereClause, column)
+
+	stats := &ColumnStats{Column: column}
+	var min, max, avg *float64
+	row := f.db.QueryRow(fmt.Sprintf("SELECT COUNT(v), MIN(v), MAX(v), AVG(v) FROM (%s)", numeric))
+	if err := row.Scan(&stats.Count, &min, &max, &avg); err != nil {
+		return nil, fmt.Errorf("failed to compute statistics: %w", err)
+	}
+
+	if stats.Count == 0 {
+		return nil, fmt.Errorf("column %q has no numeric values in the selected clients", column)
+	}
+	stats.Min, stats.Max, stats.Avg = *min, *max, *avg
+
+	for _, p := range statsPercentiles {
+		// Nearest-rank method: the ceil(p/100 * n)-th smallest value
+		rank := int(math.Ceil(float64(p) / 100 * float64(stats.Count)))
+		var value float64
+		query := fmt.Sprintf("SELECT v FROM (%s) ORDER BY v LIMIT 1 OFFSET ?", numeric)
+		if err := f.db.QueryRow(query, rank-1).Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to compute p%d: %w", p, err)
+		}
+		stats.Percentiles = append(stats.Percentiles, Percentile{P: p, Value: value})
+	}
+
+	stats.Histogram, err = f.histogram(numeric, stats.Min, stats.Max, buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// histogram spreads the values of the numeric subquery over equal-width buckets
+func (f *Filter) histogram(numeric string, min, max float64, buckets int) ([]Bucket, error) {
+	if min == max {
+		buckets = 1
+	}
+	width := (max - min) / float64(buckets)
+
+	result := make([]Bucket, buckets)
+	for i := range result {
+		result[i].Low = min + float64(i)*width
+		result[i].High = min + float64(i+1)*width
+	}
+	result[buckets-1].High = max
+
+	index := "0"
+	if width > 0 {
+		index = fmt.Sprintf("MIN(CAST((v - %v) / %v AS INTEGER), %d)", min, width, buckets-1)
+	}
+
+	rows, err := f.db.Query(fmt.Sprintf("SELECT %s AS b, COUNT(*) FROM (%s) GROUP BY b", index, numeric))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute histogram: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket, count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan histogram row: %w", err)
+		}
+		result[bucket].Count = count
+	}
+
+	return result, rows.Err()
+}
+
+// Columns returns the names of the client columns available to WHERE
+// clauses
+func (f *Filter) Columns() ([]string, error) {
+	return f.ColumnsOf("clients")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
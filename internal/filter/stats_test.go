@@ -0,0 +1,108 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestStats_Signal(t *testing.T) {
+	f, err := NewFilter("is_wired = 0")
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	defer f.Close()
+
+	stats, err := f.Stats(createTestClients(), "signal", 5)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+
+	// Wireless clients have signals -45, -70 and -55
+	if stats.Count != 3 {
+		t.Errorf("Expected count 3, got %d", stats.Count)
+	}
+	if stats.Min != -70 || stats.Max != -45 {
+		t.Errorf("Expected min -70 and max -45, got %v and %v", stats.Min, stats.Max)
+	}
+	if stats.Avg != -170.0/3 {
+		t.Errorf("Expected avg %v, got %v", -170.0/3, stats.Avg)
+	}
+
+	if stats.Percentiles[0].P != 50 || stats.Percentiles[0].Value != -55 {
+		t.Errorf("Expected p50 -55, got %+v", stats.Percentiles[0])
+	}
+	if p99 := stats.Percentiles[len(stats.Percentiles)-1]; p99.Value != -45 {
+		t.Errorf("Expected p99 -45, got %+v", p99)
+	}
+
+	if len(stats.Histogram) != 5 {
+		t.Fatalf("Expected 5 buckets, got %d", len(stats.Histogram))
+	}
+	total := 0
+	for _, b := range stats.Histogram {
+		total += b.Count
+	}
+	if total != 3 {
+		t.Errorf("Expected histogram to hold 3 values, got %d", total)
+	}
+	if stats.Histogram[4].Count != 1 || stats.Histogram[4].High != -45 {
+		t.Errorf("Expected the maximum in the last bucket, got %+v", stats.Histogram[4])
+	}
+}
+
+func TestStats_SingleValue(t *testing.T) {
+	f, _ := NewFilter("1 = 1")
+	defer f.Close()
+
+	clients := []api.Client{{MAC: "01", Uptime: 60}, {MAC: "02", Uptime: 60}}
+	stats, err := f.Stats(clients, "uptime", 10)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+
+	if len(stats.Histogram) != 1 || stats.Histogram[0].Count != 2 {
+		t.Errorf("Expected a single bucket holding both values, got %+v", stats.Histogram)
+	}
+}
+
+func TestStats_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+	}{
+		{"Unknown column", "does_not_exist"},
+		{"Injection attempt", "signal) FROM clients_view; --"},
+		{"Non-numeric column", "hostname"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, _ := NewFilter("1 = 1")
+			defer f.Close()
+
+			if _, err := f.Stats(createTestClients(), tt.column, 10); err == nil {
+				t.Errorf("Expected error for column %q", tt.column)
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	f, _ := NewFilter("1 = 1")
+	defer f.Close()
+
+	columns, err := f.Columns()
+	if err != nil {
+		t.Fatalf("Columns failed: %v", err)
+	}
+
+	for _, want := range []string{"mac", "signal", "rx_bytes"} {
+		if !contains(columns, want) {
+			t.Errorf("Expected columns to include %q, got %v", want, columns)
+		}
+	}
+	if contains(columns, "data") {
+		t.Error("Expected the raw data column to be excluded")
+	}
+}
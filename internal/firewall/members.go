@@ -0,0 +1,215 @@
+// Package firewall keeps firewall group members in sync with external lists
+// and validates port forwards.
+package firewall
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// Sync is the change that brings a group's members in line with a list
+type Sync struct {
+	Add    []string
+	Remove []string
+	// Members is the resulting member list, current members first
+	Members []string
+}
+
+// Changed reports whether the sync adds or removes anything
+func (s Sync) Changed() bool {
+	return len(s.Add) > 0 || len(s.Remove) > 0
+}
+
+// LoadMembers reads group members from the first column of a CSV file. Empty
+// lines and lines starting with # are skipped, as is a header row.
+func LoadMembers(path, groupType string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return ParseMembers(f, groupType)
+}
+
+// ParseMembers reads group members from the first column of CSV data,
+// normalized and without duplicates
+func ParseMembers(r io.Reader, groupType string) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var members []string
+	seen := map[string]bool{}
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+
+		value := strings.TrimSpace(record[0])
+		if value == "" {
+			continue
+		}
+
+		member, err := Normalize(value, groupType)
+		if err != nil {
+			if row == 0 {
+				continue // header
+			}
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if !seen[member] {
+			seen[member] = true
+			members = append(members, member)
+		}
+	}
+
+	return members, nil
+}
+
+// Normalize validates a member of a group of the given type and returns it in
+// canonical form: addresses and networks as the controller shows them (host
+// prefixes as plain addresses), ports and port ranges without spaces
+func Normalize(value, groupType string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch groupType {
+	case api.FirewallGroupAddress, api.FirewallGroupIPv6Address:
+		ipv6 := groupType == api.FirewallGroupIPv6Address
+
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil || prefix.Addr().Is6() != ipv6 {
+				return "", fmt.Errorf("invalid %s member %q", groupType, value)
+			}
+			if prefix.IsSingleIP() {
+				return prefix.Addr().String(), nil
+			}
+			return prefix.Masked().String(), nil
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil || addr.Is6() != ipv6 {
+			return "", fmt.Errorf("invalid %s member %q", groupType, value)
+		}
+		return addr.String(), nil
+
+	case api.FirewallGroupPort:
+		from, to, isRange := strings.Cut(value, "-")
+		low, err := parsePort(from)
+		if err != nil {
+			return "", fmt.Errorf("invalid port %q", value)
+		}
+		if !isRange {
+			return strconv.Itoa(low), nil
+		}
+
+		high, err := parsePort(to)
+		if err != nil || high < low {
+			return "", fmt.Errorf("invalid port range %q", value)
+		}
+		return fmt.Sprintf("%d-%d", low, high), nil
+	}
+
+	return "", fmt.Errorf("unsupported group type %q", groupType)
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", value)
+	}
+	return port, nil
+}
+
+// Plan compares a group's current members with the desired list. Missing
+// members are added; with replace, members that are not listed are removed.
+// Current members are compared in normalized form and otherwise kept as they
+// are.
+func Plan(current, desired []string, groupType string, replace bool) Sync {
+	var sync Sync
+
+	wanted := make(map[string]bool, len(desired))
+	for _, member := range desired {
+		wanted[member] = true
+	}
+
+	present := make(map[string]bool, len(current))
+	for _, member := range current {
+		key := member
+		if normalized, err := Normalize(member, groupType); err == nil {
+			key = normalized
+		}
+
+		if replace && !wanted[key] {
+			sync.Remove = append(sync.Remove, member)
+			continue
+		}
+		present[key] = true
+		sync.Members = append(sync.Members, member)
+	}
+
+	for _, member := range desired {
+		if !present[member] {
+			sync.Add = append(sync.Add, member)
+			sync.Members = append(sync.Members, member)
+		}
+	}
+
+	return sync
+}
+
+// PlanRemove removes the given (normalized) members from a group's current
+// members. Current members are compared in normalized form.
+func PlanRemove(current, remove []string, groupType string) Sync {
+	var sync Sync
+
+	unwanted := make(map[string]bool, len(remove))
+	for _, member := range remove {
+		unwanted[member] = true
+	}
+
+	for _, member := range current {
+		key := member
+		if normalized, err := Normalize(member, groupType); err == nil {
+			key = normalized
+		}
+
+		if unwanted[key] {
+			sync.Remove = append(sync.Remove, member)
+			continue
+		}
+		sync.Members = append(sync.Members, member)
+	}
+
+	return sync
+}
+
+// ParseGroupType accepts a group type by its controller name or as address,
+// ipv6-address or port
+func ParseGroupType(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "address", "ipv4", api.FirewallGroupAddress:
+		return api.FirewallGroupAddress, nil
+	case "ipv6-address", "ipv6", api.FirewallGroupIPv6Address:
+		return api.FirewallGroupIPv6Address, nil
+	case "port", api.FirewallGroupPort:
+		return api.FirewallGroupPort, nil
+	}
+	return "", fmt.Errorf("invalid group type %q (valid options: address, ipv6-address, port)", value)
+}
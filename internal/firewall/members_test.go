@@ -0,0 +1,148 @@
+package firewall
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestParseMembers(t *testing.T) {
+	data := `ip,comment
+# feed of 2026-10-16
+192.0.2.1,scanner
+198.51.100.7/24,botnet
+
+203.0.113.5/32
+192.0.2.1,duplicate
+`
+	members, err := ParseMembers(strings.NewReader(data), api.FirewallGroupAddress)
+	if err != nil {
+		t.Fatalf("ParseMembers() returned error: %v", err)
+	}
+
+	expected := []string{"192.0.2.1", "198.51.100.0/24", "203.0.113.5"}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("ParseMembers() = %v, want %v", members, expected)
+	}
+}
+
+func TestParseMembers_InvalidRow(t *testing.T) {
+	data := "192.0.2.1\nnot-an-ip\n"
+	_, err := ParseMembers(strings.NewReader(data), api.FirewallGroupAddress)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error for line 2, got %v", err)
+	}
+}
+
+func TestLoadMembers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ports.csv")
+	os.WriteFile(path, []byte("port\n443\n8000 - 8080\n"), 0o600)
+
+	members, err := LoadMembers(path, api.FirewallGroupPort)
+	if err != nil {
+		t.Fatalf("LoadMembers() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(members, []string{"443", "8000-8080"}) {
+		t.Errorf("Unexpected members %v", members)
+	}
+
+	if _, err := LoadMembers(filepath.Join(t.TempDir(), "missing.csv"), api.FirewallGroupPort); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		value     string
+		groupType string
+		expected  string
+		wantErr   bool
+	}{
+		{"192.0.2.1", api.FirewallGroupAddress, "192.0.2.1", false},
+		{"192.0.2.9/24", api.FirewallGroupAddress, "192.0.2.0/24", false},
+		{"192.0.2.9/32", api.FirewallGroupAddress, "192.0.2.9", false},
+		{"2001:db8::1", api.FirewallGroupAddress, "", true},
+		{"2001:DB8::1", api.FirewallGroupIPv6Address, "2001:db8::1", false},
+		{"2001:db8::1/48", api.FirewallGroupIPv6Address, "2001:db8::/48", false},
+		{"192.0.2.1", api.FirewallGroupIPv6Address, "", true},
+		{"22", api.FirewallGroupPort, "22", false},
+		{"8080-8000", api.FirewallGroupPort, "", true},
+		{"70000", api.FirewallGroupPort, "", true},
+		{"22", "unknown", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupType+" "+tt.value, func(t *testing.T) {
+			result, err := Normalize(tt.value, tt.groupType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("Normalize() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	current := []string{"192.0.2.1", "198.51.100.7/24", "203.0.113.5"}
+	desired := []string{"198.51.100.0/24", "192.0.2.1", "192.0.2.2"}
+
+	sync := Plan(current, desired, api.FirewallGroupAddress, false)
+	if !reflect.DeepEqual(sync.Add, []string{"192.0.2.2"}) || len(sync.Remove) != 0 {
+		t.Errorf("Unexpected sync without replace: %+v", sync)
+	}
+	if !reflect.DeepEqual(sync.Members, []string{"192.0.2.1", "198.51.100.7/24", "203.0.113.5", "192.0.2.2"}) {
+		t.Errorf("Unexpected members %v", sync.Members)
+	}
+
+	sync = Plan(current, desired, api.FirewallGroupAddress, true)
+	if !reflect.DeepEqual(sync.Add, []string{"192.0.2.2"}) || !reflect.DeepEqual(sync.Remove, []string{"203.0.113.5"}) {
+		t.Errorf("Unexpected sync with replace: %+v", sync)
+	}
+	if !reflect.DeepEqual(sync.Members, []string{"192.0.2.1", "198.51.100.7/24", "192.0.2.2"}) {
+		t.Errorf("Unexpected members %v", sync.Members)
+	}
+
+	if Plan(current, []string{"192.0.2.1"}, api.FirewallGroupAddress, false).Changed() {
+		t.Error("Expected no change for listed members")
+	}
+}
+
+func TestPlanRemove(t *testing.T) {
+	current := []string{"192.0.2.1", "198.51.100.7/24", "203.0.113.5"}
+
+	sync := PlanRemove(current, []string{"198.51.100.0/24", "192.0.2.9"}, api.FirewallGroupAddress)
+	if !reflect.DeepEqual(sync.Remove, []string{"198.51.100.7/24"}) || len(sync.Add) != 0 {
+		t.Errorf("Unexpected sync: %+v", sync)
+	}
+	if !reflect.DeepEqual(sync.Members, []string{"192.0.2.1", "203.0.113.5"}) {
+		t.Errorf("Unexpected members %v", sync.Members)
+	}
+
+	if PlanRemove(current, []string{"192.0.2.9"}, api.FirewallGroupAddress).Changed() {
+		t.Error("Expected no change for members that are not in the group")
+	}
+}
+
+func TestParseGroupType(t *testing.T) {
+	tests := map[string]string{
+		"address":            api.FirewallGroupAddress,
+		"IPv6":               api.FirewallGroupIPv6Address,
+		"port":               api.FirewallGroupPort,
+		"ipv6-address-group": api.FirewallGroupIPv6Address,
+	}
+	for value, want := range tests {
+		if got, err := ParseGroupType(value); err != nil || got != want {
+			t.Errorf("ParseGroupType(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := ParseGroupType("mac"); err == nil {
+		t.Error("Expected error for unknown group type")
+	}
+}
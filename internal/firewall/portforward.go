@@ -0,0 +1,115 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// portRange is an inclusive range of ports
+type portRange struct {
+	low, high int
+}
+
+// parsePorts parses a port forward port: a port, a range ("8000-8100") or a
+// comma separated list of both
+func parsePorts(value string) ([]portRange, error) {
+	var ranges []portRange
+	for _, part := range strings.Split(value, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		low, err := parsePort(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", value)
+		}
+
+		high := low
+		if isRange {
+			if high, err = parsePort(to); err != nil || high < low {
+				return nil, fmt.Errorf("invalid port range %q", value)
+			}
+		}
+		ranges = append(ranges, portRange{low, high})
+	}
+	return ranges, nil
+}
+
+// NormalizePorts validates a port forward port (a port, a range or a comma
+// separated list of both) and returns it without spaces
+func NormalizePorts(value string) (string, error) {
+	ranges, err := parsePorts(value)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = fmt.Sprint(r.low)
+		if r.high != r.low {
+			parts[i] = fmt.Sprintf("%d-%d", r.low, r.high)
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// ParseProto accepts a port forward protocol as tcp, udp or tcp_udp (also
+// written "both" or "tcp/udp")
+func ParseProto(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case api.ProtoTCP:
+		return api.ProtoTCP, nil
+	case api.ProtoUDP:
+		return api.ProtoUDP, nil
+	case api.ProtoTCPUDP, "both", "tcp/udp":
+		return api.ProtoTCPUDP, nil
+	}
+	return "", fmt.Errorf("invalid protocol %q (valid options: tcp, udp, tcp_udp)", value)
+}
+
+// Conflicts returns the enabled forwards that would receive some of the same
+// traffic as forward: they listen on the same WAN for an overlapping
+// protocol, source and destination port
+func Conflicts(forwards []api.PortForward, forward api.PortForward) []api.PortForward {
+	ports, err := parsePorts(forward.DstPort)
+	if err != nil {
+		return nil
+	}
+
+	var conflicts []api.PortForward
+	for _, other := range forwards {
+		if !other.Enabled || (forward.ID != "" && other.ID == forward.ID) {
+			continue
+		}
+		if !overlapsValue(other.Interface, forward.Interface, "both") ||
+			!overlapsValue(other.Proto, forward.Proto, api.ProtoTCPUDP) ||
+			!overlapsValue(other.Src, forward.Src, "any") {
+			continue
+		}
+
+		otherPorts, err := parsePorts(other.DstPort)
+		if err != nil {
+			continue
+		}
+		if overlapsPorts(ports, otherPorts) {
+			conflicts = append(conflicts, other)
+		}
+	}
+	return conflicts
+}
+
+// overlapsValue reports whether two settings match the same traffic: they
+// are equal, or either is empty or the wildcard
+func overlapsValue(a, b, wildcard string) bool {
+	return a == "" || b == "" || a == wildcard || b == wildcard || strings.EqualFold(a, b)
+}
+
+func overlapsPorts(a, b []portRange) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.low <= y.high && y.low <= x.high {
+				return true
+			}
+		}
+	}
+	return false
+}
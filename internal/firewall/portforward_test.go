@@ -0,0 +1,77 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestNormalizePorts(t *testing.T) {
+	valid := map[string]string{
+		"443":           "443",
+		" 8000 - 8100 ": "8000-8100",
+		"80, 443,81-82": "80,443,81-82",
+		"25565-25565":   "25565",
+	}
+	for input, expected := range valid {
+		if got, err := NormalizePorts(input); err != nil || got != expected {
+			t.Errorf("NormalizePorts(%q) = %q, %v; expected %q", input, got, err, expected)
+		}
+	}
+
+	for _, input := range []string{"", "0", "65536", "http", "100-90", "80,"} {
+		if _, err := NormalizePorts(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestParseProto(t *testing.T) {
+	for input, expected := range map[string]string{"tcp": api.ProtoTCP, "UDP": api.ProtoUDP, "both": api.ProtoTCPUDP, "tcp_udp": api.ProtoTCPUDP} {
+		if got, err := ParseProto(input); err != nil || got != expected {
+			t.Errorf("ParseProto(%q) = %q, %v; expected %q", input, got, err, expected)
+		}
+	}
+	if _, err := ParseProto("icmp"); err == nil {
+		t.Error("Expected error for icmp")
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	forwards := []api.PortForward{
+		{ID: "pf1", Name: "Web", Enabled: true, Interface: "wan", Src: "any", DstPort: "443", Proto: api.ProtoTCP},
+		{ID: "pf2", Name: "Games", Enabled: true, Interface: "both", DstPort: "27000-27050", Proto: api.ProtoUDP},
+		{ID: "pf3", Name: "Old", Enabled: false, Interface: "wan", DstPort: "8080", Proto: api.ProtoTCP},
+		{ID: "pf4", Name: "Office", Enabled: true, Interface: "wan", Src: "198.51.100.7", DstPort: "3389", Proto: api.ProtoTCP},
+	}
+
+	tests := []struct {
+		name     string
+		forward  api.PortForward
+		expected []string
+	}{
+		{"same port and protocol", api.PortForward{Interface: "wan", DstPort: "443", Proto: api.ProtoTCP}, []string{"Web"}},
+		{"other protocol", api.PortForward{Interface: "wan", DstPort: "443", Proto: api.ProtoUDP}, nil},
+		{"both protocols", api.PortForward{Interface: "wan", DstPort: "80,440-450", Proto: api.ProtoTCPUDP}, []string{"Web"}},
+		{"other WAN", api.PortForward{Interface: "wan2", DstPort: "443", Proto: api.ProtoTCP}, nil},
+		{"overlapping range on both WANs", api.PortForward{Interface: "wan2", DstPort: "27050-27060", Proto: api.ProtoUDP}, []string{"Games"}},
+		{"disabled forward", api.PortForward{Interface: "wan", DstPort: "8080", Proto: api.ProtoTCP}, nil},
+		{"other source", api.PortForward{Interface: "wan", Src: "203.0.113.9", DstPort: "3389", Proto: api.ProtoTCP}, nil},
+		{"any source", api.PortForward{Interface: "wan", Src: "any", DstPort: "3389", Proto: api.ProtoTCP}, []string{"Office"}},
+		{"itself", api.PortForward{ID: "pf1", Interface: "wan", DstPort: "443", Proto: api.ProtoTCP}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := Conflicts(forwards, tt.forward)
+			if len(conflicts) != len(tt.expected) {
+				t.Fatalf("Expected conflicts %v, got %+v", tt.expected, conflicts)
+			}
+			for i, name := range tt.expected {
+				if conflicts[i].Name != name {
+					t.Errorf("Expected conflict %s, got %s", name, conflicts[i].Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Load reads the records of a recorded response into v, a pointer to a
+// slice. Plain JSON arrays, e.g. the output of --format json, are read as
+// well.
+func Load(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var response struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(trimmed, &response); err != nil {
+			return fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		if response.Data == nil {
+			return fmt.Errorf("fixture %s has no data", path)
+		}
+		data = response.Data
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package fixtures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	type record struct {
+		MAC string `json:"mac"`
+	}
+
+	files := map[string]string{
+		"response.json": `{"meta":{"rc":"ok"},"data":[{"mac":"aa:bb:cc:00:00:01"},{"mac":"aa:bb:cc:00:00:02"}]}`,
+		"array.json":    "\n[{\"mac\":\"aa:bb:cc:00:00:01\"},{\"mac\":\"aa:bb:cc:00:00:02\"}]\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		var records []record
+		if err := Load(path, &records); err != nil {
+			t.Fatalf("Load(%s) returned error: %v", name, err)
+		}
+		if len(records) != 2 || records[1].MAC != "aa:bb:cc:00:00:02" {
+			t.Errorf("Unexpected records from %s: %+v", name, records)
+		}
+	}
+
+	noData := filepath.Join(dir, "status.json")
+	os.WriteFile(noData, []byte(`{"meta":{"rc":"ok"}}`), 0644)
+	var records []record
+	if err := Load(noData, &records); err == nil {
+		t.Error("Expected error for a response without data")
+	}
+	if err := Load(filepath.Join(dir, "missing.json"), &records); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
@@ -0,0 +1,52 @@
+package fixtures
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Recorder writes sanitized API responses to a directory, one file per
+// request, so they can be attached to bug reports or used as test fixtures
+type Recorder struct {
+	Dir string
+}
+
+// NewRecorder creates the fixture directory if needed
+func NewRecorder(dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return &Recorder{Dir: dir}, nil
+}
+
+// Record sanitizes a response body and writes it to the file named after the
+// request. A later response to the same request replaces the earlier one.
+func (r *Recorder) Record(method, path string, body []byte) error {
+	data, err := Sanitize(body)
+	if err != nil {
+		return err
+	}
+
+	file := filepath.Join(r.Dir, FileName(method, path))
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// FileName maps a request to its fixture file, e.g. GET
+// /proxy/network/api/s/default/stat/sta to GET_api_s_default_stat_sta.json.
+// MAC addresses in the path are anonymized like those in the body.
+func FileName(method, path string) string {
+	path = strings.TrimPrefix(path, "/proxy/network")
+	path = strings.Trim(path, "/")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = sanitizeString(segment)
+	}
+
+	return fmt.Sprintf("%s_%s.json", strings.ToUpper(method), strings.Join(segments, "_"))
+}
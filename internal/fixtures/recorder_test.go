@@ -0,0 +1,51 @@
+package fixtures
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{"GET", "/proxy/network/api/s/default/stat/sta", "GET_api_s_default_stat_sta.json"},
+		{"post", "/proxy/network/api/s/default/stat/event", "POST_api_s_default_stat_event.json"},
+		{"GET", "/proxy/network/status", "GET_status.json"},
+	}
+
+	for _, tt := range tests {
+		if got := FileName(tt.method, tt.path); got != tt.expected {
+			t.Errorf("FileName(%s, %s) = %s, expected %s", tt.method, tt.path, got, tt.expected)
+		}
+	}
+
+	name := FileName("GET", "/proxy/network/api/s/default/stat/sta/aa:bb:cc:dd:ee:ff")
+	if strings.Contains(name, "dd:ee:ff") {
+		t.Errorf("Expected MAC in path to be anonymized, got %s", name)
+	}
+}
+
+func TestRecorder_Record(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "fixtures")
+	recorder, err := NewRecorder(dir)
+	if err != nil {
+		t.Fatalf("NewRecorder() returned error: %v", err)
+	}
+
+	if err := recorder.Record("GET", "/proxy/network/api/s/default/rest/wlanconf", []byte(`{"data":[{"x_passphrase":"hunter22"}]}`)); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "GET_api_s_default_rest_wlanconf.json"))
+	if err != nil {
+		t.Fatalf("Expected fixture file: %v", err)
+	}
+	if strings.Contains(string(data), "hunter22") {
+		t.Errorf("Expected passphrase to be redacted, got:\n%s", data)
+	}
+}
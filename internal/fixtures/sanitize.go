@@ -0,0 +1,137 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the value of secret fields
+const Redacted = "REDACTED"
+
+var macPattern = regexp.MustCompile(`^(?i)([0-9a-f]{2})([:-])([0-9a-f]{2})[:-]([0-9a-f]{2})[:-][0-9a-f]{2}[:-][0-9a-f]{2}[:-][0-9a-f]{2}$`)
+
+// secretMarkers identify fields whose values must never leave the machine
+var secretMarkers = []string{"password", "passphrase", "secret", "token", "psk"}
+
+// identifyingKeys are fields that name people, places or hardware: client
+// aliases and hostnames, notes, SSIDs and serial numbers
+var identifyingKeys = []string{"name", "hostname", "note", "essid", "ssid", "serial"}
+
+// Sanitize removes sensitive data from a JSON response body:
+//   - secret fields (x_ prefixed fields, passwords, passphrases, tokens) are
+//     replaced with Redacted
+//   - identifying fields (names, hostnames, notes, SSIDs, serials) are
+//     replaced with a stable pseudonym, so records still refer to each other
+//   - MAC addresses (colon or dash separated) keep their vendor prefix but the
+//     device part is replaced with a stable hash
+//   - public IPv4 addresses are mapped into the 203.0.113.0/24 documentation
+//     range and public IPv6 addresses into 2001:db8::/32; private addresses
+//     are kept as they help reproduce filter issues
+func Sanitize(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("response is not JSON: %w", err)
+	}
+
+	data, err := json.MarshalIndent(sanitizeValue(value), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+func sanitizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSecretKey(key) {
+				if s, ok := field.(string); ok && s != "" {
+					v[key] = Redacted
+				}
+				continue
+			}
+			if isIdentifyingKey(key) {
+				if s, ok := field.(string); ok && s != "" {
+					v[key] = pseudonym(s)
+				}
+				continue
+			}
+			v[key] = sanitizeValue(field)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = sanitizeValue(item)
+		}
+		return v
+	case string:
+		return sanitizeString(v)
+	default:
+		return v
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if strings.HasPrefix(key, "x_") {
+		return true
+	}
+	for _, marker := range secretMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdentifyingKey matches the identifying keys themselves and their
+// prefixed variants such as ap_name or last_uplink_name
+func isIdentifyingKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, name := range identifyingKeys {
+		if key == name || strings.HasSuffix(key, "_"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pseudonym returns a stable placeholder for an identifying value
+func pseudonym(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return fmt.Sprintf("anon-%02x%02x%02x", sum[0], sum[1], sum[2])
+}
+
+func sanitizeString(s string) string {
+	if m := macPattern.FindStringSubmatch(s); m != nil {
+		// Hash the colon form so both spellings of a MAC map to the same value
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.ReplaceAll(s, "-", ":"))))
+		sep := m[2]
+		return strings.Join([]string{
+			strings.ToLower(m[1]), strings.ToLower(m[3]), strings.ToLower(m[4]),
+			fmt.Sprintf("%02x", sum[0]), fmt.Sprintf("%02x", sum[1]), fmt.Sprintf("%02x", sum[2]),
+		}, sep)
+	}
+
+	if ip := net.ParseIP(s); ip != nil && isPublic(ip) {
+		sum := sha256.Sum256([]byte(s))
+		if ip.To4() != nil {
+			return fmt.Sprintf("203.0.113.%d", sum[0])
+		}
+		return fmt.Sprintf("2001:db8:%x:%x::%x", uint16(sum[0])<<8|uint16(sum[1]), uint16(sum[2])<<8|uint16(sum[3]), uint16(sum[4])<<8|uint16(sum[5]))
+	}
+
+	return s
+}
+
+func isPublic(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsUnspecified() && !ip.IsMulticast()
+}
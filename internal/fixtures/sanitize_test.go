@@ -0,0 +1,70 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	body := []byte(`{"meta":{"rc":"ok"},"data":[{"mac":"AA:BB:CC:DD:EE:FF","ap_mac":"aa:bb:cc:dd:ee:ff","ip":"192.168.1.10","wan_ip":"8.8.8.8","x_passphrase":"hunter22","radius_secret":"s3cret","name":"TV","hostname":"jane-laptop","note":"Jane's laptop","essid":"HomeNet","serial":"F4E2C6A1B2C3","last_uplink_name":"Office AP","ip6":"2a00:1450:4001:80b::200e","local_ip6":"fe80::1","ula_ip6":"fd00::10","dashed_mac":"AA-BB-CC-DD-EE-FF","uptime":12345678901}]}`)
+
+	data, err := Sanitize(body)
+	if err != nil {
+		t.Fatalf("Sanitize() returned error: %v", err)
+	}
+
+	var result struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Sanitized output is not JSON: %v", err)
+	}
+	record := result.Data[0]
+
+	mac := record["mac"].(string)
+	if !strings.HasPrefix(mac, "aa:bb:cc:") || mac == "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("Expected MAC with vendor prefix kept and device part replaced, got %s", mac)
+	}
+	if record["ap_mac"] != mac {
+		t.Errorf("Expected the same MAC to map to the same value, got %s and %s", mac, record["ap_mac"])
+	}
+	if record["ip"] != "192.168.1.10" {
+		t.Errorf("Expected private IP to be kept, got %v", record["ip"])
+	}
+	if !strings.HasPrefix(record["wan_ip"].(string), "203.0.113.") {
+		t.Errorf("Expected public IP to be mapped to the documentation range, got %v", record["wan_ip"])
+	}
+	for _, key := range []string{"x_passphrase", "radius_secret"} {
+		if record[key] != Redacted {
+			t.Errorf("Expected %s to be redacted, got %v", key, record[key])
+		}
+	}
+	for _, key := range []string{"name", "hostname", "note", "essid", "serial", "last_uplink_name"} {
+		value, _ := record[key].(string)
+		if !strings.HasPrefix(value, "anon-") {
+			t.Errorf("Expected %s to be replaced with a pseudonym, got %v", key, record[key])
+		}
+	}
+	if strings.Contains(string(data), "jane") || strings.Contains(string(data), "HomeNet") {
+		t.Errorf("Expected identifying values to be removed, got:\n%s", data)
+	}
+	if !strings.HasPrefix(record["ip6"].(string), "2001:db8:") {
+		t.Errorf("Expected public IPv6 to be mapped to the documentation range, got %v", record["ip6"])
+	}
+	if record["local_ip6"] != "fe80::1" || record["ula_ip6"] != "fd00::10" {
+		t.Errorf("Expected link-local and private IPv6 to be kept, got %v and %v", record["local_ip6"], record["ula_ip6"])
+	}
+	if record["dashed_mac"] != strings.ReplaceAll(mac, ":", "-") {
+		t.Errorf("Expected dash-separated MAC to map like its colon form, got %v (colon form %s)", record["dashed_mac"], mac)
+	}
+	if !strings.Contains(string(data), "12345678901") {
+		t.Errorf("Expected large numbers to be kept verbatim, got:\n%s", data)
+	}
+}
+
+func TestSanitize_NotJSON(t *testing.T) {
+	if _, err := Sanitize([]byte("<html>")); err == nil {
+		t.Error("Expected error for non-JSON body")
+	}
+}
@@ -0,0 +1,133 @@
+// Package fleet runs a command once per configured controller profile,
+// concurrently, and collects the outcome of each run.
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Match returns the profile names matching any of the glob patterns, such as
+// "*" or "site-*", in the order of names
+func Match(names, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid profile pattern %q: %w", pattern, err)
+		}
+	}
+
+	var matched []string
+	for _, name := range names {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched = append(matched, name)
+				break
+			}
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no profiles match %q", patterns)
+	}
+	return matched, nil
+}
+
+// Environ returns env without UNIFI_ variables, which would override the
+// settings of every profile alike
+func Environ(env []string) []string {
+	var kept []string
+	for _, v := range env {
+		if !strings.HasPrefix(v, "UNIFI_") {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// Result is the outcome of running the command for one profile
+type Result struct {
+	Profile  string        `json:"profile"`
+	ExitCode int           `json:"exit_code"`
+	Duration time.Duration `json:"duration"`
+	Output   string        `json:"output"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// OK reports whether the command succeeded for the profile
+func (r Result) OK() bool {
+	return r.ExitCode == 0 && r.Error == ""
+}
+
+// Run runs the command built by command for every profile, at most parallel
+// at a time (all at once for 0 or less), and returns the results in the
+// order of profiles. Standard output and error are combined.
+func Run(ctx context.Context, profiles []string, parallel int, command func(ctx context.Context, profile string) *exec.Cmd) []Result {
+	if parallel <= 0 || parallel > len(profiles) {
+		parallel = len(profiles)
+	}
+
+	results := make([]Result, len(profiles))
+	slots := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = run(ctx, profile, command)
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+func run(ctx context.Context, profile string, command func(ctx context.Context, profile string) *exec.Cmd) Result {
+	var out bytes.Buffer
+	cmd := command(ctx, profile)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	err := cmd.Run()
+	result := Result{
+		Profile:  profile,
+		Duration: time.Since(start).Round(time.Millisecond),
+		Output:   out.String(),
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+		if result.ExitCode < 0 {
+			// Killed by a signal, e.g. when interrupted
+			result.Error = exitErr.Error()
+		}
+	case err != nil:
+		result.ExitCode = -1
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Failures returns how many runs failed
+func Failures(results []Result) int {
+	failures := 0
+	for _, r := range results {
+		if !r.OK() {
+			failures++
+		}
+	}
+	return failures
+}
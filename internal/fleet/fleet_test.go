@@ -0,0 +1,91 @@
+package fleet
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	names := []string{"home", "site-a", "site-b"}
+
+	tests := []struct {
+		patterns []string
+		want     string
+	}{
+		{[]string{"*"}, "home,site-a,site-b"},
+		{[]string{"site-*"}, "site-a,site-b"},
+		{[]string{"site-b", "home"}, "home,site-b"},
+	}
+	for _, tt := range tests {
+		matched, err := Match(names, tt.patterns)
+		if err != nil {
+			t.Fatalf("Match(%v) returned error: %v", tt.patterns, err)
+		}
+		if got := strings.Join(matched, ","); got != tt.want {
+			t.Errorf("Match(%v) = %s, want %s", tt.patterns, got, tt.want)
+		}
+	}
+
+	if _, err := Match(names, []string{"lab"}); err == nil {
+		t.Error("Expected no matches to fail")
+	}
+	if _, err := Match(names, []string{"["}); err == nil {
+		t.Error("Expected an invalid pattern to fail")
+	}
+}
+
+func TestRun(t *testing.T) {
+	command := func(ctx context.Context, profile string) *exec.Cmd {
+		script := "echo ran $0; echo oops >&2"
+		if profile == "bad" {
+			script += "; exit 3"
+		}
+		return exec.CommandContext(ctx, "sh", "-c", script, profile)
+	}
+
+	results := Run(context.Background(), []string{"good", "bad", "other"}, 2, command)
+
+	if len(results) != 3 || results[0].Profile != "good" || results[1].Profile != "bad" || results[2].Profile != "other" {
+		t.Fatalf("Expected results in profile order, got %+v", results)
+	}
+	if !results[0].OK() || results[0].Output != "ran good\noops\n" {
+		t.Errorf("Unexpected result %+v", results[0])
+	}
+	if results[1].OK() || results[1].ExitCode != 3 {
+		t.Errorf("Expected exit code 3, got %+v", results[1])
+	}
+	if Failures(results) != 1 {
+		t.Errorf("Expected 1 failure, got %d", Failures(results))
+	}
+}
+
+func TestRunConcurrently(t *testing.T) {
+	command := func(ctx context.Context, profile string) *exec.Cmd {
+		return exec.CommandContext(ctx, "sleep", "0.3")
+	}
+
+	start := time.Now()
+	Run(context.Background(), []string{"a", "b", "c", "d"}, 0, command)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the runs to overlap, took %s", elapsed)
+	}
+}
+
+func TestRunMissingCommand(t *testing.T) {
+	results := Run(context.Background(), []string{"a"}, 1, func(ctx context.Context, profile string) *exec.Cmd {
+		return exec.CommandContext(ctx, "/nonexistent/unifi")
+	})
+	if results[0].OK() || results[0].Error == "" {
+		t.Errorf("Expected a failure, got %+v", results[0])
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	env := Environ([]string{"HOME=/root", "UNIFI_HOST=https://unifi", "UNIFI_API_KEY=k", "PATH=/bin"})
+	if strings.Join(env, " ") != "HOME=/root PATH=/bin" {
+		t.Errorf("Unexpected environment %v", env)
+	}
+}
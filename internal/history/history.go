@@ -0,0 +1,127 @@
+// Package history keeps a bounded, persisted log of client change events
+// observed in watch mode, for later review.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/datafile"
+)
+
+// Event types
+const (
+	EventConnected    = "connected"
+	EventDisconnected = "disconnected"
+)
+
+// Event is a single observed change. Host and Site identify the controller it
+// was observed on.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+	Host string    `json:"host"`
+	Site string    `json:"site"`
+	MAC  string    `json:"mac"`
+	Name string    `json:"name"`
+	IP   string    `json:"ip,omitempty"`
+	// Guest is set for clients on a guest network
+	Guest bool `json:"guest,omitempty"`
+	// AP and SSID (wireless) or Switch and SwitchPort (wired) are where the
+	// client was connected
+	AP         string `json:"ap,omitempty"`
+	SSID       string `json:"ssid,omitempty"`
+	Switch     string `json:"switch,omitempty"`
+	SwitchPort int    `json:"switch_port,omitempty"`
+}
+
+// Ring is a JSON file holding the most recent events, oldest first. Adding
+// events beyond its capacity drops the oldest ones.
+type Ring struct {
+	path     string
+	capacity int
+	Events   []Event
+}
+
+// Load reads the ring at path; a missing file yields an empty ring
+func Load(path string, capacity int) (*Ring, error) {
+	if capacity < 1 {
+		return nil, fmt.Errorf("history capacity must be at least 1")
+	}
+
+	ring := &Ring{path: path, capacity: capacity}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ring, nil
+		}
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &ring.Events); err != nil {
+		return nil, fmt.Errorf("failed to parse history: %w", err)
+	}
+
+	ring.trim()
+	return ring, nil
+}
+
+// Add appends events, dropping the oldest ones beyond capacity
+func (r *Ring) Add(events ...Event) {
+	r.Events = append(r.Events, events...)
+	r.trim()
+}
+
+// Since returns the events recorded for host at or after t
+func (r *Ring) Since(host string, t time.Time) []Event {
+	var events []Event
+	for _, e := range r.Events {
+		if e.Host == host && !e.Time.Before(t) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Save writes the ring back to disk, creating its directory if needed. The
+// file is replaced atomically; use Append when other invocations may be
+// adding events at the same time.
+func (r *Ring) Save() error {
+	data, err := json.MarshalIndent(r.Events, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+
+	if err := datafile.WriteFile(r.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	return nil
+}
+
+// Append adds events to the ring at path, holding the ring's lock from load
+// to save so concurrent invocations do not lose each other's events
+func Append(path string, capacity int, events ...Event) error {
+	lock, err := datafile.LockFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to lock history: %w", err)
+	}
+	defer lock.Unlock()
+
+	ring, err := Load(path, capacity)
+	if err != nil {
+		return err
+	}
+	ring.Add(events...)
+	return ring.Save()
+}
+
+func (r *Ring) trim() {
+	if excess := len(r.Events) - r.capacity; excess > 0 {
+		r.Events = append([]Event(nil), r.Events[excess:]...)
+	}
+}
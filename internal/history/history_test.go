@@ -0,0 +1,65 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	ring, err := Load(filepath.Join(t.TempDir(), "history.json"), 10)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(ring.Events) != 0 {
+		t.Errorf("Expected empty ring, got %d events", len(ring.Events))
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "history.json"), 0); err == nil {
+		t.Error("Expected error for zero capacity")
+	}
+}
+
+func TestRing_AddDropsOldest(t *testing.T) {
+	ring, _ := Load(filepath.Join(t.TempDir(), "history.json"), 3)
+
+	for i := 1; i <= 5; i++ {
+		ring.Add(Event{MAC: string(rune('0' + i))})
+	}
+
+	if len(ring.Events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(ring.Events))
+	}
+	if ring.Events[0].MAC != "3" || ring.Events[2].MAC != "5" {
+		t.Errorf("Expected the 3 newest events, got %+v", ring.Events)
+	}
+}
+
+func TestRing_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.json")
+	now := time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC)
+
+	ring, _ := Load(path, 10)
+	ring.Add(
+		Event{Time: now.Add(-3 * time.Hour), Type: EventConnected, Host: "https://a", MAC: "aa:bb:cc:dd:ee:01"},
+		Event{Time: now.Add(-time.Hour), Type: EventDisconnected, Host: "https://a", MAC: "aa:bb:cc:dd:ee:01"},
+		Event{Time: now.Add(-time.Hour), Type: EventConnected, Host: "https://b", MAC: "aa:bb:cc:dd:ee:02"},
+	)
+	if err := ring.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	// A smaller capacity on load keeps only the newest events
+	loaded, err := Load(path, 2)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(loaded.Events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(loaded.Events))
+	}
+
+	since := loaded.Since("https://a", now.Add(-2*time.Hour))
+	if len(since) != 1 || since[0].Type != EventDisconnected {
+		t.Errorf("Expected the disconnect on host a, got %+v", since)
+	}
+}
@@ -0,0 +1,21 @@
+package history
+
+import "time"
+
+// Replay emits events in order, waiting between them for the time that passed
+// between the original events divided by speed. A speed of 0 replays without
+// waiting. sleep is time.Sleep outside of tests.
+func Replay(events []Event, speed float64, sleep func(time.Duration), emit func(Event) error) error {
+	for i, e := range events {
+		if i > 0 && speed > 0 {
+			if gap := e.Time.Sub(events[i-1].Time); gap > 0 {
+				sleep(time.Duration(float64(gap) / speed))
+			}
+		}
+
+		if err := emit(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
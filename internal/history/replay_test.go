@@ -0,0 +1,51 @@
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReplay(t *testing.T) {
+	start := time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Time: start, MAC: "1"},
+		{Time: start.Add(10 * time.Second), MAC: "2"},
+		{Time: start.Add(40 * time.Second), MAC: "3"},
+	}
+
+	var sleeps []time.Duration
+	var emitted []string
+	err := Replay(events, 10, func(d time.Duration) { sleeps = append(sleeps, d) }, func(e Event) error {
+		emitted = append(emitted, e.MAC)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay() returned error: %v", err)
+	}
+
+	if len(emitted) != 3 || emitted[2] != "3" {
+		t.Errorf("Expected all events in order, got %v", emitted)
+	}
+	if len(sleeps) != 2 || sleeps[0] != time.Second || sleeps[1] != 3*time.Second {
+		t.Errorf("Expected gaps scaled by 10x, got %v", sleeps)
+	}
+}
+
+func TestReplay_NoWaitAndErrors(t *testing.T) {
+	events := []Event{{Time: time.Unix(0, 0)}, {Time: time.Unix(60, 0)}}
+
+	slept := false
+	calls := 0
+	err := Replay(events, 0, func(time.Duration) { slept = true }, func(Event) error {
+		calls++
+		return errors.New("boom")
+	})
+
+	if slept {
+		t.Error("Expected no waiting at speed 0")
+	}
+	if err == nil || calls != 1 {
+		t.Errorf("Expected replay to stop at the first error, got %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,128 @@
+// Package hooks runs user-defined shell commands before and after CLI
+// commands, so local policy and integrations can be added without changing the
+// CLI itself.
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+)
+
+// Hook phases
+const (
+	PhasePre  = "pre"
+	PhasePost = "post"
+)
+
+// Context describes the command a hook runs around. It is available to the
+// hook's command line as template data and as UNIFI_HOOK_* environment
+// variables.
+type Context struct {
+	Phase   string
+	Command string
+	Args    []string
+	Host    string
+	Site    string
+	Profile string
+	// Error is the command's error in the post phase, empty on success
+	Error string
+}
+
+// templateData is what {{.Field}} refers to in a hook's command line. Args
+// is shell-quoted so it can be pasted into the command as is.
+type templateData struct {
+	Phase   string
+	Command string
+	Args    string
+	Host    string
+	Site    string
+	Profile string
+	Error   string
+}
+
+// Env returns the context as environment variables
+func (c Context) Env() []string {
+	status := "ok"
+	if c.Error != "" {
+		status = "error"
+	}
+
+	env := []string{
+		"UNIFI_HOOK_PHASE=" + c.Phase,
+		"UNIFI_HOOK_COMMAND=" + c.Command,
+		"UNIFI_HOOK_ARGS=" + strings.Join(c.Args, " "),
+		"UNIFI_HOOK_HOST=" + c.Host,
+		"UNIFI_HOOK_SITE=" + c.Site,
+		"UNIFI_HOOK_PROFILE=" + c.Profile,
+	}
+	if c.Phase == PhasePost {
+		env = append(env, "UNIFI_HOOK_STATUS="+status, "UNIFI_HOOK_ERROR="+c.Error)
+	}
+	return env
+}
+
+// Render expands the template fields of a hook's command line. Args, Host,
+// Site, Profile and Error are shell-quoted for use as bare words: inside
+// double quotes the single quotes become literal and $(...) in a value would
+// still run, so hooks must not put these fields inside quotes.
+func Render(command string, ctx Context) (string, error) {
+	tmpl, err := template.New("hook").Option("missingkey=error").Parse(command)
+	if err != nil {
+		return "", fmt.Errorf("invalid hook %q: %w", command, err)
+	}
+
+	quoted := make([]string, len(ctx.Args))
+	for i, arg := range ctx.Args {
+		quoted[i] = shellQuote(arg)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, templateData{
+		Phase:   ctx.Phase,
+		Command: ctx.Command,
+		Args:    strings.Join(quoted, " "),
+		Host:    shellQuote(ctx.Host),
+		Site:    shellQuote(ctx.Site),
+		Profile: shellQuote(ctx.Profile),
+		Error:   shellQuote(ctx.Error),
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid hook %q: %w", command, err)
+	}
+	return buf.String(), nil
+}
+
+// Run renders command and runs it with sh, passing the context in the
+// environment. The hook's output goes to stderr so it never mixes with the
+// command's own (possibly JSON) output.
+func Run(command string, ctx Context) error {
+	line, err := Render(command, ctx)
+	if err != nil {
+		return err
+	}
+
+	hook := exec.Command("sh", "-c", line)
+	hook.Env = append(os.Environ(), ctx.Env()...)
+	hook.Stdin = os.Stdin
+	hook.Stdout = os.Stderr
+	hook.Stderr = os.Stderr
+
+	if err := hook.Run(); err != nil {
+		return fmt.Errorf("%s hook for %q failed: %w", ctx.Phase, ctx.Command, err)
+	}
+	return nil
+}
+
+// shellQuote quotes s for sh unless it only contains safe characters
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.,:/@=+%", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,95 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	ctx := Context{
+		Phase:   PhasePost,
+		Command: "clients block",
+		Args:    []string{"aa:bb:cc:dd:ee:ff", "Kid's iPad"},
+		Host:    "https://unifi.example.com",
+		Site:    "default",
+	}
+
+	got, err := Render("notify.sh {{.Command}} {{.Args}} --site {{.Site}}", ctx)
+	if err != nil {
+		t.Fatalf("Render() returned error: %v", err)
+	}
+
+	expected := `notify.sh clients block aa:bb:cc:dd:ee:ff 'Kid'\''s iPad' --site default`
+	if got != expected {
+		t.Errorf("Render() = %s, expected %s", got, expected)
+	}
+
+	if _, err := Render("notify.sh {{.Nope}}", ctx); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+	if _, err := Render("notify.sh {{.Args", ctx); err == nil {
+		t.Error("Expected error for malformed template")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":     "plain",
+		"":          "''",
+		"two words": "'two words'",
+		"$(rm -rf)": "'$(rm -rf)'",
+		"it's":      `'it'\''s'`,
+	}
+
+	for in, expected := range tests {
+		if got := shellQuote(in); got != expected {
+			t.Errorf("shellQuote(%q) = %s, expected %s", in, got, expected)
+		}
+	}
+}
+
+func TestContext_Env(t *testing.T) {
+	pre := Context{Phase: PhasePre, Command: "clients block", Args: []string{"a", "b"}}.Env()
+	if !contains(pre, "UNIFI_HOOK_ARGS=a b") || !contains(pre, "UNIFI_HOOK_COMMAND=clients block") {
+		t.Errorf("Unexpected pre env %v", pre)
+	}
+	for _, v := range pre {
+		if strings.HasPrefix(v, "UNIFI_HOOK_STATUS=") {
+			t.Errorf("Pre hooks should not get a status, got %s", v)
+		}
+	}
+
+	post := Context{Phase: PhasePost, Error: "boom"}.Env()
+	if !contains(post, "UNIFI_HOOK_STATUS=error") || !contains(post, "UNIFI_HOOK_ERROR=boom") {
+		t.Errorf("Unexpected post env %v", post)
+	}
+}
+
+func TestRun(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	ctx := Context{Phase: PhasePost, Command: "clients block", Args: []string{"aa:bb:cc:dd:ee:ff", "$(echo injected)"}}
+
+	if err := Run(`echo "$UNIFI_HOOK_STATUS" {{.Args}} > `+out, ctx); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	data, _ := os.ReadFile(out)
+	if strings.TrimSpace(string(data)) != "ok aa:bb:cc:dd:ee:ff $(echo injected)" {
+		t.Errorf("Unexpected hook output %q", data)
+	}
+
+	if err := Run("exit 3", ctx); err == nil || !strings.Contains(err.Error(), `post hook for "clients block" failed`) {
+		t.Errorf("Expected failure for non-zero exit, got %v", err)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+// Package location works out where a client was last connected, from the
+// changes recorded in watch mode and the controller's client events.
+package location
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/history"
+)
+
+// Location sources
+const (
+	SourceHistory = "history"
+	SourceEvents  = "events"
+)
+
+// Location is where a client was seen: an AP (and SSID) for wireless clients,
+// a switch port for wired ones
+type Location struct {
+	Time       time.Time `json:"time"`
+	Source     string    `json:"source"`
+	AP         string    `json:"ap,omitempty"`
+	APName     string    `json:"ap_name,omitempty"`
+	SSID       string    `json:"ssid,omitempty"`
+	Switch     string    `json:"switch,omitempty"`
+	SwitchName string    `json:"switch_name,omitempty"`
+	SwitchPort int       `json:"switch_port,omitempty"`
+}
+
+// Last returns the most recent location of the client with the given MAC
+// among recorded changes and controller events, or nil if neither places it
+func Last(mac string, recorded []history.Event, events []api.Event) *Location {
+	var last *Location
+	consider := func(loc Location) {
+		if loc.AP == "" && loc.Switch == "" {
+			return
+		}
+		if last == nil || loc.Time.After(last.Time) {
+			last = &loc
+		}
+	}
+
+	for _, e := range recorded {
+		if strings.EqualFold(e.MAC, mac) {
+			consider(Location{
+				Time:       e.Time,
+				Source:     SourceHistory,
+				AP:         e.AP,
+				SSID:       e.SSID,
+				Switch:     e.Switch,
+				SwitchPort: e.SwitchPort,
+			})
+		}
+	}
+
+	for _, e := range events {
+		if strings.EqualFold(e.User, mac) {
+			consider(Location{
+				Time:       e.GetTime(),
+				Source:     SourceEvents,
+				AP:         e.AP,
+				APName:     e.APName,
+				SSID:       e.SSID,
+				Switch:     e.SW,
+				SwitchName: e.SWName,
+				SwitchPort: e.Port,
+			})
+		}
+	}
+
+	return last
+}
+
+// NameDevices fills in missing AP and switch names from the site's devices
+func (l *Location) NameDevices(devices []api.Device) {
+	for _, d := range devices {
+		if l.AP != "" && l.APName == "" && strings.EqualFold(d.MAC, l.AP) {
+			l.APName = d.Name
+		}
+		if l.Switch != "" && l.SwitchName == "" && strings.EqualFold(d.MAC, l.Switch) {
+			l.SwitchName = d.Name
+		}
+	}
+}
+
+// String describes the location, e.g. "Office AP (f0:9f:c2:00:00:01), SSID
+// Home" or "Core Switch (f0:9f:c2:00:00:02) port 5"
+func (l Location) String() string {
+	if l.AP != "" {
+		s := device(l.APName, l.AP)
+		if l.SSID != "" {
+			s += ", SSID " + l.SSID
+		}
+		return s
+	}
+
+	s := device(l.SwitchName, l.Switch)
+	if l.SwitchPort > 0 {
+		s += fmt.Sprintf(" port %d", l.SwitchPort)
+	}
+	return s
+}
+
+func device(name, mac string) string {
+	if name == "" {
+		return mac
+	}
+	return fmt.Sprintf("%s (%s)", name, mac)
+}
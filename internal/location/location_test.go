@@ -0,0 +1,61 @@
+package location
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/history"
+)
+
+const laptop = "aa:bb:cc:dd:ee:01"
+
+var start = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestLast(t *testing.T) {
+	recorded := []history.Event{
+		{Time: start, Type: history.EventDisconnected, MAC: laptop, AP: "f0:9f:c2:00:00:01", SSID: "Home"},
+		{Time: start.Add(2 * time.Hour), Type: history.EventDisconnected, MAC: "aa:bb:cc:dd:ee:02", AP: "f0:9f:c2:00:00:09"},
+		// Recorded before the location was kept
+		{Time: start.Add(3 * time.Hour), Type: history.EventConnected, MAC: laptop},
+	}
+	events := []api.Event{
+		{Key: "EVT_LU_Disconnected", User: "AA:BB:CC:DD:EE:01", SW: "f0:9f:c2:00:00:02", SWName: "Core Switch", Port: 5, Time: start.Add(time.Hour).UnixMilli()},
+		{Key: "EVT_WU_Disconnected", User: "aa:bb:cc:dd:ee:02", AP: "f0:9f:c2:00:00:01", Time: start.Add(4 * time.Hour).UnixMilli()},
+	}
+
+	loc := Last(laptop, recorded, events)
+	if loc == nil {
+		t.Fatal("Expected a location")
+	}
+	if loc.Source != SourceEvents || loc.Switch != "f0:9f:c2:00:00:02" || loc.SwitchPort != 5 || !loc.Time.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected the newer event location, got %+v", loc)
+	}
+	if got := loc.String(); got != "Core Switch (f0:9f:c2:00:00:02) port 5" {
+		t.Errorf("String() = %q", got)
+	}
+
+	loc = Last(laptop, recorded, nil)
+	if loc == nil || loc.Source != SourceHistory || loc.SSID != "Home" {
+		t.Errorf("Expected the recorded location, got %+v", loc)
+	}
+
+	if loc := Last("aa:bb:cc:dd:ee:99", recorded, events); loc != nil {
+		t.Errorf("Expected no location for an unknown client, got %+v", loc)
+	}
+}
+
+func TestLocation_NameDevices(t *testing.T) {
+	loc := Location{AP: "f0:9f:c2:00:00:01", SSID: "Home"}
+	loc.NameDevices([]api.Device{{MAC: "F0:9F:C2:00:00:01", Name: "Office AP"}, {MAC: "f0:9f:c2:00:00:02", Name: "Core Switch"}})
+
+	if got := loc.String(); got != "Office AP (f0:9f:c2:00:00:01), SSID Home" {
+		t.Errorf("String() = %q", got)
+	}
+
+	loc = Location{Switch: "f0:9f:c2:00:00:03"}
+	loc.NameDevices(nil)
+	if got := loc.String(); got != "f0:9f:c2:00:00:03" {
+		t.Errorf("Expected the MAC of an unknown device, got %q", got)
+	}
+}
@@ -0,0 +1,146 @@
+// Package migrate copies configuration between controllers.
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"go.yaml.in/yaml/v3"
+)
+
+// NetworkMap maps source network names to target network names. Networks
+// that are not listed are matched by name.
+type NetworkMap map[string]string
+
+// LoadNetworkMap reads a YAML file of "source network: target network" lines
+func LoadNetworkMap(path string) (NetworkMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network map: %w", err)
+	}
+
+	networks := NetworkMap{}
+	if err := yaml.Unmarshal(data, &networks); err != nil {
+		return nil, fmt.Errorf("failed to parse network map: %w", err)
+	}
+	return networks, nil
+}
+
+// ClientChange is what migrating one client does on the target controller
+type ClientChange struct {
+	MAC string
+	// TargetID is the client's record on the target; empty if it has to be
+	// created
+	TargetID string
+	// Fields are the known-client fields to set
+	Fields map[string]interface{}
+	// Block is set when the client is blocked on the source but not on the
+	// target
+	Block bool
+	// Warnings explain metadata that could not be migrated
+	Warnings []string
+}
+
+// Summary describes the change in a few words, e.g. "name, note, block"
+func (c ClientChange) Summary() string {
+	var parts []string
+	for _, field := range []string{"name", "note", "fixed_ip"} {
+		if value, ok := c.Fields[field]; ok {
+			if field == "fixed_ip" {
+				parts = append(parts, fmt.Sprintf("fixed IP %v", value))
+			} else {
+				parts = append(parts, field)
+			}
+		}
+	}
+	if c.Block {
+		parts = append(parts, "block")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PlanClients works out the changes that copy the alias, note, fixed IP and
+// block of every source client to the target. Only fields that are empty on
+// the target are filled in; a different alias, note or fixed IP the target
+// already has is left alone with a warning, unless overwrite is set. Clients
+// with nothing to copy are omitted. Fixed IPs are only copied when the
+// client's network can be found on the target.
+func PlanClients(source, target []api.User, sourceNetworks, targetNetworks []api.Network, networks NetworkMap, overwrite bool) []ClientChange {
+	targetUsers := make(map[string]api.User, len(target))
+	for _, user := range target {
+		targetUsers[strings.ToLower(user.MAC)] = user
+	}
+
+	sourceNetworkNames := make(map[string]string, len(sourceNetworks))
+	for _, network := range sourceNetworks {
+		sourceNetworkNames[network.ID] = network.Name
+	}
+
+	var changes []ClientChange
+	for _, user := range source {
+		existing, exists := targetUsers[strings.ToLower(user.MAC)]
+		change := ClientChange{MAC: strings.ToLower(user.MAC), TargetID: existing.ID, Fields: map[string]interface{}{}}
+
+		if user.Name != "" && user.Name != existing.Name {
+			if existing.Name == "" || overwrite {
+				change.Fields["name"] = user.Name
+			} else {
+				change.Warnings = append(change.Warnings, fmt.Sprintf("alias %q not migrated: target has %q (use --overwrite)", user.Name, existing.Name))
+			}
+		}
+		if user.Note != "" && user.Note != existing.Note {
+			if existing.Note == "" || overwrite {
+				change.Fields["note"] = user.Note
+				change.Fields["noted"] = true
+			} else {
+				change.Warnings = append(change.Warnings, "note not migrated: target has a different note (use --overwrite)")
+			}
+		}
+
+		hasFixedIP := existing.UseFixedIP && existing.FixedIP != ""
+		if user.UseFixedIP && user.FixedIP != "" && !(hasFixedIP && existing.FixedIP == user.FixedIP) {
+			networkID, err := mapNetwork(sourceNetworkNames[user.NetworkID], targetNetworks, networks)
+			if hasFixedIP && !overwrite {
+				change.Warnings = append(change.Warnings, fmt.Sprintf("fixed IP %s not migrated: target has %s (use --overwrite)", user.FixedIP, existing.FixedIP))
+			} else if err != nil {
+				change.Warnings = append(change.Warnings, fmt.Sprintf("fixed IP %s not migrated: %v", user.FixedIP, err))
+			} else {
+				change.Fields["use_fixedip"] = true
+				change.Fields["fixed_ip"] = user.FixedIP
+				change.Fields["network_id"] = networkID
+			}
+		}
+
+		change.Block = user.Blocked && !(exists && existing.Blocked)
+
+		if len(change.Fields) > 0 || change.Block || len(change.Warnings) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].MAC < changes[j].MAC
+	})
+	return changes
+}
+
+// mapNetwork finds the target network ID for a source network name
+func mapNetwork(name string, targetNetworks []api.Network, networks NetworkMap) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("source network unknown")
+	}
+
+	targetName := name
+	if mapped, ok := networks[name]; ok {
+		targetName = mapped
+	}
+
+	network, err := api.FindNetwork(targetNetworks, targetName)
+	if err != nil {
+		return "", fmt.Errorf("network %q not found on target", targetName)
+	}
+	return network.ID, nil
+}
@@ -0,0 +1,95 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPlanClients(t *testing.T) {
+	sourceNetworks := []api.Network{{ID: "s-lan", Name: "LAN"}, {ID: "s-iot", Name: "Things"}, {ID: "s-lab", Name: "Lab"}}
+	targetNetworks := []api.Network{{ID: "t-lan", Name: "LAN"}, {ID: "t-iot", Name: "IoT"}}
+
+	source := []api.User{
+		{MAC: "aa:bb:cc:dd:ee:01", Name: "TV", Note: "living room"},
+		{MAC: "aa:bb:cc:dd:ee:02", Name: "Printer", UseFixedIP: true, FixedIP: "10.0.0.5", NetworkID: "s-lan"},
+		{MAC: "aa:bb:cc:dd:ee:03", Name: "Camera", UseFixedIP: true, FixedIP: "10.0.30.7", NetworkID: "s-iot", Blocked: true},
+		{MAC: "aa:bb:cc:dd:ee:04", Name: "Scope", UseFixedIP: true, FixedIP: "10.0.9.9", NetworkID: "s-lab"},
+		{MAC: "aa:bb:cc:dd:ee:05", Name: "Same"},
+		{MAC: "aa:bb:cc:dd:ee:06"},
+	}
+	target := []api.User{
+		{ID: "t1", MAC: "AA:BB:CC:DD:EE:01", Name: "Old name"},
+		{ID: "t5", MAC: "aa:bb:cc:dd:ee:05", Name: "Same"},
+	}
+
+	changes := PlanClients(source, target, sourceNetworks, targetNetworks, NetworkMap{"Things": "IoT"}, false)
+
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 changes, got %d: %+v", len(changes), changes)
+	}
+
+	// The target's own alias is kept; only the missing note is filled in
+	tv := changes[0]
+	if _, ok := tv.Fields["name"]; ok || tv.TargetID != "t1" || tv.Fields["note"] != "living room" || tv.Block {
+		t.Errorf("Unexpected change for TV: %+v", tv)
+	}
+	if len(tv.Warnings) != 1 || !strings.Contains(tv.Warnings[0], "--overwrite") {
+		t.Errorf("Expected a warning about the kept alias, got %v", tv.Warnings)
+	}
+
+	printer := changes[1]
+	if printer.TargetID != "" || printer.Fields["fixed_ip"] != "10.0.0.5" || printer.Fields["network_id"] != "t-lan" {
+		t.Errorf("Unexpected change for Printer: %+v", printer)
+	}
+
+	camera := changes[2]
+	if camera.Fields["network_id"] != "t-iot" || !camera.Block {
+		t.Errorf("Expected Camera to use the mapped network and be blocked: %+v", camera)
+	}
+	if camera.Summary() != "name, fixed IP 10.0.30.7, block" {
+		t.Errorf("Unexpected summary %q", camera.Summary())
+	}
+
+	scope := changes[3]
+	if _, ok := scope.Fields["fixed_ip"]; ok || len(scope.Warnings) != 1 {
+		t.Errorf("Expected Scope's fixed IP to be skipped with a warning: %+v", scope)
+	}
+}
+
+func TestPlanClients_KeepsTargetMetadata(t *testing.T) {
+	networks := []api.Network{{ID: "lan", Name: "LAN"}}
+	source := []api.User{{MAC: "aa:bb:cc:dd:ee:01", Name: "TV", Note: "living room", UseFixedIP: true, FixedIP: "10.0.0.5", NetworkID: "lan"}}
+	target := []api.User{{ID: "t1", MAC: "aa:bb:cc:dd:ee:01", Name: "Lounge TV", Note: "wall mounted", UseFixedIP: true, FixedIP: "10.0.0.50", NetworkID: "lan"}}
+
+	changes := PlanClients(source, target, networks, networks, NetworkMap{}, false)
+	if len(changes) != 1 || len(changes[0].Fields) != 0 || len(changes[0].Warnings) != 3 {
+		t.Errorf("Expected no fields and a warning per kept value, got %+v", changes)
+	}
+	if changes[0].Summary() != "" {
+		t.Errorf("Expected nothing to apply, got %q", changes[0].Summary())
+	}
+
+	changes = PlanClients(source, target, networks, networks, NetworkMap{}, true)
+	if len(changes) != 1 || changes[0].Fields["name"] != "TV" || changes[0].Fields["note"] != "living room" || changes[0].Fields["fixed_ip"] != "10.0.0.5" {
+		t.Errorf("Expected --overwrite to replace the target's values, got %+v", changes)
+	}
+}
+
+func TestLoadNetworkMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "networks.yaml")
+	if err := os.WriteFile(path, []byte("Things: IoT\n\"Old LAN\": LAN\n"), 0644); err != nil {
+		t.Fatalf("Failed to write network map: %v", err)
+	}
+
+	networks, err := LoadNetworkMap(path)
+	if err != nil {
+		t.Fatalf("LoadNetworkMap() returned error: %v", err)
+	}
+	if networks["Things"] != "IoT" || networks["Old LAN"] != "LAN" {
+		t.Errorf("Unexpected network map %v", networks)
+	}
+}
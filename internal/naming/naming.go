@@ -0,0 +1,87 @@
+package naming
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Pattern generates names from a text/template, e.g.
+// "{{.Model}}-{{.Site}}-{{counter}}". Besides the fields of the data passed
+// to Next, patterns can use:
+//
+//   - counter: the position of the item, starting at 1. With arguments, items
+//     are counted per distinct set of arguments, so {{counter .Model}}
+//     numbers each model separately. Use printf to pad it:
+//     {{printf "%02d" counter}}
+//   - lower, upper: change the case of a string
+//   - slug: lower case with every run of characters other than letters and
+//     digits replaced by a single "-"
+type Pattern struct {
+	tmpl   *template.Template
+	counts map[string]int
+	// current memoizes the counters of the item being rendered, so a counter
+	// used twice in one pattern gives the same number
+	current map[string]int
+}
+
+// Compile parses a naming pattern
+func Compile(pattern string) (*Pattern, error) {
+	p := &Pattern{counts: map[string]int{}}
+
+	tmpl, err := template.New("name").Option("missingkey=error").Funcs(template.FuncMap{
+		"counter": p.counter,
+		"lower":   strings.ToLower,
+		"upper":   strings.ToUpper,
+		"slug":    Slug,
+	}).Parse(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	p.tmpl = tmpl
+	return p, nil
+}
+
+// Next renders the name of the next item
+func (p *Pattern) Next(data interface{}) (string, error) {
+	p.current = map[string]int{}
+
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render name: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func (p *Pattern) counter(group ...interface{}) int {
+	key := fmt.Sprint(group...)
+	if n, ok := p.current[key]; ok {
+		return n
+	}
+
+	p.counts[key]++
+	p.current[key] = p.counts[key]
+	return p.counts[key]
+}
+
+// Slug lowercases s and replaces every run of characters other than letters
+// and digits with a single "-", e.g. "Main Office (2F)" becomes
+// "main-office-2f"
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package naming
+
+import "testing"
+
+type item struct {
+	Model string
+	Site  string
+}
+
+func TestPattern_Counter(t *testing.T) {
+	p, err := Compile(`{{.Model}}-{{.Site}}-{{printf "%02d" counter}}`)
+	if err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+
+	items := []item{{"U6-Lite", "hq"}, {"U6-Lite", "hq"}, {"USW-24", "hq"}}
+	expected := []string{"U6-Lite-hq-01", "U6-Lite-hq-02", "USW-24-hq-03"}
+	for i, it := range items {
+		name, err := p.Next(it)
+		if err != nil {
+			t.Fatalf("Next() returned error: %v", err)
+		}
+		if name != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], name)
+		}
+	}
+}
+
+func TestPattern_CounterPerGroup(t *testing.T) {
+	p, err := Compile(`{{.Model | slug}}-{{counter .Model}}-{{counter .Model}}`)
+	if err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+
+	items := []item{{Model: "U6 Lite"}, {Model: "USW-24"}, {Model: "U6 Lite"}}
+	expected := []string{"u6-lite-1-1", "usw-24-1-1", "u6-lite-2-2"}
+	for i, it := range items {
+		name, err := p.Next(it)
+		if err != nil {
+			t.Fatalf("Next() returned error: %v", err)
+		}
+		if name != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], name)
+		}
+	}
+}
+
+func TestPattern_Errors(t *testing.T) {
+	if _, err := Compile("{{.Model"); err == nil {
+		t.Error("Expected error for unterminated action")
+	}
+
+	p, err := Compile("{{.Unknown}}")
+	if err != nil {
+		t.Fatalf("Compile() returned error: %v", err)
+	}
+	if _, err := p.Next(item{}); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Main Office (2F)": "main-office-2f",
+		"--AP--":           "ap",
+		"":                 "",
+	}
+	for in, expected := range tests {
+		if got := Slug(in); got != expected {
+			t.Errorf("Slug(%q): expected %q, got %q", in, expected, got)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Package network builds network (VLAN) configurations from specs.
+package network
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"go.yaml.in/yaml/v3"
+)
+
+// Spec describes a network to create, from flags or a YAML file
+type Spec struct {
+	Name string `yaml:"name"`
+	// Purpose is "corporate" (the default) or "vlan-only"
+	Purpose string `yaml:"purpose"`
+	VLAN    int    `yaml:"vlan"`
+	// Subnet is the gateway address with the prefix length, e.g.
+	// 10.0.30.1/24; corporate networks only
+	Subnet string `yaml:"subnet"`
+	// DHCPRange is the DHCP pool as "start-stop"; DHCP is off without it
+	DHCPRange string `yaml:"dhcp_range"`
+	Domain    string `yaml:"domain"`
+}
+
+// LoadSpec reads a spec from a YAML file
+func LoadSpec(path string) (Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Spec{}, fmt.Errorf("failed to read network spec: %w", err)
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to parse network spec: %w", err)
+	}
+	return spec, nil
+}
+
+// Fields validates the spec against the site's existing networks and returns
+// the networkconf fields to create it with. The name, the VLAN ID and the
+// subnet must not be in use yet.
+func (s Spec) Fields(networks []api.Network) (map[string]interface{}, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("a name is required")
+	}
+	if _, err := api.FindNetwork(networks, s.Name); err == nil {
+		return nil, fmt.Errorf("a network named %q already exists", s.Name)
+	}
+
+	if s.VLAN < 2 || s.VLAN > 4009 {
+		return nil, fmt.Errorf("VLAN ID must be between 2 and 4009, got %d", s.VLAN)
+	}
+	for _, n := range networks {
+		if n.Purpose != "wan" && n.VLANEnabled && n.VLAN == s.VLAN {
+			return nil, fmt.Errorf("VLAN %d is already used by network %s", s.VLAN, n.Name)
+		}
+	}
+
+	fields := map[string]interface{}{
+		"name":         s.Name,
+		"enabled":      true,
+		"networkgroup": "LAN",
+		"vlan_enabled": true,
+		"vlan":         s.VLAN,
+	}
+
+	switch s.Purpose {
+	case "", "corporate":
+		fields["purpose"] = "corporate"
+	case "vlan-only":
+		if s.Subnet != "" || s.DHCPRange != "" || s.Domain != "" {
+			return nil, fmt.Errorf("vlan-only networks have no subnet, DHCP range or domain")
+		}
+		fields["purpose"] = "vlan-only"
+		return fields, nil
+	default:
+		return nil, fmt.Errorf("invalid purpose %q (valid options: corporate, vlan-only)", s.Purpose)
+	}
+
+	gateway, err := ParseSubnet(s.Subnet)
+	if err != nil {
+		return nil, err
+	}
+	if n := Overlapping(networks, gateway); n != nil {
+		return nil, fmt.Errorf("subnet %s overlaps %s of network %s", gateway.Masked(), n.IPSubnet, n.Name)
+	}
+	fields["ip_subnet"] = gateway.String()
+
+	fields["dhcpd_enabled"] = s.DHCPRange != ""
+	if s.DHCPRange != "" {
+		start, stop, err := ParseDHCPRange(s.DHCPRange, gateway)
+		if err != nil {
+			return nil, err
+		}
+		fields["dhcpd_start"] = start.String()
+		fields["dhcpd_stop"] = stop.String()
+	}
+
+	if s.Domain != "" {
+		fields["domain_name"] = s.Domain
+	}
+
+	return fields, nil
+}
+
+// ParseSubnet reads a gateway address with prefix length such as
+// 10.0.30.1/24. The address must be usable as a host in the subnet.
+func ParseSubnet(subnet string) (netip.Prefix, error) {
+	if subnet == "" {
+		return netip.Prefix{}, fmt.Errorf("a subnet is required for corporate networks (e.g. 10.0.30.1/24)")
+	}
+
+	gateway, err := netip.ParsePrefix(subnet)
+	if err != nil || !gateway.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet %q (use the gateway address and prefix length, e.g. 10.0.30.1/24)", subnet)
+	}
+	if gateway.Bits() > 30 {
+		return netip.Prefix{}, fmt.Errorf("subnet %s is too small (at most /30)", subnet)
+	}
+	if gateway.Addr() == gateway.Masked().Addr() || gateway.Addr() == broadcast(gateway) {
+		return netip.Prefix{}, fmt.Errorf("gateway %s is not a host address of %s (e.g. use %s)",
+			gateway.Addr(), gateway.Masked(), netip.PrefixFrom(gateway.Masked().Addr().Next(), gateway.Bits()))
+	}
+	return gateway, nil
+}
+
+// ParseDHCPRange reads a DHCP pool such as 10.0.30.100-10.0.30.200 and checks
+// that it lies within the gateway's subnet and leaves out the gateway
+func ParseDHCPRange(spec string, gateway netip.Prefix) (start, stop netip.Addr, err error) {
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return start, stop, fmt.Errorf("invalid DHCP range %q (use start-stop)", spec)
+	}
+
+	if start, err = netip.ParseAddr(strings.TrimSpace(from)); err != nil {
+		return start, stop, fmt.Errorf("invalid DHCP range start %q", from)
+	}
+	if stop, err = netip.ParseAddr(strings.TrimSpace(to)); err != nil {
+		return start, stop, fmt.Errorf("invalid DHCP range stop %q", to)
+	}
+
+	subnet := gateway.Masked()
+	for _, addr := range []netip.Addr{start, stop} {
+		if !subnet.Contains(addr) || addr == subnet.Addr() || addr == broadcast(gateway) {
+			return start, stop, fmt.Errorf("DHCP range %s is not within %s", spec, subnet)
+		}
+	}
+	if stop.Less(start) {
+		return start, stop, fmt.Errorf("DHCP range %s ends before it starts", spec)
+	}
+	if !gateway.Addr().Less(start) && !stop.Less(gateway.Addr()) {
+		return start, stop, fmt.Errorf("DHCP range %s includes the gateway %s", spec, gateway.Addr())
+	}
+	return start, stop, nil
+}
+
+// Overlapping returns the first network whose subnet overlaps prefix, or nil
+func Overlapping(networks []api.Network, prefix netip.Prefix) *api.Network {
+	for i := range networks {
+		existing, err := netip.ParsePrefix(networks[i].IPSubnet)
+		if err != nil {
+			continue
+		}
+		if existing.Overlaps(prefix) {
+			return &networks[i]
+		}
+	}
+	return nil
+}
+
+// broadcast returns the last address of the prefix's subnet
+func broadcast(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().As4()
+	for i := prefix.Bits(); i < 32; i++ {
+		addr[i/8] |= 1 << (7 - i%8)
+	}
+	return netip.AddrFrom4(addr)
+}
@@ -0,0 +1,108 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var testNetworks = []api.Network{
+	{ID: "n1", Name: "LAN", Purpose: "corporate", IPSubnet: "192.168.1.1/24"},
+	{ID: "n2", Name: "IoT", Purpose: "corporate", VLANEnabled: true, VLAN: 30, IPSubnet: "10.0.30.1/24"},
+	{ID: "n3", Name: "Internet", Purpose: "wan", VLANEnabled: true, VLAN: 40},
+}
+
+func TestSpec_Fields(t *testing.T) {
+	spec := Spec{Name: "Cameras", VLAN: 40, Subnet: "10.0.40.1/24", DHCPRange: "10.0.40.100-10.0.40.200", Domain: "cams.lan"}
+
+	fields, err := spec.Fields(testNetworks)
+	if err != nil {
+		t.Fatalf("Fields() returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"name":          "Cameras",
+		"purpose":       "corporate",
+		"vlan_enabled":  true,
+		"vlan":          40,
+		"ip_subnet":     "10.0.40.1/24",
+		"dhcpd_enabled": true,
+		"dhcpd_start":   "10.0.40.100",
+		"dhcpd_stop":    "10.0.40.200",
+		"domain_name":   "cams.lan",
+	}
+	for key, value := range expected {
+		if fields[key] != value {
+			t.Errorf("Expected %s = %v, got %v", key, value, fields[key])
+		}
+	}
+
+	fields, err = Spec{Name: "Transit", Purpose: "vlan-only", VLAN: 50}.Fields(testNetworks)
+	if err != nil {
+		t.Fatalf("Fields() returned error: %v", err)
+	}
+	if fields["purpose"] != "vlan-only" || fields["vlan"] != 50 {
+		t.Errorf("Unexpected fields for vlan-only network: %v", fields)
+	}
+	if _, ok := fields["ip_subnet"]; ok {
+		t.Errorf("Expected no subnet for vlan-only network: %v", fields)
+	}
+
+	fields, err = Spec{Name: "Lab", VLAN: 60, Subnet: "10.0.60.1/24"}.Fields(testNetworks)
+	if err != nil {
+		t.Fatalf("Fields() returned error: %v", err)
+	}
+	if fields["dhcpd_enabled"] != false {
+		t.Errorf("Expected DHCP to be off without a range: %v", fields)
+	}
+}
+
+func TestSpec_Fields_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		want string
+	}{
+		{"no name", Spec{VLAN: 40, Subnet: "10.0.40.1/24"}, "name"},
+		{"existing name", Spec{Name: "iot", VLAN: 40, Subnet: "10.0.40.1/24"}, "already exists"},
+		{"no VLAN", Spec{Name: "Cameras", Subnet: "10.0.40.1/24"}, "between 2 and 4009"},
+		{"VLAN in use", Spec{Name: "Cameras", VLAN: 30, Subnet: "10.0.40.1/24"}, "already used by network IoT"},
+		{"bad purpose", Spec{Name: "Cameras", Purpose: "guest", VLAN: 40, Subnet: "10.0.40.1/24"}, "invalid purpose"},
+		{"vlan-only with subnet", Spec{Name: "Cameras", Purpose: "vlan-only", VLAN: 40, Subnet: "10.0.40.1/24"}, "no subnet"},
+		{"no subnet", Spec{Name: "Cameras", VLAN: 40}, "subnet is required"},
+		{"bad subnet", Spec{Name: "Cameras", VLAN: 40, Subnet: "10.0.40.1"}, "invalid subnet"},
+		{"network address", Spec{Name: "Cameras", VLAN: 40, Subnet: "10.0.40.0/24"}, "use 10.0.40.1/24"},
+		{"overlap", Spec{Name: "Cameras", VLAN: 40, Subnet: "10.0.0.1/16"}, "overlaps 10.0.30.1/24 of network IoT"},
+		{"range outside", Spec{Name: "Cameras", VLAN: 40, Subnet: "10.0.40.1/24", DHCPRange: "10.0.40.100-10.0.41.10"}, "not within"},
+		{"range reversed", Spec{Name: "Cameras", VLAN: 40, Subnet: "10.0.40.1/24", DHCPRange: "10.0.40.200-10.0.40.100"}, "ends before"},
+		{"range with gateway", Spec{Name: "Cameras", VLAN: 40, Subnet: "10.0.40.1/24", DHCPRange: "10.0.40.1-10.0.40.100"}, "includes the gateway"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.spec.Fields(testNetworks)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "network.yaml")
+	data := "name: Cameras\nvlan: 40\nsubnet: 10.0.40.1/24\ndhcp_range: 10.0.40.100-10.0.40.200\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("LoadSpec() returned error: %v", err)
+	}
+	if spec.Name != "Cameras" || spec.VLAN != 40 || spec.DHCPRange != "10.0.40.100-10.0.40.200" {
+		t.Errorf("Unexpected spec %+v", spec)
+	}
+}
@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBroker accepts one connection, acknowledges the CONNECT with code and
+// returns the packets it received
+func fakeBroker(t *testing.T, code byte) (string, <-chan []byte) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, 1024)
+		n, _ := conn.Read(buf)
+		connect := append([]byte(nil), buf[:n]...)
+		conn.Write([]byte{mqttConnack, 2, 0, code})
+
+		rest, _ := io.ReadAll(conn)
+		received <- append(connect, rest...)
+	}()
+
+	return "mqtt://" + listener.Addr().String(), received
+}
+
+func TestMQTT(t *testing.T) {
+	broker, received := fakeBroker(t, 0)
+
+	u := strings.Replace(broker, "mqtt://", "mqtt://home:secret@", 1)
+	if err := MQTT(u, "unifi/events", []byte(`{"key":"EVT_AP_Lost_Contact"}`), time.Second); err != nil {
+		t.Fatalf("MQTT() returned error: %v", err)
+	}
+
+	packets := string(<-received)
+	for _, want := range []string{"MQTT", "home", "secret", "unifi/events", `{"key":"EVT_AP_Lost_Contact"}`} {
+		if !strings.Contains(packets, want) {
+			t.Errorf("Expected %q in the packets, got %q", want, packets)
+		}
+	}
+	if packets[0] != mqttConnect || packets[len(packets)-2] != mqttDisconnect {
+		t.Errorf("Expected CONNECT first and DISCONNECT last, got %q", packets)
+	}
+}
+
+func TestMQTT_Refused(t *testing.T) {
+	broker, _ := fakeBroker(t, 5)
+
+	err := MQTT(broker, "unifi/events", []byte("{}"), time.Second)
+	if err == nil || !strings.Contains(err.Error(), "code 5") {
+		t.Errorf("Expected refused connection, got %v", err)
+	}
+}
+
+func TestMQTT_InvalidBroker(t *testing.T) {
+	if err := MQTT("https://broker", "t", nil, time.Second); err == nil {
+		t.Error("Expected error for a non-MQTT URL")
+	}
+}
+
+func TestMQTTPacket_RemainingLength(t *testing.T) {
+	packet := mqttPacket(mqttPublish, make([]byte, 321))
+	if packet[1] != 0xc1 || packet[2] != 0x02 || len(packet) != 3+321 {
+		t.Errorf("Unexpected header % x", packet[:3])
+	}
+}
+
+func TestMQTTTopic(t *testing.T) {
+	if got := MQTTTopic("unifi/events/", "", "EVT+#"); got != "unifi/events/EVT__" {
+		t.Errorf("MQTTTopic() = %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+// Package notify delivers event notifications to external systems.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Event is the JSON body posted to webhooks
+type Event struct {
+	Event string            `json:"event"`
+	Time  time.Time         `json:"time"`
+	Data  map[string]string `json:"data"`
+}
+
+// Webhook posts an event as JSON to url. Any 2xx response counts as delivered.
+func Webhook(url string, event Event, timeout time.Duration) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	return post("webhook", url, "application/json", body, nil, timeout)
+}
+
+// Slack posts a plain text message to a Slack (or compatible) incoming
+// webhook
+func Slack(url, text string, timeout time.Duration) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack payload: %w", err)
+	}
+
+	return post("Slack", url, "application/json", body, nil, timeout)
+}
+
+// Ntfy publishes a plain text message with a title to an ntfy topic URL such
+// as https://ntfy.sh/mytopic
+func Ntfy(url, title, text string, timeout time.Duration) error {
+	header := http.Header{}
+	if title != "" {
+		header.Set("Title", title)
+	}
+
+	return post("ntfy", url, "text/plain; charset=utf-8", []byte(text), header, timeout)
+}
+
+// post sends body to url. Any 2xx response counts as delivered.
+func post(sink, url, contentType string, body []byte, header http.Header, timeout time.Duration) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid %s URL: %w", sink, err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", sink, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned status %d: %s", sink, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
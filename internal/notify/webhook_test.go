@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhook(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	event := Event{Event: "test", Time: time.Unix(1700000000, 0).UTC(), Data: map[string]string{"ssid": "Guest"}}
+	if err := Webhook(server.URL, event, time.Second); err != nil {
+		t.Fatalf("Webhook() returned error: %v", err)
+	}
+
+	if received.Event != "test" || received.Data["ssid"] != "Guest" || !received.Time.Equal(event.Time) {
+		t.Errorf("Unexpected payload %+v", received)
+	}
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid token\n"))
+	}))
+	defer server.Close()
+
+	err := Webhook(server.URL, Event{Event: "test"}, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "status 400: invalid token") {
+		t.Errorf("Expected status error with body, got %v", err)
+	}
+}
+
+func TestSlack(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	if err := Slack(server.URL, "12 guests connected", time.Second); err != nil {
+		t.Fatalf("Slack() returned error: %v", err)
+	}
+	if received["text"] != "12 guests connected" {
+		t.Errorf("Unexpected payload %v", received)
+	}
+}
+
+func TestNtfy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guests" {
+			t.Errorf("Expected the topic path, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Title") != "Guest digest" {
+			t.Errorf("Expected the title header, got %q", r.Header.Get("Title"))
+		}
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "text/plain") {
+			t.Errorf("Expected a plain text body, got %s", r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "12 guests connected" {
+			t.Errorf("Unexpected body %q", body)
+		}
+	}))
+	defer server.Close()
+
+	if err := Ntfy(server.URL+"/guests", "Guest digest", "12 guests connected", time.Second); err != nil {
+		t.Fatalf("Ntfy() returned error: %v", err)
+	}
+}
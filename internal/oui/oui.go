@@ -0,0 +1,75 @@
+// Package oui is a small bundled database of MAC address prefixes (OUIs) of
+// vendors that commonly come up in network audits.
+package oui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Vendor is a manufacturer with the OUIs assigned to it
+type Vendor struct {
+	// Name is how the controller spells the vendor in its oui field
+	Name string
+	// Prefixes are lower-case OUIs, e.g. "b8:27:eb"
+	Prefixes []string
+}
+
+// vendors is keyed by the shortcut accepted on the command line. The lists
+// cover the common blocks, not every assignment; the controller's vendor name
+// catches the rest.
+var vendors = map[string]Vendor{
+	"apple": {Name: "Apple", Prefixes: []string{
+		"00:03:93", "00:0a:95", "00:17:f2", "00:1e:c2", "00:25:00", "28:cf:e9",
+		"3c:07:54", "60:fb:42", "7c:d1:c3", "88:66:a5", "a4:5e:60", "ac:bc:32",
+		"d0:23:db", "f0:18:98",
+	}},
+	"dahua": {Name: "Dahua", Prefixes: []string{
+		"14:a7:8b", "38:af:29", "3c:ef:8c", "4c:11:bf", "90:02:a9", "a0:bd:1d",
+		"bc:32:5f", "e0:50:8b",
+	}},
+	"espressif": {Name: "Espressif", Prefixes: []string{
+		"18:fe:34", "24:0a:c4", "24:6f:28", "2c:3a:e8", "30:ae:a4", "3c:71:bf",
+		"5c:cf:7f", "60:01:94", "68:c6:3a", "84:0d:8e", "84:f3:eb", "8c:aa:b5",
+		"98:f4:ab", "a4:cf:12", "bc:dd:c2", "c4:4f:33", "cc:50:e3", "dc:4f:22",
+		"e8:db:84", "ec:fa:bc",
+	}},
+	"hikvision": {Name: "Hikvision", Prefixes: []string{
+		"28:57:be", "44:19:b6", "4c:bd:8f", "54:c4:15", "58:03:fb", "8c:e7:48",
+		"a4:14:37", "bc:ad:28", "c0:56:e3", "c4:2f:90",
+	}},
+	"raspberrypi": {Name: "Raspberry Pi", Prefixes: []string{
+		"28:cd:c1", "2c:cf:67", "b8:27:eb", "d8:3a:dd", "dc:a6:32", "e4:5f:01",
+	}},
+}
+
+// Lookup returns the vendor for a shortcut such as "apple"
+func Lookup(shortcut string) (Vendor, error) {
+	vendor, ok := vendors[strings.ToLower(shortcut)]
+	if !ok {
+		return Vendor{}, fmt.Errorf("unknown vendor %q (valid options: %s)", shortcut, strings.Join(Shortcuts(), ", "))
+	}
+	return vendor, nil
+}
+
+// Shortcuts returns the known vendor shortcuts in alphabetical order
+func Shortcuts() []string {
+	names := make([]string, 0, len(vendors))
+	for name := range vendors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Matches reports whether a MAC address has one of the vendor's OUIs
+func (v Vendor) Matches(mac string) bool {
+	mac = strings.ToLower(mac)
+	for _, prefix := range v.Prefixes {
+		if strings.HasPrefix(mac, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package oui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	vendor, err := Lookup("RaspberryPi")
+	if err != nil {
+		t.Fatalf("Lookup() returned error: %v", err)
+	}
+	if vendor.Name != "Raspberry Pi" || !vendor.Matches("B8:27:EB:12:34:56") {
+		t.Errorf("Unexpected vendor %+v", vendor)
+	}
+	if vendor.Matches("aa:bb:cc:dd:ee:ff") {
+		t.Error("Expected a foreign MAC not to match")
+	}
+
+	if _, err := Lookup("acme"); err == nil || !strings.Contains(err.Error(), "apple, dahua") {
+		t.Errorf("Expected unknown vendor error listing shortcuts, got %v", err)
+	}
+}
+
+func TestVendors_Prefixes(t *testing.T) {
+	for shortcut, vendor := range vendors {
+		for _, prefix := range vendor.Prefixes {
+			if len(prefix) != 8 || prefix != strings.ToLower(prefix) || strings.Count(prefix, ":") != 2 {
+				t.Errorf("%s: malformed prefix %q", shortcut, prefix)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// severityColors colors alarm severities: high in red, medium in yellow
+var severityColors = map[string]string{
+	api.AlarmSeverityHigh:   colorRed,
+	api.AlarmSeverityMedium: colorYellow,
+}
+
+// PrintAlarmsTable lists alarms with their severity in color when colors are
+// enabled (see ColorEnabled). The Archived column is only shown when an
+// archived alarm is listed.
+func PrintAlarmsTable(alarms []api.Alarm) {
+	var archived bool
+	for _, a := range alarms {
+		archived = archived || a.Archived
+	}
+
+	header := []string{"ID", "Time", "Severity", "Alarm", "Device", "Message"}
+	if archived {
+		header = append(header, "Archived")
+	}
+	table := newTable(header)
+
+	for _, a := range alarms {
+		severity := a.Severity()
+		if color, ok := severityColors[severity]; ok {
+			severity = colorize(color, severity)
+		}
+
+		row := []string{
+			a.ID,
+			a.GetTime().Local().Format("2006-01-02 15:04:05"),
+			severity,
+			a.Key,
+			a.Device(),
+			a.Msg,
+		}
+		if archived {
+			row = append(row, yesNo(a.Archived))
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+}
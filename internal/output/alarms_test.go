@@ -0,0 +1,48 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintAlarmsTable(t *testing.T) {
+	forceColors(t)
+
+	alarms := []api.Alarm{
+		{ID: "a1", Key: "EVT_AP_Lost_Contact", Msg: "AP lost contact", Time: 1768211000000, APName: "Office AP"},
+		{ID: "a2", Key: "EVT_IPS_IpsAlert", Msg: "IPS alert", Time: 1768212000000, InnerAlertSeverity: 3},
+	}
+
+	out := captureStdout(t, func() { PrintAlarmsTable(alarms) })
+
+	for _, want := range []string{"Severity", "a1", "EVT_AP_Lost_Contact", "Office AP", "AP lost contact", colorRed + "high" + colorReset, "a2", "low"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Archived") {
+		t.Errorf("Expected no Archived column without archived alarms:\n%s", out)
+	}
+
+	alarms[1].Archived = true
+	out = captureStdout(t, func() { PrintAlarmsTable(alarms) })
+	if !strings.Contains(out, "Archived") || !strings.Contains(out, "yes") {
+		t.Errorf("Expected an Archived column:\n%s", out)
+	}
+}
+
+func TestPrintAlarmsTable_NoColor(t *testing.T) {
+	forceColors(t)
+	t.Setenv("NO_COLOR", "1")
+
+	alarms := []api.Alarm{
+		{ID: "a1", Key: "EVT_AP_Lost_Contact", Msg: "AP lost contact", Time: 1768211000000},
+	}
+
+	out := captureStdout(t, func() { PrintAlarmsTable(alarms) })
+	if !strings.Contains(out, "high") || strings.Contains(out, "\033[") {
+		t.Errorf("Expected the severity without colors:\n%s", out)
+	}
+}
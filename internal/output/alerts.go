@@ -0,0 +1,24 @@
+package output
+
+import (
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintAlertSettingsTable lists the alert categories with their email and
+// push notification state
+func PrintAlertSettingsTable(settings []api.AlertSetting) {
+	table := newTable([]string{"Category", "Email", "Push"})
+
+	for _, setting := range settings {
+		table.Append([]string{setting.Key, onOff(setting.EmailEnabled), onOff(setting.PushEnabled)})
+	}
+
+	table.Render()
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,22 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintAlertSettingsTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintAlertSettingsTable([]api.AlertSetting{
+			{Key: "EVT_AP_Lost_Contact", EmailEnabled: true, PushEnabled: false},
+		})
+	})
+
+	for _, want := range []string{"EVT_AP_Lost_Contact", "on", "off"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/audit"
+)
+
+// PrintFindingsTable prints audit findings, most severe first
+func PrintFindingsTable(findings []audit.Finding) {
+	table := newTable([]string{"Severity", "Target", "Check", "Finding"})
+
+	for _, f := range findings {
+		table.Append([]string{strings.ToUpper(f.Severity), f.Target, f.Check, f.Message})
+	}
+
+	table.Render()
+}
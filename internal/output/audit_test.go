@@ -0,0 +1,24 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/audit"
+)
+
+func TestPrintFindingsTable(t *testing.T) {
+	findings := []audit.Finding{
+		{Severity: audit.SeverityCritical, Target: "Ancient", Check: "wep", Message: "WEP encryption is broken"},
+	}
+
+	output := captureStdout(t, func() {
+		PrintFindingsTable(findings)
+	})
+
+	for _, want := range []string{"Severity", "CRITICAL", "Ancient", "wep", "WEP encryption is broken"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
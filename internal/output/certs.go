@@ -0,0 +1,30 @@
+package output
+
+import (
+	"strconv"
+
+	"github.com/nkn/unifi-cli/internal/certcheck"
+)
+
+// PrintCertificatesTable prints certificate checks with their expiry and
+// status; sources that could not be checked show the error instead
+func PrintCertificatesTable(results []certcheck.Result) {
+	table := newTable([]string{"Status", "Name", "Source", "Subject", "Expires", "Days Left"})
+
+	for _, r := range results {
+		if r.Error != "" {
+			table.Append([]string{r.Status.String(), r.Name, r.Source, r.Error, "", ""})
+			continue
+		}
+		table.Append([]string{
+			r.Status.String(),
+			r.Name,
+			r.Source,
+			r.Subject,
+			r.NotAfter.Local().Format("2006-01-02"),
+			strconv.Itoa(r.DaysLeft),
+		})
+	}
+
+	table.Render()
+}
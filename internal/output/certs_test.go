@@ -0,0 +1,25 @@
+package output
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/certcheck"
+)
+
+func TestPrintCertificatesTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintCertificatesTable([]certcheck.Result{
+			{Name: "Hotspot portal", Source: "unifi.example.com:8843", Subject: "unifi.example.com", NotAfter: time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC), DaysLeft: 12, Status: certcheck.Warning},
+			certcheck.Failed("RadSec", "radius:2083", errors.New("connection refused")),
+		})
+	})
+
+	for _, want := range []string{"Days Left", "WARNING", "Hotspot portal", "unifi.example.com:8843", "2026-04-01", "12", "UNKNOWN", "connection refused"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
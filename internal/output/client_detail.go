@@ -0,0 +1,164 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/location"
+)
+
+// PrintClientDetails prints every known field of a single client as key/value
+// pairs. Fields that do not apply (e.g. radio details of wired clients) are
+// left out.
+func PrintClientDetails(client *api.Client) {
+	details := []Detail{
+		{Key: "Name", Value: client.GetDisplayName()},
+		{Key: "Alias", Value: client.Name},
+		{Key: "Hostname", Value: client.Hostname},
+		{Key: "MAC", Value: client.MAC},
+		{Key: "Vendor", Value: client.OUI},
+		{Key: "IP", Value: client.IP},
+		{Key: "Fixed IP", Value: fixedIP(client)},
+		{Key: "Network", Value: client.GetNetworkName()},
+		{Key: "User Group", Value: client.UsergroupName},
+		{Key: "Type", Value: client.GetConnectionType()},
+		{Key: "SSID", Value: client.GetSSID()},
+		{Key: "BSSID", Value: client.BSSID},
+		{Key: "AP MAC", Value: client.ApMAC},
+		{Key: "Radio", Value: joinNonEmpty(client.Radio, client.RadioProto)},
+		{Key: "Channel", Value: nonZero(client.Channel)},
+		{Key: "Signal", Value: client.GetSignal()},
+		{Key: "RSSI", Value: nonZero(client.RSSI)},
+		{Key: "Noise", Value: dBm(client.Noise)},
+		{Key: "TX Rate", Value: rate(client.TxRate)},
+		{Key: "RX Rate", Value: rate(client.RxRate)},
+		{Key: "Satisfaction", Value: satisfaction(client)},
+		{Key: "Switch MAC", Value: client.SWMAC},
+		{Key: "Switch Port", Value: nonZero(client.SWPort)},
+		{Key: "Uptime", Value: client.GetUptime()},
+		{Key: "Associated", Value: timestamp(client.AssocTime)},
+		{Key: "Last Seen", Value: timestamp(client.LastSeen)},
+		{Key: "RX/TX", Value: api.FormatBytes(client.RxBytes) + " / " + api.FormatBytes(client.TxBytes)},
+		{Key: "RX/TX Packets", Value: fmt.Sprintf("%d / %d", client.RxPackets, client.TxPackets)},
+		{Key: "RX/TX Rate", Value: api.FormatBytes(int64(client.RxBytesR)) + "/s / " + api.FormatBytes(int64(client.TxBytesR)) + "/s"},
+		{Key: "Blocked", Value: strconv.FormatBool(client.Blocked)},
+		{Key: "QoS Policy", Value: yesNo(client.QOSPolicyApplied)},
+		{Key: "Note", Value: client.Note},
+		{Key: "User ID", Value: client.UserID},
+	}
+
+	PrintDetails(details)
+}
+
+// PrintOfflineClientDetails prints the known-client record of a client that
+// is not connected, with where it was last seen (if known)
+func PrintOfflineClientDetails(user *api.User, loc *location.Location) {
+	name := user.Name
+	if name == "" {
+		name = user.Hostname
+	}
+	if name == "" {
+		name = user.MAC
+	}
+
+	fixed := ""
+	if user.UseFixedIP {
+		fixed = user.FixedIP
+	}
+
+	connection := "Wireless"
+	if user.IsWired {
+		connection = "Wired"
+	}
+
+	details := []Detail{
+		{Key: "Name", Value: name},
+		{Key: "Alias", Value: user.Name},
+		{Key: "Hostname", Value: user.Hostname},
+		{Key: "MAC", Value: user.MAC},
+		{Key: "Vendor", Value: user.OUI},
+		{Key: "Status", Value: "offline"},
+		{Key: "Type", Value: connection},
+		{Key: "Fixed IP", Value: fixed},
+		{Key: "First Seen", Value: timestamp(user.FirstSeen)},
+		{Key: "Last Seen", Value: timestamp(user.LastSeen)},
+	}
+	if loc != nil {
+		details = append(details,
+			Detail{Key: "Last Location", Value: loc.String()},
+			Detail{Key: "Located", Value: fmt.Sprintf("%s (from %s)", loc.Time.Local().Format("2006-01-02 15:04:05"), loc.Source)},
+		)
+	} else {
+		details = append(details, Detail{Key: "Last Location", Value: "unknown"})
+	}
+	details = append(details,
+		Detail{Key: "Blocked", Value: strconv.FormatBool(user.Blocked)},
+		Detail{Key: "Note", Value: user.Note},
+		Detail{Key: "User ID", Value: user.ID},
+	)
+
+	PrintDetails(details)
+}
+
+func fixedIP(client *api.Client) string {
+	if !client.UseFixedIP {
+		return ""
+	}
+	return client.FixedIP
+}
+
+func satisfaction(client *api.Client) string {
+	if client.IsWired || client.Satisfaction == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", client.Satisfaction)
+}
+
+func nonZero(v int) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
+
+func dBm(v int) string {
+	if v == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d dBm", v)
+}
+
+// rate formats a link rate reported by the controller in Kbps
+func rate(kbps int) string {
+	if kbps == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d Mbps", kbps/1000)
+}
+
+func timestamp(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).Local().Format("2006-01-02 15:04:05")
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return ""
+}
+
+func joinNonEmpty(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return a + " (" + b + ")"
+	}
+}
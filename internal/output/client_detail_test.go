@@ -0,0 +1,94 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/location"
+)
+
+func TestPrintClientDetails_Wireless(t *testing.T) {
+	client := &api.Client{
+		MAC:          "11:22:33:44:55:66",
+		Name:         "WirelessDevice",
+		IP:           "192.168.1.101",
+		Essid:        "MyWiFi",
+		Signal:       -65,
+		Noise:        -95,
+		TxRate:       866700,
+		Satisfaction: 97,
+		ApMAC:        "f0:00:00:00:00:01",
+		Note:         "Kitchen tablet",
+		Uptime:       7200,
+	}
+
+	output := captureStdout(t, func() {
+		PrintClientDetails(client)
+	})
+
+	for _, want := range []string{"WirelessDevice", "MyWiFi", "-65 dBm", "-95 dBm", "866 Mbps", "97%", "f0:00:00:00:00:01", "Kitchen tablet", "2h"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Switch Port") {
+		t.Error("Expected switch details to be omitted for a wireless client")
+	}
+}
+
+func TestPrintClientDetails_Wired(t *testing.T) {
+	client := &api.Client{
+		MAC:        "aa:bb:cc:dd:ee:ff",
+		Hostname:   "nas",
+		IsWired:    true,
+		SWMAC:      "f0:00:00:00:00:02",
+		SWPort:     7,
+		UseFixedIP: true,
+		FixedIP:    "192.168.1.20",
+	}
+
+	output := captureStdout(t, func() {
+		PrintClientDetails(client)
+	})
+
+	for _, want := range []string{"nas", "Wired", "f0:00:00:00:00:02", "Switch Port", "192.168.1.20"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"SSID", "Signal", "Satisfaction"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected %q to be omitted for a wired client", unwanted)
+		}
+	}
+}
+
+func TestPrintOfflineClientDetails(t *testing.T) {
+	user := &api.User{MAC: "aa:bb:cc:dd:ee:01", Hostname: "laptop", LastSeen: 1700000000}
+	loc := &location.Location{
+		Time:       time.Unix(1700000000, 0),
+		Source:     location.SourceEvents,
+		Switch:     "f0:00:00:00:00:02",
+		SwitchName: "Core Switch",
+		SwitchPort: 5,
+	}
+
+	output := captureStdout(t, func() {
+		PrintOfflineClientDetails(user, loc)
+	})
+
+	for _, want := range []string{"laptop", "offline", "Core Switch (f0:00:00:00:00:02) port 5", "from events"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output = captureStdout(t, func() {
+		PrintOfflineClientDetails(user, nil)
+	})
+	if !strings.Contains(output, "unknown") {
+		t.Errorf("Expected an unknown location, got:\n%s", output)
+	}
+}
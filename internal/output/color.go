@@ -0,0 +1,55 @@
+package output
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	colorGreen  = "\033[32m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorDim    = "\033[2m"
+	colorReset  = "\033[0m"
+)
+
+// minimal is set by SetMinimal
+var minimal bool
+
+// isTerminal reports whether stdout is a terminal; tests replace it
+var isTerminal = func() bool {
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// SetMinimal switches output to minimal mode: no colors, and tables that
+// stream early through small buffers
+func SetMinimal(on bool) {
+	minimal = on
+	if on {
+		streamChunk = minimalStreamChunk
+		streamBufferSize = minimalStreamBufferSize
+	}
+}
+
+// ColorEnabled reports whether output may contain colors: not in minimal
+// mode, not when NO_COLOR is set (https://no-color.org) and only when stdout
+// is a terminal
+func ColorEnabled() bool {
+	if minimal {
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal()
+}
+
+// colorize wraps s in color when colors are enabled
+func colorize(color, s string) string {
+	if !ColorEnabled() {
+		return s
+	}
+	return color + s + colorReset
+}
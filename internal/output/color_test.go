@@ -0,0 +1,56 @@
+package output
+
+import (
+	"testing"
+)
+
+// forceColors makes ColorEnabled report true for the rest of the test, as if
+// stdout were a terminal
+func forceColors(t *testing.T) {
+	t.Helper()
+
+	previous := isTerminal
+	isTerminal = func() bool { return true }
+	t.Setenv("NO_COLOR", "")
+	t.Cleanup(func() { isTerminal = previous })
+}
+
+func TestColorEnabled(t *testing.T) {
+	forceColors(t)
+	if !ColorEnabled() {
+		t.Fatal("Expected colors on a terminal")
+	}
+	if got := colorize(colorRed, "x"); got != colorRed+"x"+colorReset {
+		t.Errorf("Expected colored text, got %q", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled() {
+		t.Error("Expected no colors with NO_COLOR set")
+	}
+	t.Setenv("NO_COLOR", "")
+
+	isTerminal = func() bool { return false }
+	if ColorEnabled() {
+		t.Error("Expected no colors when stdout is not a terminal")
+	}
+	if got := colorize(colorRed, "x"); got != "x" {
+		t.Errorf("Expected plain text, got %q", got)
+	}
+}
+
+func TestSetMinimal(t *testing.T) {
+	forceColors(t)
+	chunk, size := streamChunk, streamBufferSize
+	t.Cleanup(func() {
+		minimal, streamChunk, streamBufferSize = false, chunk, size
+	})
+
+	SetMinimal(true)
+	if ColorEnabled() {
+		t.Error("Expected no colors in minimal mode")
+	}
+	if streamChunk != minimalStreamChunk || streamBufferSize != minimalStreamBufferSize {
+		t.Errorf("Expected small stream buffers, got chunk %d and buffer %d", streamChunk, streamBufferSize)
+	}
+}
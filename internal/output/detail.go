@@ -0,0 +1,26 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// Detail is a single labelled value in a key/value view
+type Detail struct {
+	Key   string
+	Value string
+}
+
+// PrintDetails renders details as aligned "Key: value" lines, skipping
+// entries with an empty value
+func PrintDetails(details []Detail) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, d := range details {
+		if d.Value == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\t%s\n", d.Key, d.Value)
+	}
+	w.Flush()
+}
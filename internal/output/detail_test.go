@@ -0,0 +1,69 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout runs fn and returns everything it wrote to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+
+	fn()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+func TestPrintDetails(t *testing.T) {
+	output := captureStdout(t, func() {
+		PrintDetails([]Detail{
+			{Key: "Name", Value: "TestDevice"},
+			{Key: "IP Address", Value: "192.168.1.100"},
+			{Key: "Note", Value: ""},
+		})
+	})
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines (empty values skipped), got %d: %q", len(lines), output)
+	}
+
+	// Values should be aligned in a single column
+	first := strings.Index(lines[0], "TestDevice")
+	second := strings.Index(lines[1], "192.168.1.100")
+	if first != second {
+		t.Errorf("Expected values to be aligned, got offsets %d and %d", first, second)
+	}
+
+	if strings.Contains(output, "Note") {
+		t.Error("Expected empty detail to be skipped")
+	}
+}
+
+func TestPrintJSON(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := PrintJSON(map[string]int{"count": 3}); err != nil {
+			t.Errorf("PrintJSON() returned error: %v", err)
+		}
+	})
+
+	expected := "{\n  \"count\": 3\n}\n"
+	if output != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+}
@@ -0,0 +1,154 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var devicesTableHeader = []string{"Name", "Model", "IP", "Version", "Uptime", "State", "Clients"}
+
+func PrintDevicesTable(devices []api.Device) {
+	table := newTable(devicesTableHeader)
+
+	for _, device := range devices {
+		table.Append(deviceRow(device))
+	}
+
+	table.Render()
+}
+
+func deviceRow(device api.Device) []string {
+	uptime := ""
+	if device.Uptime > 0 {
+		uptime = api.FormatUptime(device.Uptime)
+	}
+
+	return []string{
+		fmt.Sprintf("%s (%s)", device.GetDisplayName(), device.MAC),
+		device.Model,
+		device.IP,
+		device.Version,
+		uptime,
+		device.GetState(),
+		strconv.Itoa(device.NumSta),
+	}
+}
+
+// PrintDevicesDetailsTable is PrintDevicesTable with the resource usage and
+// uplink of hydrated devices
+func PrintDevicesDetailsTable(devices []api.Device) {
+	table := newTable(append(devicesTableHeader, "CPU", "Mem", "Uplink"))
+
+	for _, device := range devices {
+		cpu, mem := "", ""
+		if device.SystemStats != nil {
+			cpu = percent(device.SystemStats.CPU)
+			mem = percent(device.SystemStats.Mem)
+		}
+
+		table.Append(append(deviceRow(device), cpu, mem, formatUplink(device.Uplink)))
+	}
+
+	table.Render()
+}
+
+// percent formats a decimal string percentage, e.g. "12.3%"
+func percent(value string) string {
+	if value == "" {
+		return ""
+	}
+	return value + "%"
+}
+
+// formatUplink describes how a device is connected, e.g. "1G wired" or "mesh"
+func formatUplink(uplink *api.Uplink) string {
+	switch {
+	case uplink == nil:
+		return ""
+	case uplink.Type == "wireless":
+		return "mesh"
+	case uplink.Speed >= 1000:
+		return fmt.Sprintf("%dG wired", uplink.Speed/1000)
+	case uplink.Speed > 0:
+		return fmt.Sprintf("%dM wired", uplink.Speed)
+	}
+	return uplink.Type
+}
+
+// DeviceRename is a planned change of a device's name
+type DeviceRename struct {
+	MAC   string
+	Model string
+	From  string
+	To    string
+}
+
+// PrintDeviceRenames shows the current and the new name of each device
+func PrintDeviceRenames(renames []DeviceRename) {
+	table := newTable([]string{"MAC", "Model", "Current Name", "New Name"})
+
+	for _, rename := range renames {
+		table.Append([]string{rename.MAC, rename.Model, rename.From, rename.To})
+	}
+
+	table.Render()
+}
+
+// PrintRadiosWatchTable prints one row per access point radio with its
+// channel utilization. Compared to the previous refresh, a changed channel is
+// shown in yellow with the old one, and utilization and interference with
+// their change.
+func PrintRadiosWatchTable(devices []api.Device, previous []api.Device) {
+	last := map[string]api.RadioStats{}
+	for _, device := range previous {
+		for _, radio := range device.RadioStats {
+			last[device.MAC+"/"+radio.Name] = radio
+		}
+	}
+
+	table := newTable([]string{"AP", "Band", "Channel", "Utilization", "Interference", "Self Rx/Tx", "Clients", "TX Power"})
+
+	for _, device := range devices {
+		for _, radio := range device.RadioStats {
+			channel := strconv.Itoa(radio.Channel)
+			utilization := fmt.Sprintf("%d%%", radio.CUTotal)
+			interference := fmt.Sprintf("%d%%", radio.Interference())
+			band := radio.Radio
+			if radio.GetBand() != "" {
+				band = api.BandName(radio.GetBand())
+			}
+
+			if before, ok := last[device.MAC+"/"+radio.Name]; ok {
+				if before.Channel != radio.Channel {
+					channel = colorize(colorYellow, fmt.Sprintf("%d (was %d)", radio.Channel, before.Channel))
+				}
+				utilization += formatChange(radio.CUTotal - before.CUTotal)
+				interference += formatChange(radio.Interference() - before.Interference())
+			}
+
+			table.Append([]string{
+				device.GetDisplayName(),
+				band,
+				channel,
+				utilization,
+				interference,
+				fmt.Sprintf("%d%% / %d%%", radio.CUSelfRx, radio.CUSelfTx),
+				strconv.Itoa(radio.NumSta),
+				fmt.Sprintf("%d dBm", radio.TxPower),
+			})
+		}
+	}
+
+	table.Render()
+}
+
+// formatChange formats a change in percentage points, empty when there is
+// none
+func formatChange(delta int) string {
+	if delta == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%+d)", delta)
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintDevicesTable(t *testing.T) {
+	devices := []api.Device{
+		{MAC: "f0:9f:c2:00:00:01", Name: "Office AP", Model: "U7PG2", IP: "192.168.1.2", Version: "6.6.77", State: 1, Uptime: 90000, NumSta: 12},
+		{MAC: "f0:9f:c2:00:00:02", Model: "USW24", State: 0},
+	}
+
+	output := captureStdout(t, func() {
+		PrintDevicesTable(devices)
+	})
+
+	for _, expected := range []string{"Model", "Clients", "Office AP (f0:9f:c2:00:00:01)", "U7PG2", "6.6.77", "1d 1h", "connected", "12", "USW24 (f0:9f:c2:00:00:02)", "disconnected"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPrintDevicesDetailsTable(t *testing.T) {
+	devices := []api.Device{
+		{MAC: "f0:9f:c2:00:00:01", Name: "Office AP", State: 1, SystemStats: &api.SystemStats{CPU: "12.5", Mem: "40.1"}, Uplink: &api.Uplink{Type: "wire", Speed: 1000}},
+		{MAC: "f0:9f:c2:00:00:02", Name: "Garden AP", State: 1, Uplink: &api.Uplink{Type: "wireless"}},
+		{MAC: "f0:9f:c2:00:00:03", Name: "Switch", State: 1, Uplink: &api.Uplink{Type: "wire", Speed: 100}},
+	}
+
+	output := captureStdout(t, func() {
+		PrintDevicesDetailsTable(devices)
+	})
+
+	for _, expected := range []string{"CPU", "Mem", "Uplink", "Office AP (f0:9f:c2:00:00:01)", "12.5%", "40.1%", "1G wired", "mesh", "100M wired"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPrintDeviceRenames(t *testing.T) {
+	renames := []DeviceRename{
+		{MAC: "f0:9f:c2:00:00:01", Model: "U7PG2", From: "Office AP", To: "U7PG2-hq-01"},
+	}
+
+	output := captureStdout(t, func() {
+		PrintDeviceRenames(renames)
+	})
+
+	for _, expected := range []string{"Current Name", "New Name", "f0:9f:c2:00:00:01", "Office AP", "U7PG2-hq-01"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPrintRadiosWatchTable(t *testing.T) {
+	previous := []api.Device{
+		{MAC: "f0:9f:c2:00:00:01", Name: "Office AP", RadioStats: []api.RadioStats{
+			{Name: "wifi0", Radio: "ng", Channel: 1, CUTotal: 70, CUSelfRx: 10, CUSelfTx: 10},
+		}},
+	}
+	devices := []api.Device{
+		{MAC: "f0:9f:c2:00:00:01", Name: "Office AP", RadioStats: []api.RadioStats{
+			{Name: "wifi0", Radio: "ng", Channel: 11, CUTotal: 40, CUSelfRx: 10, CUSelfTx: 10, NumSta: 7, TxPower: 17},
+			{Name: "wifi1", Radio: "na", Channel: 36, CUTotal: 12, CUSelfRx: 6, CUSelfTx: 4},
+		}},
+	}
+
+	output := captureStdout(t, func() {
+		PrintRadiosWatchTable(devices, previous)
+	})
+
+	for _, expected := range []string{"Utilization", "Interference", "Office AP", "2.4 GHz", "11 (was 1)", "40% (-30)", "20% (-30)", "10% / 10%", "17 dBm", "5 GHz", "12%"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
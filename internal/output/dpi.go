@@ -0,0 +1,104 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/dpi"
+)
+
+func dpiHeader(byCategory bool) []string {
+	if byCategory {
+		return []string{"Category", "Apps", "RX", "TX", "Total"}
+	}
+	return []string{"Application", "Category", "RX", "TX", "Total", "Clients"}
+}
+
+func dpiRow(u dpi.Usage, byCategory bool, bytes func(int64) string) []string {
+	if byCategory {
+		return []string{u.Name, strconv.Itoa(u.Apps), bytes(u.RxBytes), bytes(u.TxBytes), bytes(u.Total())}
+	}
+	return []string{u.Name, u.Category, bytes(u.RxBytes), bytes(u.TxBytes), bytes(u.Total()), nonZero(u.Clients)}
+}
+
+// PrintDPITable lists DPI traffic by application or by category. The clients
+// column is left out when no application has a client count, as for the
+// traffic of a single client.
+func PrintDPITable(usages []dpi.Usage, byCategory bool) {
+	withClients := false
+	for _, u := range usages {
+		withClients = withClients || u.Clients > 0
+	}
+
+	header := dpiHeader(byCategory)
+	if !byCategory && !withClients {
+		header = header[:len(header)-1]
+	}
+	table := newTable(header)
+
+	for _, u := range usages {
+		table.Append(dpiRow(u, byCategory, api.FormatBytes)[:len(header)])
+	}
+
+	table.Render()
+}
+
+// PrintDPICSV writes DPI traffic as CSV with a header row. Traffic is in
+// bytes, for further processing.
+func PrintDPICSV(usages []dpi.Usage, byCategory bool) error {
+	w := csv.NewWriter(os.Stdout)
+
+	if err := w.Write(dpiHeader(byCategory)); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, u := range usages {
+		if err := w.Write(dpiRow(u, byCategory, formatInt64)); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+func formatInt64(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+// PrintAppBlocksTable lists the traffic rules that block applications, with
+// the applications and clients they block. names maps client MACs and
+// network IDs to names.
+func PrintAppBlocksTable(rules []api.TrafficRule, names map[string]string) {
+	table := newTable([]string{"ID", "Description", "Applications", "Clients", "Enabled"})
+
+	name := func(id string) string {
+		if n, ok := names[id]; ok {
+			return n
+		}
+		return id
+	}
+
+	for _, r := range rules {
+		apps := make([]string, len(r.AppIDs))
+		for i, id := range r.AppIDs {
+			apps[i] = dpi.AppIDName(id)
+		}
+
+		table.Append([]string{
+			r.ID,
+			r.Description,
+			strings.Join(apps, ", "),
+			formatRouteClients(r.TargetDevices, name),
+			onOff(r.Enabled),
+		})
+	}
+
+	table.Render()
+}
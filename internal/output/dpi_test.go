@@ -0,0 +1,78 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/dpi"
+)
+
+var testDPIApps = []dpi.Usage{
+	{Name: "Netflix", Category: "Media streaming services", Cat: 4, App: 94, RxBytes: 2097152, TxBytes: 1024, Clients: 3},
+	{Name: "Web services #5", Category: "Web services", Cat: 13, App: 5, RxBytes: 40, TxBytes: 60},
+}
+
+func TestPrintDPITable(t *testing.T) {
+	output := captureStdout(t, func() {
+		PrintDPITable(testDPIApps, false)
+	})
+
+	for _, expected := range []string{"Application", "Clients", "Netflix", "Media streaming services", "2.00 MB", "3"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	categories := []dpi.Usage{{Name: "Web services", Category: "Web services", Cat: 13, App: -1, RxBytes: 40, TxBytes: 60, Apps: 4}}
+	output = captureStdout(t, func() {
+		PrintDPITable(categories, true)
+	})
+	if !strings.Contains(output, "Apps") || !strings.Contains(output, "Web services") || strings.Contains(output, "Clients") {
+		t.Errorf("Unexpected category table:\n%s", output)
+	}
+}
+
+func TestPrintDPITable_WithoutClients(t *testing.T) {
+	output := captureStdout(t, func() {
+		PrintDPITable([]dpi.Usage{{Name: "Netflix", Category: "Media streaming services", RxBytes: 10}}, false)
+	})
+
+	if strings.Contains(output, "Clients") || !strings.Contains(output, "Netflix") {
+		t.Errorf("Expected no clients column, got:\n%s", output)
+	}
+}
+
+func TestPrintDPICSV(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := PrintDPICSV(testDPIApps, false); err != nil {
+			t.Errorf("PrintDPICSV() returned error: %v", err)
+		}
+	})
+
+	expected := "Application,Category,RX,TX,Total,Clients\n" +
+		"Netflix,Media streaming services,2097152,1024,2098176,3\n" +
+		"Web services #5,Web services,40,60,100,\n"
+	if output != expected {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expected, output)
+	}
+}
+
+func TestPrintAppBlocksTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintAppBlocksTable([]api.TrafficRule{
+			{
+				ID: "r1", Description: "No torrents", Enabled: true,
+				AppIDs:        []int{dpi.AppID(1, 1), dpi.AppID(13, 200)},
+				TargetDevices: []api.RouteTargetDevice{{Type: api.RouteDeviceClient, ClientMAC: "AA:BB:CC:00:00:01"}},
+			},
+			{ID: "r2", Description: "Streaming", AppIDs: []int{dpi.AppID(4, 94)}},
+		}, map[string]string{"aa:bb:cc:00:00:01": "laptop"})
+	})
+
+	for _, want := range []string{"Applications", "r1", "No torrents", "BitTorrent, ", "laptop", "on", "Streaming", "Netflix", "all", "off"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/report"
+)
+
+// PrintEventsTable prints controller events, newest first as returned
+func PrintEventsTable(events []api.Event) {
+	table := newTable([]string{"Time", "Event", "Device", "Channel", "Message"})
+
+	for _, e := range events {
+		device := e.APName
+		if e.AP != "" {
+			device = strings.TrimSpace(fmt.Sprintf("%s (%s)", e.APName, e.AP))
+		}
+
+		channel := ""
+		if c := e.GetChannel(); c > 0 {
+			channel = strconv.Itoa(c)
+		}
+
+		table.Append([]string{
+			e.GetTime().Local().Format("2006-01-02 15:04:05"),
+			e.Key,
+			device,
+			channel,
+			e.Msg,
+		})
+	}
+
+	table.Render()
+}
+
+// PrintReceivedEvent prints a pushed event as a single log line
+func PrintReceivedEvent(e api.Event) {
+	line := fmt.Sprintf("%s  %s", e.GetTime().Local().Format("2006-01-02 15:04:05"), e.Key)
+	if e.AP != "" {
+		line += " " + e.AP
+	}
+	if e.Msg != "" && e.Msg != e.Key {
+		line += "  " + e.Msg
+	}
+	fmt.Println(line)
+}
+
+// PrintDFSReport prints DFS radar hits per AP and channel, with the number of
+// hits on each day they occurred
+func PrintDFSReport(summary []report.DFSHits) {
+	table := newTable([]string{"AP", "Channel", "Hits", "First", "Last", "Hits per Day"})
+
+	for _, hits := range summary {
+		days := make([]string, 0, len(hits.Days))
+		for day := range hits.Days {
+			days = append(days, day)
+		}
+		sort.Strings(days)
+
+		perDay := make([]string, len(days))
+		for i, day := range days {
+			perDay[i] = fmt.Sprintf("%s: %d", day[5:], hits.Days[day])
+		}
+
+		name := hits.APName
+		if name == "" {
+			name = hits.AP
+		}
+
+		channel := "?"
+		if hits.Channel > 0 {
+			channel = strconv.Itoa(hits.Channel)
+		}
+
+		table.Append([]string{
+			name,
+			channel,
+			strconv.Itoa(hits.Hits),
+			hits.First.Local().Format("2006-01-02 15:04"),
+			hits.Last.Local().Format("2006-01-02 15:04"),
+			strings.Join(perDay, ", "),
+		})
+	}
+
+	table.Render()
+}
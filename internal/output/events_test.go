@@ -0,0 +1,60 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/report"
+)
+
+func TestPrintEventsTable(t *testing.T) {
+	when := time.Date(2026, 1, 12, 10, 0, 3, 0, time.Local)
+
+	out := captureStdout(t, func() {
+		PrintEventsTable([]api.Event{
+			{Key: "EVT_AP_RadarDetected", Msg: "radar detected", AP: "f0:9f:c2:00:00:01", APName: "Office AP", RawChannel: json.RawMessage(`100`), Time: when.UnixMilli()},
+			{Key: "EVT_WU_Connected", Msg: "User connected", Time: when.UnixMilli()},
+		})
+	})
+
+	for _, want := range []string{"2026-01-12 10:00:03", "EVT_AP_RadarDetected", "Office AP (f0:9f:c2:00:00:01)", "100", "User connected"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintReceivedEvent(t *testing.T) {
+	when := time.Date(2026, 1, 12, 10, 0, 3, 0, time.Local)
+
+	out := captureStdout(t, func() {
+		PrintReceivedEvent(api.Event{Key: "device_offline", Msg: "Gateway offline", AP: "f0:9f:c2:00:00:01", Time: when.UnixMilli()})
+		PrintReceivedEvent(api.Event{Key: "Test alarm", Msg: "Test alarm", Time: when.UnixMilli()})
+	})
+
+	expected := "2026-01-12 10:00:03  device_offline f0:9f:c2:00:00:01  Gateway offline\n" +
+		"2026-01-12 10:00:03  Test alarm\n"
+	if out != expected {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestPrintDFSReport(t *testing.T) {
+	first := time.Date(2026, 1, 12, 10, 0, 0, 0, time.Local)
+
+	out := captureStdout(t, func() {
+		PrintDFSReport([]report.DFSHits{
+			{AP: "f0:9f:c2:00:00:01", APName: "Office AP", Channel: 100, Hits: 3, First: first, Last: first.Add(24 * time.Hour), Days: map[string]int{"2026-01-13": 1, "2026-01-12": 2}},
+			{AP: "f0:9f:c2:00:00:02", Hits: 1, First: first, Last: first, Days: map[string]int{"2026-01-12": 1}},
+		})
+	})
+
+	for _, want := range []string{"Office AP", "100", "01-12: 2, 01-13: 1", "f0:9f:c2:00:00:02", "?"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
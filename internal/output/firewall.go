@@ -0,0 +1,128 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintFirewallRulesTable lists firewall rules with their sources and
+// destinations. names maps firewall group and network IDs to their names.
+func PrintFirewallRulesTable(rules []api.FirewallRule, names map[string]string) {
+	table := newTable([]string{"ID", "Ruleset", "Index", "Name", "Action", "Protocol", "Source", "Destination", "Enabled"})
+
+	for _, r := range rules {
+		protocol := r.Protocol
+		if protocol == "" {
+			protocol = "all"
+		}
+
+		table.Append([]string{
+			r.ID,
+			r.Ruleset,
+			strconv.Itoa(r.Index),
+			r.Name,
+			r.Action,
+			protocol,
+			formatRuleEndpoint(r.SrcAddress, r.SrcMACAddress, r.SrcFirewallGroups, r.SrcNetworkID, r.SrcPort, names),
+			formatRuleEndpoint(r.DstAddress, "", r.DstFirewallGroups, r.DstNetworkID, r.DstPort, names),
+			onOff(r.Enabled),
+		})
+	}
+
+	table.Render()
+}
+
+// formatRuleEndpoint describes a rule's source or destination, e.g.
+// "IoT", "Blocklist:443" or "any". IDs without a name are shown as is.
+func formatRuleEndpoint(address, mac string, groupIDs []string, networkID, port string, names map[string]string) string {
+	name := func(id string) string {
+		if n, ok := names[id]; ok {
+			return n
+		}
+		return id
+	}
+
+	var parts []string
+	if address != "" {
+		parts = append(parts, address)
+	}
+	if mac != "" {
+		parts = append(parts, mac)
+	}
+	for _, id := range groupIDs {
+		parts = append(parts, name(id))
+	}
+	if networkID != "" {
+		parts = append(parts, name(networkID))
+	}
+
+	endpoint := strings.Join(parts, ", ")
+	if endpoint == "" {
+		endpoint = "any"
+	}
+	if port != "" {
+		endpoint += ":" + port
+	}
+	return endpoint
+}
+
+// groupMembersShown is how many members the groups table lists before
+// summarizing the rest
+const groupMembersShown = 5
+
+// PrintFirewallGroupsTable lists firewall groups with their type and members
+func PrintFirewallGroupsTable(groups []api.FirewallGroup) {
+	table := newTable([]string{"ID", "Name", "Type", "Count", "Members"})
+
+	for _, g := range groups {
+		members := g.GroupMembers
+		more := ""
+		if len(members) > groupMembersShown {
+			more = fmt.Sprintf(", ... (+%d more)", len(members)-groupMembersShown)
+			members = members[:groupMembersShown]
+		}
+
+		table.Append([]string{
+			g.ID,
+			g.Name,
+			strings.TrimSuffix(g.GroupType, "-group"),
+			strconv.Itoa(len(g.GroupMembers)),
+			strings.Join(members, ", ") + more,
+		})
+	}
+
+	table.Render()
+}
+
+// PrintPortForwardsTable lists port forwards with the WAN port and the LAN
+// address they forward to
+func PrintPortForwardsTable(forwards []api.PortForward) {
+	table := newTable([]string{"ID", "Name", "WAN", "Protocol", "Source", "Port", "Forward To", "Enabled"})
+
+	for _, f := range forwards {
+		wan := f.Interface
+		if wan == "" {
+			wan = "wan"
+		}
+		src := f.Src
+		if src == "" {
+			src = "any"
+		}
+
+		table.Append([]string{
+			f.ID,
+			f.Name,
+			wan,
+			strings.ReplaceAll(f.Proto, "_", "/"),
+			src,
+			f.DstPort,
+			fmt.Sprintf("%s:%s", f.FwdIP, f.FwdPort),
+			onOff(f.Enabled),
+		})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintFirewallRulesTable(t *testing.T) {
+	names := map[string]string{"g1": "Blocklist", "n2": "IoT"}
+
+	out := captureStdout(t, func() {
+		PrintFirewallRulesTable([]api.FirewallRule{
+			{ID: "r1", Name: "Block IoT", Ruleset: "LAN_IN", Index: 2000, Action: "drop", Enabled: true, SrcNetworkID: "n2", DstFirewallGroups: []string{"g1"}, DstPort: "443", Protocol: "tcp"},
+			{ID: "r2", Name: "Allow admin", Ruleset: "WAN_IN", Index: 2001, Action: "accept", SrcAddress: "192.0.2.10", DstNetworkID: "n9"},
+		}, names)
+	})
+
+	for _, want := range []string{"Ruleset", "r1", "LAN_IN", "2000", "Block IoT", "drop", "tcp", "IoT", "Blocklist:443", "on", "all", "192.0.2.10", "n9", "off"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatRuleEndpoint(t *testing.T) {
+	names := map[string]string{"g1": "Blocklist", "g2": "Web Ports"}
+
+	tests := []struct {
+		address, mac string
+		groups       []string
+		network      string
+		port         string
+		want         string
+	}{
+		{want: "any"},
+		{port: "53", want: "any:53"},
+		{address: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{mac: "aa:bb:cc:dd:ee:ff", want: "aa:bb:cc:dd:ee:ff"},
+		{groups: []string{"g1", "g2"}, want: "Blocklist, Web Ports"},
+		{network: "n7", want: "n7"},
+	}
+
+	for _, tt := range tests {
+		if got := formatRuleEndpoint(tt.address, tt.mac, tt.groups, tt.network, tt.port, names); got != tt.want {
+			t.Errorf("formatRuleEndpoint(%+v) = %q, want %q", tt, got, tt.want)
+		}
+	}
+}
+
+func TestPrintFirewallGroupsTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintFirewallGroupsTable([]api.FirewallGroup{
+			{ID: "g1", Name: "Blocklist", GroupType: api.FirewallGroupAddress, GroupMembers: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4", "192.0.2.5", "192.0.2.6", "192.0.2.7"}},
+			{ID: "g2", Name: "Web Ports", GroupType: api.FirewallGroupPort, GroupMembers: []string{"80", "443"}},
+		})
+	})
+
+	for _, want := range []string{"Blocklist", "address", "7", "192.0.2.5, ... (+2 more)", "Web Ports", "port", "80, 443"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "192.0.2.6") {
+		t.Errorf("Expected members beyond the fifth to be summarized:\n%s", out)
+	}
+}
+
+func TestPrintPortForwardsTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintPortForwardsTable([]api.PortForward{
+			{ID: "pf1", Name: "Web", Enabled: true, Interface: "both", Src: "any", DstPort: "443", FwdIP: "192.168.1.10", FwdPort: "8443", Proto: api.ProtoTCP},
+			{ID: "pf2", Name: "Games", DstPort: "27000-27050", FwdIP: "192.168.1.20", FwdPort: "27000-27050", Proto: api.ProtoTCPUDP},
+		})
+	})
+
+	for _, want := range []string{"Forward To", "pf1", "Web", "both", "tcp", "443", "192.168.1.10:8443", "on", "wan", "tcp/udp", "any", "192.168.1.20:27000-27050", "off"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
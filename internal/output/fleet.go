@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/nkn/unifi-cli/internal/fleet"
+)
+
+// PrintFleetOutputs prints the output of every run under a header naming
+// its profile
+func PrintFleetOutputs(results []fleet.Result) {
+	for _, r := range results {
+		fmt.Printf("==> %s <==\n", r.Profile)
+		fmt.Print(r.Output)
+		if r.Output != "" && !strings.HasSuffix(r.Output, "\n") {
+			fmt.Println()
+		}
+		fmt.Println()
+	}
+}
+
+// PrintFleetTable summarizes the runs of fleet exec, with failures in capitals
+// and the last line of each run's output
+func PrintFleetTable(results []fleet.Result) {
+	table := newTable([]string{"Profile", "Result", "Duration", "Last Output"})
+
+	for _, r := range results {
+		status := "ok"
+		switch {
+		case r.Error != "":
+			status = "FAILED: " + r.Error
+		case r.ExitCode != 0:
+			status = fmt.Sprintf("FAILED (exit %d)", r.ExitCode)
+		}
+		table.Append([]string{r.Profile, status, r.Duration.String(), lastLine(r.Output)})
+	}
+
+	table.Render()
+}
+
+// lastLine returns the last line of out with text in it, skipping blank lines
+// and table borders
+func lastLine(out string) string {
+	lines := strings.Split(out, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.IndexFunc(lines[i], func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			return strings.TrimSpace(lines[i])
+		}
+	}
+	return ""
+}
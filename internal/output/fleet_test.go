@@ -0,0 +1,35 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/fleet"
+)
+
+var testFleetResults = []fleet.Result{
+	{Profile: "home", Duration: 1200 * time.Millisecond, Output: "Blocked aa:bb:cc:dd:ee:ff\n"},
+	{Profile: "table", Output: "│ default │ 16 │\n└─────────┴────┘\n"},
+	{Profile: "office", ExitCode: 1, Duration: 300 * time.Millisecond, Output: "Error: client not found\n\n"},
+	{Profile: "lab", ExitCode: -1, Error: "exec: not found"},
+}
+
+func TestPrintFleetTable(t *testing.T) {
+	out := captureStdout(t, func() { PrintFleetTable(testFleetResults) })
+
+	for _, want := range []string{"Last Output", "home", "ok", "1.2s", "Blocked aa:bb:cc:dd:ee:ff", "office", "FAILED (exit 1)", "Error: client not found", "FAILED: exec: not found", "│ default │"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintFleetOutputs(t *testing.T) {
+	out := captureStdout(t, func() { PrintFleetOutputs([]fleet.Result{testFleetResults[0], testFleetResults[2]}) })
+
+	want := "==> home <==\nBlocked aa:bb:cc:dd:ee:ff\n\n==> office <==\nError: client not found\n\n\n"
+	if out != want {
+		t.Errorf("Expected:\n%q\ngot:\n%q", want, out)
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintGatewayPortsTable prints the ports of a gateway with their role,
+// link, address and the networks they carry
+func PrintGatewayPortsTable(ports []api.GatewayPort) {
+	table := newTable([]string{"Port", "Interface", "Role", "Link", "IP", "Networks", "VLANs"})
+
+	for _, port := range ports {
+		name := strconv.Itoa(port.Index)
+		if port.Name != "" {
+			name = fmt.Sprintf("%d %s", port.Index, port.Name)
+		}
+
+		table.Append([]string{
+			name,
+			port.IfName,
+			port.Role,
+			formatLink(port.Port),
+			port.IP,
+			strings.Join(port.Networks, ", "),
+			formatVLANs(port.VLANs),
+		})
+	}
+
+	table.Render()
+}
+
+// formatVLANs lists VLAN IDs, naming VLAN 0 "untagged"
+func formatVLANs(vlans []int) string {
+	parts := make([]string, len(vlans))
+	for i, vlan := range vlans {
+		if vlan == 0 {
+			parts[i] = "untagged"
+		} else {
+			parts[i] = strconv.Itoa(vlan)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintGatewayPortsTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintGatewayPortsTable([]api.GatewayPort{
+			{Port: api.Port{Index: 1, Name: "Port 1", IfName: "eth0", Enabled: true, Up: true, Speed: 1000, FullDuplex: true}, Role: "LAN", Networks: []string{"Default", "IoT"}, VLANs: []int{0, 20}},
+			{Port: api.Port{Index: 9, Name: "WAN", IfName: "eth8", IP: "203.0.113.7", Enabled: true}, Role: "WAN", Networks: []string{"Internet 1"}, VLANs: []int{7}},
+		})
+	})
+
+	for _, want := range []string{"1 Port 1", "eth0", "LAN", "1G FDX", "Default, IoT", "untagged, 20", "9 WAN", "eth8", "203.0.113.7", "down", "Internet 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatVLANs(t *testing.T) {
+	if got := formatVLANs([]int{0, 10, 20}); got != "untagged, 10, 20" {
+		t.Errorf("formatVLANs() = %q", got)
+	}
+	if got := formatVLANs(nil); got != "" {
+		t.Errorf("formatVLANs(nil) = %q", got)
+	}
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintGuestsTable lists authorized guests with the time they have left at
+// now. names maps MAC addresses to client names where known.
+func PrintGuestsTable(guests []api.Guest, names map[string]string, now time.Time) {
+	table := newTable([]string{"MAC", "Name", "Authorized By", "Expires", "Remaining", "Limits"})
+
+	for _, g := range guests {
+		name := names[strings.ToLower(g.MAC)]
+		if name == "" {
+			name = g.Hostname
+		}
+
+		table.Append([]string{
+			g.MAC,
+			name,
+			g.AuthorizedBy,
+			g.GetEnd().Local().Format("2006-01-02 15:04"),
+			api.FormatUptime(int64(g.GetEnd().Sub(now).Seconds())),
+			guestLimits(g.QosRateMaxUp, g.QosRateMaxDown, g.QosUsageQuota),
+		})
+	}
+
+	table.Render()
+}
+
+// guestLimits formats the bandwidth limits (in Kbps) and data limit (in MB)
+// of a guest or voucher, leaving out those that are not set
+func guestLimits(up, down, data int) string {
+	var limits []string
+	if down > 0 {
+		limits = append(limits, fmt.Sprintf("down %d Kbps", down))
+	}
+	if up > 0 {
+		limits = append(limits, fmt.Sprintf("up %d Kbps", up))
+	}
+	if data > 0 {
+		limits = append(limits, fmt.Sprintf("%d MB", data))
+	}
+	return strings.Join(limits, ", ")
+}
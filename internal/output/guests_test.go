@@ -0,0 +1,26 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintGuestsTable(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	guests := []api.Guest{
+		{MAC: "AA:BB:CC:DD:EE:01", End: now.Add(90 * time.Minute).Unix(), AuthorizedBy: "api", QosRateMaxDown: 2000, QosUsageQuota: 500},
+		{MAC: "aa:bb:cc:dd:ee:02", Hostname: "guest-phone", End: now.Add(26 * time.Hour).Unix(), AuthorizedBy: "voucher"},
+	}
+	names := map[string]string{"aa:bb:cc:dd:ee:01": "Lobby Kiosk"}
+
+	output := captureStdout(t, func() { PrintGuestsTable(guests, names, now) })
+
+	for _, expected := range []string{"Remaining", "Lobby Kiosk", "1h 30m", "down 2000 Kbps, 500 MB", "guest-phone", "1d 2h", "voucher"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
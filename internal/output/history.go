@@ -0,0 +1,22 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/history"
+)
+
+// PrintHistoryEvent prints a recorded change as one line, marking connects
+// with a green "+" and disconnects with a red "-" as watch mode does
+func PrintHistoryEvent(e history.Event) {
+	marker := colorize(colorGreen, "+")
+	if e.Type == history.EventDisconnected {
+		marker = colorize(colorRed, "-")
+	}
+
+	line := fmt.Sprintf("%s  %s %s (%s) %s", e.Time.Local().Format("2006-01-02 15:04:05"), marker, e.Name, e.MAC, e.Type)
+	if e.IP != "" {
+		line += " " + e.IP
+	}
+	fmt.Println(line)
+}
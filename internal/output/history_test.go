@@ -0,0 +1,34 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/history"
+)
+
+func TestPrintHistoryEvent(t *testing.T) {
+	forceColors(t)
+
+	when := time.Date(2026, 1, 12, 10, 0, 3, 0, time.Local)
+
+	out := captureStdout(t, func() {
+		PrintHistoryEvent(history.Event{Time: when, Type: history.EventConnected, MAC: "aa:bb:cc:dd:ee:01", Name: "iPhone", IP: "192.168.1.10"})
+		PrintHistoryEvent(history.Event{Time: when, Type: history.EventDisconnected, MAC: "aa:bb:cc:dd:ee:02", Name: "Laptop"})
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", out)
+	}
+
+	for _, want := range []string{"2026-01-12 10:00:03", colorGreen + "+", "iPhone (aa:bb:cc:dd:ee:01) connected 192.168.1.10"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("Expected %q in %q", want, lines[0])
+		}
+	}
+	if !strings.Contains(lines[1], colorRed+"-") || !strings.HasSuffix(lines[1], "Laptop (aa:bb:cc:dd:ee:02) disconnected") {
+		t.Errorf("Unexpected disconnect line %q", lines[1])
+	}
+}
@@ -0,0 +1,14 @@
+package output
+
+import "github.com/nkn/unifi-cli/internal/api"
+
+// PrintHotspotOperatorsTable lists hotspot operators; passwords are left out
+func PrintHotspotOperatorsTable(operators []api.HotspotOperator) {
+	table := newTable([]string{"Name", "Note", "ID"})
+
+	for _, op := range operators {
+		table.Append([]string{op.Name, op.Note, op.ID})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,23 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintHotspotOperatorsTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintHotspotOperatorsTable([]api.HotspotOperator{{ID: "op1", Name: "reception", Password: "secret", Note: "Front desk"}})
+	})
+
+	for _, want := range []string{"reception", "Front desk", "op1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("Expected no password in output:\n%s", out)
+	}
+}
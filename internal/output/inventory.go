@@ -0,0 +1,59 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var inventoryHeader = []string{"Site", "Name", "Model", "Type", "Serial", "MAC", "Firmware", "Adopted", "LTS", "EOL"}
+
+func inventoryRow(item api.InventoryItem) []string {
+	return []string{
+		item.Site,
+		item.Name,
+		item.Model,
+		item.Type,
+		item.Serial,
+		item.MAC,
+		item.Firmware,
+		item.Adopted,
+		yesNo(item.LTS),
+		yesNo(item.EOL),
+	}
+}
+
+// PrintInventoryTable lists devices with their model, serial, firmware and
+// adoption date
+func PrintInventoryTable(items []api.InventoryItem) {
+	table := newTable(inventoryHeader)
+
+	for _, item := range items {
+		table.Append(inventoryRow(item))
+	}
+
+	table.Render()
+}
+
+// PrintInventoryCSV writes the inventory as CSV with a header row, for
+// spreadsheets and asset registers
+func PrintInventoryCSV(items []api.InventoryItem) error {
+	w := csv.NewWriter(os.Stdout)
+
+	if err := w.Write(inventoryHeader); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, item := range items {
+		if err := w.Write(inventoryRow(item)); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
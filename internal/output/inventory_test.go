@@ -0,0 +1,40 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var testInventory = []api.InventoryItem{
+	{Site: "default", Name: "Office AP", Model: "U7PG2", Type: "uap", Serial: "F09FC2000001", MAC: "f0:9f:c2:00:00:01", Firmware: "6.6.77", Adopted: "2023-11-14", EOL: true},
+	{Site: "default", Name: "Switch, core", Model: "USW24", Type: "usw", Serial: "F09FC2000002", MAC: "f0:9f:c2:00:00:02", Firmware: "6.5.59"},
+}
+
+func TestPrintInventoryTable(t *testing.T) {
+	output := captureStdout(t, func() {
+		PrintInventoryTable(testInventory)
+	})
+
+	for _, expected := range []string{"Serial", "Firmware", "Adopted", "Office AP", "F09FC2000001", "2023-11-14", "yes"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPrintInventoryCSV(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := PrintInventoryCSV(testInventory); err != nil {
+			t.Errorf("PrintInventoryCSV() returned error: %v", err)
+		}
+	})
+
+	expected := "Site,Name,Model,Type,Serial,MAC,Firmware,Adopted,LTS,EOL\n" +
+		"default,Office AP,U7PG2,uap,F09FC2000001,f0:9f:c2:00:00:01,6.6.77,2023-11-14,,yes\n" +
+		"default,\"Switch, core\",USW24,usw,F09FC2000002,f0:9f:c2:00:00:02,6.5.59,,,\n"
+	if output != expected {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expected, output)
+	}
+}
@@ -3,12 +3,19 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/nkn/unifi-cli/internal/api"
 )
 
 func PrintClientsJSON(clients []api.Client) error {
-	data, err := json.MarshalIndent(clients, "", "  ")
+	return PrintJSON(clients)
+}
+
+// PrintJSON pretty-prints any value as indented JSON
+func PrintJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
@@ -16,3 +23,48 @@ func PrintClientsJSON(clients []api.Client) error {
 	fmt.Println(string(data))
 	return nil
 }
+
+// FieldValue returns a single JSON field of v as a raw value for shell
+// scripts: strings without quotes, null as empty, and objects and arrays as
+// compact JSON
+func FieldValue(v interface{}, field string) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("value has no fields: %w", err)
+	}
+
+	raw, ok := fields[field]
+	if !ok {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("unknown field %q (valid fields: %s)", field, strings.Join(names, ", "))
+	}
+
+	var s string
+	switch {
+	case string(raw) == "null":
+		return "", nil
+	case json.Unmarshal(raw, &s) == nil:
+		return s, nil
+	}
+	return string(raw), nil
+}
+
+// PrintField prints a single JSON field of v as a raw value
+func PrintField(v interface{}, field string) error {
+	value, err := FieldValue(v, field)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(value)
+	return nil
+}
@@ -140,3 +140,42 @@ func TestPrintClientsJSON_ValidFormat(t *testing.T) {
 		t.Errorf("Expected Name 'TestDevice', got '%s'", result[0].Name)
 	}
 }
+
+func TestFieldValue(t *testing.T) {
+	client := api.Client{MAC: "aa:bb:cc:dd:ee:ff", IP: "192.168.1.100", IsWired: true, Uptime: 3600, RxBytesR: 12.5}
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{"ip", "192.168.1.100"},
+		{"is_wired", "true"},
+		{"uptime", "3600"},
+		{"rx_bytes-r", "12.5"},
+		{"note", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			result, err := FieldValue(client, tt.field)
+			if err != nil {
+				t.Fatalf("FieldValue() returned error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("FieldValue(%q) = %q, want %q", tt.field, result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := FieldValue(client, "nope"); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+
+	nested := map[string]interface{}{"tags": []string{"a", "b"}, "none": nil}
+	if result, _ := FieldValue(nested, "tags"); result != `["a","b"]` {
+		t.Errorf("Expected compact JSON for arrays, got %q", result)
+	}
+	if result, _ := FieldValue(nested, "none"); result != "" {
+		t.Errorf("Expected null to be empty, got %q", result)
+	}
+}
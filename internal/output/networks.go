@@ -0,0 +1,53 @@
+package output
+
+import (
+	"strconv"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintNetworksTable lists networks with their VLAN, subnet, DHCP range and
+// multicast and IPv6 settings
+func PrintNetworksTable(networks []api.Network) {
+	table := newTable([]string{"Name", "Purpose", "VLAN", "Subnet", "DHCP", "Domain", "IGMP Snooping", "mDNS", "IPv6"})
+
+	for _, n := range networks {
+		vlan := ""
+		if n.VLANEnabled && n.VLAN > 0 {
+			vlan = strconv.Itoa(n.VLAN)
+		}
+
+		name := n.Name
+		if !n.Enabled {
+			name += " (disabled)"
+		}
+
+		table.Append([]string{
+			name,
+			n.Purpose,
+			vlan,
+			n.IPSubnet,
+			formatDHCP(n),
+			n.DomainName,
+			onOff(n.IGMPSnooping),
+			onOff(n.MDNSEnabled),
+			n.IPv6InterfaceType,
+		})
+	}
+
+	table.Render()
+}
+
+// formatDHCP shows the DHCP range of a network, "off" if the network has a
+// subnet but no DHCP server and nothing for WANs
+func formatDHCP(n api.Network) string {
+	switch {
+	case n.DHCPEnabled && n.DHCPStart != "":
+		return n.DHCPStart + " - " + n.DHCPStop
+	case n.DHCPEnabled:
+		return "on"
+	case n.IPSubnet != "":
+		return "off"
+	}
+	return ""
+}
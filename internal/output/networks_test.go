@@ -0,0 +1,23 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintNetworksTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintNetworksTable([]api.Network{
+			{Name: "LAN", Purpose: "corporate", Enabled: true, IPSubnet: "192.168.1.1/24", DHCPEnabled: true, DHCPStart: "192.168.1.6", DHCPStop: "192.168.1.254", DomainName: "lan", IPv6InterfaceType: "pd"},
+			{Name: "IoT", Purpose: "corporate", VLANEnabled: true, VLAN: 30, IPSubnet: "10.0.30.1/24", IGMPSnooping: true},
+		})
+	})
+
+	for _, want := range []string{"IGMP Snooping", "LAN", "corporate", "192.168.1.1/24", "192.168.1.6 - 192.168.1.254", "lan", "pd", "IoT (disabled)", "30", "off", "on"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
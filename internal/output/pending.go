@@ -0,0 +1,30 @@
+package output
+
+import (
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/pending"
+)
+
+// PrintPendingActionsTable lists scheduled actions with the time left until
+// each one runs
+func PrintPendingActionsTable(actions []pending.Action, now time.Time) {
+	table := newTable([]string{"Action", "MAC", "Site", "Due", "Remaining"})
+
+	for _, action := range actions {
+		remaining := "due"
+		if action.Due.After(now) {
+			remaining = action.Due.Sub(now).Round(time.Minute).String()
+		}
+
+		table.Append([]string{
+			action.Type,
+			action.MAC,
+			action.Site,
+			action.Due.Local().Format("2006-01-02 15:04"),
+			remaining,
+		})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/pending"
+)
+
+func TestPrintPendingActionsTable(t *testing.T) {
+	now := time.Now()
+	actions := []pending.Action{
+		{Type: pending.ActionUnblock, MAC: "aa:bb:cc:dd:ee:01", Site: "default", Due: now.Add(2 * time.Hour)},
+		{Type: pending.ActionUnblock, MAC: "aa:bb:cc:dd:ee:02", Site: "default", Due: now.Add(-time.Minute)},
+	}
+
+	output := captureStdout(t, func() {
+		PrintPendingActionsTable(actions, now)
+	})
+
+	for _, want := range []string{"Remaining", "aa:bb:cc:dd:ee:01", "2h0m0s", "aa:bb:cc:dd:ee:02", "due"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintPortsTable prints the port table of a switch: link, PoE, profile and
+// traffic per port
+func PrintPortsTable(ports []api.PortStatus) {
+	table := newTable([]string{"Port", "Link", "PoE", "Profile", "Network", "RX/TX", "Errors", "Clients"})
+
+	for _, port := range ports {
+		name := strconv.Itoa(port.Index)
+		if port.Name != "" {
+			name = fmt.Sprintf("%d %s", port.Index, port.Name)
+		}
+
+		network := port.Network
+		if port.VLAN > 0 {
+			network = fmt.Sprintf("%s (VLAN %d)", port.Network, port.VLAN)
+		}
+
+		table.Append([]string{
+			name,
+			formatLink(port.Port),
+			formatPoE(port),
+			port.Profile,
+			network,
+			api.FormatBytes(port.RxBytes) + " / " + api.FormatBytes(port.TxBytes),
+			fmt.Sprintf("%d / %d", port.RxErrors, port.TxErrors),
+			strconv.Itoa(port.Clients),
+		})
+	}
+
+	table.Render()
+}
+
+// formatLink describes a port's link, e.g. "1G FDX", "down" or "disabled"
+func formatLink(port api.Port) string {
+	switch {
+	case !port.Enabled:
+		return "disabled"
+	case !port.Up:
+		return "down"
+	}
+
+	speed := fmt.Sprintf("%dM", port.Speed)
+	if port.Speed >= 1000 && port.Speed%1000 == 0 {
+		speed = fmt.Sprintf("%dG", port.Speed/1000)
+	} else if port.Speed >= 1000 {
+		speed = fmt.Sprintf("%.1fG", float64(port.Speed)/1000)
+	}
+
+	duplex := "HDX"
+	if port.FullDuplex {
+		duplex = "FDX"
+	}
+
+	if port.IsUplink {
+		return speed + " " + duplex + " uplink"
+	}
+	return speed + " " + duplex
+}
+
+// formatPoE describes a port's PoE: the power drawn, "off", or "-" when
+// nothing is powered
+func formatPoE(port api.PortStatus) string {
+	switch {
+	case !port.PoE:
+		return ""
+	case port.PoEMode == "off":
+		return "off"
+	case port.PoEWatts > 0:
+		return fmt.Sprintf("%.1f W", port.PoEWatts)
+	}
+	return "-"
+}
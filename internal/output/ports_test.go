@@ -0,0 +1,67 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintPortsTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintPortsTable([]api.PortStatus{
+			{Port: api.Port{Index: 1, Name: "Uplink", Enabled: true, Up: true, Speed: 10000, FullDuplex: true, IsUplink: true, RxBytes: 2048}},
+			{Port: api.Port{Index: 7, Name: "Camera", Enabled: true, Up: true, Speed: 100, FullDuplex: true, PoE: true, PoEMode: "auto", PoEGood: true, RxErrors: 12, TxErrors: 3}, Profile: "Cameras", Network: "IoT", VLAN: 20, PoEWatts: 4.52, Clients: 1},
+		})
+	})
+
+	for _, want := range []string{"1 Uplink", "10G FDX uplink", "2.00 KB / 0 B", "7 Camera", "100M FDX", "4.5 W", "Cameras", "IoT (VLAN 20)", "12 / 3"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		port     api.Port
+		expected string
+	}{
+		{"Disabled", api.Port{Enabled: false, Up: true}, "disabled"},
+		{"Down", api.Port{Enabled: true}, "down"},
+		{"Gigabit", api.Port{Enabled: true, Up: true, Speed: 1000, FullDuplex: true}, "1G FDX"},
+		{"2.5 Gigabit", api.Port{Enabled: true, Up: true, Speed: 2500, FullDuplex: true}, "2.5G FDX"},
+		{"Half duplex", api.Port{Enabled: true, Up: true, Speed: 10}, "10M HDX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatLink(tt.port); got != tt.expected {
+				t.Errorf("formatLink() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatPoE(t *testing.T) {
+	tests := []struct {
+		name     string
+		port     api.PortStatus
+		expected string
+	}{
+		{"No PoE", api.PortStatus{}, ""},
+		{"Off", api.PortStatus{Port: api.Port{PoE: true, PoEMode: "off"}}, "off"},
+		{"Drawing", api.PortStatus{Port: api.Port{PoE: true, PoEMode: "auto", Up: true, PoEGood: true}, PoEWatts: 6.25}, "6.2 W"},
+		{"Unpowered link", api.PortStatus{Port: api.Port{PoE: true, PoEMode: "auto", Up: true}}, "-"},
+		{"Idle", api.PortStatus{Port: api.Port{PoE: true, PoEMode: "auto"}}, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPoE(tt.port); got != tt.expected {
+				t.Errorf("formatPoE() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
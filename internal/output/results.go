@@ -0,0 +1,33 @@
+package output
+
+// ActionResult is the outcome of applying an action to one target
+type ActionResult struct {
+	Target string
+	Err    error
+}
+
+// PrintActionResults prints a per-target success/failure summary
+func PrintActionResults(results []ActionResult) {
+	table := newTable([]string{"Target", "Result"})
+
+	for _, result := range results {
+		status := "ok"
+		if result.Err != nil {
+			status = "failed: " + result.Err.Error()
+		}
+		table.Append([]string{result.Target, status})
+	}
+
+	table.Render()
+}
+
+// CountFailures returns how many results carry an error
+func CountFailures(results []ActionResult) int {
+	failures := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failures++
+		}
+	}
+	return failures
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPrintActionResults(t *testing.T) {
+	results := []ActionResult{
+		{Target: "aa:bb:cc:dd:ee:01"},
+		{Target: "aa:bb:cc:dd:ee:02", Err: errors.New("API returned error: error")},
+	}
+
+	output := captureStdout(t, func() {
+		PrintActionResults(results)
+	})
+
+	for _, want := range []string{"aa:bb:cc:dd:ee:01", "ok", "aa:bb:cc:dd:ee:02", "failed: API returned error: error"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestCountFailures(t *testing.T) {
+	results := []ActionResult{
+		{Target: "a"},
+		{Target: "b", Err: errors.New("boom")},
+		{Target: "c", Err: errors.New("boom")},
+	}
+
+	if n := CountFailures(results); n != 2 {
+		t.Errorf("Expected 2 failures, got %d", n)
+	}
+	if n := CountFailures(nil); n != 0 {
+		t.Errorf("Expected 0 failures for no results, got %d", n)
+	}
+}
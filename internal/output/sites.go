@@ -0,0 +1,132 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintSitesTable lists sites with their status and client and device counts
+func PrintSitesTable(sites []api.SiteSummary) {
+	table := newTable([]string{"Site", "Description", "Role", "Status", "Clients", "Devices"})
+
+	for _, site := range sites {
+		table.Append([]string{
+			site.Name,
+			site.Desc,
+			site.Role,
+			site.Status,
+			strconv.Itoa(site.Clients),
+			formatSiteDevices(site),
+		})
+	}
+
+	table.Render()
+}
+
+// formatSiteDevices shows the device count with the devices that need
+// attention, e.g. "6 (1 offline, 1 pending)"
+func formatSiteDevices(site api.SiteSummary) string {
+	var problems []string
+	if site.Disconnected > 0 {
+		problems = append(problems, fmt.Sprintf("%d offline", site.Disconnected))
+	}
+	if site.Pending > 0 {
+		problems = append(problems, fmt.Sprintf("%d pending", site.Pending))
+	}
+
+	if len(problems) == 0 {
+		return strconv.Itoa(site.Devices)
+	}
+	return fmt.Sprintf("%d (%s)", site.Devices, strings.Join(problems, ", "))
+}
+
+// siteSubsystems are the health subsystems shown by PrintSitesStatusTable
+var siteSubsystems = []string{"www", "wan", "wlan", "lan"}
+
+// PrintSitesStatusTable shows the state of the WWW, WAN, WLAN and LAN
+// subsystems of every site
+func PrintSitesStatusTable(sites []api.Site) {
+	table := newTable([]string{"Site", "Description", "WWW", "WAN", "WLAN", "LAN"})
+
+	for _, site := range sites {
+		row := []string{site.Name, site.Desc}
+		for _, subsystem := range siteSubsystems {
+			row = append(row, formatSubsystem(site.GetHealth(subsystem)))
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+}
+
+// formatSubsystem shows a subsystem's status with its most telling detail,
+// e.g. "ok (12 ms)" for www or "warning (1 offline)" for wlan
+func formatSubsystem(health *api.SiteHealth) string {
+	if health == nil {
+		return "-"
+	}
+
+	var detail string
+	switch {
+	case health.Subsystem == "www" && health.Latency > 0:
+		detail = fmt.Sprintf("%d ms", health.Latency)
+	case health.Subsystem == "wan" && health.WANIP != "":
+		detail = health.WANIP
+	case health.NumDisconnected > 0:
+		detail = fmt.Sprintf("%d offline", health.NumDisconnected)
+	}
+
+	if detail == "" {
+		return health.Status
+	}
+	return fmt.Sprintf("%s (%s)", health.Status, detail)
+}
+
+// PrintHealthTable shows every subsystem of a site on its own row, with
+// latency, throughput and device and client counts where it reports them
+func PrintHealthTable(health []api.SiteHealth) {
+	table := newTable([]string{"Subsystem", "Status", "Latency", "RX", "TX", "Devices", "Clients", "WAN IP"})
+
+	for _, h := range health {
+		latency := ""
+		if h.Latency > 0 {
+			latency = fmt.Sprintf("%d ms", h.Latency)
+		}
+
+		devices := h.NumAP + h.NumSW + h.NumGW
+		summary := api.SiteSummary{Devices: devices, Disconnected: h.NumDisconnected, Pending: h.NumPending}
+		devicesCell := ""
+		if devices > 0 || h.NumDisconnected > 0 || h.NumPending > 0 {
+			devicesCell = formatSiteDevices(summary)
+		}
+
+		clients := nonZero(h.NumUser)
+		if h.NumGuest > 0 {
+			clients = fmt.Sprintf("%d (+%d guests)", h.NumUser, h.NumGuest)
+		}
+
+		table.Append([]string{
+			strings.ToUpper(h.Subsystem),
+			h.Status,
+			latency,
+			formatRate(h.RxRate),
+			formatRate(h.TxRate),
+			devicesCell,
+			clients,
+			h.WANIP,
+		})
+	}
+
+	table.Render()
+}
+
+// formatRate shows a throughput in bytes per second, e.g. "1.19 MB/s"
+func formatRate(bytesPerSecond float64) string {
+	if bytesPerSecond <= 0 {
+		return ""
+	}
+	return api.FormatBytes(int64(bytesPerSecond)) + "/s"
+}
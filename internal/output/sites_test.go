@@ -0,0 +1,60 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintSitesTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintSitesTable([]api.SiteSummary{
+			{Site: api.Site{Name: "default", Desc: "Head Office", Role: "admin"}, Status: "ok", Clients: 40, Devices: 6},
+			{Site: api.Site{Name: "b1", Desc: "Branch Berlin", Role: "readonly"}, Status: "warning", Clients: 5, Devices: 3, Disconnected: 1, Pending: 2},
+		})
+	})
+
+	for _, want := range []string{"default", "Head Office", "admin", "40", "b1", "readonly", "warning", "3 (1 offline, 2 pending)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintSitesStatusTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintSitesStatusTable([]api.Site{
+			{Name: "default", Desc: "Head Office", Health: []api.SiteHealth{
+				{Subsystem: "www", Status: "ok", Latency: 12},
+				{Subsystem: "wan", Status: "ok", WANIP: "203.0.113.7"},
+				{Subsystem: "wlan", Status: "warning", NumDisconnected: 1},
+				{Subsystem: "lan", Status: "ok"},
+			}},
+			{Name: "b1", Desc: "Branch"},
+		})
+	})
+
+	for _, want := range []string{"WWW", "default", "Head Office", "ok (12 ms)", "ok (203.0.113.7)", "warning (1 offline)", "b1", "-"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintHealthTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintHealthTable([]api.SiteHealth{
+			{Subsystem: "www", Status: "ok", Latency: 12, RxRate: 1250000, TxRate: 300},
+			{Subsystem: "wan", Status: "ok", NumGW: 1, WANIP: "203.0.113.7"},
+			{Subsystem: "wlan", Status: "warning", NumAP: 3, NumDisconnected: 1, NumUser: 20, NumGuest: 3},
+			{Subsystem: "vpn", Status: "unknown"},
+		})
+	})
+
+	for _, want := range []string{"Subsystem", "WWW", "12 ms", "1.19 MB/s", "300 B/s", "203.0.113.7", "WLAN", "warning", "3 (1 offline)", "20 (+3 guests)", "VPN", "unknown"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
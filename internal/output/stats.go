@@ -0,0 +1,56 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/filter"
+)
+
+const histogramWidth = 40
+
+// PrintColumnStats prints summary statistics followed by a text histogram
+func PrintColumnStats(stats *filter.ColumnStats) {
+	details := []Detail{
+		{Key: "Column", Value: stats.Column},
+		{Key: "Count", Value: strconv.Itoa(stats.Count)},
+		{Key: "Min", Value: formatNumber(stats.Min)},
+		{Key: "Max", Value: formatNumber(stats.Max)},
+		{Key: "Avg", Value: formatNumber(stats.Avg)},
+	}
+	for _, p := range stats.Percentiles {
+		details = append(details, Detail{Key: fmt.Sprintf("p%d", p.P), Value: formatNumber(p.Value)})
+	}
+	PrintDetails(details)
+	fmt.Println()
+
+	peak := 0
+	for _, b := range stats.Histogram {
+		if b.Count > peak {
+			peak = b.Count
+		}
+	}
+
+	table := newTable([]string{"Range", "Count", ""})
+	for _, b := range stats.Histogram {
+		bar := ""
+		if peak > 0 {
+			bar = strings.Repeat("#", b.Count*histogramWidth/peak)
+		}
+		table.Append([]string{
+			fmt.Sprintf("%s .. %s", formatNumber(b.Low), formatNumber(b.High)),
+			strconv.Itoa(b.Count),
+			bar,
+		})
+	}
+	table.Render()
+}
+
+// formatNumber prints integral values without decimals and others with two
+func formatNumber(v float64) string {
+	if v == float64(int64(v)) {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
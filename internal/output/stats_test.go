@@ -0,0 +1,50 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/filter"
+)
+
+func TestPrintColumnStats(t *testing.T) {
+	stats := &filter.ColumnStats{
+		Column:      "signal",
+		Count:       3,
+		Min:         -70,
+		Max:         -45,
+		Avg:         -56.666,
+		Percentiles: []filter.Percentile{{P: 50, Value: -55}},
+		Histogram: []filter.Bucket{
+			{Low: -70, High: -57.5, Count: 1},
+			{Low: -57.5, High: -45, Count: 2},
+		},
+	}
+
+	output := captureStdout(t, func() {
+		PrintColumnStats(stats)
+	})
+
+	for _, want := range []string{"signal", "-70", "-56.67", "p50", "-57.50 .. -45", strings.Repeat("#", histogramWidth)} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{-65, "-65"},
+		{0, "0"},
+		{12.345, "12.35"},
+	}
+
+	for _, tt := range tests {
+		if got := formatNumber(tt.value); got != tt.expected {
+			t.Errorf("formatNumber(%v) = %q, expected %q", tt.value, got, tt.expected)
+		}
+	}
+}
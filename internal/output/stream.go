@@ -0,0 +1,159 @@
+package output
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter/pkg/twwidth"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// streamChunk is the number of rows a table collects before it starts
+// streaming. Smaller tables are laid out by tablewriter as a whole; larger
+// ones take their column widths from the first chunk and write every row as
+// it is appended, so huge listings neither build the whole table in memory
+// nor wait for the last row.
+var streamChunk = 1000
+
+// streamBufferSize is the size of the buffer rows are written through;
+// streamed output is flushed whenever it fills up and after every chunk
+var streamBufferSize = 64 * 1024
+
+// In minimal mode tables stream early through a small buffer, to keep memory
+// use low on consoles and small boards
+const (
+	minimalStreamChunk      = 100
+	minimalStreamBufferSize = 4 * 1024
+)
+
+// streamWriter draws table rows with fixed column widths, like tablewriter
+// draws them with the active theme. Cells wider than their column push the
+// rest of that row to the right rather than widening the column.
+type streamWriter struct {
+	w       *bufio.Writer
+	theme   Theme
+	symbols tw.Symbols
+	widths  []int
+	// spaces pads cells without allocating
+	spaces string
+	rows   int
+}
+
+// newStreamWriter measures the columns of header and rows and writes them,
+// leaving the table open for more rows
+func newStreamWriter(w io.Writer, theme Theme, header []string, rows [][]string) *streamWriter {
+	widths := make([]int, len(header))
+	measure := func(row []string) {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], cellWidth(cell))
+			}
+		}
+	}
+	measure(header)
+	for _, row := range rows {
+		measure(row)
+	}
+
+	widest := 0
+	for _, width := range widths {
+		widest = max(widest, width)
+	}
+
+	s := &streamWriter{
+		w:       bufio.NewWriterSize(w, streamBufferSize),
+		theme:   theme,
+		symbols: tw.NewSymbols(symbolStyles[theme.Symbols]),
+		widths:  widths,
+		spaces:  strings.Repeat(" ", widest),
+	}
+
+	if theme.Borders {
+		s.line(s.symbols.TopLeft(), s.symbols.TopMid(), s.symbols.TopRight())
+	}
+	s.row(header)
+	s.line(s.symbols.MidLeft(), s.symbols.Center(), s.symbols.MidRight())
+	for _, row := range rows {
+		s.Append(row)
+	}
+
+	return s
+}
+
+// cellWidth is the display width of a cell. Plain ASCII, by far the most
+// common content, is measured without allocating; colors and wide characters
+// are left to tablewriter's width function.
+func cellWidth(cell string) int {
+	for i := 0; i < len(cell); i++ {
+		if cell[i] >= 0x7f || cell[i] == '\x1b' {
+			return twwidth.WidthNoCache(cell)
+		}
+	}
+	return len(cell)
+}
+
+// line draws a horizontal rule. left and right are only drawn with borders,
+// cross only between separated columns.
+func (s *streamWriter) line(left, cross, right string) {
+	if s.theme.Borders {
+		s.w.WriteString(left)
+	}
+	for i, width := range s.widths {
+		if i > 0 && !s.theme.Compact {
+			s.w.WriteString(cross)
+		}
+		for j := 0; j < width+2; j++ {
+			s.w.WriteString(s.symbols.Row())
+		}
+	}
+	if s.theme.Borders {
+		s.w.WriteString(right)
+	}
+	s.w.WriteByte('\n')
+}
+
+// row writes one row of padded cells
+func (s *streamWriter) row(cells []string) {
+	if s.theme.Borders {
+		s.w.WriteString(s.symbols.Column())
+	}
+	for i, width := range s.widths {
+		if i > 0 && !s.theme.Compact {
+			s.w.WriteString(s.symbols.Column())
+		}
+
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		s.w.WriteByte(' ')
+		s.w.WriteString(cell)
+		if pad := width - cellWidth(cell); pad > 0 {
+			s.w.WriteString(s.spaces[:pad])
+		}
+		s.w.WriteByte(' ')
+	}
+	if s.theme.Borders {
+		s.w.WriteString(s.symbols.Column())
+	}
+	s.w.WriteByte('\n')
+}
+
+// Append writes a row, flushing after every chunk of rows
+func (s *streamWriter) Append(cells []string) {
+	s.row(cells)
+
+	s.rows++
+	if s.rows%streamChunk == 0 {
+		s.w.Flush()
+	}
+}
+
+// Close draws the bottom border and flushes the table
+func (s *streamWriter) Close() {
+	if s.theme.Borders {
+		s.line(s.symbols.BottomLeft(), s.symbols.BottomMid(), s.symbols.BottomRight())
+	}
+	s.w.Flush()
+}
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// renderRows renders rows in the active theme with the given stream chunk
+func renderRows(chunk int, header []string, rows [][]string) string {
+	previous := streamChunk
+	streamChunk = chunk
+	defer func() { streamChunk = previous }()
+
+	var b bytes.Buffer
+	table := newTableTo(&b, header)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+	return b.String()
+}
+
+func TestStreamWriter_MatchesTablewriter(t *testing.T) {
+	previous := activeTheme
+	defer func() { activeTheme = previous }()
+
+	header := []string{"Name", "IP", "State", "Note"}
+	// The widest cells come first so the streamed widths match
+	rows := [][]string{
+		{"Living room speaker", "192.168.100.200", colorGreen + "connected" + colorReset, "日本語"},
+		{"nas", "192.168.1.20", colorRed + "offline" + colorReset, ""},
+		{"printer", "", "", "2nd floor"},
+		{"a", "10.0.0.1", "", ""},
+		{"b", "10.0.0.2", "", ""},
+	}
+
+	themesToCheck := map[string]Theme{
+		"borderless": {Symbols: "unicode"},
+		"heavy":      {Symbols: "heavy", Borders: true, Compact: true},
+		"double":     {Symbols: "double", Borders: true, HeaderCase: "title"},
+	}
+	for _, name := range ThemeNames() {
+		themesToCheck[name] = themes[name]
+	}
+
+	for name, theme := range themesToCheck {
+		t.Run(name, func(t *testing.T) {
+			activeTheme = theme
+
+			whole := renderRows(1000, header, rows)
+			streamed := renderRows(2, header, rows)
+			if streamed != whole {
+				t.Errorf("Expected the streamed table to match tablewriter:\n%s\ngot:\n%s", whole, streamed)
+			}
+		})
+	}
+}
+
+func TestStreamWriter_WideLaterCells(t *testing.T) {
+	previous := activeTheme
+	defer func() { activeTheme = previous }()
+	activeTheme = themes[DefaultTheme]
+
+	out := renderRows(1, []string{"Name", "IP"}, [][]string{
+		{"a", "10.0.0.1"},
+		{"b", "10.0.0.2"},
+		{"much longer name", "10.0.0.3"},
+	})
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines, got:\n%s", out)
+	}
+	if lines[3] != "│ a    │ 10.0.0.1 │" {
+		t.Errorf("Expected the widths of the first chunk, got %q", lines[3])
+	}
+	if lines[5] != "│ much longer name │ 10.0.0.3 │" {
+		t.Errorf("Expected the wide cell to push the row right, got %q", lines[5])
+	}
+}
+
+func TestStreamWriter_Flushes(t *testing.T) {
+	previous := streamChunk
+	streamChunk = 10
+	defer func() { streamChunk = previous }()
+
+	var b bytes.Buffer
+	table := newTableTo(&b, []string{"N"})
+	for i := 0; i < 25; i++ {
+		table.Append([]string{fmt.Sprint(i)})
+	}
+
+	// The first chunk starts the stream and one more chunk is flushed
+	// before Render
+	if !strings.Contains(b.String(), "│ 19 │") || strings.Contains(b.String(), "│ 20 │") {
+		t.Errorf("Expected rows to be flushed chunk by chunk, got:\n%s", b.String())
+	}
+
+	table.Render()
+	if !strings.Contains(b.String(), "│ 24 │\n└") {
+		t.Errorf("Expected the rest of the table on Render, got:\n%s", b.String())
+	}
+}
+
+func BenchmarkStreamWriter(b *testing.B) {
+	rows := make([][]string, 10000)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("client-%d", i), fmt.Sprintf("10.0.%d.%d", i/256, i%256), "Wireless", "-61 dBm"}
+	}
+	header := []string{"Name", "IP", "Type", "Signal"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		renderRows(1000, header, rows)
+	}
+}
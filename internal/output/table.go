@@ -2,35 +2,71 @@ package output
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/nkn/unifi-cli/internal/api"
-	"github.com/olekukonko/tablewriter"
 )
 
+var clientsTableHeader = []string{"Name", "IP", "Network", "Type", "SSID", "Signal", "Uptime", "RX/TX", "User Group"}
+
 func PrintClientsTable(clients []api.Client) {
-	table := tablewriter.NewWriter(os.Stdout)
+	table := newTable(clientsTableHeader)
+
+	for _, client := range clients {
+		table.Append(clientRow(client))
+	}
+
+	table.Render()
+}
+
+func clientRow(client api.Client) []string {
+	rxTx := api.FormatBytes(client.RxBytes) + " / " + api.FormatBytes(client.TxBytes)
 
-	// Add header row
-	table.Append([]string{"Name", "IP", "Type", "SSID", "Signal", "Uptime", "RX/TX"})
+	// Combine name and MAC address - MAC shown in parentheses to save space
+	nameWithMAC := fmt.Sprintf("%s (%s)", client.GetDisplayName(), client.MAC)
+
+	return []string{
+		nameWithMAC,
+		client.IP,
+		client.GetNetworkName(),
+		client.GetConnectionType(),
+		client.GetSSID(),
+		client.GetSignal(),
+		client.GetUptime(),
+		rxTx,
+		client.UsergroupName,
+	}
+}
+
+// ClientChange describes how a client changed since the previous refresh in
+// watch mode
+type ClientChange int
+
+const (
+	ClientUnchanged ClientChange = iota
+	ClientConnected
+	ClientDisconnected
+)
+
+// PrintClientsWatchTable prints the clients table with a leading change column.
+// Newly connected clients are marked "+" in green and clients that have just
+// disconnected "-" in red; changes maps MAC addresses to their change.
+func PrintClientsWatchTable(clients []api.Client, changes map[string]ClientChange) {
+	table := newTable(append([]string{""}, clientsTableHeader...))
 
 	for _, client := range clients {
-		rxTx := api.FormatBytes(client.RxBytes) + " / " + api.FormatBytes(client.TxBytes)
-
-		// Combine name and MAC address - MAC shown in parentheses to save space
-		nameWithMAC := fmt.Sprintf("%s (%s)", client.GetDisplayName(), client.MAC)
-
-		row := []string{
-			nameWithMAC,
-			client.IP,
-			client.GetConnectionType(),
-			client.GetSSID(),
-			client.GetSignal(),
-			client.GetUptime(),
-			rxTx,
+		row := clientRow(client)
+
+		marker := ""
+		switch changes[client.MAC] {
+		case ClientConnected:
+			marker = "+"
+			row[0] = colorize(colorGreen, row[0])
+		case ClientDisconnected:
+			marker = "-"
+			row[0] = colorize(colorRed, row[0])
 		}
 
-		table.Append(row)
+		table.Append(append([]string{marker}, row...))
 	}
 
 	table.Render()
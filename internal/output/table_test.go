@@ -111,14 +111,16 @@ func TestPrintClientsTable(t *testing.T) {
 func TestPrintClientsTable_OutputFormat(t *testing.T) {
 	clients := []api.Client{
 		{
-			MAC:      "aa:bb:cc:dd:ee:ff",
-			Name:     "TestDevice",
-			IP:       "192.168.1.100",
-			IsWired:  true,
-			Hostname: "test-host",
-			Uptime:   86400, // 1 day
-			RxBytes:  1048576,
-			TxBytes:  2097152,
+			MAC:           "aa:bb:cc:dd:ee:ff",
+			Name:          "TestDevice",
+			IP:            "192.168.1.100",
+			IsWired:       true,
+			Hostname:      "test-host",
+			Uptime:        86400, // 1 day
+			RxBytes:       1048576,
+			TxBytes:       2097152,
+			NetworkName:   "Servers",
+			UsergroupName: "Kids",
 		},
 	}
 
@@ -141,11 +143,15 @@ func TestPrintClientsTable_OutputFormat(t *testing.T) {
 	expectedValues := []string{
 		"Name",
 		"IP",
+		"Network",
 		"Type",
 		"aa:bb:cc:dd:ee:ff", // MAC should be in output (in parentheses with name)
 		"TestDevice",
 		"192.168.1.100",
+		"Servers",
 		"Wired",
+		"User Group",
+		"Kids",
 	}
 
 	for _, expected := range expectedValues {
@@ -206,3 +212,30 @@ func TestPrintClientsTable_WiredVsWireless(t *testing.T) {
 		t.Error("Output should contain signal strength for wireless client")
 	}
 }
+
+func TestPrintClientsWatchTable(t *testing.T) {
+	forceColors(t)
+
+	clients := []api.Client{
+		{MAC: "aa:bb:cc:dd:ee:01", Name: "Staying", IP: "192.168.1.1"},
+		{MAC: "aa:bb:cc:dd:ee:02", Name: "Arrived", IP: "192.168.1.2"},
+		{MAC: "aa:bb:cc:dd:ee:03", Name: "Left", IP: "192.168.1.3"},
+	}
+	changes := map[string]ClientChange{
+		"aa:bb:cc:dd:ee:02": ClientConnected,
+		"aa:bb:cc:dd:ee:03": ClientDisconnected,
+	}
+
+	output := captureStdout(t, func() {
+		PrintClientsWatchTable(clients, changes)
+	})
+
+	for _, expected := range []string{"+", colorGreen + "Arrived", colorRed + "Left", "Staying (aa:bb:cc:dd:ee:01)"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Watch table should contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, colorGreen+"Staying") || strings.Contains(output, colorRed+"Staying") {
+		t.Errorf("Unchanged clients should not be highlighted, got:\n%s", output)
+	}
+}
@@ -0,0 +1,184 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// Theme controls how tables are drawn
+type Theme struct {
+	// Symbols is the line drawing style: unicode, ascii, rounded, heavy or
+	// double
+	Symbols string
+	// Borders draws the outer frame of the table
+	Borders bool
+	// Compact drops the separators between columns
+	Compact bool
+	// HeaderCase is upper, lower, title, or empty to keep headers as written
+	HeaderCase string
+	// Zebra dims every other row
+	Zebra bool
+}
+
+// DefaultTheme is the name of the theme used unless another is selected
+const DefaultTheme = "default"
+
+var themes = map[string]Theme{
+	"default": {Symbols: "unicode", Borders: true},
+	"ascii":   {Symbols: "ascii", Borders: true},
+	"rounded": {Symbols: "rounded", Borders: true},
+	"compact": {Symbols: "unicode", Compact: true, HeaderCase: "upper"},
+	"zebra":   {Symbols: "unicode", Borders: true, Zebra: true},
+}
+
+var symbolStyles = map[string]tw.BorderStyle{
+	"unicode": tw.StyleLight,
+	"ascii":   tw.StyleASCII,
+	"rounded": tw.StyleRounded,
+	"heavy":   tw.StyleHeavy,
+	"double":  tw.StyleDouble,
+}
+
+var headerCases = map[string]func(string) string{
+	"":      func(s string) string { return s },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"title": titleCase,
+}
+
+// activeTheme is used by every table printed
+var activeTheme = themes[DefaultTheme]
+
+// ThemeNames returns the names of the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme returns a built-in theme by name
+func LookupTheme(name string) (Theme, error) {
+	theme, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (valid options: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	return theme, nil
+}
+
+// SetTheme makes theme the one used for all tables
+func SetTheme(theme Theme) error {
+	if _, ok := symbolStyles[theme.Symbols]; !ok {
+		return fmt.Errorf("invalid theme symbols %q (valid options: unicode, ascii, rounded, heavy, double)", theme.Symbols)
+	}
+	if _, ok := headerCases[theme.HeaderCase]; !ok {
+		return fmt.Errorf("invalid theme header case %q (valid options: upper, lower, title)", theme.HeaderCase)
+	}
+
+	activeTheme = theme
+	return nil
+}
+
+// table renders rows below a header in the active theme. Rows are collected
+// and laid out by tablewriter on Render, unless there are more than
+// streamChunk of them: then the table switches to a streamWriter.
+type table struct {
+	out    io.Writer
+	header []string
+	theme  Theme
+	rows   [][]string
+	stream *streamWriter
+	count  int
+}
+
+// newTable starts a table on stdout with the given column headers
+func newTable(header []string) *table {
+	return newTableTo(os.Stdout, header)
+}
+
+func newTableTo(w io.Writer, header []string) *table {
+	theme := activeTheme
+
+	formatted := make([]string, len(header))
+	for i, h := range header {
+		formatted[i] = headerCases[theme.HeaderCase](h)
+	}
+
+	return &table{out: w, header: formatted, theme: theme}
+}
+
+// Append adds a row, dimming every other row in zebra themes when colors are
+// enabled
+func (t *table) Append(row []string) {
+	if t.theme.Zebra && t.count%2 == 1 && ColorEnabled() {
+		dimmed := make([]string, len(row))
+		for i, cell := range row {
+			// Colored cells reset all attributes; dim again after each reset
+			dimmed[i] = colorDim + strings.ReplaceAll(cell, colorReset, colorReset+colorDim) + colorReset
+		}
+		row = dimmed
+	}
+	t.count++
+
+	switch {
+	case t.stream != nil:
+		t.stream.Append(row)
+	case len(t.rows) == streamChunk:
+		t.stream = newStreamWriter(t.out, t.theme, t.header, append(t.rows, row))
+		t.rows = nil
+	default:
+		t.rows = append(t.rows, row)
+	}
+}
+
+// Render writes the table, or the rest of it once it is streaming
+func (t *table) Render() {
+	if t.stream != nil {
+		t.stream.Close()
+		return
+	}
+
+	borders := tw.Off
+	if t.theme.Borders {
+		borders = tw.On
+	}
+	columns := tw.On
+	if t.theme.Compact {
+		columns = tw.Off
+	}
+
+	writer := tablewriter.NewTable(t.out,
+		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{
+			Symbols: tw.NewSymbols(symbolStyles[t.theme.Symbols]),
+			Borders: tw.Border{Left: borders, Right: borders, Top: borders, Bottom: borders},
+			Settings: tw.Settings{
+				Separators: tw.Separators{BetweenColumns: columns, BetweenRows: tw.Off},
+				Lines:      tw.Lines{ShowHeaderLine: tw.On},
+			},
+		})),
+		tablewriter.WithHeaderAutoFormat(tw.Off),
+		tablewriter.WithHeaderAlignment(tw.AlignLeft),
+	)
+	writer.Header(t.header)
+	for _, row := range t.rows {
+		writer.Append(row)
+	}
+	writer.Render()
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
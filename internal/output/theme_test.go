@@ -0,0 +1,123 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func renderWithTheme(t *testing.T, theme Theme) string {
+	t.Helper()
+
+	previous := activeTheme
+	defer func() { activeTheme = previous }()
+
+	if err := SetTheme(theme); err != nil {
+		t.Fatalf("SetTheme() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	table := newTableTo(&buf, []string{"Name", "IP"})
+	table.Append([]string{"alpha", "10.0.0.1"})
+	table.Append([]string{"beta", colorGreen + "10.0.0.2" + colorReset})
+	table.Render()
+	return buf.String()
+}
+
+func TestTheme_Default(t *testing.T) {
+	out := renderWithTheme(t, themes[DefaultTheme])
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected top, header, header line, 2 rows and bottom, got:\n%s", out)
+	}
+	if !strings.HasPrefix(lines[0], "┌") || !strings.HasPrefix(lines[2], "├") {
+		t.Errorf("Expected unicode borders and a header line, got:\n%s", out)
+	}
+	if !strings.Contains(lines[1], "Name") || strings.Contains(lines[1], "NAME") {
+		t.Errorf("Expected headers as written, got %q", lines[1])
+	}
+}
+
+func TestTheme_ASCIIUpper(t *testing.T) {
+	out := renderWithTheme(t, Theme{Symbols: "ascii", Borders: true, HeaderCase: "upper"})
+
+	if strings.ContainsAny(out, "┌│─") {
+		t.Errorf("Expected only ASCII symbols, got:\n%s", out)
+	}
+	if !strings.HasPrefix(out, "+") || !strings.Contains(out, "NAME") {
+		t.Errorf("Expected ASCII borders and upper case headers, got:\n%s", out)
+	}
+}
+
+func TestTheme_Compact(t *testing.T) {
+	out := renderWithTheme(t, themes["compact"])
+
+	if strings.Contains(out, "│") || strings.Contains(out, "┌") {
+		t.Errorf("Expected no borders or column separators, got:\n%s", out)
+	}
+	if !strings.Contains(out, "NAME") || !strings.Contains(out, "─") {
+		t.Errorf("Expected upper case headers above a line, got:\n%s", out)
+	}
+}
+
+func TestTheme_Zebra(t *testing.T) {
+	forceColors(t)
+
+	out := renderWithTheme(t, themes["zebra"])
+
+	lines := strings.Split(out, "\n")
+	var alpha, beta string
+	for _, line := range lines {
+		if strings.Contains(line, "alpha") {
+			alpha = line
+		}
+		if strings.Contains(line, "beta") {
+			beta = line
+		}
+	}
+
+	if strings.Contains(alpha, colorDim) {
+		t.Errorf("Expected the first row undimmed, got %q", alpha)
+	}
+	if !strings.Contains(beta, colorDim+"beta"+colorReset) {
+		t.Errorf("Expected the second row dimmed, got %q", beta)
+	}
+	// Colors inside a dimmed cell are followed by dim again
+	if !strings.Contains(beta, colorGreen+"10.0.0.2"+colorReset+colorDim) {
+		t.Errorf("Expected dim to be restored after a colored value, got %q", beta)
+	}
+}
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range ThemeNames() {
+		if _, err := LookupTheme(name); err != nil {
+			t.Errorf("LookupTheme(%q) returned error: %v", name, err)
+		}
+	}
+
+	if _, err := LookupTheme("nope"); err == nil {
+		t.Error("Expected error for unknown theme")
+	}
+}
+
+func TestSetTheme_Invalid(t *testing.T) {
+	previous := activeTheme
+	defer func() { activeTheme = previous }()
+
+	if err := SetTheme(Theme{Symbols: "weird"}); err == nil {
+		t.Error("Expected error for unknown symbols")
+	}
+	if err := SetTheme(Theme{Symbols: "ascii", HeaderCase: "shouty"}); err == nil {
+		t.Error("Expected error for unknown header case")
+	}
+	if activeTheme != previous {
+		t.Error("Expected an invalid theme to leave the active theme unchanged")
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	if got := titleCase("HITS per day"); got != "Hits Per Day" {
+		t.Errorf("titleCase() = %q, expected %q", got, "Hits Per Day")
+	}
+}
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/report"
+)
+
+// PrintTrafficTable lists traffic shares, largest first
+func PrintTrafficTable(shares []report.TrafficShare) {
+	table := newTable([]string{"Name", "Traffic", "Share", "Clients"})
+
+	for _, s := range shares {
+		table.Append([]string{s.Name, api.FormatBytes(s.Bytes), formatPercent(s.Percent), strconv.Itoa(s.Clients)})
+	}
+
+	table.Render()
+}
+
+// pieSymbols mark the shares in the ASCII breakdown, in order
+var pieSymbols = []string{"#", "=", "*", "+", "o", "%", "@", "~", "x", ":", "-", "."}
+
+// pieWidth is the width of the ASCII breakdown bar
+const pieWidth = 60
+
+// PrintTrafficPie prints traffic shares as one bar split in proportion to
+// them, followed by a legend
+func PrintTrafficPie(shares []report.TrafficShare) {
+	var bar strings.Builder
+	for i, width := range segmentWidths(shares, pieWidth) {
+		bar.WriteString(strings.Repeat(pieSymbols[i%len(pieSymbols)], width))
+	}
+	fmt.Printf("[%s]\n\n", bar.String())
+
+	table := newTable([]string{"", "Name", "Share", "Traffic", "Clients"})
+	for i, s := range shares {
+		table.Append([]string{pieSymbols[i%len(pieSymbols)], s.Name, formatPercent(s.Percent), api.FormatBytes(s.Bytes), strconv.Itoa(s.Clients)})
+	}
+	table.Render()
+}
+
+// segmentWidths splits width between the shares in proportion to their
+// percentages, giving the cells lost to rounding to the largest remainders
+func segmentWidths(shares []report.TrafficShare, width int) []int {
+	widths := make([]int, len(shares))
+	remainders := make([]float64, len(shares))
+	used := 0
+	for i, s := range shares {
+		exact := s.Percent * float64(width) / 100
+		widths[i] = int(exact)
+		remainders[i] = exact - float64(widths[i])
+		used += widths[i]
+	}
+
+	var total float64
+	for _, s := range shares {
+		total += s.Percent
+	}
+	// Shares only add up to the full width when they cover all traffic
+	target := int(math.Round(total * float64(width) / 100))
+
+	for ; used < target; used++ {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		widths[best]++
+		remainders[best] = -1
+	}
+	return widths
+}
+
+func formatPercent(p float64) string {
+	return fmt.Sprintf("%.1f%%", p)
+}
+
+// pieColors fill the slices of the SVG chart, in order
+var pieColors = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+// WriteTrafficSVG draws traffic shares as an SVG pie chart with a legend
+func WriteTrafficSVG(w io.Writer, shares []report.TrafficShare, title string) error {
+	const (
+		cx, cy, r = 160.0, 180.0, 130.0
+		legendX   = 330
+	)
+	height := max(360, 70+len(shares)*24)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="720" height="%d" viewBox="0 0 720 %d" font-family="sans-serif" font-size="14">`+"\n", height, height)
+	fmt.Fprintf(&b, `<text x="20" y="30" font-size="18" font-weight="bold">%s</text>`+"\n", html.EscapeString(title))
+
+	angle := -math.Pi / 2
+	for i, s := range shares {
+		color := pieColors[i%len(pieColors)]
+		sweep := s.Percent / 100 * 2 * math.Pi
+
+		switch {
+		case sweep <= 0:
+		case sweep >= 2*math.Pi-1e-9:
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`+"\n", cx, cy, r, color)
+		default:
+			x1, y1 := cx+r*math.Cos(angle), cy+r*math.Sin(angle)
+			x2, y2 := cx+r*math.Cos(angle+sweep), cy+r*math.Sin(angle+sweep)
+			large := 0
+			if sweep > math.Pi {
+				large = 1
+			}
+			fmt.Fprintf(&b, `<path d="M%.1f,%.1f L%.1f,%.1f A%.1f,%.1f 0 %d 1 %.1f,%.1f Z" fill="%s" stroke="#fff"/>`+"\n",
+				cx, cy, x1, y1, r, r, large, x2, y2, color)
+		}
+		angle += sweep
+
+		y := 60 + i*24
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="14" height="14" fill="%s"/>`+"\n", legendX, y, color)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s %s (%s)</text>`+"\n", legendX+22, y+12,
+			html.EscapeString(s.Name), formatPercent(s.Percent), api.FormatBytes(s.Bytes))
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
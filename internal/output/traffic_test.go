@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/report"
+)
+
+var testShares = []report.TrafficShare{
+	{Name: "TV", Bytes: 3 << 30, Percent: 60, Clients: 1},
+	{Name: "Laptop & Phone", Bytes: 1 << 30, Percent: 20, Clients: 2},
+	{Name: "Other", Bytes: 1 << 30, Percent: 20, Clients: 5},
+}
+
+func TestPrintTrafficTable(t *testing.T) {
+	out := captureStdout(t, func() { PrintTrafficTable(testShares) })
+
+	for _, want := range []string{"Traffic", "TV", "3.00 GB", "60.0%", "Laptop & Phone", "Other", "5"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintTrafficPie(t *testing.T) {
+	out := captureStdout(t, func() { PrintTrafficPie(testShares) })
+
+	bar := "[" + strings.Repeat("#", 36) + strings.Repeat("=", 12) + strings.Repeat("*", 12) + "]"
+	if !strings.HasPrefix(out, bar+"\n") {
+		t.Errorf("Expected the bar %s, got:\n%s", bar, out)
+	}
+	for _, want := range []string{"TV", "60.0%", "Laptop & Phone", "20.0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestSegmentWidths(t *testing.T) {
+	shares := []report.TrafficShare{{Percent: 33.4}, {Percent: 33.3}, {Percent: 33.3}}
+	widths := segmentWidths(shares, 10)
+	if widths[0]+widths[1]+widths[2] != 10 || widths[0] != 4 {
+		t.Errorf("Expected the widths to fill the bar, got %v", widths)
+	}
+
+	if got := segmentWidths([]report.TrafficShare{{Percent: 0.1}}, 10); got[0] != 0 {
+		t.Errorf("Expected a tiny share to get no cells, got %v", got)
+	}
+}
+
+func TestWriteTrafficSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTrafficSVG(&buf, testShares, "Traffic <24h>"); err != nil {
+		t.Fatalf("WriteTrafficSVG() returned error: %v", err)
+	}
+
+	svg := buf.String()
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Errorf("Expected well-formed XML, got %v:\n%s", err, svg)
+	}
+	if strings.Count(svg, "<path") != 3 || !strings.Contains(svg, "Laptop &amp; Phone") || !strings.Contains(svg, "Traffic &lt;24h&gt;") {
+		t.Errorf("Unexpected SVG:\n%s", svg)
+	}
+
+	buf.Reset()
+	WriteTrafficSVG(&buf, []report.TrafficShare{{Name: "All", Percent: 100}}, "")
+	if !strings.Contains(buf.String(), "<circle") {
+		t.Errorf("Expected a full circle for a single share:\n%s", buf.String())
+	}
+}
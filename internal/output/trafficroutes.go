@@ -0,0 +1,76 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// PrintTrafficRoutesTable lists traffic routes with the traffic they match
+// and the interface it leaves through. names maps network IDs and client
+// MACs to names.
+func PrintTrafficRoutesTable(routes []api.TrafficRoute, names map[string]string) {
+	table := newTable([]string{"ID", "Description", "Clients", "Destination", "Interface", "Kill Switch", "Enabled"})
+
+	name := func(id string) string {
+		if n, ok := names[id]; ok {
+			return n
+		}
+		return id
+	}
+
+	for _, r := range routes {
+		table.Append([]string{
+			r.ID,
+			r.Description,
+			formatRouteClients(r.TargetDevices, name),
+			formatRouteDestination(r),
+			name(r.NetworkID),
+			yesNo(r.KillSwitchEnabled),
+			onOff(r.Enabled),
+		})
+	}
+
+	table.Render()
+}
+
+// formatRouteClients lists the clients and networks a route applies to
+func formatRouteClients(devices []api.RouteTargetDevice, name func(string) string) string {
+	var parts []string
+	for _, d := range devices {
+		switch d.Type {
+		case api.RouteDeviceClient:
+			parts = append(parts, name(strings.ToLower(d.ClientMAC)))
+		case api.RouteDeviceNetwork:
+			parts = append(parts, name(d.NetworkID))
+		default:
+			parts = append(parts, "all")
+		}
+	}
+	if len(parts) == 0 {
+		return "all"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatRouteDestination describes the traffic a route matches, e.g.
+// "corp.example.com, vpn.example.com" or "all traffic"
+func formatRouteDestination(r api.TrafficRoute) string {
+	var parts []string
+	switch r.MatchingTarget {
+	case api.RouteTargetDomain:
+		for _, d := range r.Domains {
+			parts = append(parts, d.Domain)
+		}
+	case api.RouteTargetIP:
+		for _, ip := range r.IPAddresses {
+			parts = append(parts, ip.IPOrSubnet)
+		}
+	case api.RouteTargetRegion:
+		parts = append(parts, fmt.Sprintf("regions %s", strings.Join(r.Regions, ", ")))
+	default:
+		return "all traffic"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintTrafficRoutesTable(t *testing.T) {
+	names := map[string]string{"v1": "Work VPN", "w2": "Internet 2", "n2": "IoT", "aa:bb:cc:00:00:01": "laptop"}
+
+	out := captureStdout(t, func() {
+		PrintTrafficRoutesTable([]api.TrafficRoute{
+			{
+				ID: "tr1", Description: "Work domains", Enabled: true, MatchingTarget: api.RouteTargetDomain, NetworkID: "v1", KillSwitchEnabled: true,
+				TargetDevices: []api.RouteTargetDevice{{Type: api.RouteDeviceClient, ClientMAC: "AA:BB:CC:00:00:01"}, {Type: api.RouteDeviceNetwork, NetworkID: "n2"}},
+				Domains:       []api.RouteDomain{{Domain: "corp.example.com"}, {Domain: "vpn.example.com"}},
+			},
+			{
+				ID: "tr2", Description: "Failover", MatchingTarget: api.RouteTargetInternet, NetworkID: "w2",
+				TargetDevices: []api.RouteTargetDevice{{Type: api.RouteDeviceAllClients}},
+			},
+			{
+				ID: "tr3", Description: "Office", Enabled: true, MatchingTarget: api.RouteTargetIP, NetworkID: "v9",
+				IPAddresses: []api.RouteIPAddress{{IPOrSubnet: "10.1.0.0/16"}},
+			},
+		}, names)
+	})
+
+	for _, want := range []string{"Kill Switch", "tr1", "Work domains", "laptop, IoT", "corp.example.com, vpn.example.com", "Work VPN", "yes", "on",
+		"Failover", "all", "all traffic", "Internet 2", "off", "10.1.0.0/16", "v9"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/usage"
+)
+
+// PrintUsageTable prints the usage stats of every command, with run durations
+// and the latencies of their API requests
+func PrintUsageTable(stats []usage.Stats) {
+	table := newTable([]string{"Command", "Runs", "Failed", "Mean", "P95", "Max", "API Requests", "API P50", "API P95", "API P99"})
+
+	for _, s := range stats {
+		table.Append([]string{
+			s.Command,
+			strconv.Itoa(s.Runs),
+			nonZero(s.Failures),
+			formatElapsed(s.MeanDuration),
+			formatElapsed(s.P95Duration),
+			formatElapsed(s.MaxDuration),
+			nonZero(s.Requests),
+			formatElapsed(s.P50Latency),
+			formatElapsed(s.P95Latency),
+			formatElapsed(s.P99Latency),
+		})
+	}
+
+	table.Render()
+}
+
+// formatElapsed rounds d to milliseconds, leaving zero empty
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d == 0:
+		return ""
+	case d < time.Millisecond:
+		return "<1ms"
+	}
+	return d.Round(time.Millisecond).String()
+}
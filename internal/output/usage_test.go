@@ -0,0 +1,27 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/usage"
+)
+
+func TestPrintUsageTable(t *testing.T) {
+	stats := []usage.Stats{
+		{Command: "clients list", Runs: 12, Failures: 1, MeanDuration: 1234567 * time.Microsecond, P95Duration: 2 * time.Second, MaxDuration: 3 * time.Second, Requests: 24, P50Latency: 450 * time.Millisecond, P95Latency: 900 * time.Millisecond, P99Latency: time.Second},
+		{Command: "sysinfo", Runs: 2, MeanDuration: time.Millisecond, MaxDuration: 400 * time.Microsecond},
+	}
+
+	out := captureStdout(t, func() { PrintUsageTable(stats) })
+
+	for _, want := range []string{"API P99", "clients list", "12", "1.235s", "450ms", "sysinfo", "1ms", "<1ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "0s") {
+		t.Errorf("Expected missing latencies to be left empty:\n%s", out)
+	}
+}
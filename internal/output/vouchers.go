@@ -0,0 +1,60 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var voucherHeader = []string{"Code", "Duration", "Uses", "Limits", "Note", "Created", "ID"}
+
+func voucherRow(v api.Voucher) []string {
+	uses := fmt.Sprintf("%d/%d", v.Used, v.Quota)
+	if v.Quota == 0 {
+		uses = fmt.Sprintf("%d/unlimited", v.Used)
+	}
+
+	return []string{
+		v.FormattedCode(),
+		api.FormatUptime(int64(v.Duration) * 60),
+		uses,
+		guestLimits(v.QosRateMaxUp, v.QosRateMaxDown, v.QosUsageQuota),
+		v.Note,
+		v.GetCreateTime().Local().Format("2006-01-02 15:04:05"),
+		v.ID,
+	}
+}
+
+// PrintVouchersTable lists vouchers with their code first, for handing out
+func PrintVouchersTable(vouchers []api.Voucher) {
+	table := newTable(voucherHeader)
+
+	for _, v := range vouchers {
+		table.Append(voucherRow(v))
+	}
+
+	table.Render()
+}
+
+// PrintVouchersCSV writes vouchers as CSV with a header row, e.g. for
+// printing them on cards
+func PrintVouchersCSV(vouchers []api.Voucher) error {
+	w := csv.NewWriter(os.Stdout)
+
+	if err := w.Write(voucherHeader); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, v := range vouchers {
+		if err := w.Write(voucherRow(v)); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
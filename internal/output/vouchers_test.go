@@ -0,0 +1,41 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var testVouchers = []api.Voucher{
+	{ID: "v1", Code: "1234567890", CreateTime: 1700000000, Duration: 1440, Quota: 1, Note: "Room 12, east wing"},
+	{ID: "v2", Code: "0987654321", CreateTime: 1700000000, Duration: 90, Quota: 0, Used: 3, QosRateMaxDown: 5000, QosUsageQuota: 1024},
+}
+
+func TestPrintVouchersTable(t *testing.T) {
+	output := captureStdout(t, func() {
+		PrintVouchersTable(testVouchers)
+	})
+
+	for _, expected := range []string{"Code", "12345-67890", "1d", "0/1", "Room 12", "09876-54321", "1h 30m", "3/unlimited", "down 5000 Kbps, 1024 MB"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Table output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPrintVouchersCSV(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := PrintVouchersCSV(testVouchers[:1]); err != nil {
+			t.Errorf("PrintVouchersCSV() returned error: %v", err)
+		}
+	})
+
+	created := time.Unix(1700000000, 0).Local().Format("2006-01-02 15:04:05")
+	expected := "Code,Duration,Uses,Limits,Note,Created,ID\n" +
+		"12345-67890,1d,0/1,,\"Room 12, east wing\"," + created + ",v1\n"
+	if output != expected {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expected, output)
+	}
+}
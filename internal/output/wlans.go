@@ -0,0 +1,143 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"github.com/nkn/unifi-cli/internal/wlan"
+)
+
+// PrintWLANsTable lists wireless networks with their security, bands, network
+// and connected clients
+func PrintWLANsTable(wlans []api.WLANStatus) {
+	table := newTable([]string{"SSID", "Enabled", "Security", "Bands", "Network", "Guest", "Clients"})
+
+	for _, wlan := range wlans {
+		ssid := wlan.Name
+		if wlan.HideSSID {
+			ssid += " (hidden)"
+		}
+
+		table.Append([]string{
+			ssid,
+			onOff(wlan.Enabled),
+			wlan.GetSecurity(),
+			wlan.GetBands(),
+			wlanNetwork(wlan),
+			yesNo(wlan.IsGuest),
+			strconv.Itoa(wlan.Clients),
+		})
+	}
+
+	table.Render()
+}
+
+// PrintWLANDetails shows the settings of a single wireless network. The
+// passphrase is only included when showPassphrase is set.
+func PrintWLANDetails(wlan api.WLANStatus, showPassphrase bool) {
+	passphrase := ""
+	switch {
+	case wlan.Security == "open" || wlan.Security == "wpaeap":
+	case showPassphrase:
+		passphrase = wlan.Passphrase
+	default:
+		passphrase = "hidden (use --show-passphrase)"
+	}
+
+	schedule := ""
+	if wlan.ScheduleEnabled {
+		schedule = fmt.Sprintf("%d periods", len(wlan.Schedule))
+	}
+
+	privatePSKs := ""
+	if wlan.PrivatePSKsEnabled {
+		privatePSKs = strconv.Itoa(len(wlan.PrivatePSKs))
+	}
+
+	PrintDetails([]Detail{
+		{Key: "SSID", Value: wlan.Name},
+		{Key: "ID", Value: wlan.ID},
+		{Key: "Enabled", Value: onOff(wlan.Enabled)},
+		{Key: "Hidden", Value: yesNo(wlan.HideSSID)},
+		{Key: "Security", Value: wlan.GetSecurity()},
+		{Key: "Passphrase", Value: passphrase},
+		{Key: "Private PSKs", Value: privatePSKs},
+		{Key: "Bands", Value: wlan.GetBands()},
+		{Key: "Network", Value: wlanNetwork(wlan)},
+		{Key: "Guest", Value: yesNo(wlan.IsGuest)},
+		{Key: "Schedule", Value: schedule},
+		{Key: "Clients", Value: strconv.Itoa(wlan.Clients)},
+	})
+}
+
+// wlanNetwork names the network a WLAN is bridged to, with its VLAN
+func wlanNetwork(wlan api.WLANStatus) string {
+	if wlan.VLANID == 0 {
+		return wlan.Network
+	}
+	if wlan.Network == "" {
+		return fmt.Sprintf("VLAN %d", wlan.VLANID)
+	}
+	return fmt.Sprintf("%s (VLAN %d)", wlan.Network, wlan.VLANID)
+}
+
+// PrintPrivatePSKsTable lists the private pre-shared keys of a WLAN with the
+// network each one puts its clients on. The passphrases are only included
+// when showPassphrase is set.
+func PrintPrivatePSKsTable(keys []api.PrivatePSK, networks []api.Network, showPassphrase bool) {
+	networkByID := make(map[string]api.Network, len(networks))
+	for _, n := range networks {
+		networkByID[n.ID] = n
+	}
+
+	table := newTable([]string{"Passphrase", "Network", "VLAN"})
+
+	for _, key := range keys {
+		name, vlan := key.NetworkConfID, "untagged"
+		if network, ok := networkByID[key.NetworkConfID]; ok {
+			name = network.Name
+			if network.VLANEnabled {
+				vlan = strconv.Itoa(network.VLAN)
+			}
+		}
+		passphrase := "hidden"
+		if showPassphrase {
+			passphrase = key.Password
+		}
+		table.Append([]string{passphrase, name, vlan})
+	}
+
+	table.Render()
+}
+
+// PrintScheduleTable lists the periods a scheduled WLAN is on. Periods that
+// end on a later day show the number of days in the Off column, e.g.
+// "23:00 (+2d)".
+func PrintScheduleTable(blocks []api.ScheduleBlock) {
+	table := newTable([]string{"Days", "On", "Off", "Duration"})
+
+	for _, block := range blocks {
+		start := block.StartHour*60 + block.StartMinute
+		end := start + block.Duration
+
+		off := clock(end % (24 * 60))
+		if days := end / (24 * 60); days > 0 {
+			off = fmt.Sprintf("%s (+%dd)", off, days)
+		}
+
+		table.Append([]string{
+			wlan.FormatDays(block.Days),
+			clock(start),
+			off,
+			api.FormatUptime(int64(block.Duration) * 60),
+		})
+	}
+
+	table.Render()
+}
+
+// clock formats minutes after midnight as HH:MM
+func clock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestPrintWLANsTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintWLANsTable([]api.WLANStatus{
+			{WLAN: api.WLAN{Name: "Home", Enabled: true, Security: "wpapsk", WPAMode: "wpa2", WLANBand: "both"}, Network: "LAN", Clients: 12},
+			{WLAN: api.WLAN{Name: "Guest", Security: "open", IsGuest: true, HideSSID: true, WLANBand: "2g"}, Network: "Guests", VLANID: 30},
+			{WLAN: api.WLAN{Name: "Legacy", Enabled: true, Security: "wpapsk", WPAMode: "wpa1"}, VLANID: 40},
+		})
+	})
+
+	for _, want := range []string{"Home", "on", "WPA2", "2.4/5 GHz", "LAN", "12", "Guest (hidden)", "off", "Open", "2.4 GHz", "Guests (VLAN 30)", "yes", "VLAN 40"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintWLANDetails(t *testing.T) {
+	wlan := api.WLANStatus{WLAN: api.WLAN{Name: "Office", Security: "wpapsk", WPAMode: "wpa2", Passphrase: "correct horse"}, Network: "Staff", VLANID: 20}
+
+	hidden := captureStdout(t, func() { PrintWLANDetails(wlan, false) })
+	if strings.Contains(hidden, "correct horse") || !strings.Contains(hidden, "--show-passphrase") {
+		t.Errorf("Expected the passphrase to be hidden:\n%s", hidden)
+	}
+	if !strings.Contains(hidden, "Staff (VLAN 20)") {
+		t.Errorf("Expected the network in output:\n%s", hidden)
+	}
+
+	shown := captureStdout(t, func() { PrintWLANDetails(wlan, true) })
+	if !strings.Contains(shown, "correct horse") {
+		t.Errorf("Expected the passphrase with showPassphrase:\n%s", shown)
+	}
+}
+
+func TestPrintPrivatePSKsTable(t *testing.T) {
+	keys := []api.PrivatePSK{{Password: "kids-passphrase", NetworkConfID: "n2"}, {Password: "lan-passphrase", NetworkConfID: "n1"}}
+	networks := []api.Network{{ID: "n1", Name: "LAN"}, {ID: "n2", Name: "Kids", VLANEnabled: true, VLAN: 20}}
+
+	hidden := captureStdout(t, func() {
+		PrintPrivatePSKsTable(keys, networks, false)
+	})
+	if strings.Contains(hidden, "kids-passphrase") || !strings.Contains(hidden, "hidden") {
+		t.Errorf("Expected the passphrases to be hidden:\n%s", hidden)
+	}
+
+	out := captureStdout(t, func() {
+		PrintPrivatePSKsTable(keys, networks, true)
+	})
+
+	for _, want := range []string{"Passphrase", "kids-passphrase", "Kids", "20", "lan-passphrase", "LAN", "untagged"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintScheduleTable(t *testing.T) {
+	out := captureStdout(t, func() {
+		PrintScheduleTable([]api.ScheduleBlock{
+			{Days: []string{"tue", "wed", "thu", "fri"}, StartHour: 6, Duration: 17 * 60},
+			{Days: []string{"sat"}, StartHour: 6, StartMinute: 30, Duration: 2*24*60 + 16*60 + 30},
+		})
+	})
+
+	for _, want := range []string{"Days", "tue-fri", "06:00", "23:00", "17h", "sat", "06:30", "23:00 (+2d)", "2d 16h 30m"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
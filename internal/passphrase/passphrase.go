@@ -0,0 +1,126 @@
+// Package passphrase generates WPA pre-shared keys.
+package passphrase
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+const (
+	// MinLength and MaxLength bound a WPA passphrase
+	MinLength = 8
+	MaxLength = 63
+
+	// MinWordlistSize is the smallest accepted word list, so that a few
+	// words still give a reasonable amount of entropy
+	MinWordlistSize = 1000
+)
+
+// alphabet leaves out characters that are easily confused when a passphrase
+// is read off a screen or a printed sign (0/O, 1/l/I)
+const alphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Random returns a passphrase of length random characters
+func Random(length int) (string, error) {
+	if err := checkLength(length); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := randomIndex(len(alphabet))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(alphabet[n])
+	}
+	return sb.String(), nil
+}
+
+// Words returns random words joined by dashes, adding words until the
+// passphrase is at least minLength characters long
+func Words(words []string, minLength int) (string, error) {
+	if err := checkLength(minLength); err != nil {
+		return "", err
+	}
+	if len(words) < MinWordlistSize {
+		return "", fmt.Errorf("word list has %d words, at least %d are required", len(words), MinWordlistSize)
+	}
+
+	var chosen []string
+	length := -1
+	for length < minLength {
+		n, err := randomIndex(len(words))
+		if err != nil {
+			return "", err
+		}
+		chosen = append(chosen, words[n])
+		length += len(words[n]) + 1
+	}
+
+	if length > MaxLength {
+		return "", fmt.Errorf("passphrase from word list is longer than %d characters; use a shorter length", MaxLength)
+	}
+	return strings.Join(chosen, "-"), nil
+}
+
+// LoadWordlist reads one word per line. Diceware lists ("11111<TAB>word") are
+// accepted as well: only the last field of each line is used.
+func LoadWordlist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer file.Close()
+
+	seen := map[string]bool{}
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		word := strings.ToLower(fields[len(fields)-1])
+		if !isPlainWord(word) || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+	return words, nil
+}
+
+func checkLength(length int) error {
+	if length < MinLength || length > MaxLength {
+		return fmt.Errorf("length must be between %d and %d", MinLength, MaxLength)
+	}
+	return nil
+}
+
+// isPlainWord skips possessives, accented words and the like, which are
+// awkward to type on a phone
+func isPlainWord(word string) bool {
+	for _, r := range word {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return word != ""
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random number: %w", err)
+	}
+	return int(i.Int64()), nil
+}
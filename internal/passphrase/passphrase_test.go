@@ -0,0 +1,76 @@
+package passphrase
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testWords(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%c%c", 'a'+i%26, 'a'+i/26%26)
+	}
+	return words
+}
+
+func TestRandom(t *testing.T) {
+	p, err := Random(16)
+	if err != nil {
+		t.Fatalf("Random() returned error: %v", err)
+	}
+	if len(p) != 16 {
+		t.Errorf("Expected 16 characters, got %d", len(p))
+	}
+	if strings.ContainsAny(p, "0O1lI") {
+		t.Errorf("Expected no ambiguous characters, got %s", p)
+	}
+
+	other, _ := Random(16)
+	if p == other {
+		t.Error("Expected two random passphrases to differ")
+	}
+
+	for _, length := range []int{7, 64} {
+		if _, err := Random(length); err == nil {
+			t.Errorf("Expected error for length %d", length)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	p, err := Words(testWords(MinWordlistSize), 16)
+	if err != nil {
+		t.Fatalf("Words() returned error: %v", err)
+	}
+	if len(p) < 16 {
+		t.Errorf("Expected at least 16 characters, got %q", p)
+	}
+	if len(strings.Split(p, "-")) < 3 {
+		t.Errorf("Expected at least 3 dash separated words, got %q", p)
+	}
+
+	if _, err := Words(testWords(10), 16); err == nil {
+		t.Error("Expected error for a too small word list")
+	}
+}
+
+func TestLoadWordlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	content := "11111\tabacus\n11112\tabdomen\n\nApple\napple\nbob's\ncafé\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+
+	words, err := LoadWordlist(path)
+	if err != nil {
+		t.Fatalf("LoadWordlist() returned error: %v", err)
+	}
+
+	expected := []string{"abacus", "abdomen", "apple"}
+	if strings.Join(words, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, words)
+	}
+}
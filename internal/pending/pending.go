@@ -0,0 +1,130 @@
+package pending
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/datafile"
+)
+
+// ActionUnblock lifts a temporary client block
+const ActionUnblock = "unblock"
+
+// Action is a controller operation deferred until Due. Host and Site pin the
+// action to the controller it was scheduled against.
+type Action struct {
+	Type string    `json:"type"`
+	Host string    `json:"host"`
+	Site string    `json:"site"`
+	MAC  string    `json:"mac"`
+	Due  time.Time `json:"due"`
+}
+
+// Store is a JSON file of pending actions
+type Store struct {
+	path    string
+	Actions []Action
+}
+
+// Load reads the store at path; a missing file yields an empty store
+func Load(path string) (*Store, error) {
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read pending actions: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &store.Actions); err != nil {
+		return nil, fmt.Errorf("failed to parse pending actions: %w", err)
+	}
+
+	return store, nil
+}
+
+// Add schedules an action, replacing any action of the same type already
+// scheduled for the same client. MACs are stored in lower case.
+func (s *Store) Add(action Action) {
+	action.MAC = strings.ToLower(action.MAC)
+	s.Remove(action)
+	s.Actions = append(s.Actions, action)
+}
+
+// Remove drops the action of the same type scheduled for the same client
+func (s *Store) Remove(action Action) {
+	kept := s.Actions[:0]
+	for _, a := range s.Actions {
+		if !a.sameTarget(action) {
+			kept = append(kept, a)
+		}
+	}
+	s.Actions = kept
+}
+
+// Complete drops a finished action, unless the client has been rescheduled
+// to another time since it was loaded
+func (s *Store) Complete(action Action) {
+	kept := s.Actions[:0]
+	for _, a := range s.Actions {
+		if !a.sameTarget(action) || !a.Due.Equal(action.Due) {
+			kept = append(kept, a)
+		}
+	}
+	s.Actions = kept
+}
+
+// Due returns the actions for host that are due at now
+func (s *Store) Due(host string, now time.Time) []Action {
+	var due []Action
+	for _, a := range s.Actions {
+		if a.Host == host && !a.Due.After(now) {
+			due = append(due, a)
+		}
+	}
+	return due
+}
+
+// Save writes the store back to disk, creating its directory if needed. The
+// file is replaced atomically; use Update to change the store when other
+// invocations may be changing it at the same time.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s.Actions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pending actions: %w", err)
+	}
+
+	if err := datafile.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pending actions: %w", err)
+	}
+
+	return nil
+}
+
+// Update loads the store at path, applies change and saves it, holding the
+// store's lock throughout so concurrent invocations do not lose each other's
+// changes
+func Update(path string, change func(*Store)) error {
+	lock, err := datafile.LockFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to lock pending actions: %w", err)
+	}
+	defer lock.Unlock()
+
+	store, err := Load(path)
+	if err != nil {
+		return err
+	}
+	change(store)
+	return store.Save()
+}
+
+func (a Action) sameTarget(other Action) bool {
+	return a.Type == other.Type && a.Host == other.Host && a.Site == other.Site && strings.EqualFold(a.MAC, other.MAC)
+}
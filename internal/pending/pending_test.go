@@ -0,0 +1,152 @@
+package pending
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	store, err := Load(filepath.Join(t.TempDir(), "pending.json"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(store.Actions) != 0 {
+		t.Errorf("Expected empty store, got %d actions", len(store.Actions))
+	}
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "pending.json")
+	due := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store, _ := Load(path)
+	store.Add(Action{Type: ActionUnblock, Host: "https://h", Site: "default", MAC: "aa:bb:cc:dd:ee:ff", Due: due})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(loaded.Actions) != 1 {
+		t.Fatalf("Expected 1 action, got %d", len(loaded.Actions))
+	}
+	if !loaded.Actions[0].Due.Equal(due) {
+		t.Errorf("Expected due %v, got %v", due, loaded.Actions[0].Due)
+	}
+}
+
+func TestStore_AddReplacesSameTarget(t *testing.T) {
+	store := &Store{}
+	action := Action{Type: ActionUnblock, Host: "https://h", Site: "default", MAC: "aa:bb:cc:dd:ee:ff"}
+
+	store.Add(action)
+	action.Due = time.Now().Add(time.Hour)
+	store.Add(action)
+
+	if len(store.Actions) != 1 {
+		t.Fatalf("Expected 1 action, got %d", len(store.Actions))
+	}
+	if !store.Actions[0].Due.Equal(action.Due) {
+		t.Error("Expected the newer action to replace the older one")
+	}
+
+	store.Remove(action)
+	if len(store.Actions) != 0 {
+		t.Errorf("Expected action to be removed, got %d", len(store.Actions))
+	}
+}
+
+func TestStore_MACCaseInsensitive(t *testing.T) {
+	store := &Store{}
+	upper := Action{Type: ActionUnblock, Host: "https://h", Site: "default", MAC: "AA:BB:CC:DD:EE:FF", Due: time.Now().Add(2 * time.Hour)}
+
+	store.Add(upper)
+	if store.Actions[0].MAC != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("Expected the MAC to be stored in lower case, got %s", store.Actions[0].MAC)
+	}
+
+	// A permanent block typed in lower case must cancel the timed one
+	store.Remove(Action{Type: ActionUnblock, Host: "https://h", Site: "default", MAC: "aa:bb:cc:dd:ee:ff"})
+	if len(store.Actions) != 0 {
+		t.Errorf("Expected the scheduled unblock to be removed, got %+v", store.Actions)
+	}
+
+	// Entries stored in upper case by earlier versions still match
+	store.Actions = []Action{upper}
+	store.Add(Action{Type: ActionUnblock, Host: "https://h", Site: "default", MAC: "aa:bb:cc:dd:ee:ff"})
+	if len(store.Actions) != 1 {
+		t.Errorf("Expected the action to be replaced, got %+v", store.Actions)
+	}
+}
+
+func TestStore_Due(t *testing.T) {
+	now := time.Now()
+	store := &Store{Actions: []Action{
+		{Type: ActionUnblock, Host: "https://a", MAC: "01", Due: now.Add(-time.Minute)},
+		{Type: ActionUnblock, Host: "https://a", MAC: "02", Due: now.Add(time.Minute)},
+		{Type: ActionUnblock, Host: "https://b", MAC: "03", Due: now.Add(-time.Minute)},
+	}}
+
+	due := store.Due("https://a", now)
+	if len(due) != 1 || due[0].MAC != "01" {
+		t.Errorf("Expected only action 01 to be due, got %+v", due)
+	}
+}
+
+func TestUpdate_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pending.json")
+	due := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- Update(path, func(store *Store) {
+				store.Add(Action{Type: ActionUnblock, Host: "https://h", Site: "default", MAC: fmt.Sprintf("aa:bb:cc:dd:ee:%02x", i), Due: due})
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Update() returned error: %v", err)
+		}
+	}
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(store.Actions) != 20 {
+		t.Errorf("Expected all 20 actions to be kept, got %d", len(store.Actions))
+	}
+}
+
+func TestStore_CompleteKeepsRescheduled(t *testing.T) {
+	first := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	action := Action{Type: ActionUnblock, Host: "https://h", Site: "default", MAC: "aa:bb:cc:dd:ee:ff", Due: first}
+
+	store := &Store{}
+	store.Add(action)
+	store.Complete(action)
+	if len(store.Actions) != 0 {
+		t.Errorf("Expected the completed action to be removed, got %v", store.Actions)
+	}
+
+	rescheduled := action
+	rescheduled.Due = first.Add(time.Hour)
+	store.Add(rescheduled)
+	store.Complete(action)
+	if len(store.Actions) != 1 {
+		t.Errorf("Expected the rescheduled action to be kept, got %v", store.Actions)
+	}
+}
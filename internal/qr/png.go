@@ -0,0 +1,40 @@
+package qr
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// imageQuietZone is the border around the symbol in images, in modules. The
+// standard asks for four; terminals get away with less.
+const imageQuietZone = 4
+
+// Image renders the code as a black and white image with scale pixels per
+// module
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+
+	size := (c.Size + 2*imageQuietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, size, size))
+
+	for py := 0; py < size; py++ {
+		for px := 0; px < size; px++ {
+			value := color.Gray{Y: 0xff}
+			if c.darkAt(px/scale-imageQuietZone, py/scale-imageQuietZone) {
+				value = color.Gray{Y: 0}
+			}
+			img.SetGray(px, py, value)
+		}
+	}
+
+	return img
+}
+
+// WritePNG writes the code as a PNG image with scale pixels per module
+func (c *Code) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, c.Image(scale))
+}
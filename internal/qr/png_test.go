@@ -0,0 +1,43 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestCode_WritePNG(t *testing.T) {
+	code, err := Encode("hello")
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := code.WritePNG(&buf, 4); err != nil {
+		t.Fatalf("WritePNG() returned error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	size := (code.Size + 2*imageQuietZone) * 4
+	if b := img.Bounds(); b.Dx() != size || b.Dy() != size {
+		t.Fatalf("Expected %dx%d image, got %v", size, size, b)
+	}
+
+	dark := func(x, y int) bool {
+		r, _, _, _ := img.At(x, y).RGBA()
+		return r == 0
+	}
+
+	// The quiet zone is light, the finder pattern's corner dark
+	if dark(0, 0) {
+		t.Error("Expected the quiet zone to be light")
+	}
+	corner := imageQuietZone * 4
+	if !dark(corner, corner) || !dark(corner+3, corner+3) {
+		t.Error("Expected the top-left finder corner to be dark")
+	}
+}
@@ -0,0 +1,486 @@
+// Package qr encodes short texts such as WiFi credentials as QR codes.
+//
+// Only what the CLI needs is supported: byte mode, error correction level M
+// and versions 1 to 10 (up to 213 bytes), which comfortably fits a WiFi
+// payload with a 32 byte SSID and a 63 character passphrase.
+package qr
+
+import (
+	"fmt"
+)
+
+// MaxVersion is the largest symbol version Encode produces
+const MaxVersion = 10
+
+// Error correction level M: number of blocks and EC codewords per block for
+// versions 1 to 10
+var (
+	numBlocks     = [MaxVersion + 1]int{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5}
+	eccPerBlock   = [MaxVersion + 1]int{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26}
+	alignPatterns = [MaxVersion + 1][]int{
+		nil, nil,
+		{6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34},
+		{6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50},
+	}
+)
+
+// formatBitsM are the error correction level bits of level M in the format
+// information
+const formatBitsM = 0
+
+// Code is an encoded QR symbol
+type Code struct {
+	// Size is the width and height in modules, without quiet zone
+	Size    int
+	modules [][]bool
+	// isFunction marks finder, timing, alignment and format modules, which
+	// carry no data and are never masked
+	isFunction [][]bool
+}
+
+// Encode encodes text in the smallest version that fits it
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+
+	for version := 1; version <= MaxVersion; version++ {
+		if len(data) > dataCapacity(version) {
+			continue
+		}
+
+		code := newCode(version)
+		code.drawFunctionPatterns(version)
+		code.drawCodewords(addECC(version, encodeData(version, data)))
+		code.applyBestMask(version)
+		return code, nil
+	}
+
+	return nil, fmt.Errorf("text too long for a QR code (%d bytes, at most %d)", len(data), dataCapacity(MaxVersion))
+}
+
+// Dark reports whether the module at column x, row y is dark
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+func newCode(version int) *Code {
+	size := version*4 + 17
+	c := &Code{Size: size, modules: make([][]bool, size), isFunction: make([][]bool, size)}
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.isFunction[i] = make([]bool, size)
+	}
+	return c
+}
+
+// rawCodewords is the number of 8 bit codewords (data and EC) that fit a
+// version
+func rawCodewords(version int) int {
+	modules := (16*version+128)*version + 64
+	if version >= 2 {
+		align := version/7 + 2
+		modules -= (25*align-10)*align - 55
+		if version >= 7 {
+			modules -= 36
+		}
+	}
+	return modules / 8
+}
+
+func dataCodewords(version int) int {
+	return rawCodewords(version) - numBlocks[version]*eccPerBlock[version]
+}
+
+// dataCapacity is the number of bytes a version holds in byte mode
+func dataCapacity(version int) int {
+	// 4 bit mode indicator plus the character count
+	return (dataCodewords(version)*8 - 4 - countBits(version)) / 8
+}
+
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData builds the data codewords: mode indicator, character count,
+// the bytes, terminator and padding
+func encodeData(version int, data []byte) []byte {
+	var bits bitBuffer
+	bits.append(0x4, 4) // byte mode
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := dataCodewords(version) * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	return bits.bytes()
+}
+
+// addECC splits data into blocks, appends Reed-Solomon codewords to each and
+// interleaves the result
+func addECC(version int, data []byte) []byte {
+	blocks := numBlocks[version]
+	eccLen := eccPerBlock[version]
+	raw := rawCodewords(version)
+	shortBlocks := blocks - raw%blocks
+	shortLen := raw / blocks
+
+	divisor := reedSolomonDivisor(eccLen)
+	var all [][]byte
+	for i, k := 0, 0; i < blocks; i++ {
+		n := shortLen - eccLen
+		if i >= shortBlocks {
+			n++
+		}
+		block := append([]byte{}, data[k:k+n]...)
+		k += n
+		ecc := reedSolomonRemainder(block, divisor)
+		if i < shortBlocks {
+			// Placeholder so all blocks have the same length
+			block = append(block, 0)
+		}
+		all = append(all, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, raw)
+	for i := range all[0] {
+		for j, block := range all {
+			if i != shortLen-eccLen || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns(version int) {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	positions := alignPatterns[version]
+	n := len(positions)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			// Skip the three corners occupied by finders
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			c.drawAlignment(positions[i], positions[j])
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn once the mask is known
+	c.drawFormatBits(0)
+	c.drawVersion(version)
+}
+
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.Size || yy < 0 || yy >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatBits computes the 15 bit format information for level M and mask
+func formatBits(mask int) int {
+	data := formatBitsM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+
+	// First copy, around the top left finder
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	// Second copy, split between the other two finders
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.Size-8, true)
+}
+
+// versionBits computes the 18 bit version information (versions 7 and up)
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+func (c *Code) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+
+	bits := versionBits(version)
+	for i := 0; i < 18; i++ {
+		a := c.Size - 11 + i%3
+		b := i / 3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords places the codewords in the zigzag pattern, two columns at a
+// time from the bottom right, skipping function modules
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.isFunction[y][x] && i < len(data)*8 {
+					c.modules[y][x] = bit(int(data[i>>3]), 7-i&7)
+					i++
+				}
+			}
+		}
+	}
+}
+
+func maskApplies(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// applyMask flips the data modules selected by mask; applying it twice undoes it
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.isFunction[y][x] && maskApplies(mask, x, y) {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask tries all eight masks and keeps the one with the lowest
+// penalty score
+func (c *Code) applyBestMask(version int) {
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask)
+	}
+
+	c.applyMask(best)
+	c.drawFormatBits(best)
+}
+
+// penalty scores how hard the symbol is to read: long runs, 2x2 blocks,
+// finder-like patterns and an unbalanced dark/light ratio
+func (c *Code) penalty() int {
+	penalty := 0
+	dark := 0
+
+	line := func(get func(i int) bool) {
+		run := 1
+		for i := 1; i < c.Size; i++ {
+			if get(i) == get(i-1) {
+				run++
+				continue
+			}
+			if run >= 5 {
+				penalty += run - 2
+			}
+			run = 1
+		}
+		if run >= 5 {
+			penalty += run - 2
+		}
+
+		for i := 0; i+11 <= c.Size; i++ {
+			if matchesFinderLike(get, i) {
+				penalty += 40
+			}
+		}
+	}
+
+	for y := 0; y < c.Size; y++ {
+		line(func(i int) bool { return c.modules[y][i] })
+	}
+	for x := 0; x < c.Size; x++ {
+		line(func(i int) bool { return c.modules[i][x] })
+	}
+
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size {
+				v := c.modules[y][x]
+				if c.modules[y][x+1] == v && c.modules[y+1][x] == v && c.modules[y+1][x+1] == v {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	total := c.Size * c.Size
+	deviation := abs(dark*20-total*10) / total
+	penalty += deviation * 10
+
+	return penalty
+}
+
+var (
+	finderLikeA = []bool{true, false, true, true, true, false, true, false, false, false, false}
+	finderLikeB = []bool{false, false, false, false, true, false, true, true, true, false, true}
+)
+
+func matchesFinderLike(get func(i int) bool, start int) bool {
+	a, b := true, true
+	for i := 0; i < 11; i++ {
+		v := get(start + i)
+		a = a && v == finderLikeA[i]
+		b = b && v == finderLikeB[i]
+	}
+	return a || b
+}
+
+// reedSolomonDivisor returns the generator polynomial of the given degree
+// (without its leading term)
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, (len(b)+7)/8)
+	for i, set := range b {
+		if set {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 == 1
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
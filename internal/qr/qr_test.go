@@ -0,0 +1,179 @@
+package qr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" as version 1-Q data codewords and their EC codewords
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236}
+	expected := []byte{168, 72, 22, 82, 217, 54, 156, 0, 46, 15, 180, 122, 16}
+
+	if got := reedSolomonRemainder(data, reedSolomonDivisor(13)); !bytes.Equal(got, expected) {
+		t.Errorf("Expected EC codewords %v, got %v", expected, got)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	expected := map[int]int{
+		0: 0b101010000010010,
+		1: 0b101000100100101,
+		5: 0b100000011001110,
+		7: 0b100101010100000,
+	}
+
+	for mask, bits := range expected {
+		if got := formatBits(mask); got != bits {
+			t.Errorf("formatBits(%d) = %015b, expected %015b", mask, got, bits)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	if got := versionBits(7); got != 0b000111110010010100 {
+		t.Errorf("versionBits(7) = %018b", got)
+	}
+	if got := versionBits(10); got != 0b001010010011010011 {
+		t.Errorf("versionBits(10) = %018b", got)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	expected := map[int]int{1: 14, 2: 26, 5: 84, 6: 106, 7: 122, 10: 213}
+
+	for version, capacity := range expected {
+		if got := dataCapacity(version); got != capacity {
+			t.Errorf("dataCapacity(%d) = %d, expected %d", version, got, capacity)
+		}
+	}
+}
+
+func TestEncode_Version(t *testing.T) {
+	tests := []struct {
+		length int
+		size   int
+	}{
+		{14, 21},
+		{15, 25},
+		{106, 4*6 + 17},
+		{107, 4*7 + 17},
+		{213, 4*10 + 17},
+	}
+
+	for _, tt := range tests {
+		code, err := Encode(strings.Repeat("a", tt.length))
+		if err != nil {
+			t.Fatalf("Encode(%d bytes) returned error: %v", tt.length, err)
+		}
+		if code.Size != tt.size {
+			t.Errorf("Expected size %d for %d bytes, got %d", tt.size, tt.length, code.Size)
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", 214)); err == nil {
+		t.Error("Expected error for text exceeding the largest version")
+	}
+}
+
+func TestEncode_FinderPatterns(t *testing.T) {
+	code, err := Encode("WIFI:T:WPA;S:Home;P:secret;;")
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	// The outer ring of each finder is dark, the ring inside it light
+	for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+		x, y := corner[0], corner[1]
+		if !code.Dark(x, y) || !code.Dark(x+6, y+6) || code.Dark(x+1, y+1) || !code.Dark(x+3, y+3) {
+			t.Errorf("Missing finder pattern at %d,%d", x, y)
+		}
+	}
+}
+
+// TestEncode_RoundTrip reads a single-block symbol back: it identifies the
+// mask from the format bits, unmasks the data modules and checks the decoded
+// segment against the input
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, text := range []string{"hi", "WIFI:T:WPA;S:Home;P:secret;;", strings.Repeat("x", 42)} {
+		code, err := Encode(text)
+		if err != nil {
+			t.Fatalf("Encode() returned error: %v", err)
+		}
+		version := (code.Size - 17) / 4
+		if numBlocks[version] != 1 {
+			t.Fatalf("Round trip only supports single-block versions, got version %d", version)
+		}
+
+		format := 0
+		for i := 0; i <= 5; i++ {
+			if code.Dark(8, i) {
+				format |= 1 << i
+			}
+		}
+		for i, pos := range [][2]int{{8, 7}, {8, 8}, {7, 8}} {
+			if code.Dark(pos[0], pos[1]) {
+				format |= 1 << (6 + i)
+			}
+		}
+		for i := 9; i < 15; i++ {
+			if code.Dark(14-i, 8) {
+				format |= 1 << i
+			}
+		}
+
+		mask := -1
+		for m := 0; m < 8; m++ {
+			if formatBits(m) == format {
+				mask = m
+			}
+		}
+		if mask < 0 {
+			t.Fatalf("Format bits %015b match no mask", format)
+		}
+
+		layout := newCode(version)
+		layout.drawFunctionPatterns(version)
+
+		var bits bitBuffer
+		for right := code.Size - 1; right >= 1; right -= 2 {
+			if right == 6 {
+				right = 5
+			}
+			for vert := 0; vert < code.Size; vert++ {
+				for j := 0; j < 2; j++ {
+					x, y := right-j, vert
+					if (right+1)&2 == 0 {
+						y = code.Size - 1 - vert
+					}
+					if !layout.isFunction[y][x] {
+						bits = append(bits, code.Dark(x, y) != maskApplies(mask, x, y))
+					}
+				}
+			}
+		}
+
+		data := bits.bytes()
+		if data[0]>>4 != 0x4 {
+			t.Fatalf("Expected byte mode indicator, got %x", data[0]>>4)
+		}
+		length := int(data[0]&0x0F)<<4 | int(data[1]>>4)
+		if length != len(text) {
+			t.Fatalf("Expected length %d, got %d", len(text), length)
+		}
+		decoded := make([]byte, length)
+		for i := range decoded {
+			decoded[i] = data[1+i]<<4 | data[2+i]>>4
+		}
+		if string(decoded) != text {
+			t.Errorf("Expected %q, decoded %q", text, decoded)
+		}
+
+		dataLen := dataCodewords(version)
+		ecc := reedSolomonRemainder(data[:dataLen], reedSolomonDivisor(eccPerBlock[version]))
+		if !bytes.Equal(ecc, data[dataLen:dataLen+len(ecc)]) {
+			t.Error("EC codewords do not match the data codewords")
+		}
+	}
+}
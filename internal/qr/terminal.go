@@ -0,0 +1,37 @@
+package qr
+
+import "strings"
+
+// quietZone is the light border around the symbol, in modules
+const quietZone = 2
+
+// Terminal renders the code with Unicode half blocks, two rows per line.
+// Colors are set explicitly so the code scans on dark and light terminal
+// themes alike.
+func (c *Code) Terminal() string {
+	var sb strings.Builder
+
+	for y := -quietZone; y < c.Size+quietZone; y += 2 {
+		for x := -quietZone; x < c.Size+quietZone; x++ {
+			fg, bg := "97", "107"
+			if c.darkAt(x, y) {
+				fg = "30"
+			}
+			if c.darkAt(x, y+1) {
+				bg = "40"
+			}
+			sb.WriteString("\033[" + fg + ";" + bg + "m▀")
+		}
+		sb.WriteString("\033[0m\n")
+	}
+
+	return sb.String()
+}
+
+// darkAt is Dark extended with a light quiet zone around the symbol
+func (c *Code) darkAt(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
@@ -0,0 +1,34 @@
+package qr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCode_Terminal(t *testing.T) {
+	code, err := Encode("hello")
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(), "\n"), "\n")
+
+	// Two module rows per line, including the quiet zone
+	expectedLines := (code.Size + 2*quietZone + 1) / 2
+	if len(lines) != expectedLines {
+		t.Errorf("Expected %d lines, got %d", expectedLines, len(lines))
+	}
+	for _, line := range lines {
+		if n := strings.Count(line, "▀"); n != code.Size+2*quietZone {
+			t.Fatalf("Expected %d modules per line, got %d", code.Size+2*quietZone, n)
+		}
+		if !strings.HasSuffix(line, "\033[0m") {
+			t.Fatal("Expected each line to reset colors")
+		}
+	}
+
+	// The first line is all quiet zone: light on light
+	if strings.Contains(lines[0], "30;") || strings.Contains(lines[0], ";40m") {
+		t.Error("Expected the quiet zone to be light")
+	}
+}
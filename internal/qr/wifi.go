@@ -0,0 +1,29 @@
+package qr
+
+import "strings"
+
+// WiFi authentication types understood by phone cameras
+const (
+	AuthWPA    = "WPA"
+	AuthWEP    = "WEP"
+	AuthNoPass = "nopass"
+)
+
+var wifiEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `"`, `\"`, `:`, `\:`)
+
+// WiFi builds the payload phones recognise as network credentials, e.g.
+// WIFI:T:WPA;S:Home;P:secret;;
+func WiFi(ssid, auth, passphrase string, hidden bool) string {
+	var sb strings.Builder
+
+	sb.WriteString("WIFI:T:" + auth + ";S:" + wifiEscaper.Replace(ssid) + ";")
+	if auth != AuthNoPass {
+		sb.WriteString("P:" + wifiEscaper.Replace(passphrase) + ";")
+	}
+	if hidden {
+		sb.WriteString("H:true;")
+	}
+	sb.WriteString(";")
+
+	return sb.String()
+}
@@ -0,0 +1,27 @@
+package qr
+
+import "testing"
+
+func TestWiFi(t *testing.T) {
+	tests := []struct {
+		name       string
+		ssid       string
+		auth       string
+		passphrase string
+		hidden     bool
+		expected   string
+	}{
+		{"WPA", "Home", AuthWPA, "secret123", false, "WIFI:T:WPA;S:Home;P:secret123;;"},
+		{"Open", "Cafe", AuthNoPass, "", false, "WIFI:T:nopass;S:Cafe;;"},
+		{"Hidden", "Lab", AuthWPA, "pw", true, "WIFI:T:WPA;S:Lab;P:pw;H:true;;"},
+		{"Escaping", `a;b,c`, AuthWPA, `p:"q"\`, false, `WIFI:T:WPA;S:a\;b\,c;P:p\:\"q\"\\;;`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WiFi(tt.ssid, tt.auth, tt.passphrase, tt.hidden); got != tt.expected {
+				t.Errorf("WiFi() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package receive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// Env returns an event as UNIFI_EVENT_* environment variables
+func Env(e api.Event) []string {
+	return []string{
+		"UNIFI_EVENT_ID=" + e.ID,
+		"UNIFI_EVENT_KEY=" + e.Key,
+		"UNIFI_EVENT_MSG=" + e.Msg,
+		"UNIFI_EVENT_SUBSYSTEM=" + e.Subsystem,
+		"UNIFI_EVENT_TIME=" + e.GetTime().UTC().Format(time.RFC3339),
+		"UNIFI_EVENT_DEVICE=" + e.AP,
+	}
+}
+
+// Exec runs command with sh for an event, passing the event as JSON on stdin
+// and as UNIFI_EVENT_* environment variables
+func Exec(command string, e api.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	handler := exec.Command("sh", "-c", command)
+	handler.Env = append(os.Environ(), Env(e)...)
+	handler.Stdin = bytes.NewReader(payload)
+	handler.Stdout = os.Stdout
+	handler.Stderr = os.Stderr
+
+	if err := handler.Run(); err != nil {
+		return fmt.Errorf("handler for %s failed: %w", e.Key, err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package receive
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestExec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	event := api.Event{Key: "EVT_AP_Lost_Contact", Msg: "AP lost contact", Time: 1700000000000}
+
+	if err := Exec(`echo "$UNIFI_EVENT_KEY $UNIFI_EVENT_TIME" > `+out+` && cat >> `+out, event); err != nil {
+		t.Fatalf("Exec() returned error: %v", err)
+	}
+
+	data, _ := os.ReadFile(out)
+	lines := strings.SplitN(string(data), "\n", 2)
+	if lines[0] != "EVT_AP_Lost_Contact 2023-11-14T22:13:20Z" {
+		t.Errorf("Unexpected environment %q", lines[0])
+	}
+	if len(lines) < 2 || !strings.Contains(lines[1], `"msg":"AP lost contact"`) {
+		t.Errorf("Expected the event as JSON on stdin, got %q", data)
+	}
+
+	if err := Exec("exit 3", event); err == nil || !strings.Contains(err.Error(), "EVT_AP_Lost_Contact") {
+		t.Errorf("Expected handler error, got %v", err)
+	}
+}
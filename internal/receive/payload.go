@@ -0,0 +1,122 @@
+// Package receive accepts event pushes from UniFi controllers (webhooks and
+// alarm manager notifications) and turns them into controller events.
+package receive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// SubsystemAlarm is the subsystem of events from alarm manager payloads
+const SubsystemAlarm = "alarm"
+
+// alarmPayload is what the UniFi OS alarm manager posts to a webhook
+type alarmPayload struct {
+	Alarm *struct {
+		Name     string `json:"name"`
+		Triggers []struct {
+			Key       string `json:"key"`
+			Device    string `json:"device"`
+			EventID   string `json:"eventId"`
+			Timestamp int64  `json:"timestamp"`
+		} `json:"triggers"`
+	} `json:"alarm"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Parse reads the events of a pushed payload: a controller event, a list of
+// them (bare or in a {"data": [...]} envelope), or an alarm manager
+// notification with one event per trigger. Events without a time get now.
+func Parse(body []byte, now time.Time) ([]api.Event, error) {
+	body = bytes.TrimSpace(body)
+
+	var events []api.Event
+	switch {
+	case bytes.HasPrefix(body, []byte("[")):
+		if err := json.Unmarshal(body, &events); err != nil {
+			return nil, fmt.Errorf("failed to parse payload: %w", err)
+		}
+
+	case bytes.HasPrefix(body, []byte("{")):
+		var probe struct {
+			Key   string          `json:"key"`
+			Data  json.RawMessage `json:"data"`
+			Alarm json.RawMessage `json:"alarm"`
+		}
+		if err := json.Unmarshal(body, &probe); err != nil {
+			return nil, fmt.Errorf("failed to parse payload: %w", err)
+		}
+
+		switch {
+		case probe.Alarm != nil:
+			var alarm alarmPayload
+			if err := json.Unmarshal(body, &alarm); err != nil {
+				return nil, fmt.Errorf("failed to parse alarm: %w", err)
+			}
+			events = alarmEvents(alarm)
+		case probe.Key != "":
+			var event api.Event
+			if err := json.Unmarshal(body, &event); err != nil {
+				return nil, fmt.Errorf("failed to parse event: %w", err)
+			}
+			events = []api.Event{event}
+		case probe.Data != nil:
+			if err := json.Unmarshal(probe.Data, &events); err != nil {
+				return nil, fmt.Errorf("failed to parse events: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unrecognized payload (expected a controller event or an alarm)")
+		}
+
+	default:
+		return nil, fmt.Errorf("unrecognized payload (expected JSON)")
+	}
+
+	for i := range events {
+		if events[i].Key == "" {
+			return nil, fmt.Errorf("event %d has no key", i+1)
+		}
+		if events[i].Time == 0 {
+			events[i].Time = now.UnixMilli()
+		}
+	}
+	return events, nil
+}
+
+// alarmEvents turns every trigger of an alarm into an event keyed by the
+// trigger and described by the alarm's name. An alarm without triggers
+// yields one event keyed by its name.
+func alarmEvents(alarm alarmPayload) []api.Event {
+	if len(alarm.Alarm.Triggers) == 0 {
+		return []api.Event{{
+			Key:       alarm.Alarm.Name,
+			Msg:       alarm.Alarm.Name,
+			Subsystem: SubsystemAlarm,
+			Time:      alarm.Timestamp,
+		}}
+	}
+
+	events := make([]api.Event, 0, len(alarm.Alarm.Triggers))
+	for _, trigger := range alarm.Alarm.Triggers {
+		event := api.Event{
+			ID:        trigger.EventID,
+			Key:       trigger.Key,
+			Msg:       alarm.Alarm.Name,
+			Subsystem: SubsystemAlarm,
+			Time:      trigger.Timestamp,
+			AP:        trigger.Device,
+		}
+		if event.Key == "" {
+			event.Key = alarm.Alarm.Name
+		}
+		if event.Time == 0 {
+			event.Time = alarm.Timestamp
+		}
+		events = append(events, event)
+	}
+	return events
+}
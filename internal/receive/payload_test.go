@@ -0,0 +1,74 @@
+package receive
+
+import (
+	"testing"
+	"time"
+)
+
+var now = time.UnixMilli(1700000000000)
+
+func TestParse_Event(t *testing.T) {
+	events, err := Parse([]byte(`{"key":"EVT_AP_Lost_Contact","msg":"AP lost contact","subsystem":"wlan","time":1699999999000,"ap":"f0:9f:c2:00:00:01"}`), now)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if len(events) != 1 || events[0].Key != "EVT_AP_Lost_Contact" || events[0].Time != 1699999999000 || events[0].AP != "f0:9f:c2:00:00:01" {
+		t.Errorf("Unexpected events %+v", events)
+	}
+}
+
+func TestParse_Lists(t *testing.T) {
+	for _, body := range []string{
+		`[{"key":"EVT_A"},{"key":"EVT_B"}]`,
+		`{"meta":{"rc":"ok"},"data":[{"key":"EVT_A"},{"key":"EVT_B"}]}`,
+	} {
+		events, err := Parse([]byte(body), now)
+		if err != nil {
+			t.Fatalf("Parse(%s) returned error: %v", body, err)
+		}
+		if len(events) != 2 || events[1].Key != "EVT_B" || events[0].Time != now.UnixMilli() {
+			t.Errorf("Unexpected events for %s: %+v", body, events)
+		}
+	}
+}
+
+func TestParse_Alarm(t *testing.T) {
+	body := `{"alarm":{"name":"Gateway offline","triggers":[
+		{"key":"device_offline","device":"f0:9f:c2:00:00:01","eventId":"e1","timestamp":1699999990000},
+		{"key":"","device":"f0:9f:c2:00:00:02","eventId":"e2"}]},"timestamp":1699999995000}`
+
+	events, err := Parse([]byte(body), now)
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected one event per trigger, got %+v", events)
+	}
+
+	first := events[0]
+	if first.Key != "device_offline" || first.Msg != "Gateway offline" || first.Subsystem != SubsystemAlarm || first.ID != "e1" || first.AP != "f0:9f:c2:00:00:01" || first.Time != 1699999990000 {
+		t.Errorf("Unexpected first event %+v", first)
+	}
+	if events[1].Key != "Gateway offline" || events[1].Time != 1699999995000 {
+		t.Errorf("Expected alarm name and time as fallback, got %+v", events[1])
+	}
+
+	events, err = Parse([]byte(`{"alarm":{"name":"Test alarm"}}`), now)
+	if err != nil || len(events) != 1 || events[0].Key != "Test alarm" || events[0].Time != now.UnixMilli() {
+		t.Errorf("Expected one event for an alarm without triggers, got %+v, %v", events, err)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, body := range []string{
+		``,
+		`hello`,
+		`{"foo":"bar"}`,
+		`[{"msg":"no key"}]`,
+		`{"key":`,
+	} {
+		if _, err := Parse([]byte(body), now); err == nil {
+			t.Errorf("Expected error for %q", body)
+		}
+	}
+}
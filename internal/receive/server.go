@@ -0,0 +1,69 @@
+package receive
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// MaxPayload bounds the size of a pushed payload
+const MaxPayload = 1 << 20
+
+// ErrBusy is returned by a deliver function that cannot take more events
+// right now; the sender is asked to retry later
+var ErrBusy = errors.New("too many pending events")
+
+// Handler accepts payloads POSTed to any path and passes their events to
+// deliver. With a token set, requests must carry it as a bearer token or in
+// the token query parameter.
+func Handler(token string, deliver func([]api.Event) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if token != "" && !authorized(r, token) {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxPayload))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read payload: %v", err), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		events, err := Parse(body, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := deliver(events); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrBusy) {
+				status = http.StatusServiceUnavailable
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func authorized(r *http.Request, token string) bool {
+	given := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		given = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
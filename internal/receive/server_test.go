@@ -0,0 +1,64 @@
+package receive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestHandler(t *testing.T) {
+	var delivered []api.Event
+	handler := Handler("s3cret", func(events []api.Event) error {
+		delivered = append(delivered, events...)
+		return nil
+	})
+
+	post := func(target, auth, body string) int {
+		req := httptest.NewRequest("POST", target, strings.NewReader(body))
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post("/", "", `{"key":"EVT_A"}`); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without token, got %d", code)
+	}
+	if code := post("/", "Bearer wrong", `{"key":"EVT_A"}`); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with wrong token, got %d", code)
+	}
+	if code := post("/", "Bearer s3cret", `{"key":"EVT_A"}`); code != http.StatusAccepted {
+		t.Errorf("Expected 202 with bearer token, got %d", code)
+	}
+	if code := post("/hook?token=s3cret", "", `{"key":"EVT_B"}`); code != http.StatusAccepted {
+		t.Errorf("Expected 202 with token parameter, got %d", code)
+	}
+	if code := post("/?token=s3cret", "", `{"foo":1}`); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown payload, got %d", code)
+	}
+
+	if len(delivered) != 2 || delivered[0].Key != "EVT_A" || delivered[1].Key != "EVT_B" {
+		t.Errorf("Unexpected delivered events %+v", delivered)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/?token=s3cret", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Busy(t *testing.T) {
+	handler := Handler("", func(events []api.Event) error { return ErrBusy })
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{"key":"EVT_A"}`)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 when busy, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,75 @@
+// Package report aggregates controller data into summaries for review.
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// DFSHits summarizes the radar detections of one AP on one channel
+type DFSHits struct {
+	AP      string    `json:"ap"`
+	APName  string    `json:"ap_name"`
+	Channel int       `json:"channel"`
+	Hits    int       `json:"hits"`
+	First   time.Time `json:"first"`
+	Last    time.Time `json:"last"`
+	// Days counts hits per local calendar day (YYYY-MM-DD)
+	Days map[string]int `json:"days"`
+}
+
+// DFS groups the DFS radar events among events by AP and channel, most hits
+// first. Channels that are hit often are candidates to replace with non-DFS
+// channels.
+func DFS(events []api.Event) []DFSHits {
+	type key struct {
+		ap      string
+		channel int
+	}
+
+	groups := map[key]*DFSHits{}
+	for _, e := range events {
+		if !e.IsDFS() {
+			continue
+		}
+
+		k := key{e.AP, e.GetChannel()}
+		hits, ok := groups[k]
+		if !ok {
+			hits = &DFSHits{AP: e.AP, APName: e.APName, Channel: k.channel, Days: map[string]int{}}
+			groups[k] = hits
+		}
+
+		when := e.GetTime()
+		if hits.Hits == 0 || when.Before(hits.First) {
+			hits.First = when
+		}
+		if hits.Hits == 0 || when.After(hits.Last) {
+			hits.Last = when
+		}
+		if hits.APName == "" {
+			hits.APName = e.APName
+		}
+		hits.Hits++
+		hits.Days[when.Local().Format(time.DateOnly)]++
+	}
+
+	summary := make([]DFSHits, 0, len(groups))
+	for _, hits := range groups {
+		summary = append(summary, *hits)
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		a, b := summary[i], summary[j]
+		if a.Hits != b.Hits {
+			return a.Hits > b.Hits
+		}
+		if a.AP != b.AP {
+			return a.AP < b.AP
+		}
+		return a.Channel < b.Channel
+	})
+	return summary
+}
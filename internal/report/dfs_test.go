@@ -0,0 +1,53 @@
+package report
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestDFS(t *testing.T) {
+	day := time.Date(2026, 1, 12, 10, 0, 0, 0, time.Local)
+	radar := func(ap string, channel int, at time.Time) api.Event {
+		return api.Event{
+			Key:        "EVT_AP_RadarDetected",
+			AP:         ap,
+			APName:     "AP " + ap,
+			RawChannel: json.RawMessage(strconv.Itoa(channel)),
+			Time:       at.UnixMilli(),
+		}
+	}
+
+	events := []api.Event{
+		radar("a", 100, day.Add(24*time.Hour)),
+		radar("b", 120, day),
+		radar("a", 100, day),
+		radar("a", 100, day.Add(time.Hour)),
+		radar("a", 116, day),
+		{Key: "EVT_AP_Lost_Contact", AP: "a", Time: day.UnixMilli()},
+	}
+
+	summary := DFS(events)
+	if len(summary) != 3 {
+		t.Fatalf("Expected 3 AP/channel groups, got %+v", summary)
+	}
+
+	top := summary[0]
+	if top.AP != "a" || top.Channel != 100 || top.Hits != 3 {
+		t.Errorf("Expected AP a channel 100 with 3 hits first, got %+v", top)
+	}
+	if !top.First.Equal(day) || !top.Last.Equal(day.Add(24*time.Hour)) {
+		t.Errorf("Unexpected first/last %v / %v", top.First, top.Last)
+	}
+	if top.Days["2026-01-12"] != 2 || top.Days["2026-01-13"] != 1 {
+		t.Errorf("Unexpected per-day hits %v", top.Days)
+	}
+
+	// Ties are ordered by AP, then channel
+	if summary[1].AP != "a" || summary[1].Channel != 116 || summary[2].AP != "b" {
+		t.Errorf("Unexpected order %+v", summary)
+	}
+}
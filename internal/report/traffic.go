@@ -0,0 +1,76 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// OtherShare names the share that TopShares merges the smaller ones into
+const OtherShare = "Other"
+
+// TrafficShare is the traffic of one client, vendor or SSID and its share of
+// the total
+type TrafficShare struct {
+	Name    string  `json:"name"`
+	Bytes   int64   `json:"bytes"`
+	Percent float64 `json:"percent"`
+	Clients int     `json:"clients"`
+}
+
+// TrafficShares adds up the traffic of every group, as named by group for a
+// client MAC, largest first
+func TrafficShares(traffic []api.UserTraffic, group func(mac string) string) []TrafficShare {
+	shares := map[string]*TrafficShare{}
+	clients := map[string]map[string]bool{}
+	var total int64
+
+	for _, t := range traffic {
+		mac := strings.ToLower(t.User)
+		name := group(mac)
+
+		share, ok := shares[name]
+		if !ok {
+			share = &TrafficShare{Name: name}
+			shares[name] = share
+			clients[name] = map[string]bool{}
+		}
+		share.Bytes += t.Bytes()
+		clients[name][mac] = true
+		total += t.Bytes()
+	}
+
+	result := make([]TrafficShare, 0, len(shares))
+	for name, share := range shares {
+		share.Clients = len(clients[name])
+		if total > 0 {
+			share.Percent = float64(share.Bytes) * 100 / float64(total)
+		}
+		result = append(result, *share)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Bytes != result[j].Bytes {
+			return result[i].Bytes > result[j].Bytes
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// TopShares keeps the n largest shares and merges the rest into one named
+// Other. n <= 0 keeps every share.
+func TopShares(shares []TrafficShare, n int) []TrafficShare {
+	if n <= 0 || len(shares) <= n {
+		return shares
+	}
+
+	other := TrafficShare{Name: OtherShare}
+	for _, share := range shares[n:] {
+		other.Bytes += share.Bytes
+		other.Percent += share.Percent
+		other.Clients += share.Clients
+	}
+	return append(append([]TrafficShare{}, shares[:n]...), other)
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var traffic = []api.UserTraffic{
+	{User: "aa:00:00:00:00:01", RxBytes: 600, TxBytes: 100},
+	{User: "AA:00:00:00:00:01", RxBytes: 50, TxBytes: 50},
+	{User: "aa:00:00:00:00:02", RxBytes: 100},
+	{User: "aa:00:00:00:00:03", RxBytes: 80, TxBytes: 20},
+}
+
+func TestTrafficShares(t *testing.T) {
+	vendors := map[string]string{"aa:00:00:00:00:01": "Apple", "aa:00:00:00:00:02": "Sonos", "aa:00:00:00:00:03": "Apple"}
+	shares := TrafficShares(traffic, func(mac string) string { return vendors[mac] })
+
+	if len(shares) != 2 {
+		t.Fatalf("Expected 2 shares, got %+v", shares)
+	}
+	if shares[0].Name != "Apple" || shares[0].Bytes != 900 || shares[0].Clients != 2 || shares[0].Percent != 90 {
+		t.Errorf("Unexpected first share %+v", shares[0])
+	}
+	if shares[1].Name != "Sonos" || shares[1].Bytes != 100 || shares[1].Clients != 1 || shares[1].Percent != 10 {
+		t.Errorf("Unexpected second share %+v", shares[1])
+	}
+}
+
+func TestTopShares(t *testing.T) {
+	shares := TrafficShares(traffic, func(mac string) string { return mac })
+	if len(shares) != 3 || shares[0].Name != "aa:00:00:00:00:01" {
+		t.Fatalf("Unexpected shares %+v", shares)
+	}
+
+	top := TopShares(shares, 1)
+	if len(top) != 2 || top[1].Name != OtherShare || top[1].Bytes != 200 || top[1].Clients != 2 || top[1].Percent != 20 {
+		t.Errorf("Unexpected top shares %+v", top)
+	}
+
+	if len(TopShares(shares, 0)) != 3 || len(TopShares(shares, 5)) != 3 {
+		t.Error("Expected every share to be kept")
+	}
+}
@@ -0,0 +1,148 @@
+package sorting
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ByKeys stably sorts a slice of structs by a comma separated list of JSON
+// field names, e.g. "essid,-signal". A leading "-" sorts that key in
+// descending order. Strings compare case-insensitively. Because the sort is
+// stable, items that compare equal keep their existing relative order.
+func ByKeys[T any](items []T, spec string) error {
+	elemType := reflect.TypeOf((*T)(nil)).Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot sort %s values by key", elemType)
+	}
+
+	fields := jsonFields(elemType)
+
+	type sortKey struct {
+		index []int
+		desc  bool
+	}
+
+	var keys []sortKey
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		index, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("unknown sort key %q (valid keys: %s)", name, strings.Join(Keys[T](), ", "))
+		}
+		keys = append(keys, sortKey{index: index, desc: desc})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a := reflect.ValueOf(&items[i]).Elem()
+		b := reflect.ValueOf(&items[j]).Elem()
+
+		for _, key := range keys {
+			c := compare(a.FieldByIndex(key.index), b.FieldByIndex(key.index))
+			if c == 0 {
+				continue
+			}
+			if key.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	return nil
+}
+
+// Keys returns the sortable JSON field names of T in alphabetical order
+func Keys[T any]() []string {
+	fields := jsonFields(reflect.TypeOf((*T)(nil)).Elem())
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonFields maps JSON names of sortable (scalar) fields to their index.
+// Fields of embedded structs are included as encoding/json promotes them,
+// unless the outer struct has a field of the same name.
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := map[string][]int{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, field)
+			continue
+		}
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			fields[name] = field.Index
+		}
+	}
+
+	for _, field := range embedded {
+		for name, index := range jsonFields(field.Type) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = append(append([]int{}, field.Index...), index...)
+			}
+		}
+	}
+
+	return fields
+}
+
+func compare(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(strings.ToLower(a.String()), strings.ToLower(b.String()))
+	case reflect.Bool:
+		return compareOrdered(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareOrdered(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float(), b.Float())
+	}
+	return 0
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
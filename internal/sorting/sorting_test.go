@@ -0,0 +1,103 @@
+package sorting
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	Name    string  `json:"name"`
+	Signal  int     `json:"signal"`
+	Rate    float64 `json:"rate"`
+	Wired   bool    `json:"is_wired"`
+	Tags    []string
+	private string
+}
+
+func names(items []item) []string {
+	result := make([]string, len(items))
+	for i, it := range items {
+		result[i] = it.Name
+	}
+	return result
+}
+
+func TestByKeys(t *testing.T) {
+	base := []item{
+		{Name: "delta", Signal: -70, Rate: 1.5, Wired: false},
+		{Name: "Alpha", Signal: -45, Rate: 3.0, Wired: true},
+		{Name: "charlie", Signal: -70, Rate: 0.5, Wired: false},
+		{Name: "bravo", Signal: -55, Rate: 2.0, Wired: true},
+	}
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected []string
+	}{
+		{"String ascending, case-insensitive", "name", []string{"Alpha", "bravo", "charlie", "delta"}},
+		{"Int descending", "-signal", []string{"Alpha", "bravo", "delta", "charlie"}},
+		{"Ties keep existing order", "signal", []string{"delta", "charlie", "bravo", "Alpha"}},
+		{"Multiple keys", "signal,name", []string{"charlie", "delta", "bravo", "Alpha"}},
+		{"Float", "rate", []string{"charlie", "delta", "bravo", "Alpha"}},
+		{"Bool then name", "is_wired, name", []string{"charlie", "delta", "Alpha", "bravo"}},
+		{"Empty spec keeps order", "", []string{"delta", "Alpha", "charlie", "bravo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := append([]item(nil), base...)
+			if err := ByKeys(items, tt.spec); err != nil {
+				t.Fatalf("ByKeys returned error: %v", err)
+			}
+			if got := names(items); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestByKeys_UnknownKey(t *testing.T) {
+	items := []item{{Name: "a"}, {Name: "b"}}
+
+	err := ByKeys(items, "Tags")
+	if err == nil || !strings.Contains(err.Error(), "valid keys: is_wired, name, rate, signal") {
+		t.Errorf("Expected unknown key error listing valid keys, got %v", err)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	expected := []string{"is_wired", "name", "rate", "signal"}
+	if got := Keys[item](); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestByKeys_Embedded(t *testing.T) {
+	type status struct {
+		item
+		Name    string `json:"name"`
+		Clients int    `json:"clients"`
+	}
+
+	items := []status{
+		{item: item{Name: "b", Signal: -40}, Name: "x", Clients: 1},
+		{item: item{Name: "a", Signal: -80}, Name: "y", Clients: 2},
+	}
+
+	if err := ByKeys(items, "signal"); err != nil {
+		t.Fatalf("ByKeys() returned error: %v", err)
+	}
+	if items[0].Name != "y" {
+		t.Errorf("Expected sorting by the embedded signal field, got %+v", items)
+	}
+
+	// The outer name shadows the embedded one, as in encoding/json
+	if err := ByKeys(items, "-name"); err != nil {
+		t.Fatalf("ByKeys() returned error: %v", err)
+	}
+	if items[0].Name != "y" {
+		t.Errorf("Expected sorting by the outer name field, got %+v", items)
+	}
+}
@@ -0,0 +1,175 @@
+package topology
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// nodeID turns a node ID (a MAC address) into an identifier both diagram
+// formats accept
+func nodeID(node *Node) string {
+	return "n_" + strings.NewReplacer(":", "", "-", "").Replace(node.ID)
+}
+
+// Mermaid renders the tree as a Mermaid flowchart
+func Mermaid(roots []*Node) string {
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+
+	walk(roots, nil, func(node, _ *Node) {
+		fmt.Fprintf(&b, "  %s%s\n", nodeID(node), mermaidShape(node))
+	})
+	walk(roots, nil, func(node, parent *Node) {
+		if parent == nil {
+			return
+		}
+		if node.Link != "" {
+			fmt.Fprintf(&b, "  %s -- \"%s\" --> %s\n", nodeID(parent), mermaidEscape(node.Link), nodeID(node))
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", nodeID(parent), nodeID(node))
+		}
+	})
+	return b.String()
+}
+
+func mermaidShape(node *Node) string {
+	label := `"` + mermaidEscape(node.Label) + `"`
+	switch node.Kind {
+	case KindGateway:
+		return "{{" + label + "}}"
+	case KindAP:
+		return "([" + label + "])"
+	case KindClient:
+		return "(" + label + ")"
+	}
+	return "[" + label + "]"
+}
+
+// mermaidEscape replaces the characters that end a quoted Mermaid label
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// Layout of the draw.io diagram
+const (
+	drawioWidth   = 160
+	drawioHeight  = 40
+	drawioSpacing = 20
+	drawioLevel   = 120
+)
+
+// drawioStyles are the vertex styles by node kind
+var drawioStyles = map[string]string{
+	KindGateway: "shape=hexagon;perimeter=hexagonPerimeter2;whiteSpace=wrap;html=1;fillColor=#dae8fc;strokeColor=#6c8ebf;",
+	KindSwitch:  "rounded=0;whiteSpace=wrap;html=1;fillColor=#d5e8d4;strokeColor=#82b366;",
+	KindAP:      "ellipse;whiteSpace=wrap;html=1;fillColor=#fff2cc;strokeColor=#d6b656;",
+	KindDevice:  "rounded=0;whiteSpace=wrap;html=1;",
+	KindClient:  "rounded=1;whiteSpace=wrap;html=1;fillColor=#f5f5f5;strokeColor=#666666;",
+}
+
+type mxFile struct {
+	XMLName xml.Name  `xml:"mxfile"`
+	Host    string    `xml:"host,attr"`
+	Diagram mxDiagram `xml:"diagram"`
+}
+
+type mxDiagram struct {
+	ID    string   `xml:"id,attr"`
+	Name  string   `xml:"name,attr"`
+	Cells []mxCell `xml:"mxGraphModel>root>mxCell"`
+}
+
+type mxCell struct {
+	ID       string      `xml:"id,attr"`
+	Value    string      `xml:"value,attr,omitempty"`
+	Style    string      `xml:"style,attr,omitempty"`
+	Vertex   string      `xml:"vertex,attr,omitempty"`
+	Edge     string      `xml:"edge,attr,omitempty"`
+	Parent   string      `xml:"parent,attr,omitempty"`
+	Source   string      `xml:"source,attr,omitempty"`
+	Target   string      `xml:"target,attr,omitempty"`
+	Geometry *mxGeometry `xml:"mxGeometry,omitempty"`
+}
+
+type mxGeometry struct {
+	X        int    `xml:"x,attr,omitempty"`
+	Y        int    `xml:"y,attr,omitempty"`
+	Width    int    `xml:"width,attr,omitempty"`
+	Height   int    `xml:"height,attr,omitempty"`
+	Relative string `xml:"relative,attr,omitempty"`
+	As       string `xml:"as,attr"`
+}
+
+// DrawIO renders the tree as a draw.io (diagrams.net) file, laid out top
+// down with every level of the tree on its own row
+func DrawIO(roots []*Node) (string, error) {
+	cells := []mxCell{{ID: "0"}, {ID: "1", Parent: "0"}}
+
+	// Leaves take consecutive slots; parents are centred over their children
+	positions := map[*Node]int{}
+	next := 0
+	var place func(node *Node) int
+	place = func(node *Node) int {
+		if len(node.Children) == 0 {
+			x := next * (drawioWidth + drawioSpacing)
+			next++
+			positions[node] = x
+			return x
+		}
+		first := place(node.Children[0])
+		last := first
+		for _, child := range node.Children[1:] {
+			last = place(child)
+		}
+		positions[node] = (first + last) / 2
+		return positions[node]
+	}
+	for _, root := range roots {
+		place(root)
+	}
+
+	depth := map[*Node]int{}
+	walk(roots, nil, func(node, parent *Node) {
+		if parent != nil {
+			depth[node] = depth[parent] + 1
+		}
+		cells = append(cells, mxCell{
+			ID:     nodeID(node),
+			Value:  node.Label,
+			Style:  drawioStyles[node.Kind],
+			Vertex: "1",
+			Parent: "1",
+			Geometry: &mxGeometry{
+				X: positions[node], Y: depth[node] * drawioLevel,
+				Width: drawioWidth, Height: drawioHeight,
+				As: "geometry",
+			},
+		})
+	})
+	walk(roots, nil, func(node, parent *Node) {
+		if parent == nil {
+			return
+		}
+		cells = append(cells, mxCell{
+			ID:       "e_" + nodeID(node),
+			Value:    node.Link,
+			Style:    "endArrow=none;html=1;",
+			Edge:     "1",
+			Parent:   "1",
+			Source:   nodeID(parent),
+			Target:   nodeID(node),
+			Geometry: &mxGeometry{Relative: "1", As: "geometry"},
+		})
+	})
+
+	file := mxFile{
+		Host:    "unifi-cli",
+		Diagram: mxDiagram{ID: "topology", Name: "Topology", Cells: cells},
+	}
+	data, err := xml.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode diagram: %w", err)
+	}
+	return xml.Header + string(data) + "\n", nil
+}
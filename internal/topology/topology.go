@@ -0,0 +1,130 @@
+// Package topology builds the uplink tree of a site's devices and clients and
+// renders it as diagram source for Mermaid and draw.io.
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// Node kinds
+const (
+	KindGateway = "gateway"
+	KindSwitch  = "switch"
+	KindAP      = "ap"
+	KindDevice  = "device"
+	KindClient  = "client"
+)
+
+// Node is a device or client in the tree
+type Node struct {
+	// ID is the lower-case MAC address
+	ID    string
+	Label string
+	Kind  string
+	// Link describes the connection to the parent, e.g. "port 5" or "mesh"
+	Link     string
+	Children []*Node
+}
+
+// Build arranges devices and clients by their uplinks. Devices are linked to
+// the device their uplink reports, wired clients to their switch and wireless
+// clients to their access point. Nodes whose parent is unknown become roots;
+// roots are returned gateways first, then by label.
+func Build(devices []api.Device, clients []api.Client) []*Node {
+	nodes := map[string]*Node{}
+	parents := map[string]string{}
+
+	for i := range devices {
+		d := &devices[i]
+		id := strings.ToLower(d.MAC)
+		nodes[id] = &Node{ID: id, Label: d.GetDisplayName(), Kind: deviceKind(d)}
+		if d.Uplink != nil && d.Uplink.UplinkMAC != "" {
+			parents[id] = strings.ToLower(d.Uplink.UplinkMAC)
+			nodes[id].Link = uplinkLink(d.Uplink)
+		}
+	}
+
+	for i := range clients {
+		c := &clients[i]
+		id := strings.ToLower(c.MAC)
+		if _, ok := nodes[id]; ok {
+			continue
+		}
+		node := &Node{ID: id, Label: c.GetDisplayName(), Kind: KindClient}
+		if c.IsWired {
+			parents[id] = strings.ToLower(c.SWMAC)
+			if c.SWPort > 0 {
+				node.Link = fmt.Sprintf("port %d", c.SWPort)
+			}
+		} else {
+			parents[id] = strings.ToLower(c.ApMAC)
+			node.Link = c.Essid
+		}
+		nodes[id] = node
+	}
+
+	var roots []*Node
+	for id, node := range nodes {
+		parent, ok := nodes[parents[id]]
+		if ok && parent != node {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	for _, node := range nodes {
+		sortNodes(node.Children)
+	}
+	sortNodes(roots)
+	return roots
+}
+
+// kindOrder sorts infrastructure before clients
+var kindOrder = map[string]int{KindGateway: 0, KindSwitch: 1, KindAP: 2, KindDevice: 3, KindClient: 4}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if kindOrder[nodes[i].Kind] != kindOrder[nodes[j].Kind] {
+			return kindOrder[nodes[i].Kind] < kindOrder[nodes[j].Kind]
+		}
+		if nodes[i].Label != nodes[j].Label {
+			return nodes[i].Label < nodes[j].Label
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+}
+
+func deviceKind(d *api.Device) string {
+	switch {
+	case d.IsGateway():
+		return KindGateway
+	case d.Type == "usw":
+		return KindSwitch
+	case d.Type == "uap":
+		return KindAP
+	}
+	return KindDevice
+}
+
+func uplinkLink(uplink *api.Uplink) string {
+	if uplink.Type == "wireless" {
+		return "mesh"
+	}
+	if uplink.RemotePort > 0 {
+		return fmt.Sprintf("port %d", uplink.RemotePort)
+	}
+	return ""
+}
+
+// walk calls fn for every node depth first, with its parent (nil for roots)
+func walk(nodes []*Node, parent *Node, fn func(node, parent *Node)) {
+	for _, node := range nodes {
+		fn(node, parent)
+		walk(node.Children, node, fn)
+	}
+}
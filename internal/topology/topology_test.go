@@ -0,0 +1,111 @@
+package topology
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var (
+	testDevices = []api.Device{
+		{MAC: "AA:00:00:00:00:03", Name: "Office AP", Type: "uap", Uplink: &api.Uplink{Type: "wire", UplinkMAC: "aa:00:00:00:00:02", RemotePort: 8}},
+		{MAC: "aa:00:00:00:00:01", Name: "UDM \"Pro\"", Type: "udm"},
+		{MAC: "aa:00:00:00:00:02", Name: "Core Switch", Type: "usw", Uplink: &api.Uplink{Type: "wire", UplinkMAC: "aa:00:00:00:00:01", RemotePort: 1}},
+		{MAC: "aa:00:00:00:00:04", Name: "Garden AP", Type: "uap", Uplink: &api.Uplink{Type: "wireless", UplinkMAC: "aa:00:00:00:00:03"}},
+	}
+	testClients = []api.Client{
+		{MAC: "bb:00:00:00:00:01", Name: "NAS", IsWired: true, SWMAC: "aa:00:00:00:00:02", SWPort: 5},
+		{MAC: "bb:00:00:00:00:02", Hostname: "laptop", ApMAC: "AA:00:00:00:00:03", Essid: "Home"},
+		{MAC: "bb:00:00:00:00:03", Hostname: "orphan", ApMAC: "cc:00:00:00:00:00"},
+	}
+)
+
+func TestBuild(t *testing.T) {
+	roots := Build(testDevices, testClients)
+
+	if len(roots) != 2 || roots[0].Label != `UDM "Pro"` || roots[1].Label != "orphan" {
+		t.Fatalf("Expected the gateway and the orphaned client as roots, got %v", roots)
+	}
+
+	gateway := roots[0]
+	if gateway.Kind != KindGateway || len(gateway.Children) != 1 {
+		t.Fatalf("Unexpected gateway %+v", gateway)
+	}
+
+	core := gateway.Children[0]
+	if core.Label != "Core Switch" || core.Link != "port 1" {
+		t.Errorf("Unexpected switch %+v", core)
+	}
+	if len(core.Children) != 2 || core.Children[0].Label != "Office AP" || core.Children[1].Label != "NAS" {
+		t.Fatalf("Expected the AP before the client under the switch, got %v", core.Children)
+	}
+	if core.Children[1].Link != "port 5" {
+		t.Errorf("Expected the NAS on port 5, got %q", core.Children[1].Link)
+	}
+
+	ap := core.Children[0]
+	if len(ap.Children) != 2 || ap.Children[0].Label != "Garden AP" || ap.Children[0].Link != "mesh" {
+		t.Fatalf("Expected the mesh AP first under the office AP, got %v", ap.Children)
+	}
+	if ap.Children[1].Label != "laptop" || ap.Children[1].Link != "Home" {
+		t.Errorf("Unexpected wireless client %+v", ap.Children[1])
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	out := Mermaid(Build(testDevices, testClients))
+
+	expected := []string{
+		"graph TD\n",
+		`n_aa0000000001{{"UDM #quot;Pro#quot;"}}`,
+		`n_aa0000000002["Core Switch"]`,
+		`n_aa0000000003(["Office AP"])`,
+		`n_bb0000000002("laptop")`,
+		`n_aa0000000001 -- "port 1" --> n_aa0000000002`,
+		`n_aa0000000003 -- "mesh" --> n_aa0000000004`,
+	}
+	for _, s := range expected {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected output to contain %q, got:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "--> n_bb0000000003") {
+		t.Errorf("Expected no link to the orphaned client, got:\n%s", out)
+	}
+}
+
+func TestDrawIO(t *testing.T) {
+	out, err := DrawIO(Build(testDevices, testClients))
+	if err != nil {
+		t.Fatalf("DrawIO() returned error: %v", err)
+	}
+
+	var file mxFile
+	if err := xml.Unmarshal([]byte(out), &file); err != nil {
+		t.Fatalf("Expected valid XML, got %v:\n%s", err, out)
+	}
+
+	vertices, edges := 0, 0
+	geometry := map[string]*mxGeometry{}
+	for _, cell := range file.Diagram.Cells {
+		switch {
+		case cell.Vertex == "1":
+			vertices++
+			geometry[cell.ID] = cell.Geometry
+		case cell.Edge == "1":
+			edges++
+		}
+	}
+	if vertices != 7 || edges != 5 {
+		t.Errorf("Expected 7 vertices and 5 edges, got %d and %d", vertices, edges)
+	}
+
+	if g := geometry["n_aa0000000002"]; g == nil || g.Y != drawioLevel {
+		t.Errorf("Expected the switch on the second row, got %+v", g)
+	}
+	if !strings.Contains(out, `value="UDM &#34;Pro&#34;"`) {
+		t.Errorf("Expected the label to be escaped, got:\n%s", out)
+	}
+}
@@ -0,0 +1,137 @@
+// Package trafficroute builds traffic routes (policy-based routing) from
+// specs.
+package trafficroute
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// Spec describes a traffic route to create
+type Spec struct {
+	Description string
+	// Interface is the name or ID of the WAN or VPN client network the
+	// traffic leaves through
+	Interface string
+	// Clients are client MAC addresses and Networks network names or IDs
+	// whose traffic is routed; all clients without either
+	Clients  []string
+	Networks []string
+	// Domains or IPs (addresses or subnets) limit the route to some
+	// destinations; all traffic without either
+	Domains    []string
+	IPs        []string
+	KillSwitch bool
+}
+
+// interfacePurposes are the network purposes traffic can be routed out of
+var interfacePurposes = []string{"wan", "vpn-client"}
+
+// Route validates the spec against the site's networks and returns the
+// route to create
+func (s Spec) Route(networks []api.Network) (api.TrafficRoute, error) {
+	if s.Description == "" {
+		return api.TrafficRoute{}, fmt.Errorf("a description is required")
+	}
+
+	iface, err := api.FindNetwork(networks, s.Interface)
+	if err != nil {
+		return api.TrafficRoute{}, fmt.Errorf("unknown interface: %w", err)
+	}
+	if !contains(interfacePurposes, iface.Purpose) {
+		return api.TrafficRoute{}, fmt.Errorf("network %s is not a WAN or VPN client network", iface.Name)
+	}
+
+	route := api.TrafficRoute{
+		Description:       s.Description,
+		Enabled:           true,
+		MatchingTarget:    api.RouteTargetInternet,
+		NetworkID:         iface.ID,
+		KillSwitchEnabled: s.KillSwitch,
+		TargetDevices:     []api.RouteTargetDevice{},
+		Domains:           []api.RouteDomain{},
+		IPAddresses:       []api.RouteIPAddress{},
+		Regions:           []string{},
+	}
+
+	for _, arg := range s.Clients {
+		mac, ok := api.NormalizeMAC(arg)
+		if !ok {
+			return api.TrafficRoute{}, fmt.Errorf("invalid MAC address: %s", arg)
+		}
+		route.TargetDevices = append(route.TargetDevices, api.RouteTargetDevice{Type: api.RouteDeviceClient, ClientMAC: mac})
+	}
+	for _, name := range s.Networks {
+		network, err := api.FindNetwork(networks, name)
+		if err != nil {
+			return api.TrafficRoute{}, err
+		}
+		if contains(interfacePurposes, network.Purpose) {
+			return api.TrafficRoute{}, fmt.Errorf("network %s is a WAN or VPN client network, not a LAN", network.Name)
+		}
+		route.TargetDevices = append(route.TargetDevices, api.RouteTargetDevice{Type: api.RouteDeviceNetwork, NetworkID: network.ID})
+	}
+	if len(route.TargetDevices) == 0 {
+		route.TargetDevices = append(route.TargetDevices, api.RouteTargetDevice{Type: api.RouteDeviceAllClients})
+	}
+
+	if len(s.Domains) > 0 && len(s.IPs) > 0 {
+		return api.TrafficRoute{}, fmt.Errorf("a route matches either domains or IP addresses, not both")
+	}
+	for _, domain := range s.Domains {
+		domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+		if domain == "" || strings.ContainsAny(domain, " /:") {
+			return api.TrafficRoute{}, fmt.Errorf("invalid domain %q", domain)
+		}
+		route.MatchingTarget = api.RouteTargetDomain
+		route.Domains = append(route.Domains, api.RouteDomain{Domain: domain, Ports: []int{}})
+	}
+	for _, ip := range s.IPs {
+		address, version, err := parseIP(ip)
+		if err != nil {
+			return api.TrafficRoute{}, err
+		}
+		route.MatchingTarget = api.RouteTargetIP
+		route.IPAddresses = append(route.IPAddresses, api.RouteIPAddress{IPOrSubnet: address, IPVersion: version, Ports: []int{}})
+	}
+
+	return route, nil
+}
+
+// parseIP validates an address or subnet and returns it in canonical form
+// with its IP version, "v4" or "v6"
+func parseIP(value string) (string, string, error) {
+	value = strings.TrimSpace(value)
+
+	var addr netip.Addr
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid IP address or subnet %q", value)
+		}
+		addr, value = prefix.Addr(), prefix.Masked().String()
+	} else {
+		var err error
+		if addr, err = netip.ParseAddr(value); err != nil {
+			return "", "", fmt.Errorf("invalid IP address or subnet %q", value)
+		}
+		value = addr.String()
+	}
+
+	if addr.Is4() {
+		return value, "v4", nil
+	}
+	return value, "v6", nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
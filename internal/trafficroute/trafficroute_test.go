@@ -0,0 +1,97 @@
+package trafficroute
+
+import (
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var testNetworks = []api.Network{
+	{ID: "n1", Name: "LAN", Purpose: "corporate"},
+	{ID: "n2", Name: "IoT", Purpose: "corporate"},
+	{ID: "w1", Name: "Internet 1", Purpose: "wan"},
+	{ID: "w2", Name: "Internet 2", Purpose: "wan"},
+	{ID: "v1", Name: "Work VPN", Purpose: "vpn-client"},
+}
+
+func TestSpec_Route(t *testing.T) {
+	route, err := Spec{
+		Description: "Work domains",
+		Interface:   "work vpn",
+		Clients:     []string{"AA:BB:CC:00:00:01"},
+		Networks:    []string{"IoT"},
+		Domains:     []string{"Corp.Example.com."},
+		KillSwitch:  true,
+	}.Route(testNetworks)
+	if err != nil {
+		t.Fatalf("Route() returned error: %v", err)
+	}
+
+	if route.NetworkID != "v1" || !route.Enabled || !route.KillSwitchEnabled || route.MatchingTarget != api.RouteTargetDomain {
+		t.Errorf("Unexpected route %+v", route)
+	}
+	if len(route.TargetDevices) != 2 || route.TargetDevices[0].ClientMAC != "aa:bb:cc:00:00:01" || route.TargetDevices[1].NetworkID != "n2" {
+		t.Errorf("Unexpected target devices %+v", route.TargetDevices)
+	}
+	if len(route.Domains) != 1 || route.Domains[0].Domain != "corp.example.com" {
+		t.Errorf("Unexpected domains %+v", route.Domains)
+	}
+}
+
+func TestSpec_Route_Defaults(t *testing.T) {
+	route, err := Spec{Description: "Failover", Interface: "w2"}.Route(testNetworks)
+	if err != nil {
+		t.Fatalf("Route() returned error: %v", err)
+	}
+
+	if route.MatchingTarget != api.RouteTargetInternet {
+		t.Errorf("Expected all traffic to be routed, got %s", route.MatchingTarget)
+	}
+	if len(route.TargetDevices) != 1 || route.TargetDevices[0].Type != api.RouteDeviceAllClients {
+		t.Errorf("Expected all clients, got %+v", route.TargetDevices)
+	}
+	if route.Domains == nil || route.IPAddresses == nil || route.Regions == nil {
+		t.Error("Expected empty lists rather than null")
+	}
+}
+
+func TestSpec_Route_IPs(t *testing.T) {
+	route, err := Spec{Description: "Office", Interface: "Work VPN", IPs: []string{"10.1.2.3/16", "192.0.2.7", "2001:db8::/32"}}.Route(testNetworks)
+	if err != nil {
+		t.Fatalf("Route() returned error: %v", err)
+	}
+
+	expected := []api.RouteIPAddress{
+		{IPOrSubnet: "10.1.0.0/16", IPVersion: "v4"},
+		{IPOrSubnet: "192.0.2.7", IPVersion: "v4"},
+		{IPOrSubnet: "2001:db8::/32", IPVersion: "v6"},
+	}
+	if route.MatchingTarget != api.RouteTargetIP || len(route.IPAddresses) != len(expected) {
+		t.Fatalf("Unexpected route %+v", route)
+	}
+	for i, e := range expected {
+		if got := route.IPAddresses[i]; got.IPOrSubnet != e.IPOrSubnet || got.IPVersion != e.IPVersion {
+			t.Errorf("Expected %+v, got %+v", e, got)
+		}
+	}
+}
+
+func TestSpec_Route_Invalid(t *testing.T) {
+	tests := map[string]Spec{
+		"no description":      {Interface: "Work VPN"},
+		"unknown interface":   {Description: "x", Interface: "Other"},
+		"LAN as interface":    {Description: "x", Interface: "LAN"},
+		"invalid MAC":         {Description: "x", Interface: "Work VPN", Clients: []string{"laptop"}},
+		"WAN as target":       {Description: "x", Interface: "Work VPN", Networks: []string{"Internet 1"}},
+		"domains and IPs":     {Description: "x", Interface: "Work VPN", Domains: []string{"example.com"}, IPs: []string{"192.0.2.1"}},
+		"invalid IP":          {Description: "x", Interface: "Work VPN", IPs: []string{"192.0.2.300"}},
+		"invalid domain":      {Description: "x", Interface: "Work VPN", Domains: []string{"https://example.com"}},
+		"unknown LAN network": {Description: "x", Interface: "Work VPN", Networks: []string{"Guest"}},
+	}
+
+	for name, spec := range tests {
+		if _, err := spec.Route(testNetworks); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
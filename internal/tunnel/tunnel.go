@@ -0,0 +1,206 @@
+// Package tunnel reaches the controller through an SSH jump host, for
+// controllers that are only reachable via a bastion. Connections to the
+// controller are forwarded over one SSH connection to the jump host, like
+// ssh -L does. Keys come from the SSH agent and the default key files in
+// ~/.ssh, and the jump host must be listed in ~/.ssh/known_hosts;
+// ~/.ssh/config is not read.
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// homeDir returns the directory holding .ssh, replaced in tests
+var homeDir = os.UserHomeDir
+
+// keyFiles are the private keys tried after the agent's, in ~/.ssh
+var keyFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// keepAliveInterval is how often an idle connection to the jump host is
+// checked, like ssh's ServerAliveInterval
+const keepAliveInterval = 15 * time.Second
+
+// Tunnel is an open SSH connection to the jump host that forwards
+// connections to the controller
+type Tunnel struct {
+	client *ssh.Client
+	target string
+	done   chan struct{}
+}
+
+// Target returns the host:port the controller URL points at, as seen from the
+// jump host
+func Target(host string) (string, error) {
+	u, err := url.Parse(host)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("invalid host %q", host)
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// Destination splits a jump host given as [user@]host[:port] into the user
+// and the address to connect to. The user defaults to the local user name and
+// the port to 22.
+func Destination(jump string) (string, string, error) {
+	name, host, ok := strings.Cut(jump, "@")
+	if !ok {
+		host = jump
+		current, err := user.Current()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get the local user name: %w", err)
+		}
+		name = current.Username
+	}
+	if name == "" || host == "" {
+		return "", "", fmt.Errorf("invalid jump host %q (expected [user@]host[:port])", jump)
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+	return name, host, nil
+}
+
+// Open connects to jump (a destination such as user@bastion) and returns a
+// tunnel that forwards connections to target through it
+func Open(jump, target string, timeout time.Duration) (*Tunnel, error) {
+	name, addr, err := Destination(jump)
+	if err != nil {
+		return nil, err
+	}
+
+	home, err := homeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the home directory: %w", err)
+	}
+	sshDir := filepath.Join(home, ".ssh")
+
+	hostKeys, err := knownhosts.New(filepath.Join(sshDir, "known_hosts"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("host key of %s is not in known_hosts (connect with ssh once to add it)", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read known_hosts: %w", err)
+	}
+
+	auth, closeAgent := authMethods(sshDir)
+	defer closeAgent()
+	if len(auth) == 0 {
+		return nil, fmt.Errorf("no SSH key for %s (start ssh-agent or add a key to %s)", jump, sshDir)
+	}
+
+	client, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            name,
+		Auth:            auth,
+		HostKeyCallback: hostKeys,
+		Timeout:         timeout,
+	})
+	if err != nil {
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) {
+			if len(keyErr.Want) == 0 {
+				return nil, fmt.Errorf("host key of %s is not in known_hosts (connect with ssh once to add it)", addr)
+			}
+			return nil, fmt.Errorf("host key of %s does not match known_hosts", addr)
+		}
+		return nil, fmt.Errorf("ssh to %s failed: %w", jump, err)
+	}
+
+	t := &Tunnel{client: client, target: target, done: make(chan struct{})}
+	go t.keepAlive()
+	return t, nil
+}
+
+// authMethods returns the keys offered to the jump host: the agent's, then
+// the default key files without a passphrase. The returned function closes
+// the agent connection once the handshake is done.
+func authMethods(sshDir string) ([]ssh.AuthMethod, func()) {
+	var methods []ssh.AuthMethod
+	closeAgent := func() {}
+
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+			closeAgent = func() { conn.Close() }
+		}
+	}
+
+	var signers []ssh.Signer
+	for _, name := range keyFiles {
+		data, err := os.ReadFile(filepath.Join(sshDir, name))
+		if err != nil {
+			continue
+		}
+		// Keys with a passphrase need the agent
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	return methods, closeAgent
+}
+
+// keepAlive pings the jump host until the tunnel is closed, so idle
+// connections are not dropped by firewalls in between
+func (t *Tunnel) keepAlive() {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+			if _, _, err := t.client.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// DialContext opens a connection to the controller through the jump host
+func (t *Tunnel) DialContext(ctx context.Context) (net.Conn, error) {
+	conn, err := t.client.DialContext(ctx, "tcp", t.target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s through the ssh tunnel: %w", t.target, err)
+	}
+	return conn, nil
+}
+
+// Close disconnects from the jump host
+func (t *Tunnel) Close() error {
+	select {
+	case <-t.done:
+		return nil
+	default:
+	}
+
+	close(t.done)
+	return t.client.Close()
+}
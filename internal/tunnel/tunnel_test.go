@@ -0,0 +1,248 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"https://unifi.example.com", "unifi.example.com:443"},
+		{"https://10.0.0.1:8443", "10.0.0.1:8443"},
+		{"http://unifi.lan", "unifi.lan:80"},
+		{"https://[fd00::1]", "[fd00::1]:443"},
+	}
+
+	for _, tt := range tests {
+		got, err := Target(tt.host)
+		if err != nil {
+			t.Errorf("Target(%q) returned error: %v", tt.host, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Target(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+
+	if _, err := Target("unifi.example.com"); err == nil {
+		t.Error("Expected an error for a host without scheme")
+	}
+}
+
+func TestDestination(t *testing.T) {
+	tests := []struct {
+		jump string
+		user string
+		addr string
+	}{
+		{"admin@bastion", "admin", "bastion:22"},
+		{"admin@bastion:2222", "admin", "bastion:2222"},
+		{"admin@[fd00::1]", "admin", "[fd00::1]:22"},
+		{"admin@[fd00::1]:2222", "admin", "[fd00::1]:2222"},
+	}
+
+	for _, tt := range tests {
+		user, addr, err := Destination(tt.jump)
+		if err != nil {
+			t.Errorf("Destination(%q) returned error: %v", tt.jump, err)
+			continue
+		}
+		if user != tt.user || addr != tt.addr {
+			t.Errorf("Destination(%q) = %q, %q, want %q, %q", tt.jump, user, addr, tt.user, tt.addr)
+		}
+	}
+
+	if _, _, err := Destination("@bastion"); err == nil {
+		t.Error("Expected an error for an empty user")
+	}
+}
+
+// sshServer is a jump host that accepts key and forwards direct-tcpip
+// channels, in a fake home directory whose ~/.ssh holds the client key and,
+// when trusted, the server's host key
+func sshServer(t *testing.T, trusted bool) string {
+	t.Helper()
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	_, hostPriv, _ := ed25519.GenerateKey(rand.Reader)
+	hostKey, err := ssh.NewSignerFromKey(hostPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPub, clientPriv, _ := ed25519.GenerateKey(rand.Reader)
+	authorized, err := ssh.NewPublicKey(clientPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorized.Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config)
+		}
+	}()
+
+	home := t.TempDir()
+	sshDir := filepath.Join(home, ".ssh")
+	if err := os.Mkdir(sshDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(clientPriv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sshDir, "id_ed25519"), pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	knownHosts := ""
+	if trusted {
+		knownHosts = knownhosts.Line([]string{l.Addr().String()}, hostKey.PublicKey()) + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(sshDir, "known_hosts"), []byte(knownHosts), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := homeDir
+	homeDir = func() (string, error) { return home, nil }
+	t.Cleanup(func() { homeDir = previous })
+
+	return "admin@" + l.Addr().String()
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			newChannel.Reject(ssh.UnknownChannelType, "not supported")
+			continue
+		}
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		remote, err := net.Dial("tcp", net.JoinHostPort(target.Host, fmt.Sprint(target.Port)))
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			remote.Close()
+			continue
+		}
+		go ssh.DiscardRequests(requests)
+		go func() {
+			io.Copy(channel, remote)
+			channel.Close()
+		}()
+		go func() {
+			io.Copy(remote, channel)
+			remote.Close()
+		}()
+	}
+}
+
+func TestOpen_Forwards(t *testing.T) {
+	jump := sshServer(t, true)
+
+	controller, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer controller.Close()
+	go func() {
+		conn, err := controller.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("hello from the controller"))
+		conn.Close()
+	}()
+
+	tun, err := Open(jump, controller.Addr().String(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	defer tun.Close()
+
+	conn, err := tun.DialContext(context.Background())
+	if err != nil {
+		t.Fatalf("DialContext() returned error: %v", err)
+	}
+	defer conn.Close()
+
+	got, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello from the controller" {
+		t.Errorf("Expected the controller's greeting, got %q", got)
+	}
+}
+
+func TestOpen_UnknownHost(t *testing.T) {
+	jump := sshServer(t, false)
+
+	_, err := Open(jump, "10.0.0.1:443", 5*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "not in known_hosts") {
+		t.Errorf("Expected an unknown host key error, got %v", err)
+	}
+}
+
+func TestOpen_NoKey(t *testing.T) {
+	jump := sshServer(t, true)
+	home, _ := homeDir()
+	if err := os.Remove(filepath.Join(home, ".ssh", "id_ed25519")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Open(jump, "10.0.0.1:443", 5*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "no SSH key") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+}
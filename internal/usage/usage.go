@@ -0,0 +1,178 @@
+// Package usage keeps opt-in statistics about the commands run and their API
+// requests in a local file, to find slow workflows. Nothing is ever sent
+// anywhere.
+package usage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/nkn/unifi-cli/internal/datafile"
+)
+
+// Capacity is how many runs the log keeps; older runs are dropped
+const Capacity = 1000
+
+// AllCommands names the summary of every command together
+const AllCommands = "(all)"
+
+// Run is a single command invocation
+type Run struct {
+	Command  string        `json:"command"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	Failed   bool          `json:"failed,omitempty"`
+	// Requests are the latencies of the API requests the command made,
+	// including failed ones
+	Requests []time.Duration `json:"requests,omitempty"`
+}
+
+// Log is a JSON file holding the most recent runs, oldest first
+type Log struct {
+	path string
+	Runs []Run
+}
+
+// Load reads the log at path; a missing file yields an empty log
+func Load(path string) (*Log, error) {
+	log := &Log{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return log, nil
+		}
+		return nil, fmt.Errorf("failed to read usage stats: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &log.Runs); err != nil {
+		return nil, fmt.Errorf("failed to parse usage stats: %w", err)
+	}
+
+	return log, nil
+}
+
+// Add appends a run, dropping the oldest runs beyond Capacity
+func (l *Log) Add(run Run) {
+	l.Runs = append(l.Runs, run)
+	if excess := len(l.Runs) - Capacity; excess > 0 {
+		l.Runs = append([]Run(nil), l.Runs[excess:]...)
+	}
+}
+
+// Save writes the log back to disk, creating its directory if needed. The
+// file is replaced atomically; use Append when other invocations may be
+// adding runs at the same time.
+func (l *Log) Save() error {
+	data, err := json.Marshal(l.Runs)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage stats: %w", err)
+	}
+
+	if err := datafile.WriteFile(l.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write usage stats: %w", err)
+	}
+
+	return nil
+}
+
+// Append adds a run to the log at path, holding the log's lock from load to
+// save so concurrent invocations do not lose each other's runs
+func Append(path string, run Run) error {
+	lock, err := datafile.LockFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to lock usage stats: %w", err)
+	}
+	defer lock.Unlock()
+
+	log, err := Load(path)
+	if err != nil {
+		return err
+	}
+	log.Add(run)
+	return log.Save()
+}
+
+// Stats summarizes the runs of a command. Durations are whole runs, latencies
+// single API requests.
+type Stats struct {
+	Command      string        `json:"command"`
+	Runs         int           `json:"runs"`
+	Failures     int           `json:"failures"`
+	MeanDuration time.Duration `json:"mean_duration"`
+	P95Duration  time.Duration `json:"p95_duration"`
+	MaxDuration  time.Duration `json:"max_duration"`
+	Requests     int           `json:"requests"`
+	P50Latency   time.Duration `json:"p50_latency"`
+	P95Latency   time.Duration `json:"p95_latency"`
+	P99Latency   time.Duration `json:"p99_latency"`
+}
+
+// Summarize returns the stats of every command, slowest (by 95th percentile
+// duration) first, followed by the stats of all runs together
+func Summarize(runs []Run) []Stats {
+	byCommand := map[string][]Run{}
+	for _, run := range runs {
+		byCommand[run.Command] = append(byCommand[run.Command], run)
+	}
+
+	stats := make([]Stats, 0, len(byCommand)+1)
+	for command, runs := range byCommand {
+		stats = append(stats, summarize(command, runs))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].P95Duration != stats[j].P95Duration {
+			return stats[i].P95Duration > stats[j].P95Duration
+		}
+		return stats[i].Command < stats[j].Command
+	})
+
+	if len(runs) > 0 {
+		stats = append(stats, summarize(AllCommands, runs))
+	}
+	return stats
+}
+
+func summarize(command string, runs []Run) Stats {
+	s := Stats{Command: command, Runs: len(runs)}
+
+	var durations, latencies []time.Duration
+	var total time.Duration
+	for _, run := range runs {
+		if run.Failed {
+			s.Failures++
+		}
+		total += run.Duration
+		durations = append(durations, run.Duration)
+		latencies = append(latencies, run.Requests...)
+	}
+
+	s.MeanDuration = total / time.Duration(len(runs))
+	s.P95Duration = Percentile(durations, 95)
+	s.MaxDuration = Percentile(durations, 100)
+	s.Requests = len(latencies)
+	s.P50Latency = Percentile(latencies, 50)
+	s.P95Latency = Percentile(latencies, 95)
+	s.P99Latency = Percentile(latencies, 99)
+	return s
+}
+
+// Percentile returns the p-th percentile of durations by the nearest-rank
+// method, or zero for no durations. durations is left unchanged.
+func Percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = min(max(rank, 1), len(sorted))
+	return sorted[rank-1]
+}
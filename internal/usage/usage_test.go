@@ -0,0 +1,110 @@
+package usage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	log, err := Load(filepath.Join(t.TempDir(), "usage.json"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(log.Runs) != 0 {
+		t.Errorf("Expected empty log, got %d runs", len(log.Runs))
+	}
+}
+
+func TestLog_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "usage.json")
+
+	log, _ := Load(path)
+	log.Add(Run{Command: "clients list", Duration: 2 * time.Second, Requests: []time.Duration{time.Second}})
+	if err := log.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(loaded.Runs) != 1 || loaded.Runs[0].Command != "clients list" || loaded.Runs[0].Requests[0] != time.Second {
+		t.Errorf("Unexpected runs after reload: %+v", loaded.Runs)
+	}
+}
+
+func TestLog_AddDropsOldest(t *testing.T) {
+	log, _ := Load(filepath.Join(t.TempDir(), "usage.json"))
+
+	for i := 0; i < Capacity+5; i++ {
+		log.Add(Run{Duration: time.Duration(i)})
+	}
+
+	if len(log.Runs) != Capacity {
+		t.Fatalf("Expected %d runs, got %d", Capacity, len(log.Runs))
+	}
+	if log.Runs[0].Duration != 5 {
+		t.Errorf("Expected the oldest runs to be dropped, first is %v", log.Runs[0].Duration)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1},
+		{50, 5},
+		{95, 10},
+		{100, 10},
+	}
+	for _, tt := range tests {
+		if got := Percentile(durations, tt.p); got != tt.want {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+
+	if durations[0] != 5 {
+		t.Error("Percentile() reordered its input")
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Percentile(nil) = %v, want 0", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	runs := []Run{
+		{Command: "clients list", Duration: time.Second, Requests: []time.Duration{100 * time.Millisecond}},
+		{Command: "clients list", Duration: 3 * time.Second, Failed: true, Requests: []time.Duration{300 * time.Millisecond}},
+		{Command: "devices list", Duration: 5 * time.Second, Requests: []time.Duration{200 * time.Millisecond, 400 * time.Millisecond}},
+	}
+
+	stats := Summarize(runs)
+
+	if len(stats) != 3 {
+		t.Fatalf("Expected 2 commands and the total, got %+v", stats)
+	}
+	if stats[0].Command != "devices list" || stats[1].Command != "clients list" || stats[2].Command != AllCommands {
+		t.Errorf("Expected slowest command first and the total last, got %+v", stats)
+	}
+
+	clients := stats[1]
+	if clients.Runs != 2 || clients.Failures != 1 {
+		t.Errorf("Expected 2 runs with 1 failure, got %+v", clients)
+	}
+	if clients.MeanDuration != 2*time.Second || clients.MaxDuration != 3*time.Second {
+		t.Errorf("Unexpected durations %+v", clients)
+	}
+
+	all := stats[2]
+	if all.Runs != 3 || all.Requests != 4 || all.P50Latency != 200*time.Millisecond || all.P99Latency != 400*time.Millisecond {
+		t.Errorf("Unexpected total %+v", all)
+	}
+
+	if stats := Summarize(nil); len(stats) != 0 {
+		t.Errorf("Expected no stats without runs, got %+v", stats)
+	}
+}
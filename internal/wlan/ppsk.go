@@ -0,0 +1,56 @@
+package wlan
+
+import (
+	"fmt"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// CheckPrivatePSK reports why a WLAN cannot have private pre-shared keys: the
+// controller only supports them on WPA2 personal networks
+func CheckPrivatePSK(w *api.WLAN) error {
+	if w.Security != "wpapsk" {
+		return fmt.Errorf("WLAN %s uses %s security; private pre-shared keys need WPA personal (wpapsk)", w.Name, w.Security)
+	}
+	if w.WPA3Support {
+		return fmt.Errorf("WLAN %s has WPA3 enabled; private pre-shared keys need WPA2 only", w.Name)
+	}
+	return nil
+}
+
+// AddPrivatePSK returns the WLAN's private keys with key added. The
+// passphrase must be valid and differ from the WLAN's passphrase and every
+// other private key, since it is what tells clients apart.
+func AddPrivatePSK(w *api.WLAN, key api.PrivatePSK) ([]api.PrivatePSK, error) {
+	if err := ValidatePassphrase(key.Password); err != nil {
+		return nil, err
+	}
+	if key.Password == w.Passphrase {
+		return nil, fmt.Errorf("passphrase is the main passphrase of %s", w.Name)
+	}
+	for _, existing := range w.PrivatePSKs {
+		if existing.Password == key.Password {
+			return nil, fmt.Errorf("%s already has this private passphrase", w.Name)
+		}
+	}
+
+	keys := make([]api.PrivatePSK, 0, len(w.PrivatePSKs)+1)
+	keys = append(keys, w.PrivatePSKs...)
+	return append(keys, key), nil
+}
+
+// RemovePrivatePSK returns the WLAN's private keys without the one with the
+// given passphrase
+func RemovePrivatePSK(w *api.WLAN, password string) ([]api.PrivatePSK, error) {
+	keys := make([]api.PrivatePSK, 0, len(w.PrivatePSKs))
+	for _, key := range w.PrivatePSKs {
+		if key.Password != password {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == len(w.PrivatePSKs) {
+		return nil, fmt.Errorf("%s has no private passphrase %q", w.Name, password)
+	}
+	return keys, nil
+}
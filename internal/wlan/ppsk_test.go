@@ -0,0 +1,61 @@
+package wlan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestCheckPrivatePSK(t *testing.T) {
+	if err := CheckPrivatePSK(&api.WLAN{Name: "Home", Security: "wpapsk"}); err != nil {
+		t.Errorf("Expected WPA2 personal to support PPSK, got %v", err)
+	}
+	if err := CheckPrivatePSK(&api.WLAN{Name: "Cafe", Security: "open"}); err == nil {
+		t.Error("Expected open networks to be refused")
+	}
+	if err := CheckPrivatePSK(&api.WLAN{Name: "Home", Security: "wpapsk", WPA3Support: true}); err == nil || !strings.Contains(err.Error(), "WPA3") {
+		t.Errorf("Expected WPA3 networks to be refused, got %v", err)
+	}
+}
+
+func TestAddPrivatePSK(t *testing.T) {
+	w := &api.WLAN{
+		Name:        "Home",
+		Passphrase:  "main-passphrase",
+		PrivatePSKs: []api.PrivatePSK{{Password: "kids-passphrase", NetworkConfID: "n2"}},
+	}
+
+	keys, err := AddPrivatePSK(w, api.PrivatePSK{Password: "guest-passphrase", NetworkConfID: "n3"})
+	if err != nil {
+		t.Fatalf("AddPrivatePSK() returned error: %v", err)
+	}
+	if len(keys) != 2 || keys[1].Password != "guest-passphrase" || len(w.PrivatePSKs) != 1 {
+		t.Errorf("Unexpected keys %+v (WLAN keys %+v)", keys, w.PrivatePSKs)
+	}
+
+	for _, password := range []string{"short", "main-passphrase", "kids-passphrase"} {
+		if _, err := AddPrivatePSK(w, api.PrivatePSK{Password: password}); err == nil {
+			t.Errorf("Expected %q to be refused", password)
+		}
+	}
+}
+
+func TestRemovePrivatePSK(t *testing.T) {
+	w := &api.WLAN{
+		Name:        "Home",
+		PrivatePSKs: []api.PrivatePSK{{Password: "kids-passphrase"}, {Password: "guest-passphrase"}},
+	}
+
+	keys, err := RemovePrivatePSK(w, "kids-passphrase")
+	if err != nil {
+		t.Fatalf("RemovePrivatePSK() returned error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Password != "guest-passphrase" {
+		t.Errorf("Unexpected keys %+v", keys)
+	}
+
+	if _, err := RemovePrivatePSK(w, "unknown-passphrase"); err == nil {
+		t.Error("Expected error for unknown passphrase")
+	}
+}
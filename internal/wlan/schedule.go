@@ -0,0 +1,228 @@
+package wlan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+// Weekdays are the day names of the controller's schedule blocks, in order
+var Weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+// ParseDays reads a day list such as "mon-fri", "sat,sun", "weekdays",
+// "weekends" or "daily" and returns the day indexes (0 is Monday) in order
+func ParseDays(spec string) ([]int, error) {
+	switch strings.ToLower(strings.TrimSpace(spec)) {
+	case "", "daily", "all":
+		return []int{0, 1, 2, 3, 4, 5, 6}, nil
+	case "weekdays":
+		return []int{0, 1, 2, 3, 4}, nil
+	case "weekends":
+		return []int{5, 6}, nil
+	}
+
+	selected := make([]bool, 7)
+	for _, part := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := dayIndex(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = dayIndex(to); err != nil {
+				return nil, err
+			}
+		}
+
+		// Ranges may wrap around the week, e.g. fri-mon
+		for d := first; ; d = (d + 1) % 7 {
+			selected[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+
+	var days []int
+	for d, ok := range selected {
+		if ok {
+			days = append(days, d)
+		}
+	}
+	return days, nil
+}
+
+var longDayNames = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// dayIndex accepts day names abbreviated to three letters or more
+func dayIndex(name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, day := range longDayNames {
+		if len(name) >= 3 && strings.HasPrefix(day, name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day %q (use mon, tue, wed, thu, fri, sat, sun)", name)
+}
+
+// ParseTimeRange reads a range such as "23:00-06:00" and returns its start
+// and end in minutes after midnight. The end may be before the start for
+// ranges that run past midnight.
+func ParseTimeRange(spec string) (start, end int, err error) {
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time range %q (use HH:MM-HH:MM)", spec)
+	}
+
+	if start, err = parseClock(from); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(to); err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("time range %q is empty", spec)
+	}
+	return start, end, nil
+}
+
+func parseClock(s string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(strings.TrimSpace(s), "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("invalid time %q (use HH:MM)", s)
+	}
+	// 24:00 is accepted as the end of the day
+	if hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return (hour*60 + minute) % minutesPerDay, nil
+}
+
+// OnSchedule returns the blocks that turn the WLAN on from start to end
+// (minutes after midnight) on the given days
+func OnSchedule(start, end int, days []int) []api.ScheduleBlock {
+	names := make([]string, len(days))
+	for i, d := range days {
+		names[i] = Weekdays[d]
+	}
+
+	return []api.ScheduleBlock{{
+		Days:        names,
+		StartHour:   start / 60,
+		StartMinute: start % 60,
+		Duration:    (end - start + minutesPerDay) % minutesPerDay,
+	}}
+}
+
+// OffSchedule returns the blocks that keep the WLAN on except from start to
+// end (minutes after midnight) on the given days. An off period that runs
+// past midnight ends on the following day. The result is empty when the
+// WLAN would never be on (or there are no days).
+func OffSchedule(start, end int, days []int) []api.ScheduleBlock {
+	var on [minutesPerWeek]bool
+	for i := range on {
+		on[i] = true
+	}
+
+	length := (end - start + minutesPerDay) % minutesPerDay
+	for _, d := range days {
+		for m := 0; m < length; m++ {
+			on[(d*minutesPerDay+start+m)%minutesPerWeek] = false
+		}
+	}
+
+	// Start scanning after an off minute so no on period is split at the
+	// end of the week
+	first := -1
+	for i := range on {
+		if !on[i] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+
+	type period struct{ start, duration int }
+	var periods []period
+	for i := 1; i <= minutesPerWeek; i++ {
+		m := (first + i) % minutesPerWeek
+		if !on[m] {
+			continue
+		}
+		if n := len(periods); n > 0 && (periods[n-1].start+periods[n-1].duration)%minutesPerWeek == m {
+			periods[n-1].duration++
+			continue
+		}
+		periods = append(periods, period{start: m, duration: 1})
+	}
+
+	// Periods starting at the same time of day with the same length share a
+	// block
+	var blocks []api.ScheduleBlock
+	sort.Slice(periods, func(i, j int) bool { return periods[i].start < periods[j].start })
+	for _, p := range periods {
+		day, minute := p.start/minutesPerDay, p.start%minutesPerDay
+		merged := false
+		for i := range blocks {
+			b := &blocks[i]
+			if b.StartHour*60+b.StartMinute == minute && b.Duration == p.duration {
+				b.Days = append(b.Days, Weekdays[day])
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			blocks = append(blocks, api.ScheduleBlock{
+				Days:        []string{Weekdays[day]},
+				StartHour:   minute / 60,
+				StartMinute: minute % 60,
+				Duration:    p.duration,
+			})
+		}
+	}
+	return blocks
+}
+
+// FormatDays describes a list of day names compactly, e.g. "mon-fri, sun" or
+// "daily"
+func FormatDays(days []string) string {
+	var selected [7]bool
+	for _, name := range days {
+		if d, err := dayIndex(name); err == nil {
+			selected[d] = true
+		}
+	}
+
+	var parts []string
+	for d := 0; d < 7; d++ {
+		if !selected[d] {
+			continue
+		}
+		last := d
+		for last+1 < 7 && selected[last+1] {
+			last++
+		}
+		switch {
+		case d == 0 && last == 6:
+			return "daily"
+		case last-d >= 2:
+			parts = append(parts, Weekdays[d]+"-"+Weekdays[last])
+		case last == d+1:
+			parts = append(parts, Weekdays[d], Weekdays[last])
+		default:
+			parts = append(parts, Weekdays[d])
+		}
+		d = last
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,93 @@
+package wlan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []int
+	}{
+		{"mon-fri", []int{0, 1, 2, 3, 4}},
+		{"sat,sun", []int{5, 6}},
+		{"Friday-Mon", []int{0, 4, 5, 6}},
+		{"weekends", []int{5, 6}},
+		{"", []int{0, 1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDays(tt.spec)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseDays(%q) = %v, %v; want %v", tt.spec, got, err, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"mo", "funday", "mon-xyz"} {
+		if _, err := ParseDays(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+func TestParseTimeRange(t *testing.T) {
+	start, end, err := ParseTimeRange("23:00-06:30")
+	if err != nil || start != 23*60 || end != 6*60+30 {
+		t.Errorf("Unexpected range %d-%d, %v", start, end, err)
+	}
+
+	for _, spec := range []string{"23:00", "25:00-06:00", "08:00-08:00", "8-9"} {
+		if _, _, err := ParseTimeRange(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+func TestOnSchedule(t *testing.T) {
+	blocks := OnSchedule(22*60, 2*60, []int{4, 5})
+
+	want := []api.ScheduleBlock{{Days: []string{"fri", "sat"}, StartHour: 22, Duration: 240}}
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("OnSchedule() = %+v, want %+v", blocks, want)
+	}
+}
+
+func TestOffSchedule(t *testing.T) {
+	// Off on the nights starting Monday to Friday: on from 06:00 to 23:00
+	// Tuesday to Friday, and from Saturday 06:00 through the weekend until
+	// Monday 23:00
+	blocks := OffSchedule(23*60, 6*60, []int{0, 1, 2, 3, 4})
+
+	want := []api.ScheduleBlock{
+		{Days: []string{"tue", "wed", "thu", "fri"}, StartHour: 6, Duration: 17 * 60},
+		{Days: []string{"sat"}, StartHour: 6, Duration: 2*24*60 + 17*60},
+	}
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("OffSchedule() = %+v, want %+v", blocks, want)
+	}
+
+	if blocks := OffSchedule(0, 0, nil); blocks != nil {
+		t.Errorf("Expected no blocks without days, got %+v", blocks)
+	}
+}
+
+func TestFormatDays(t *testing.T) {
+	tests := []struct {
+		days []string
+		want string
+	}{
+		{[]string{"mon", "tue", "wed", "thu", "fri"}, "mon-fri"},
+		{[]string{"sat", "sun"}, "sat, sun"},
+		{[]string{"mon", "wed", "thu", "fri", "sun"}, "mon, wed-fri, sun"},
+		{Weekdays, "daily"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDays(tt.days); got != tt.want {
+			t.Errorf("FormatDays(%v) = %q, want %q", tt.days, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package wlan builds wireless network configurations from specs.
+package wlan
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nkn/unifi-cli/internal/api"
+	"go.yaml.in/yaml/v3"
+)
+
+// Spec describes a wireless network to create, from flags or a YAML file
+type Spec struct {
+	Name       string `yaml:"name"`
+	Passphrase string `yaml:"passphrase"`
+	// Security is "wpapsk" (the default) or "open"
+	Security string `yaml:"security"`
+	// VLAN is the VLAN ID or the name of the network to bridge the SSID to;
+	// empty for the default network
+	VLAN string `yaml:"vlan"`
+	// Band is "2g", "5g" or "both" (the default)
+	Band   string `yaml:"band"`
+	Guest  bool   `yaml:"guest"`
+	Hidden bool   `yaml:"hidden"`
+}
+
+// LoadSpec reads a spec from a YAML file
+func LoadSpec(path string) (Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Spec{}, fmt.Errorf("failed to read WLAN spec: %w", err)
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to parse WLAN spec: %w", err)
+	}
+	return spec, nil
+}
+
+// Fields validates the spec against the site's networks and WLANs and returns
+// the wlanconf fields to create it with. The user group and AP groups are
+// taken from an existing WLAN, as the controller requires them.
+func (s Spec) Fields(networks []api.Network, wlans []api.WLAN) (map[string]interface{}, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("a name (SSID) is required")
+	}
+	if len(s.Name) > 32 {
+		return nil, fmt.Errorf("SSID %q is longer than 32 bytes", s.Name)
+	}
+	if _, err := api.FindWLAN(wlans, s.Name); err == nil {
+		return nil, fmt.Errorf("a WLAN with SSID %q already exists", s.Name)
+	}
+
+	fields := map[string]interface{}{
+		"name":      s.Name,
+		"enabled":   true,
+		"is_guest":  s.Guest,
+		"hide_ssid": s.Hidden,
+	}
+
+	switch s.Security {
+	case "", "wpapsk":
+		if err := ValidatePassphrase(s.Passphrase); err != nil {
+			return nil, err
+		}
+		fields["security"] = "wpapsk"
+		fields["wpa_mode"] = "wpa2"
+		fields["wpa_enc"] = "ccmp"
+		fields["x_passphrase"] = s.Passphrase
+	case "open":
+		if s.Passphrase != "" {
+			return nil, fmt.Errorf("open networks have no passphrase")
+		}
+		fields["security"] = "open"
+	default:
+		return nil, fmt.Errorf("invalid security %q (valid options: wpapsk, open)", s.Security)
+	}
+
+	switch s.Band {
+	case "", "both":
+		fields["wlan_band"] = "both"
+		fields["wlan_bands"] = []string{"2g", "5g"}
+	case "2g", "5g":
+		fields["wlan_band"] = s.Band
+		fields["wlan_bands"] = []string{s.Band}
+	default:
+		return nil, fmt.Errorf("invalid band %q (valid options: 2g, 5g, both)", s.Band)
+	}
+
+	network, err := FindNetwork(networks, s.VLAN)
+	if err != nil {
+		return nil, err
+	}
+	if network != nil {
+		fields["networkconf_id"] = network.ID
+	}
+
+	if len(wlans) > 0 {
+		if wlans[0].UsergroupID != "" {
+			fields["usergroup_id"] = wlans[0].UsergroupID
+		}
+		if len(wlans[0].APGroupIDs) > 0 {
+			fields["ap_group_ids"] = wlans[0].APGroupIDs
+		}
+	}
+
+	return fields, nil
+}
+
+// ValidatePassphrase checks the WPA passphrase rules: 8 to 63 printable ASCII
+// characters, or a 64 digit hex key
+func ValidatePassphrase(passphrase string) error {
+	if passphrase == "" {
+		return fmt.Errorf("a passphrase is required for WPA networks")
+	}
+
+	if len(passphrase) == 64 {
+		for _, r := range passphrase {
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return fmt.Errorf("a 64 character passphrase must be a hex key")
+			}
+		}
+		return nil
+	}
+
+	if len(passphrase) < 8 || len(passphrase) > 63 {
+		return fmt.Errorf("passphrase must be 8 to 63 characters, got %d", len(passphrase))
+	}
+	for _, r := range passphrase {
+		if r < 0x20 || r > 0x7e {
+			return fmt.Errorf("passphrase may only contain printable ASCII characters")
+		}
+	}
+	return nil
+}
+
+// FindNetwork returns the LAN network with the given VLAN ID or name, or nil
+// for an empty query (the controller's default network)
+func FindNetwork(networks []api.Network, query string) (*api.Network, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	if id, err := strconv.Atoi(query); err == nil {
+		for i := range networks {
+			n := &networks[i]
+			if n.Purpose != "wan" && n.VLANEnabled && n.VLAN == id {
+				return n, nil
+			}
+		}
+		return nil, fmt.Errorf("no network with VLAN %d; create the network first", id)
+	}
+
+	network, err := api.FindNetwork(networks, query)
+	if err != nil {
+		return nil, err
+	}
+	if network.Purpose == "wan" {
+		return nil, fmt.Errorf("network %s is a WAN", network.Name)
+	}
+	return network, nil
+}
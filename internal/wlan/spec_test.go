@@ -0,0 +1,97 @@
+package wlan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nkn/unifi-cli/internal/api"
+)
+
+var (
+	testNetworks = []api.Network{
+		{ID: "n1", Name: "LAN", Purpose: "corporate"},
+		{ID: "n2", Name: "IoT", Purpose: "corporate", VLANEnabled: true, VLAN: 30},
+		{ID: "n3", Name: "Internet", Purpose: "wan"},
+	}
+	testWLANs = []api.WLAN{{ID: "w1", Name: "Home", UsergroupID: "ug1", APGroupIDs: []string{"ap1"}}}
+)
+
+func TestSpec_Fields(t *testing.T) {
+	spec := Spec{Name: "Things", Passphrase: "correct-horse", VLAN: "30", Band: "2g", Guest: true}
+
+	fields, err := spec.Fields(testNetworks, testWLANs)
+	if err != nil {
+		t.Fatalf("Fields() returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"name":           "Things",
+		"security":       "wpapsk",
+		"x_passphrase":   "correct-horse",
+		"networkconf_id": "n2",
+		"wlan_band":      "2g",
+		"is_guest":       true,
+		"usergroup_id":   "ug1",
+	}
+	for key, value := range expected {
+		if fields[key] != value {
+			t.Errorf("Expected %s = %v, got %v", key, value, fields[key])
+		}
+	}
+
+	fields, err = Spec{Name: "Cafe", Security: "open", VLAN: "iot"}.Fields(testNetworks, nil)
+	if err != nil {
+		t.Fatalf("Fields() returned error: %v", err)
+	}
+	if fields["security"] != "open" || fields["networkconf_id"] != "n2" || fields["wlan_band"] != "both" {
+		t.Errorf("Unexpected fields for open network: %v", fields)
+	}
+	if _, ok := fields["usergroup_id"]; ok {
+		t.Errorf("Expected no user group without existing WLANs: %v", fields)
+	}
+}
+
+func TestSpec_Fields_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		want string
+	}{
+		{"no name", Spec{Passphrase: "correct-horse"}, "name"},
+		{"existing SSID", Spec{Name: "home", Passphrase: "correct-horse"}, "already exists"},
+		{"no passphrase", Spec{Name: "Office"}, "passphrase is required"},
+		{"short passphrase", Spec{Name: "Office", Passphrase: "short"}, "8 to 63"},
+		{"bad hex key", Spec{Name: "Office", Passphrase: strings.Repeat("x", 64)}, "hex"},
+		{"open with passphrase", Spec{Name: "Office", Security: "open", Passphrase: "correct-horse"}, "no passphrase"},
+		{"unknown VLAN", Spec{Name: "Office", Passphrase: "correct-horse", VLAN: "40"}, "no network with VLAN 40"},
+		{"WAN network", Spec{Name: "Office", Passphrase: "correct-horse", VLAN: "Internet"}, "WAN"},
+		{"bad band", Spec{Name: "Office", Passphrase: "correct-horse", Band: "6g"}, "invalid band"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.spec.Fields(testNetworks, testWLANs)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wlan.yaml")
+	data := "name: Office\npassphrase: correct-horse\nvlan: 30\nband: 5g\nhidden: true\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("LoadSpec() returned error: %v", err)
+	}
+	if spec.Name != "Office" || spec.VLAN != "30" || spec.Band != "5g" || !spec.Hidden {
+		t.Errorf("Unexpected spec %+v", spec)
+	}
+}